@@ -24,6 +24,7 @@ import (
 	"github.com/perses/perses/internal/cli/cmd/lint"
 	"github.com/perses/perses/internal/cli/cmd/login"
 	"github.com/perses/perses/internal/cli/cmd/migrate"
+	"github.com/perses/perses/internal/cli/cmd/move"
 	"github.com/perses/perses/internal/cli/cmd/plugin"
 	"github.com/perses/perses/internal/cli/cmd/project"
 	"github.com/perses/perses/internal/cli/cmd/refresh"
@@ -55,6 +56,7 @@ func newRootCommand() *cobra.Command {
 	cmd.AddCommand(lint.NewCMD())
 	cmd.AddCommand(login.NewCMD())
 	cmd.AddCommand(migrate.NewCMD())
+	cmd.AddCommand(move.NewCMD())
 	cmd.AddCommand(plugin.NewCMD())
 	cmd.AddCommand(project.NewCMD())
 	cmd.AddCommand(refresh.NewCMD())
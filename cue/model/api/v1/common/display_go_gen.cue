@@ -7,4 +7,8 @@ package common
 #Display: {
 	name?:        string @go(Name)
 	description?: string @go(Description)
+
+	// LocalizedNames contains alternate names per locale, the key being a BCP 47 language tag (e.g. "fr-FR").
+	// Name remains the name displayed when no localized name matches the locale of the user.
+	localizedNames?: {[string]: string} @go(LocalizedNames,map[string]string)
 }
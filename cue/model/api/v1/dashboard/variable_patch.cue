@@ -21,7 +21,10 @@
 
 package dashboard
 
-import "github.com/perses/perses/cue/model/api/v1/variable"
+import (
+	"github.com/perses/perses/cue/model/api/v1/common"
+	"github.com/perses/perses/cue/model/api/v1/variable"
+)
 
 #TextVariableSpec: {
 	name: string @go(Name)
@@ -34,6 +37,11 @@ import "github.com/perses/perses/cue/model/api/v1/variable"
 }
 
 #Variable: {
-	kind: variable.#Kind                        @go(Kind)
-	spec: #TextVariableSpec | #ListVariableSpec @go(Spec)
+	kind: variable.#Kind @go(Kind)
+
+	// Ref is the project variable ("variables/<name>") or the global variable ("globalvariables/<name>") the variable
+	// refers to. When set, the kind and the spec are replaced by the ones of the referenced variable each time the
+	// dashboard is saved or retrieved. Only the name of the variable is kept.
+	$ref?: common.#ExternalRef & =~"^(global)?variables/" @go(Ref,*common.ExternalRef)
+	spec:  #TextVariableSpec | #ListVariableSpec          @go(Spec)
 }
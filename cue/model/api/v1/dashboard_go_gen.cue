@@ -4,7 +4,11 @@
 
 package v1
 
-import "github.com/perses/perses/cue/model/api/v1/common"
+import (
+	"github.com/perses/perses/cue/model/api/v1/common"
+	"github.com/perses/perses/cue/model/api/v1/dashboard"
+	"time"
+)
 
 #Link: {
 	name?:            string @go(Name)
@@ -21,20 +25,37 @@ import "github.com/perses/perses/cue/model/api/v1/common"
 	plugin:  common.#Plugin @go(Plugin)
 	queries?: [...#Query] @go(Queries,[]Query)
 	links?: [...#Link] @go(Links,[]Link)
-}
 
-#Panel: {
-	kind: string     @go(Kind)
-	spec: #PanelSpec @go(Spec)
+	// Transforms are applied, in order, to the series returned by the queries, e.g. to compute the ratio of two
+	// queries. See dashboard.ApplyTransforms.
+	transforms?: [...dashboard.#Transform] @go(Transforms,[]dashboard.Transform)
+
+	// AlertRule is the name of the alert rule visualized by the panel, so tools can cross-link panels and alerts.
+	alertRule?: string @go(AlertRule)
+
+	// Extensions is a free map to store the metadata specific to an organization (e.g. the owner of the panel).
+	// It is ignored by Perses.
+	extensions?: {[string]: _} @go(Extensions,map[string]interface{})
 }
 
+#Panel: _
+
 #Query: {
 	kind: string     @go(Kind)
 	spec: #QuerySpec @go(Spec)
 }
 
 #QuerySpec: {
-	plugin: common.#Plugin @go(Plugin)
+	// Datasource is the name of the datasource targeted by the query. When set, it takes precedence over the
+	// datasource of the plugin spec, so the queries of a single panel can target different datasources.
+	datasource?: string         @go(Datasource)
+	plugin:      common.#Plugin @go(Plugin)
+}
+
+// AbsoluteTimeRange is a fixed time range, e.g. the window of an incident.
+#AbsoluteTimeRange: {
+	start: time.Time @go(Start)
+	end:   time.Time @go(End)
 }
 
 #DashboardSpec: _
@@ -33,6 +33,14 @@ import (
 
 #Panel: {
 	kind: "Panel"
+
+	// LibraryPanel is the name of the LibraryPanel, in the project of the dashboard, the panel refers to.
+	// When set, the spec is replaced by the one of the LibraryPanel each time the dashboard is saved or retrieved.
+	libraryPanel?: string @go(LibraryPanel)
+
+	// Ref is the same reference to a LibraryPanel written as an external reference: "librarypanels/<name>".
+	$ref?: common.#ExternalRef & =~"^librarypanels/" @go(Ref,*common.ExternalRef)
+	spec:  #PanelSpec                                @go(Spec)
 }
 
 #DashboardSpec: {
@@ -46,7 +54,12 @@ import (
 	}
 	layouts: [...dashboard.#Layout] @go(Layouts,[]Layout)
 	duration:         common.#Duration | *"1h" @go(Duration)
+	timeRange?:       #AbsoluteTimeRange       @go(TimeRange,*AbsoluteTimeRange)
 	refreshInterval?: common.#Duration         @go(RefreshInterval)
+	timezone?:        string                   @go(Timezone)
+	refreshIntervals?: [...common.#Duration] @go(RefreshIntervals,[]common.Duration)
+	links?: [...#Link] @go(Links,[]Link)
+	extensions?: {[string]: _} @go(Extensions,map[string]interface{})
 }
 
 #Dashboard: {
@@ -16,10 +16,12 @@ package v1
 	#KindGlobalRoleBinding |
 	#KindGlobalVariable |
 	#KindGlobalSecret |
+	#KindLibraryPanel |
 	#KindProject |
 	#KindRole |
 	#KindRoleBinding |
 	#KindSecret |
+	#KindSnapshot |
 	#KindUser |
 	#KindVariable
 
@@ -32,9 +34,11 @@ package v1
 #KindGlobalRoleBinding:  #Kind & "GlobalRoleBinding"
 #KindGlobalVariable:     #Kind & "GlobalVariable"
 #KindGlobalSecret:       #Kind & "GlobalSecret"
+#KindLibraryPanel:       #Kind & "LibraryPanel"
 #KindProject:            #Kind & "Project"
 #KindRole:               #Kind & "Role"
 #KindRoleBinding:        #Kind & "RoleBinding"
 #KindSecret:             #Kind & "Secret"
+#KindSnapshot:           #Kind & "Snapshot"
 #KindUser:               #Kind & "User"
 #KindVariable:           #Kind & "Variable"
@@ -27,6 +27,7 @@ import (
 	createdAt: time.Time @go(CreatedAt)
 	updatedAt: time.Time @go(UpdatedAt)
 	version:   uint64    @go(Version)
+	annotations?: {[string]: string} @go(Annotations,map[string]string)
 	// Placeholder values required to pass the CUE evaluation, as those
 	// attributes are flagged as mandatory in the (Go) datamodel but
 	// populated by the server in the end.
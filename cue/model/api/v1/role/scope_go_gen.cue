@@ -7,6 +7,7 @@ package role
 #Scope: _ // #enumScope
 
 #enumScope:
+	#AuditScope |
 	#DashboardScope |
 	#DatasourceScope |
 	#EphemeralDashboardScope |
@@ -16,14 +17,17 @@ package role
 	#GlobalRoleBindingScope |
 	#GlobalSecretScope |
 	#GlobalVariableScope |
+	#LibraryPanelScope |
 	#ProjectScope |
 	#RoleScope |
 	#RoleBindingScope |
 	#SecretScope |
+	#SnapshotScope |
 	#UserScope |
 	#VariableScope |
 	#WildcardScope
 
+#AuditScope:              #Scope & "Audit"
 #DashboardScope:          #Scope & "Dashboard"
 #DatasourceScope:         #Scope & "Datasource"
 #EphemeralDashboardScope: #Scope & "EphemeralDashboard"
@@ -33,10 +37,12 @@ package role
 #GlobalRoleBindingScope:  #Scope & "GlobalRoleBinding"
 #GlobalSecretScope:       #Scope & "GlobalSecret"
 #GlobalVariableScope:     #Scope & "GlobalVariable"
+#LibraryPanelScope:       #Scope & "LibraryPanel"
 #ProjectScope:            #Scope & "Project"
 #RoleScope:               #Scope & "Role"
 #RoleBindingScope:        #Scope & "RoleBinding"
 #SecretScope:             #Scope & "Secret"
+#SnapshotScope:           #Scope & "Snapshot"
 #UserScope:               #Scope & "User"
 #VariableScope:           #Scope & "Variable"
 #WildcardScope:           #Scope & "*"
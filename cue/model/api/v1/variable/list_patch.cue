@@ -30,6 +30,8 @@ import "github.com/perses/perses/cue/model/api/v1/common"
 
 #Sort: "none" | "alphabetical-asc" | "alphabetical-desc" | "numerical-asc" | "numerical-desc" | "alphabetical-ci-asc" | "alphabetical-ci-desc"
 
+#Refresh: "onDashboardLoad" | "onTimeRangeChange"
+
 #ListSpec: {
 	display?: #Display @go(Display)
 	// Value from the list to be selected by default.
@@ -44,6 +46,10 @@ import "github.com/perses/perses/cue/model/api/v1/common"
 	// If empty, then nothing is filtered. That's the equivalent of setting CapturingRegexp with (.*)
 	capturingRegexp?: string @go(CapturingRegexp)
 	// Sort method to apply when rendering the list of values
-	sort?:  #Sort          @go(Sort)
-	plugin: common.#Plugin @go(Plugin)
+	sort?: #Sort @go(Sort)
+	// Refresh tells when the values are reloaded. See Refresh.
+	refresh?: #Refresh       @go(Refresh)
+	plugin:   common.#Plugin @go(Plugin)
+	// Extensions is a free map to store the metadata specific to an organization. It is ignored by Perses.
+	extensions?: {[string]: _} @go(Extensions,map[string]interface{})
 }
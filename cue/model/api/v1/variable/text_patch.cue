@@ -25,4 +25,5 @@ package variable
 	display?:  #Display @go(Display)
 	value:     string   @go(Value)
 	constant?: bool     @go(Constant)
+	extensions?: {[string]: _} @go(Extensions,map[string]interface{})
 }
@@ -0,0 +1,74 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package client pushes the dashboards built with the SDK to a Perses server through its REST API.
+package client
+
+import (
+	"github.com/perses/perses/pkg/client/config"
+	"github.com/perses/perses/pkg/client/perseshttp"
+)
+
+type Client struct {
+	restClient *perseshttp.RESTClient
+}
+
+// New returns a client for the Perses server described by the config, authenticating with the credentials of the
+// config if any.
+func New(restConfig config.RestConfigClient) (*Client, error) {
+	if err := restConfig.Validate(); err != nil {
+		return nil, err
+	}
+	restClient, err := config.NewRESTClient(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithClient(restClient), nil
+}
+
+// NewWithClient returns a client using an existing REST client, e.g. the one of percli.
+func NewWithClient(restClient *perseshttp.RESTClient) *Client {
+	return &Client{restClient: restClient}
+}
+
+// Secret returns the client of the secrets of the given project.
+func (c *Client) Secret(project string) *SecretClient {
+	return &SecretClient{
+		restClient: c.restClient,
+		project:    project,
+	}
+}
+
+// LibraryPanel returns the client of the library panels of the given project.
+func (c *Client) LibraryPanel(project string) *LibraryPanelClient {
+	return &LibraryPanelClient{
+		restClient: c.restClient,
+		project:    project,
+	}
+}
+
+// Dashboard returns the client of the dashboards of the given project.
+func (c *Client) Dashboard(project string) *DashboardClient {
+	return &DashboardClient{
+		restClient: c.restClient,
+		project:    project,
+	}
+}
+
+// EphemeralDashboard returns the client of the ephemeral dashboards of the given project.
+func (c *Client) EphemeralDashboard(project string) *EphemeralDashboardClient {
+	return &EphemeralDashboardClient{
+		restClient: c.restClient,
+		project:    project,
+	}
+}
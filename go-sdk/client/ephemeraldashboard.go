@@ -0,0 +1,95 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/perses/perses/pkg/client/perseshttp"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const ephemeralDashboardResource = "ephemeraldashboards"
+
+type EphemeralDashboardClient struct {
+	restClient *perseshttp.RESTClient
+	project    string
+}
+
+// Apply creates the ephemeral dashboard, or updates it if an ephemeral dashboard with the same name already exists in
+// the project.
+// The project of the ephemeral dashboard is set to the project of the client when empty, and must match it otherwise.
+func (c *EphemeralDashboardClient) Apply(ctx context.Context, dashboard v1.EphemeralDashboard) (*v1.EphemeralDashboard, error) {
+	if len(dashboard.Metadata.Project) == 0 {
+		dashboard.Metadata.Project = c.project
+	} else if dashboard.Metadata.Project != c.project {
+		return nil, fmt.Errorf("ephemeral dashboard %q belongs to the project %q, not to %q", dashboard.Metadata.Name, dashboard.Metadata.Project, c.project)
+	}
+
+	_, err := c.Get(ctx, dashboard.Metadata.Name)
+	if err != nil && !errors.Is(err, perseshttp.RequestNotFoundError) {
+		return nil, err
+	}
+	request := c.restClient.Put().Name(dashboard.Metadata.Name)
+	if err != nil {
+		request = c.restClient.Post()
+	}
+
+	result := &v1.EphemeralDashboard{}
+	err = request.
+		Context(ctx).
+		Resource(ephemeralDashboardResource).
+		Project(c.project).
+		Body(dashboard).
+		Do().
+		Object(result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply the ephemeral dashboard %q: %w", dashboard.Metadata.Name, err)
+	}
+	return result, nil
+}
+
+// Get returns the ephemeral dashboard with the given name.
+// The error wraps perseshttp.RequestNotFoundError when the ephemeral dashboard doesn't exist.
+func (c *EphemeralDashboardClient) Get(ctx context.Context, name string) (*v1.EphemeralDashboard, error) {
+	result := &v1.EphemeralDashboard{}
+	err := c.restClient.Get().
+		Context(ctx).
+		Resource(ephemeralDashboardResource).
+		Name(name).
+		Project(c.project).
+		Do().
+		Object(result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the ephemeral dashboard %q: %w", name, err)
+	}
+	return result, nil
+}
+
+// Delete removes the ephemeral dashboard with the given name.
+func (c *EphemeralDashboardClient) Delete(ctx context.Context, name string) error {
+	err := c.restClient.Delete().
+		Context(ctx).
+		Resource(ephemeralDashboardResource).
+		Name(name).
+		Project(c.project).
+		Do().
+		Error()
+	if err != nil {
+		return fmt.Errorf("unable to delete the ephemeral dashboard %q: %w", name, err)
+	}
+	return nil
+}
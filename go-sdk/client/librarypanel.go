@@ -0,0 +1,94 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/perses/perses/pkg/client/perseshttp"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const libraryPanelResource = "librarypanels"
+
+type LibraryPanelClient struct {
+	restClient *perseshttp.RESTClient
+	project    string
+}
+
+// Apply creates the library panel, or updates it if a library panel with the same name already exists in the project.
+// The project of the library panel is set to the project of the client when empty, and must match it otherwise.
+func (c *LibraryPanelClient) Apply(ctx context.Context, libraryPanel v1.LibraryPanel) (*v1.LibraryPanel, error) {
+	if len(libraryPanel.Metadata.Project) == 0 {
+		libraryPanel.Metadata.Project = c.project
+	} else if libraryPanel.Metadata.Project != c.project {
+		return nil, fmt.Errorf("library panel %q belongs to the project %q, not to %q", libraryPanel.Metadata.Name, libraryPanel.Metadata.Project, c.project)
+	}
+
+	_, err := c.Get(ctx, libraryPanel.Metadata.Name)
+	if err != nil && !errors.Is(err, perseshttp.RequestNotFoundError) {
+		return nil, err
+	}
+	request := c.restClient.Put().Name(libraryPanel.Metadata.Name)
+	if err != nil {
+		request = c.restClient.Post()
+	}
+
+	result := &v1.LibraryPanel{}
+	err = request.
+		Context(ctx).
+		Resource(libraryPanelResource).
+		Project(c.project).
+		Body(libraryPanel).
+		Do().
+		Object(result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply the library panel %q: %w", libraryPanel.Metadata.Name, err)
+	}
+	return result, nil
+}
+
+// Get returns the library panel with the given name.
+// The error wraps perseshttp.RequestNotFoundError when the library panel doesn't exist.
+func (c *LibraryPanelClient) Get(ctx context.Context, name string) (*v1.LibraryPanel, error) {
+	result := &v1.LibraryPanel{}
+	err := c.restClient.Get().
+		Context(ctx).
+		Resource(libraryPanelResource).
+		Name(name).
+		Project(c.project).
+		Do().
+		Object(result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the library panel %q: %w", name, err)
+	}
+	return result, nil
+}
+
+// Delete removes the library panel with the given name.
+func (c *LibraryPanelClient) Delete(ctx context.Context, name string) error {
+	err := c.restClient.Delete().
+		Context(ctx).
+		Resource(libraryPanelResource).
+		Name(name).
+		Project(c.project).
+		Do().
+		Error()
+	if err != nil {
+		return fmt.Errorf("unable to delete the library panel %q: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/perses/perses/pkg/client/perseshttp"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const secretResource = "secrets"
+
+type SecretClient struct {
+	restClient *perseshttp.RESTClient
+	project    string
+}
+
+// Apply creates the secret, or updates it if a secret with the same name already exists in the project.
+// The project of the secret is set to the project of the client when empty, and must match it otherwise.
+func (c *SecretClient) Apply(ctx context.Context, secret v1.Secret) (*v1.Secret, error) {
+	if len(secret.Metadata.Project) == 0 {
+		secret.Metadata.Project = c.project
+	} else if secret.Metadata.Project != c.project {
+		return nil, fmt.Errorf("secret %q belongs to the project %q, not to %q", secret.Metadata.Name, secret.Metadata.Project, c.project)
+	}
+
+	_, err := c.Get(ctx, secret.Metadata.Name)
+	if err != nil && !errors.Is(err, perseshttp.RequestNotFoundError) {
+		return nil, err
+	}
+	request := c.restClient.Put().Name(secret.Metadata.Name)
+	if err != nil {
+		request = c.restClient.Post()
+	}
+
+	result := &v1.Secret{}
+	err = request.
+		Context(ctx).
+		Resource(secretResource).
+		Project(c.project).
+		Body(secret).
+		Do().
+		Object(result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply the secret %q: %w", secret.Metadata.Name, err)
+	}
+	return result, nil
+}
+
+// Get returns the secret with the given name. The sensitive values are hidden by the server.
+// The error wraps perseshttp.RequestNotFoundError when the secret doesn't exist.
+func (c *SecretClient) Get(ctx context.Context, name string) (*v1.Secret, error) {
+	result := &v1.Secret{}
+	err := c.restClient.Get().
+		Context(ctx).
+		Resource(secretResource).
+		Name(name).
+		Project(c.project).
+		Do().
+		Object(result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the secret %q: %w", name, err)
+	}
+	return result, nil
+}
+
+// Delete removes the secret with the given name.
+func (c *SecretClient) Delete(ctx context.Context, name string) error {
+	err := c.restClient.Delete().
+		Context(ctx).
+		Resource(secretResource).
+		Name(name).
+		Project(c.project).
+		Do().
+		Error()
+	if err != nil {
+		return fmt.Errorf("unable to delete the secret %q: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"errors"
+	"fmt"
+)
+
+// OptionError is the error of an option located by its path in the nested builders, e.g.
+// panelGroup[Resource usage].panel[2].query[0].
+type OptionError struct {
+	Path string
+	Err  error
+}
+
+func (e *OptionError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Err)
+}
+
+func (e *OptionError) Unwrap() error {
+	return e.Err
+}
+
+// WithPath prefixes the path of the error with the given element, e.g. panel[2]. The errors aggregated by ApplyOptions
+// are prefixed one by one, so each of them keeps its own path.
+func WithPath(element string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs := joined.Unwrap()
+		result := make([]error, 0, len(errs))
+		for _, e := range errs {
+			result = append(result, WithPath(element, e))
+		}
+		return errors.Join(result...)
+	}
+	if optionErr, ok := err.(*OptionError); ok {
+		return &OptionError{Path: element + "." + optionErr.Path, Err: optionErr.Err}
+	}
+	return &OptionError{Path: element, Err: err}
+}
+
+// ApplyOptions applies all the options to the builder, even when some of them fail, so all the problems are reported
+// at once. The errors are joined with errors.Join.
+func ApplyOptions[B any, O ~func(B) error](builder B, options []O) error {
+	var errs []error
+	for _, opt := range options {
+		if err := opt(builder); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
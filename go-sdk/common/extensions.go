@@ -0,0 +1,41 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SetExtension stores the value under the key in the extensions map, creating the map if needed.
+// The value is normalized through JSON, so what is stored is exactly what is read back once the spec is unmarshalled.
+func SetExtension(extensions *map[string]interface{}, key string, value interface{}) error {
+	if len(strings.TrimSpace(key)) == 0 {
+		return fmt.Errorf("extension key cannot be empty")
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("extension %q cannot be encoded in JSON: %w", key, err)
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return fmt.Errorf("extension %q cannot be decoded from JSON: %w", key, err)
+	}
+	if *extensions == nil {
+		*extensions = make(map[string]interface{})
+	}
+	(*extensions)[key] = normalized
+	return nil
+}
@@ -16,6 +16,7 @@ package common
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 )
 
 type TimeUnit string
@@ -81,20 +82,23 @@ func (f *Format) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+// SupportedUnits lists the units supported by the format of the panels.
+var SupportedUnits = []string{
+	string(MilliSecondsUnit), string(SecondsUnit), string(MinutesUnit),
+	string(HoursUnit), string(DaysUnit), string(WeeksUnit), string(MonthsUnit),
+	string(YearsUnit), string(PercentUnit), string(PercentDecimalUnit), DecimalUnit, BytesUnit,
+	string(BitsPerSecondsUnit), string(BytesPerSecondsUnit), string(CountsPerSecondsUnit), string(EventsPerSecondsUnit),
+	string(MessagesPerSecondsUnit), string(OpsPerSecondsUnit), string(PacketsPerSecondsUnit),
+	string(ReadsPerSecondsUnit), string(RecordsPerSecondsUnit), string(RequestsPerSecondsUnit),
+	string(RowsPerSecondsUnit), string(WritesPerSecondsUnit),
+}
+
 func (f *Format) validate() error {
 	if f.Unit == nil {
 		return nil
 	}
-	switch *f.Unit {
-	case string(MilliSecondsUnit), string(SecondsUnit), string(MinutesUnit),
-		string(HoursUnit), string(DaysUnit), string(WeeksUnit), string(MonthsUnit),
-		string(YearsUnit), string(PercentUnit), string(PercentDecimalUnit), DecimalUnit, BytesUnit,
-		string(BitsPerSecondsUnit), string(BytesPerSecondsUnit), string(CountsPerSecondsUnit), string(EventsPerSecondsUnit),
-		string(MessagesPerSecondsUnit), string(OpsPerSecondsUnit), string(PacketsPerSecondsUnit),
-		string(ReadsPerSecondsUnit), string(RecordsPerSecondsUnit), string(RequestsPerSecondsUnit),
-		string(RowsPerSecondsUnit), string(WritesPerSecondsUnit):
-		return nil
-	default:
+	if !slices.Contains(SupportedUnits, *f.Unit) {
 		return fmt.Errorf("unknown format")
 	}
+	return nil
 }
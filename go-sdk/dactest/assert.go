@@ -0,0 +1,41 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dactest provides helpers to test Dashboard-as-Code programs.
+package dactest
+
+import (
+	"testing"
+
+	"github.com/perses/perses/go-sdk/dashboard"
+)
+
+// volatilePaths are set by the server and are not relevant when comparing two builders.
+var volatilePaths = []string{"metadata.createdAt", "metadata.updatedAt", "metadata.version"}
+
+// AssertBuildersEqual fails the test if the two builders don't produce the same dashboard.
+// The dashboards are compared semantically: the order of the keys and the metadata set by the server are ignored.
+// On mismatch, the changes between the two dashboards are printed.
+func AssertBuildersEqual(t testing.TB, expected dashboard.Builder, actual dashboard.Builder) bool {
+	t.Helper()
+	changes, err := dashboard.Diff(&expected.Dashboard, &actual.Dashboard, dashboard.IgnorePaths(volatilePaths...))
+	if err != nil {
+		t.Errorf("unable to compare the dashboards: %s", err)
+		return false
+	}
+	if len(changes) > 0 {
+		t.Errorf("dashboards are not equal (-expected +actual):\n%s", changes)
+		return false
+	}
+	return true
+}
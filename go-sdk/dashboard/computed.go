@@ -0,0 +1,71 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"fmt"
+
+	txtVar "github.com/perses/perses/go-sdk/variable/text-variable"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+)
+
+// ComputeFunc computes the value of a variable from the default values of the variables it depends on.
+type ComputeFunc func(selection map[string]string) (string, error)
+
+// AddComputedVariable adds a hidden constant text variable whose value is computed from the default values of the
+// variables listed in dependsOn (e.g. a region derived from the default cluster).
+// Perses has no computed variable: the value is computed once at build time and is not updated when another value
+// is selected in the dashboard. The variables listed must be declared and have a single default value.
+func AddComputedVariable(name string, dependsOn []string, compute ComputeFunc) Option {
+	return inPhase(phaseDerived, func(builder *Builder) error {
+		selection := make(map[string]string, len(dependsOn))
+		for _, dependency := range dependsOn {
+			v, ok := builder.findVariable(dependency)
+			if !ok {
+				return fmt.Errorf("computed variable %q depends on the variable %q which is not declared", name, dependency)
+			}
+			value, err := defaultSingleValue(v)
+			if err != nil {
+				return fmt.Errorf("computed variable %q: %w", name, err)
+			}
+			selection[dependency] = value
+		}
+		value, err := compute(selection)
+		if err != nil {
+			return fmt.Errorf("unable to compute the variable %q: %w", name, err)
+		}
+		return AddVariable(name, txtVar.Text(value, txtVar.Constant(true), txtVar.Hidden(true)))(builder)
+	})
+}
+
+// defaultSingleValue returns the value selected by default for the variable.
+func defaultSingleValue(v dashboard.Variable) (string, error) {
+	switch spec := v.Spec.(type) {
+	case *dashboard.TextVariableSpec:
+		return spec.Value, nil
+	case *dashboard.ListVariableSpec:
+		if spec.DefaultValue == nil {
+			return "", fmt.Errorf("variable %q has no default value", spec.Name)
+		}
+		if len(spec.DefaultValue.SliceValues) > 1 {
+			return "", fmt.Errorf("variable %q has several default values", spec.Name)
+		}
+		if len(spec.DefaultValue.SliceValues) == 1 {
+			return spec.DefaultValue.SliceValues[0], nil
+		}
+		return spec.DefaultValue.SingleValue, nil
+	default:
+		return "", fmt.Errorf("unknown variable spec %+v", v.Spec)
+	}
+}
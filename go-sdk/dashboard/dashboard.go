@@ -14,8 +14,13 @@
 package dashboard
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
 	"time"
 
+	sdk "github.com/perses/perses/go-sdk/common"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 )
 
@@ -33,10 +38,9 @@ func New(name string, options ...Option) (Builder, error) {
 		Duration(time.Hour),
 	}
 
-	for _, opt := range append(defaults, options...) {
-		if err := opt(builder); err != nil {
-			return *builder, err
-		}
+	optionsErr := sdk.ApplyOptions(builder, append(defaults, options...))
+	if err := builder.finalize(optionsErr); err != nil {
+		return *builder, err
 	}
 
 	return *builder, nil
@@ -44,4 +48,109 @@ func New(name string, options ...Option) (Builder, error) {
 
 type Builder struct {
 	Dashboard v1.Dashboard `json:"-" yaml:"-"`
+	// requiredVariables lists, per panel group title, the variables that must be declared in the dashboard.
+	requiredVariables map[string][]string
+	// phase is the phase being run. See runInPhase.
+	phase phase
+	// deferred are the options waiting for their phase to run.
+	deferred map[phase][]Option
+	// minRefreshInterval is the smallest refresh interval accepted by Lint. 0 means no minimum.
+	minRefreshInterval time.Duration
+	// maxDimensionBudget is the maximum number of label names the queries can use before Lint warns. 0 means no limit.
+	maxDimensionBudget int
+	// conversionWarnings lists what FromGrafana couldn't convert. They are reported by Lint.
+	conversionWarnings []Warning
+	// externalVariables are the project and global variables the dashboard can reference. See ExternalVariables.
+	externalVariables []string
+	// strictVariables makes the build fail when a query of a panel references an undeclared variable.
+	strictVariables bool
+	// secrets are the secrets declared with AddSecret. See Secrets.
+	secrets []v1.Secret
+	// defaultDatasources gives the datasource set with DefaultDatasource, per datasource plugin kind.
+	defaultDatasources map[string]string
+}
+
+// phase orders the options that depend on each other, whatever the order they are given to New.
+type phase int
+
+const (
+	// phaseOptions is the application of the options given to New, in their order.
+	phaseOptions phase = iota
+	// phaseResources registers the datasources and the variables.
+	phaseResources
+	// phasePanels builds the panel groups and their panels.
+	phasePanels
+	// phaseDerived completes the dashboard with what is derived from its content (footer, audit variable...).
+	phaseDerived
+	// phaseFinalizers runs the user hooks added with AddFinalizer.
+	phaseFinalizers
+)
+
+// runInPhase defers the option to the given phase, or runs it right away if the phase has already started.
+func (b *Builder) runInPhase(p phase, opt Option) error {
+	if b.phase >= p {
+		return opt(b)
+	}
+	if b.deferred == nil {
+		b.deferred = make(map[phase][]Option)
+	}
+	b.deferred[p] = append(b.deferred[p], opt)
+	return nil
+}
+
+// inPhase returns an option running the given option in the given phase.
+func inPhase(p phase, opt Option) Option {
+	return func(builder *Builder) error {
+		return builder.runInPhase(p, opt)
+	}
+}
+
+func (b *Builder) runPhase(p phase) error {
+	b.phase = p
+	// Options deferred to the current phase run right away, so the list doesn't grow while it is iterated.
+	return sdk.ApplyOptions(b, b.deferred[p])
+}
+
+// finalize runs once every option has been applied: it runs the deferred options phase by phase, then checks the
+// dashboard as a whole. The deferred options run even if some options failed, so all their errors are reported at once,
+// but the dashboard is only checked as a whole when every option succeeded.
+func (b *Builder) finalize(optionsErr error) error {
+	errs := []error{optionsErr}
+	for _, p := range []phase{phaseResources, phasePanels, phaseDerived} {
+		errs = append(errs, b.runPhase(p))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	if err := b.runPhase(phaseFinalizers); err != nil {
+		return err
+	}
+	for _, group := range sortedKeys(b.requiredVariables) {
+		for _, name := range b.requiredVariables[group] {
+			if !b.isVariableDeclared(name) {
+				return fmt.Errorf("panel group %q requires the variable %q which is not declared", group, name)
+			}
+		}
+	}
+	if err := b.validateVariableDependencies(); err != nil {
+		return err
+	}
+	if err := b.validateRangeVariables(); err != nil {
+		return err
+	}
+	return b.validateQueryDatasources()
+}
+
+// Secrets returns the secrets declared with AddSecret, to be applied in the project before the dashboard.
+func (b Builder) Secrets() []v1.Secret {
+	return slices.Clone(b.secrets)
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
@@ -0,0 +1,93 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"fmt"
+
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+)
+
+// DefaultDatasource makes the queries of the panels and the variables compatible with the given kind of datasource
+// (e.g. PrometheusDatasource) target the datasource with the given name, when they don't target a datasource already.
+// The references are filled once all the panels and the variables are added. The datasource can be a datasource of the
+// dashboard, of the project or a global one.
+func DefaultDatasource(kind string, name string) Option {
+	return func(builder *Builder) error {
+		if len(kind) == 0 {
+			return fmt.Errorf("the kind of the default datasource cannot be empty")
+		}
+		if err := common.ValidateID(name); err != nil {
+			return fmt.Errorf("invalid default datasource name %q: %w", name, err)
+		}
+		if existing, ok := builder.defaultDatasources[kind]; ok && existing != name {
+			return fmt.Errorf("the datasources %q and %q are both defined as the default %s", existing, name, kind)
+		}
+		if builder.defaultDatasources == nil {
+			builder.defaultDatasources = make(map[string]string)
+		}
+		builder.defaultDatasources[kind] = name
+		return builder.runInPhase(phaseDerived, func(builder *Builder) error {
+			return builder.fillDefaultDatasource(kind, name)
+		})
+	}
+}
+
+func (b *Builder) fillDefaultDatasource(kind string, name string) error {
+	if ds, ok := b.Dashboard.Spec.Datasources[name]; ok && ds.Plugin.Kind != kind {
+		return fmt.Errorf("the default %s %q is a datasource of kind %s", kind, name, ds.Plugin.Kind)
+	}
+	selector := map[string]interface{}{"kind": kind, "name": name}
+	for _, panelKey := range sortedKeys(b.Dashboard.Spec.Panels) {
+		p := b.Dashboard.Spec.Panels[panelKey]
+		for i := range p.Spec.Queries {
+			q := &p.Spec.Queries[i]
+			if len(q.Spec.Datasource) > 0 {
+				continue
+			}
+			if err := setDefaultDatasource(&q.Spec.Plugin, kind, selector); err != nil {
+				return fmt.Errorf("panel %q, query %d: %w", p.Spec.Display.Name, i, err)
+			}
+		}
+	}
+	for _, v := range b.Dashboard.Spec.Variables {
+		spec, isList := v.Spec.(*dashboard.ListVariableSpec)
+		if !isList || spec.Plugin.Kind == datasourceVariableKind {
+			continue
+		}
+		if err := setDefaultDatasource(&spec.Plugin, kind, selector); err != nil {
+			return fmt.Errorf("variable %q: %w", spec.Name, err)
+		}
+	}
+	return nil
+}
+
+// setDefaultDatasource sets the datasource of the plugin when the plugin is compatible with the kind of datasource and
+// doesn't target a datasource already.
+func setDefaultDatasource(plugin *common.Plugin, kind string, selector map[string]interface{}) error {
+	if !isQueryCompatible(plugin.Kind, kind) {
+		return nil
+	}
+	spec, err := decodePluginSpec(*plugin)
+	if err != nil {
+		return err
+	}
+	if _, exist := spec[queryDatasourceSpecField]; exist {
+		return nil
+	}
+	spec[queryDatasourceSpecField] = selector
+	plugin.Spec = spec
+	return nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+)
+
+// variableReferenceRegexp captures the variables referenced as $name, ${name} or ${name:format}.
+var variableReferenceRegexp = regexp.MustCompile(`\$\{?(\w+)`)
+
+const (
+	unvisited = iota
+	visiting
+	visited
+)
+
+// validateVariableDependencies checks that the variables only reference declared variables, and that they don't
+// depend on each other in a circle (e.g. $a uses $b which uses $a), since such variables can never be resolved.
+// With StrictVariables, the queries of the panels must also only reference declared variables.
+func (b *Builder) validateVariableDependencies() error {
+	dependencies := make(map[string][]string, len(b.Dashboard.Spec.Variables))
+	for _, v := range b.Dashboard.Spec.Variables {
+		name := v.Spec.GetName()
+		references, err := variableSpecReferences(v)
+		if err != nil {
+			return fmt.Errorf("variable %q: %w", name, err)
+		}
+		for _, reference := range references {
+			if !b.isVariableDeclared(reference) {
+				return fmt.Errorf("variable %q uses the variable %q which is not declared", name, reference)
+			}
+		}
+		dependencies[name] = references
+	}
+
+	state := make(map[string]int, len(dependencies))
+	var path []string
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			cycle := append(path[slices.Index(path, name):], name)
+			return fmt.Errorf("circular dependency between the variables %s", strings.Join(cycle, " -> "))
+		}
+		state[name] = visiting
+		path = append(path, name)
+		for _, dependency := range dependencies[name] {
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+	for _, v := range b.Dashboard.Spec.Variables {
+		if err := visit(v.Spec.GetName()); err != nil {
+			return err
+		}
+	}
+
+	if !b.strictVariables {
+		return nil
+	}
+	for _, panelKey := range sortedKeys(b.Dashboard.Spec.Panels) {
+		p := b.Dashboard.Spec.Panels[panelKey]
+		for i, q := range p.Spec.Queries {
+			spec, err := decodePluginSpec(q.Spec.Plugin)
+			if err != nil {
+				return fmt.Errorf("panel %q, query %d: %w", p.Spec.Display.Name, i, err)
+			}
+			for _, reference := range variableReferences(spec) {
+				if !b.isVariableDeclared(reference) {
+					return fmt.Errorf("panel %q, query %d: the variable %q is not declared", p.Spec.Display.Name, i, reference)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isVariableDeclared returns true if the variable is declared in the dashboard, declared with ExternalVariables, or is
+// a builtin variable like $__rate_interval.
+func (b *Builder) isVariableDeclared(name string) bool {
+	if _, ok := b.findVariable(name); ok {
+		return true
+	}
+	return slices.Contains(b.externalVariables, name) || v1.IsBuiltinVariable(name)
+}
+
+func variableSpecReferences(v dashboard.Variable) ([]string, error) {
+	switch spec := v.Spec.(type) {
+	case *dashboard.TextVariableSpec:
+		return variableReferences(spec.Value), nil
+	case *dashboard.ListVariableSpec:
+		pluginSpec, err := decodePluginSpec(spec.Plugin)
+		if err != nil {
+			return nil, err
+		}
+		return variableReferences(pluginSpec), nil
+	default:
+		return nil, fmt.Errorf("unknown variable spec %+v", v.Spec)
+	}
+}
+
+// variableReferences returns the variables referenced in the strings of a value decoded from JSON, in order of
+// appearance. Numbers like $1 are not variables: they are the placeholders of functions like label_replace in PromQL.
+func variableReferences(value interface{}) []string {
+	var result []string
+	var walk func(value interface{})
+	walk = func(value interface{}) {
+		switch v := value.(type) {
+		case string:
+			for _, match := range variableReferenceRegexp.FindAllStringSubmatch(v, -1) {
+				name := match[1]
+				if _, err := strconv.Atoi(name); err == nil || slices.Contains(result, name) {
+					continue
+				}
+				result = append(result, name)
+			}
+		case map[string]interface{}:
+			for _, key := range sortedKeys(v) {
+				walk(v[key])
+			}
+		case []interface{}:
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	walk(value)
+	return result
+}
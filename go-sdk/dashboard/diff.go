@@ -0,0 +1,358 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kylelemons/godebug/diff"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const (
+	pathWildcard       = "*"
+	pathSeparator      = "."
+	jsonPathRootPrefix = "$."
+)
+
+type ChangeType string
+
+const (
+	ChangeAdded    ChangeType = "added"
+	ChangeRemoved  ChangeType = "removed"
+	ChangeModified ChangeType = "modified"
+)
+
+type ChangeKind string
+
+const (
+	ChangeKindMetadata ChangeKind = "metadata"
+	// ChangeKindSettings covers the fields of the spec that are not a datasource, a variable, a panel or a layout,
+	// e.g. the display or the duration.
+	ChangeKindSettings   ChangeKind = "settings"
+	ChangeKindDatasource ChangeKind = "datasource"
+	ChangeKindVariable   ChangeKind = "variable"
+	ChangeKindPanel      ChangeKind = "panel"
+	ChangeKindLayout     ChangeKind = "layout"
+)
+
+var changeSymbols = map[ChangeType]string{
+	ChangeAdded:    "+",
+	ChangeRemoved:  "-",
+	ChangeModified: "~",
+}
+
+// specElements are the fields of the spec compared element by element. The other ones are compared as the settings.
+var specElements = []string{"datasources", "variables", "panels", "layouts"}
+
+// Change is the metadata, the settings, a datasource, a variable, a panel or a layout added, removed or modified
+// between two dashboards.
+type Change struct {
+	Kind ChangeKind `json:"kind" yaml:"kind"`
+	// Name is the key of the datasource or of the panel, the name of the variable, the position of the layout
+	// followed by its title, or the name of the dashboard for the metadata and the settings.
+	Name string     `json:"name" yaml:"name"`
+	Type ChangeType `json:"type" yaml:"type"`
+	// Diff is the line diff of the JSON representations, for a modification.
+	Diff string `json:"diff,omitempty" yaml:"diff,omitempty"`
+}
+
+func (c Change) String() string {
+	result := fmt.Sprintf("%s %s %q %s", changeSymbols[c.Type], c.Kind, c.Name, c.Type)
+	if len(c.Diff) > 0 {
+		result += "\n" + c.Diff
+	}
+	return result
+}
+
+// Changes is the list of the changes between two dashboards, in the order: metadata, settings, datasources, variables,
+// panels and layouts.
+type Changes []Change
+
+// String returns the changes in a human-readable form, one change per line followed by its diff if any.
+func (c Changes) String() string {
+	lines := make([]string, 0, len(c))
+	for _, change := range c {
+		lines = append(lines, change.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+type diffOptions struct {
+	ignorePaths [][]string
+}
+
+// DiffOption tunes the comparison done by Diff.
+type DiffOption func(options *diffOptions)
+
+// IgnorePaths removes the fields from both dashboards before the comparison, e.g. "metadata.createdAt". The path
+// elements are separated by dots, "*" matches any key or index (e.g. "spec.panels.*.spec.display"), and a leading "$."
+// is accepted.
+func IgnorePaths(paths ...string) DiffOption {
+	return func(options *diffOptions) {
+		for _, path := range paths {
+			options.ignorePaths = append(options.ignorePaths, strings.Split(strings.TrimPrefix(path, jsonPathRootPrefix), pathSeparator))
+		}
+	}
+}
+
+// Diff returns the metadata, the settings, the datasources, the variables, the panels and the layouts added, removed or
+// modified from the dashboard before to the dashboard after, e.g. to print in a CI pipeline what a change of a
+// dashboard alters before applying it, or to compare the dashboards built by two builders in a test. The dashboards are
+// compared on their JSON representations, so the order of the keys is ignored. No change is returned when they are
+// equal.
+func Diff(before *v1.Dashboard, after *v1.Dashboard, options ...DiffOption) (Changes, error) {
+	if before == nil || after == nil {
+		return nil, fmt.Errorf("dashboards to compare cannot be nil")
+	}
+	opts := &diffOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+	beforeContent, err := normalizedContent(before, opts.ignorePaths)
+	if err != nil {
+		return nil, err
+	}
+	afterContent, err := normalizedContent(after, opts.ignorePaths)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes Changes
+	add := func(kind ChangeKind, name string, beforeValue interface{}, afterValue interface{}) error {
+		change, changed, compareErr := compareValues(kind, name, beforeValue, afterValue)
+		if compareErr != nil {
+			return fmt.Errorf("%s %q: %w", kind, name, compareErr)
+		}
+		if changed {
+			changes = append(changes, change)
+		}
+		return nil
+	}
+
+	if addErr := add(ChangeKindMetadata, after.Metadata.Name, field(beforeContent, "metadata"), field(afterContent, "metadata")); addErr != nil {
+		return nil, addErr
+	}
+
+	beforeSpec, afterSpec := field(beforeContent, "spec"), field(afterContent, "spec")
+	if addErr := add(ChangeKindSettings, after.Metadata.Name, settings(beforeSpec), settings(afterSpec)); addErr != nil {
+		return nil, addErr
+	}
+
+	beforeDatasources, afterDatasources := mapField(beforeSpec, "datasources"), mapField(afterSpec, "datasources")
+	for _, name := range sortedUnion(beforeDatasources, afterDatasources) {
+		if addErr := add(ChangeKindDatasource, name, nilIfAbsent(beforeDatasources, name), nilIfAbsent(afterDatasources, name)); addErr != nil {
+			return nil, addErr
+		}
+	}
+
+	beforeVariables, beforeNames := variablesByName(beforeSpec)
+	afterVariables, afterNames := variablesByName(afterSpec)
+	var variableNames []string
+	for _, name := range append(beforeNames, afterNames...) {
+		if !slices.Contains(variableNames, name) {
+			variableNames = append(variableNames, name)
+		}
+	}
+	for _, name := range variableNames {
+		if addErr := add(ChangeKindVariable, name, nilIfAbsent(beforeVariables, name), nilIfAbsent(afterVariables, name)); addErr != nil {
+			return nil, addErr
+		}
+	}
+
+	beforePanels, afterPanels := mapField(beforeSpec, "panels"), mapField(afterSpec, "panels")
+	for _, name := range sortedUnion(beforePanels, afterPanels) {
+		if addErr := add(ChangeKindPanel, name, nilIfAbsent(beforePanels, name), nilIfAbsent(afterPanels, name)); addErr != nil {
+			return nil, addErr
+		}
+	}
+
+	// The layouts have no name: they are compared by position.
+	beforeLayouts, afterLayouts := listField(beforeSpec, "layouts"), listField(afterSpec, "layouts")
+	for i := 0; i < max(len(beforeLayouts), len(afterLayouts)); i++ {
+		var beforeLayout, afterLayout interface{}
+		name := fmt.Sprintf("#%d", i)
+		if i < len(beforeLayouts) {
+			beforeLayout = beforeLayouts[i]
+			name = layoutName(i, beforeLayouts[i])
+		}
+		if i < len(afterLayouts) {
+			afterLayout = afterLayouts[i]
+			name = layoutName(i, afterLayouts[i])
+		}
+		if addErr := add(ChangeKindLayout, name, beforeLayout, afterLayout); addErr != nil {
+			return nil, addErr
+		}
+	}
+	return changes, nil
+}
+
+// normalizedContent returns the dashboard decoded from its JSON representation, without the ignored paths.
+func normalizedContent(dashboard *v1.Dashboard, ignorePaths [][]string) (interface{}, error) {
+	data, err := json.Marshal(dashboard)
+	if err != nil {
+		return nil, err
+	}
+	var content interface{}
+	if err := json.Unmarshal(data, &content); err != nil {
+		return nil, err
+	}
+	for _, path := range ignorePaths {
+		removePath(content, path)
+	}
+	return content, nil
+}
+
+// removePath removes the fields matching the path from the content decoded from JSON.
+func removePath(content interface{}, path []string) {
+	if len(path) == 0 {
+		return
+	}
+	last := len(path) == 1
+	switch node := content.(type) {
+	case map[string]interface{}:
+		for key, value := range node {
+			if path[0] != pathWildcard && path[0] != key {
+				continue
+			}
+			if last {
+				delete(node, key)
+			} else {
+				removePath(value, path[1:])
+			}
+		}
+	case []interface{}:
+		// Removing an element would shift the following ones, so only the content of the elements is removed.
+		for i, value := range node {
+			if path[0] != pathWildcard && path[0] != strconv.Itoa(i) {
+				continue
+			}
+			if !last {
+				removePath(value, path[1:])
+			}
+		}
+	}
+}
+
+// compareValues compares the JSON representations of two values. A nil value stands for an absent element.
+func compareValues(kind ChangeKind, name string, before interface{}, after interface{}) (Change, bool, error) {
+	change := Change{Kind: kind, Name: name}
+	switch {
+	case before == nil && after == nil:
+		return change, false, nil
+	case before == nil:
+		change.Type = ChangeAdded
+		return change, true, nil
+	case after == nil:
+		change.Type = ChangeRemoved
+		return change, true, nil
+	}
+	beforeJSON, err := json.MarshalIndent(before, "", "  ")
+	if err != nil {
+		return change, false, err
+	}
+	afterJSON, err := json.MarshalIndent(after, "", "  ")
+	if err != nil {
+		return change, false, err
+	}
+	if string(beforeJSON) == string(afterJSON) {
+		return change, false, nil
+	}
+	change.Type = ChangeModified
+	change.Diff = diff.Diff(string(beforeJSON), string(afterJSON))
+	return change, true, nil
+}
+
+// field returns the value of the key of a JSON object, or nil when the node is not an object or has no such key.
+func field(node interface{}, key string) interface{} {
+	object, _ := node.(map[string]interface{})
+	return object[key]
+}
+
+func mapField(node interface{}, key string) map[string]interface{} {
+	result, _ := field(node, key).(map[string]interface{})
+	return result
+}
+
+func listField(node interface{}, key string) []interface{} {
+	result, _ := field(node, key).([]interface{})
+	return result
+}
+
+// settings returns the fields of the spec that are not compared element by element, or nil when there are none.
+func settings(spec interface{}) interface{} {
+	object, ok := spec.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	result := make(map[string]interface{}, len(object))
+	for key, value := range object {
+		if !slices.Contains(specElements, key) {
+			result[key] = value
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func layoutName(index int, layout interface{}) string {
+	title, _ := field(field(field(layout, "spec"), "display"), "title").(string)
+	if len(title) == 0 {
+		return fmt.Sprintf("#%d", index)
+	}
+	return fmt.Sprintf("#%d %s", index, title)
+}
+
+// variablesByName returns the variables of the spec by name, and their names in order. A variable whose name has been
+// ignored is named after its position.
+func variablesByName(spec interface{}) (map[string]interface{}, []string) {
+	variables := listField(spec, "variables")
+	result := make(map[string]interface{}, len(variables))
+	names := make([]string, 0, len(variables))
+	for i, v := range variables {
+		name, _ := field(field(v, "spec"), "name").(string)
+		if len(name) == 0 {
+			name = fmt.Sprintf("#%d", i)
+		}
+		result[name] = v
+		names = append(names, name)
+	}
+	return result, names
+}
+
+// nilIfAbsent returns the element of the map, or an untyped nil when the key is absent so compareValues can tell.
+func nilIfAbsent[T any](m map[string]T, key string) interface{} {
+	value, ok := m[key]
+	if !ok {
+		return nil
+	}
+	return value
+}
+
+func sortedUnion[T any](a map[string]T, b map[string]T) []string {
+	union := make(map[string]bool, len(a)+len(b))
+	for key := range a {
+		union[key] = true
+	}
+	for key := range b {
+		union[key] = true
+	}
+	return sortedKeys(union)
+}
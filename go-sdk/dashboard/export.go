@@ -0,0 +1,154 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+	"gopkg.in/yaml.v3"
+)
+
+var nonSlugCharRegexp = regexp.MustCompile(`[^a-z0-9]+`)
+
+// GroupFragment is the content of a panel group as written by ExportByGroup.
+// It is meant for review only and cannot be applied on its own.
+type GroupFragment struct {
+	Title  string               `json:"title" yaml:"title"`
+	Layout dashboard.Layout     `json:"layout" yaml:"layout"`
+	Panels map[string]*v1.Panel `json:"panels" yaml:"panels"`
+}
+
+// OutputFormat is a format the dashboard can be written in.
+type OutputFormat string
+
+const (
+	JSONFormat OutputFormat = "json"
+	YAMLFormat OutputFormat = "yaml"
+)
+
+// Write encodes the dashboard in the given format directly to the writer. See WriteJSON and WriteYAML.
+func (b Builder) Write(w io.Writer, format OutputFormat) error {
+	switch format {
+	case JSONFormat:
+		return b.WriteJSON(w)
+	case YAMLFormat:
+		return b.WriteYAML(w)
+	default:
+		return fmt.Errorf("output format must be %q or %q, not %q", JSONFormat, YAMLFormat, format)
+	}
+}
+
+// MarshalJSON encodes the dashboard, so the builder itself can be given to json.Marshal.
+func (b Builder) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.Dashboard)
+}
+
+// MarshalYAML returns the dashboard, so the builder itself can be given to yaml.Marshal.
+func (b Builder) MarshalYAML() (interface{}, error) {
+	return b.Dashboard, nil
+}
+
+// WriteJSON encodes the dashboard in JSON directly to the writer, without buffering the whole output.
+func (b Builder) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(b.Dashboard)
+}
+
+// WriteYAML encodes the dashboard in YAML directly to the writer, without buffering the whole output.
+func (b Builder) WriteYAML(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	if err := encoder.Encode(b.Dashboard); err != nil {
+		_ = encoder.Close()
+		return err
+	}
+	return encoder.Close()
+}
+
+// ExportByGroup writes the dashboard in <dir>/<name>.json, then writes one fragment file per panel group in
+// <dir>/<name>-groups/ to ease the review of large dashboards.
+// The dashboard file remains the source of truth: the fragments are not meant to be applied.
+func (b Builder) ExportByGroup(dir string) error {
+	name := b.Dashboard.Metadata.Name
+	if len(name) == 0 {
+		return fmt.Errorf("dashboard name cannot be empty")
+	}
+	if err := writeJSONFile(filepath.Join(dir, name+".json"), b.Dashboard); err != nil {
+		return err
+	}
+
+	groupsDir := filepath.Join(dir, name+"-groups")
+	if err := os.MkdirAll(groupsDir, 0755); err != nil {
+		return err
+	}
+	for i, layout := range b.Dashboard.Spec.Layouts {
+		fragment := b.groupFragment(layout)
+		fileName := fmt.Sprintf("%02d_%s.json", i, slugify(fragment.Title))
+		if err := writeJSONFile(filepath.Join(groupsDir, fileName), fragment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b Builder) groupFragment(layout dashboard.Layout) GroupFragment {
+	fragment := GroupFragment{
+		Layout: layout,
+		Panels: make(map[string]*v1.Panel),
+	}
+	var spec dashboard.GridLayoutSpec
+	switch s := layout.Spec.(type) {
+	case dashboard.GridLayoutSpec:
+		spec = s
+	case *dashboard.GridLayoutSpec:
+		spec = *s
+	default:
+		return fragment
+	}
+	if spec.Display != nil {
+		fragment.Title = spec.Display.Title
+	}
+	for _, item := range spec.Items {
+		if item.Content == nil {
+			continue
+		}
+		ref := strings.TrimPrefix(item.Content.Ref, "#/spec/panels/")
+		if p, exist := b.Dashboard.Spec.Panels[ref]; exist {
+			fragment.Panels[ref] = p
+		}
+	}
+	return fragment
+}
+
+func slugify(title string) string {
+	slug := strings.Trim(nonSlugCharRegexp.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if len(slug) == 0 {
+		return "group"
+	}
+	return slug
+}
+
+func writeJSONFile(path string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,375 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	sdk "github.com/perses/perses/go-sdk/common"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+	"github.com/perses/perses/pkg/model/api/v1/variable"
+)
+
+const (
+	grafanaRowType              = "row"
+	grafanaHiddenVariable       = 2
+	grafanaRelativeTimePrefix   = "now-"
+	grafanaDefaultGroupTitle    = "Panels"
+	timeSeriesChartKind         = "TimeSeriesChart"
+	statChartKind               = "StatChart"
+	gaugeChartKind              = "GaugeChart"
+	tableKind                   = "Table"
+	datasourceVariablePromQuery = "prometheus"
+	promDatasourceKind          = "PrometheusDatasource"
+)
+
+var (
+	// grafanaOldVariableRegexp matches the deprecated Grafana syntax [[var]].
+	grafanaOldVariableRegexp = regexp.MustCompile(`\[\[(\w+)]]`)
+	labelValuesRegexp        = regexp.MustCompile(`^label_values\(\s*(?:(.+),\s*)?(\w+)\s*\)$`)
+	labelNamesRegexp         = regexp.MustCompile(`^label_names\(\s*(.*?)\s*\)$`)
+	grafanaPanelKinds        = map[string]string{
+		"timeseries": timeSeriesChartKind,
+		"graph":      timeSeriesChartKind,
+		"stat":       statChartKind,
+		"gauge":      gaugeChartKind,
+		"table":      tableKind,
+	}
+)
+
+type grafanaGridPos struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"w"`
+	Height int `json:"h"`
+}
+
+type grafanaTarget struct {
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat"`
+}
+
+type grafanaPanel struct {
+	Type        string                 `json:"type"`
+	Title       string                 `json:"title"`
+	Description string                 `json:"description"`
+	Collapsed   bool                   `json:"collapsed"`
+	GridPos     grafanaGridPos         `json:"gridPos"`
+	Panels      []grafanaPanel         `json:"panels"`
+	Targets     []grafanaTarget        `json:"targets"`
+	Options     map[string]interface{} `json:"options"`
+}
+
+type grafanaVariable struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Label       string `json:"label"`
+	Description string `json:"description"`
+	Hide        int    `json:"hide"`
+	Multi       bool   `json:"multi"`
+	IncludeAll  bool   `json:"includeAll"`
+	AllValue    string `json:"allValue"`
+	// Query is a string, or an object with a field "query" for the recent Prometheus variables.
+	Query   interface{} `json:"query"`
+	Current struct {
+		Value interface{} `json:"value"`
+	} `json:"current"`
+}
+
+type grafanaDashboard struct {
+	UID         string `json:"uid"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// Refresh is a duration, or false when the auto-refresh is disabled.
+	Refresh interface{} `json:"refresh"`
+	Time    struct {
+		From string `json:"from"`
+	} `json:"time"`
+	Panels     []grafanaPanel `json:"panels"`
+	Templating struct {
+		List []grafanaVariable `json:"list"`
+	} `json:"templating"`
+}
+
+// FromGrafana creates a dashboard from the JSON of a Grafana dashboard, then applies the options on top of it, like New.
+// The conversion is done by the go-sdk without the migration scripts of the plugins, so it only covers the common
+// cases: the rows, the time series, stat, gauge, table and text panels with their Prometheus queries, and the custom,
+// text box, constant, interval, datasource and Prometheus query variables.
+// The queries use the default Prometheus datasource. What cannot be converted is reported by Lint: the panels are
+// replaced by a Markdown panel and the variables are dropped.
+// For a complete migration, use `percli migrate` that relies on the migration scripts of the plugins.
+func FromGrafana(grafanaJSON []byte, options ...Option) (Builder, error) {
+	var g grafanaDashboard
+	if err := json.Unmarshal(grafanaJSON, &g); err != nil {
+		return Builder{}, fmt.Errorf("unable to decode the Grafana dashboard: %w", err)
+	}
+	builder := &Builder{
+		Dashboard: v1.Dashboard{
+			Kind: v1.KindDashboard,
+		},
+	}
+	if err := fromGrafana(g)(builder); err != nil {
+		return *builder, err
+	}
+	if err := builder.finalize(sdk.ApplyOptions(builder, options)); err != nil {
+		return *builder, err
+	}
+	return *builder, nil
+}
+
+func fromGrafana(g grafanaDashboard) Option {
+	return func(builder *Builder) error {
+		name := g.UID
+		if common.ValidateID(name) != nil {
+			name = slugify(g.Title)
+		}
+		builder.Dashboard.Metadata.Name = name
+		if len(g.Title) > 0 || len(g.Description) > 0 {
+			builder.Dashboard.Spec.Display = &common.Display{Name: g.Title, Description: g.Description}
+		}
+
+		builder.Dashboard.Spec.Duration = common.Duration(time.Hour)
+		if from := strings.TrimPrefix(g.Time.From, grafanaRelativeTimePrefix); from != g.Time.From {
+			if d, err := common.ParseDuration(from); err == nil {
+				builder.Dashboard.Spec.Duration = d
+			} else {
+				builder.warnConversion("", fmt.Sprintf("time range %q is not supported, the duration is set to 1h", g.Time.From))
+			}
+		}
+		if refresh, ok := g.Refresh.(string); ok && len(refresh) > 0 {
+			if d, err := common.ParseDuration(refresh); err == nil {
+				builder.Dashboard.Spec.RefreshInterval = d
+			} else {
+				builder.warnConversion("", fmt.Sprintf("refresh interval %q is not supported", refresh))
+			}
+		}
+
+		for _, v := range g.Templating.List {
+			if result, ok := builder.convertGrafanaVariable(v); ok {
+				builder.Dashboard.Spec.Variables = append(builder.Dashboard.Spec.Variables, result)
+			}
+		}
+		builder.convertGrafanaPanels(g.Panels)
+		return nil
+	}
+}
+
+// convertGrafanaPanels creates one panel group per Grafana row. The panels placed before the first row go in a first
+// group. The panels of an expanded row are listed after the row by Grafana, while the ones of a collapsed row are
+// nested in the row.
+func (b *Builder) convertGrafanaPanels(panels []grafanaPanel) {
+	b.Dashboard.Spec.Panels = make(map[string]*v1.Panel)
+	b.Dashboard.Spec.Layouts = []dashboard.Layout{}
+	var groups []grafanaPanel
+	for _, p := range panels {
+		if p.Type == grafanaRowType {
+			groups = append(groups, p)
+			continue
+		}
+		if len(groups) == 0 {
+			groups = append(groups, grafanaPanel{Title: grafanaDefaultGroupTitle})
+		}
+		groups[len(groups)-1].Panels = append(groups[len(groups)-1].Panels, p)
+	}
+
+	for _, group := range groups {
+		if len(group.Panels) == 0 {
+			continue
+		}
+		spec := dashboard.GridLayoutSpec{
+			Display: &dashboard.GridLayoutDisplay{
+				Title:    group.Title,
+				Collapse: &dashboard.GridLayoutCollapse{Open: !group.Collapsed},
+			},
+			Items: []dashboard.GridItem{},
+		}
+		// Grafana positions are absolute in the dashboard, while Perses positions are relative to the group.
+		minY := group.Panels[0].GridPos.Y
+		for _, p := range group.Panels {
+			minY = min(minY, p.GridPos.Y)
+		}
+		for i, p := range group.Panels {
+			panelRef := fmt.Sprintf("%d_%d", len(b.Dashboard.Spec.Layouts), i)
+			b.Dashboard.Spec.Panels[panelRef] = b.convertGrafanaPanel(p)
+			spec.Items = append(spec.Items, dashboard.GridItem{
+				X:      p.GridPos.X,
+				Y:      p.GridPos.Y - minY,
+				Width:  p.GridPos.Width,
+				Height: p.GridPos.Height,
+				Content: &common.JSONRef{
+					Ref: fmt.Sprintf("#/spec/panels/%s", panelRef),
+				},
+			})
+		}
+		b.Dashboard.Spec.Layouts = append(b.Dashboard.Spec.Layouts, dashboard.Layout{
+			Kind: dashboard.KindGridLayout,
+			Spec: spec,
+		})
+	}
+}
+
+func (b *Builder) convertGrafanaPanel(p grafanaPanel) *v1.Panel {
+	result := &v1.Panel{
+		Kind: "Panel",
+		Spec: v1.PanelSpec{
+			Display: v1.PanelDisplay{Name: p.Title, Description: p.Description},
+		},
+	}
+	if p.Type == "text" {
+		content, _ := p.Options["content"].(string)
+		result.Spec.Plugin = common.Plugin{Kind: markdownPanelKind, Spec: map[string]interface{}{"text": convertGrafanaVariableSyntax(content)}}
+		return result
+	}
+	kind, ok := grafanaPanelKinds[p.Type]
+	if !ok {
+		b.warnConversion("", fmt.Sprintf("panel %q: Grafana panel type %q is not supported, it is replaced by a Markdown panel", p.Title, p.Type))
+		result.Spec.Plugin = common.Plugin{
+			Kind: markdownPanelKind,
+			Spec: map[string]interface{}{"text": fmt.Sprintf("The Grafana panel of type `%s` has not been converted.", p.Type)},
+		}
+		return result
+	}
+	spec := map[string]interface{}{}
+	if kind == statChartKind || kind == gaugeChartKind {
+		spec["calculation"] = "last-number"
+	}
+	result.Spec.Plugin = common.Plugin{Kind: kind, Spec: spec}
+	for _, target := range p.Targets {
+		if len(target.Expr) == 0 {
+			b.warnConversion("", fmt.Sprintf("panel %q: only the Prometheus queries are converted", p.Title))
+			continue
+		}
+		querySpec := map[string]interface{}{"query": convertGrafanaVariableSyntax(target.Expr)}
+		if len(target.LegendFormat) > 0 {
+			querySpec["seriesNameFormat"] = convertGrafanaVariableSyntax(target.LegendFormat)
+		}
+		result.Spec.Queries = append(result.Spec.Queries, v1.Query{
+			Kind: "TimeSeriesQuery",
+			Spec: v1.QuerySpec{
+				Plugin: common.Plugin{Kind: promTimeSeriesQueryKind, Spec: querySpec},
+			},
+		})
+	}
+	return result
+}
+
+func (b *Builder) convertGrafanaVariable(v grafanaVariable) (dashboard.Variable, bool) {
+	display := &variable.Display{
+		Name:        v.Label,
+		Description: v.Description,
+		Hidden:      v.Hide == grafanaHiddenVariable,
+	}
+	query := grafanaVariableQuery(v.Query)
+	switch v.Type {
+	case "textbox", "constant":
+		constant := v.Type == "constant"
+		if constant {
+			display.Hidden = true
+		}
+		return dashboard.Variable{
+			Kind: variable.KindText,
+			Spec: &dashboard.TextVariableSpec{
+				TextSpec: variable.TextSpec{Display: display, Value: query, Constant: constant},
+				Name:     v.Name,
+			},
+		}, true
+	case "custom", "interval":
+		var values []interface{}
+		for _, value := range strings.Split(query, ",") {
+			if value = strings.TrimSpace(value); len(value) > 0 {
+				values = append(values, value)
+			}
+		}
+		return b.grafanaListVariable(v, display, common.Plugin{Kind: staticListVariableKind, Spec: map[string]interface{}{"values": values}}), true
+	case "datasource":
+		if query != datasourceVariablePromQuery {
+			break
+		}
+		return b.grafanaListVariable(v, display, common.Plugin{
+			Kind: datasourceVariableKind,
+			Spec: map[string]interface{}{datasourceKindSpecField: promDatasourceKind},
+		}), true
+	case "query":
+		if match := labelValuesRegexp.FindStringSubmatch(query); match != nil {
+			spec := map[string]interface{}{"labelName": match[2]}
+			if len(match[1]) > 0 {
+				spec["matchers"] = []interface{}{convertGrafanaVariableSyntax(strings.TrimSpace(match[1]))}
+			}
+			return b.grafanaListVariable(v, display, common.Plugin{Kind: promLabelValuesVariableKind, Spec: spec}), true
+		}
+		if match := labelNamesRegexp.FindStringSubmatch(query); match != nil {
+			spec := map[string]interface{}{}
+			if len(match[1]) > 0 {
+				spec["matchers"] = []interface{}{convertGrafanaVariableSyntax(match[1])}
+			}
+			return b.grafanaListVariable(v, display, common.Plugin{Kind: promLabelNamesVariableKind, Spec: spec}), true
+		}
+	}
+	b.warnConversion(v.Name, fmt.Sprintf("Grafana variable of type %q with the query %q is not supported, it is dropped", v.Type, query))
+	return dashboard.Variable{}, false
+}
+
+func (b *Builder) grafanaListVariable(v grafanaVariable, display *variable.Display, plugin common.Plugin) dashboard.Variable {
+	spec := &dashboard.ListVariableSpec{
+		ListSpec: variable.ListSpec{
+			Display:        display,
+			AllowAllValue:  v.IncludeAll,
+			AllowMultiple:  v.Multi,
+			CustomAllValue: v.AllValue,
+			Plugin:         plugin,
+		},
+		Name: v.Name,
+	}
+	if !v.IncludeAll {
+		spec.CustomAllValue = ""
+	}
+	switch value := v.Current.Value.(type) {
+	case string:
+		if len(value) > 0 && value != AllValue {
+			spec.DefaultValue = &variable.DefaultValue{SingleValue: value}
+		}
+	case []interface{}:
+		values := stringSlice(value)
+		if len(values) > 0 && values[0] != AllValue && v.Multi {
+			spec.DefaultValue = &variable.DefaultValue{SliceValues: values}
+		}
+	}
+	return dashboard.Variable{Kind: variable.KindList, Spec: spec}
+}
+
+func grafanaVariableQuery(query interface{}) string {
+	switch q := query.(type) {
+	case string:
+		return q
+	case map[string]interface{}:
+		s, _ := q["query"].(string)
+		return s
+	}
+	return ""
+}
+
+// convertGrafanaVariableSyntax replaces the deprecated Grafana syntax [[var]] by ${var}.
+func convertGrafanaVariableSyntax(s string) string {
+	return grafanaOldVariableRegexp.ReplaceAllString(s, "$${$1}")
+}
+
+func (b *Builder) warnConversion(variableName string, message string) {
+	b.conversionWarnings = append(b.conversionWarnings, Warning{Variable: variableName, Message: message})
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"fmt"
+
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+)
+
+// PluginUse is a plugin used by the dashboard.
+// Plugins are not versioned in the dashboard spec, so only the kind of the plugin is known.
+type PluginUse struct {
+	Kind string `json:"kind" yaml:"kind"`
+	// Path is the JSON path of the plugin in the dashboard, e.g. "/spec/panels/0_0/spec/queries/0/spec/plugin".
+	Path string `json:"path" yaml:"path"`
+}
+
+// PluginInventory returns every plugin used by the datasources, the variables and the panels (including their queries)
+// of the dashboard. The result is sorted by path: datasources by name, then variables in their declaration order, then
+// panels by key.
+func (b Builder) PluginInventory() []PluginUse {
+	var result []PluginUse
+	for _, name := range sortedKeys(b.Dashboard.Spec.Datasources) {
+		result = append(result, PluginUse{
+			Kind: b.Dashboard.Spec.Datasources[name].Plugin.Kind,
+			Path: fmt.Sprintf("/spec/datasources/%s/plugin", name),
+		})
+	}
+	for i, v := range b.Dashboard.Spec.Variables {
+		if spec, ok := v.Spec.(*dashboard.ListVariableSpec); ok {
+			result = append(result, PluginUse{
+				Kind: spec.Plugin.Kind,
+				Path: fmt.Sprintf("/spec/variables/%d/spec/plugin", i),
+			})
+		}
+	}
+	for _, key := range sortedKeys(b.Dashboard.Spec.Panels) {
+		p := b.Dashboard.Spec.Panels[key]
+		result = append(result, PluginUse{
+			Kind: p.Spec.Plugin.Kind,
+			Path: fmt.Sprintf("/spec/panels/%s/spec/plugin", key),
+		})
+		for i, q := range p.Spec.Queries {
+			result = append(result, PluginUse{
+				Kind: q.Spec.Plugin.Kind,
+				Path: fmt.Sprintf("/spec/panels/%s/spec/queries/%d/spec/plugin", key, i),
+			})
+		}
+	}
+	return result
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	panelgroup "github.com/perses/perses/go-sdk/panel-group"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+)
+
+const gridColumns = 24
+
+// panelGroupLayout returns the position of each panel of the group. The panels placed with panelgroup.PanelAt keep their
+// position, the other panels follow the automatic flow below them.
+func panelGroupLayout(r panelgroup.Builder) []dashboard.GridItem {
+	items := make([]dashboard.GridItem, len(r.Panels))
+	flow := r
+	flow.Panels = nil
+	flow.PanelWeights = nil
+	var flowIndexes []int
+	top := 0
+	for i := range r.Panels {
+		if position, ok := r.PanelPositions[i]; ok {
+			items[i] = position
+			top = max(top, position.Y+position.Height)
+			continue
+		}
+		if weight, ok := r.PanelWeights[i]; ok {
+			if flow.PanelWeights == nil {
+				flow.PanelWeights = make(map[int]int)
+			}
+			flow.PanelWeights[len(flow.Panels)] = weight
+		}
+		flowIndexes = append(flowIndexes, i)
+		flow.Panels = append(flow.Panels, r.Panels[i])
+	}
+
+	var flowItems []dashboard.GridItem
+	if len(flow.PanelWeights) > 0 {
+		flowItems = weightedLayout(flow)
+	} else {
+		flowItems = regularLayout(flow)
+	}
+	for j, i := range flowIndexes {
+		items[i] = flowItems[j]
+		items[i].Y += top
+	}
+	return items
+}
+
+// regularLayout returns the position of each panel of the group when all the panels have the size set for the group.
+func regularLayout(r panelgroup.Builder) []dashboard.GridItem {
+	items := make([]dashboard.GridItem, 0, len(r.Panels))
+	for i := range r.Panels {
+		items = append(items, dashboard.GridItem{
+			X:      (i * r.PanelsWidth) % gridColumns,
+			Y:      (i * r.PanelsWidth) / gridColumns * r.PanelsHeight,
+			Width:  r.PanelsWidth,
+			Height: r.PanelsHeight,
+		})
+	}
+	return items
+}
+
+// weightedLayout returns the position of each panel of the group when the panels share the lines according to their
+// weight. The lines hold as many panels as with the width of the group, i.e. 24 / PanelsWidth.
+func weightedLayout(r panelgroup.Builder) []dashboard.GridItem {
+	panelsPerLine := max(gridColumns/r.PanelsWidth, 1)
+	items := make([]dashboard.GridItem, 0, len(r.Panels))
+	for start := 0; start < len(r.Panels); start += panelsPerLine {
+		end := min(start+panelsPerLine, len(r.Panels))
+		weights := make([]int, 0, end-start)
+		for i := start; i < end; i++ {
+			weight, ok := r.PanelWeights[i]
+			if !ok {
+				weight = 1
+			}
+			weights = append(weights, weight)
+		}
+		x := 0
+		for _, width := range weightedWidths(weights) {
+			items = append(items, dashboard.GridItem{
+				X:      x,
+				Y:      start / panelsPerLine * r.PanelsHeight,
+				Width:  width,
+				Height: r.PanelsHeight,
+			})
+			x += width
+		}
+	}
+	return items
+}
+
+// weightedWidths shares the columns of a line proportionally to the weights. The line is always filled, and each panel
+// is at least one column wide.
+func weightedWidths(weights []int) []int {
+	total := 0
+	for _, weight := range weights {
+		total += weight
+	}
+	widths := make([]int, len(weights))
+	x, cumulated := 0, 0
+	for i, weight := range weights {
+		cumulated += weight
+		// The end of the panel is rounded to the closest column, leaving a column for each of the following panels.
+		end := (gridColumns*cumulated + total/2) / total
+		end = min(max(end, x+1), gridColumns-(len(weights)-1-i))
+		widths[i] = end - x
+		x = end
+	}
+	return widths
+}
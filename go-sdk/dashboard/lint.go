@@ -0,0 +1,167 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+)
+
+const (
+	promLabelNamesVariableKind  = "PrometheusLabelNamesVariable"
+	promLabelValuesVariableKind = "PrometheusLabelValuesVariable"
+)
+
+// matchAllNameRegexp matches selectors like {__name__=~".*"} or {__name__=~".+"} that select every series.
+var matchAllNameRegexp = regexp.MustCompile(`^\{\s*__name__\s*=~\s*"\.[*+]"\s*}$`)
+
+// Warning is a non-blocking issue found by Lint.
+// Contrary to the errors returned by the options, a warning doesn't prevent the dashboard to be built.
+type Warning struct {
+	// Variable is the name of the variable concerned by the warning, if any.
+	Variable string `json:"variable,omitempty" yaml:"variable,omitempty"`
+	Message  string `json:"message" yaml:"message"`
+}
+
+func (w Warning) String() string {
+	if len(w.Variable) > 0 {
+		return fmt.Sprintf("variable %q: %s", w.Variable, w.Message)
+	}
+	return w.Message
+}
+
+// Lint looks for bad practices in the dashboard built and returns them as warnings.
+// For a dashboard created by FromGrafana, it also returns what couldn't be converted.
+func (b Builder) Lint() []Warning {
+	warnings := append([]Warning(nil), b.conversionWarnings...)
+	warnings = append(warnings, b.lintRefreshIntervals()...)
+	warnings = append(warnings, b.lintDimensionBudget()...)
+	for _, v := range b.Dashboard.Spec.Variables {
+		warnings = append(warnings, lintVariable(v)...)
+	}
+	return warnings
+}
+
+func (b Builder) lintRefreshIntervals() []Warning {
+	if b.minRefreshInterval == 0 {
+		return nil
+	}
+	var warnings []Warning
+	minimum := common.Duration(b.minRefreshInterval)
+	if interval := b.Dashboard.Spec.RefreshInterval; interval > 0 && interval < minimum {
+		warnings = append(warnings, Warning{
+			Message: fmt.Sprintf("refresh interval %s is smaller than the minimum %s", interval, minimum),
+		})
+	}
+	for _, interval := range b.Dashboard.Spec.RefreshIntervals {
+		if interval > 0 && interval < minimum {
+			warnings = append(warnings, Warning{
+				Message: fmt.Sprintf("selectable refresh interval %s is smaller than the minimum %s", interval, minimum),
+			})
+		}
+	}
+	return warnings
+}
+
+func (b Builder) lintDimensionBudget() []Warning {
+	if b.maxDimensionBudget == 0 {
+		return nil
+	}
+	labels := b.ReferencedLabels()
+	if len(labels) <= b.maxDimensionBudget {
+		return nil
+	}
+	return []Warning{{
+		Message: fmt.Sprintf("queries use %d distinct labels (%s), more than the budget of %d", len(labels), strings.Join(labels, ", "), b.maxDimensionBudget),
+	}}
+}
+
+func lintVariable(v dashboard.Variable) []Warning {
+	spec, ok := v.Spec.(*dashboard.ListVariableSpec)
+	if !ok {
+		return nil
+	}
+	if spec.Plugin.Kind != promLabelNamesVariableKind && spec.Plugin.Kind != promLabelValuesVariableKind {
+		return nil
+	}
+	pluginSpec, err := decodePluginSpec(spec.Plugin)
+	if err != nil {
+		return []Warning{{Variable: spec.Name, Message: fmt.Sprintf("unable to read the plugin spec: %s", err)}}
+	}
+	matchers := stringSlice(pluginSpec["matchers"])
+	if len(matchers) == 0 {
+		return []Warning{{
+			Variable: spec.Name,
+			Message:  fmt.Sprintf("%s has no matchers, the query will scan the whole TSDB", spec.Plugin.Kind),
+		}}
+	}
+	var warnings []Warning
+	for _, matcher := range matchers {
+		if isBroadMatcher(matcher) {
+			warnings = append(warnings, Warning{
+				Variable: spec.Name,
+				Message:  fmt.Sprintf("%s matcher %q doesn't select any series, the query will scan the whole TSDB", spec.Plugin.Kind, matcher),
+			})
+		}
+	}
+	return warnings
+}
+
+// isBroadMatcher returns true when the series selector doesn't restrict the series to look at.
+func isBroadMatcher(matcher string) bool {
+	m := strings.TrimSpace(matcher)
+	if len(m) == 0 {
+		return true
+	}
+	if strings.ReplaceAll(m, " ", "") == "{}" {
+		return true
+	}
+	return matchAllNameRegexp.MatchString(m)
+}
+
+// decodePluginSpec returns the spec of the plugin as a generic map.
+// The go-sdk doesn't know the plugin structs (they are provided by the plugin SDKs), so the spec goes through JSON.
+func decodePluginSpec(plugin common.Plugin) (map[string]interface{}, error) {
+	if plugin.Spec == nil {
+		return map[string]interface{}{}, nil
+	}
+	data, err := json.Marshal(plugin.Spec)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]interface{})
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func stringSlice(value interface{}) []string {
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		if s, isString := item.(string); isString {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,255 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+)
+
+const (
+	promTimeSeriesQueryKind = "PrometheusTimeSeriesQuery"
+	promQLVariableKind      = "PrometheusPromQLVariable"
+	promQLSpecField         = "query"
+	promQLVarSpecField      = "expr"
+	matchersSpecField       = "matchers"
+)
+
+var (
+	labelNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	// promNotSelectors are the identifiers that can't be a metric name when they are not followed by a "(".
+	promNotSelectors = map[string]bool{
+		"and": true, "or": true, "unless": true, "bool": true, "offset": true, "atan2": true, "inf": true, "nan": true,
+		"sum": true, "min": true, "max": true, "avg": true, "group": true, "stddev": true, "stdvar": true, "count": true,
+		"count_values": true, "bottomk": true, "topk": true, "quantile": true, "limitk": true, "limit_ratio": true,
+	}
+	// promGroupingKeywords are followed by a list of labels, e.g. "by (pod)".
+	promGroupingKeywords = map[string]bool{
+		"by": true, "without": true, "on": true, "ignoring": true, "group_left": true, "group_right": true,
+	}
+)
+
+// EnforceLabelMatcher adds the matcher label="value" (e.g. tenant="$tenant") to every series selector of the
+// Prometheus queries of the panels and of the Prometheus variables, unless the selector already has a matcher on this
+// label. It is applied once the panels and the variables have been added.
+func EnforceLabelMatcher(label string, value string) Option {
+	return func(builder *Builder) error {
+		if !labelNameRegexp.MatchString(label) {
+			return fmt.Errorf("invalid label name %q", label)
+		}
+		return builder.runInPhase(phaseDerived, func(builder *Builder) error {
+			for _, key := range sortedKeys(builder.Dashboard.Spec.Panels) {
+				p := builder.Dashboard.Spec.Panels[key]
+				for i := range p.Spec.Queries {
+					plugin := &p.Spec.Queries[i].Spec.Plugin
+					if plugin.Kind != promTimeSeriesQueryKind {
+						continue
+					}
+					if err := injectInPluginSpec(plugin, promQLSpecField, label, value); err != nil {
+						return fmt.Errorf("panel %q, query %d: %w", p.Spec.Display.Name, i, err)
+					}
+				}
+			}
+			for _, v := range builder.Dashboard.Spec.Variables {
+				spec, ok := v.Spec.(*dashboard.ListVariableSpec)
+				if !ok {
+					continue
+				}
+				var err error
+				switch spec.Plugin.Kind {
+				case promQLVariableKind:
+					err = injectInPluginSpec(&spec.Plugin, promQLVarSpecField, label, value)
+				case promLabelNamesVariableKind, promLabelValuesVariableKind:
+					err = injectInMatchers(&spec.Plugin, label, value)
+				}
+				if err != nil {
+					return fmt.Errorf("variable %q: %w", spec.Name, err)
+				}
+			}
+			return nil
+		})
+	}
+}
+
+func injectInPluginSpec(plugin *common.Plugin, field string, label string, value string) error {
+	spec, err := decodePluginSpec(*plugin)
+	if err != nil {
+		return err
+	}
+	expr, ok := spec[field].(string)
+	if !ok || len(expr) == 0 {
+		return nil
+	}
+	spec[field] = injectLabelMatcher(expr, label, value)
+	plugin.Spec = spec
+	return nil
+}
+
+func injectInMatchers(plugin *common.Plugin, label string, value string) error {
+	spec, err := decodePluginSpec(*plugin)
+	if err != nil {
+		return err
+	}
+	matchers := stringSlice(spec[matchersSpecField])
+	if len(matchers) == 0 {
+		matchers = []string{"{}"}
+	}
+	result := make([]interface{}, 0, len(matchers))
+	for _, matcher := range matchers {
+		result = append(result, injectLabelMatcher(matcher, label, value))
+	}
+	spec[matchersSpecField] = result
+	plugin.Spec = spec
+	return nil
+}
+
+// injectLabelMatcher adds label="value" to every series selector of the PromQL expression which doesn't have a matcher
+// on the label yet. The expression is scanned token by token, without being fully parsed: strings, ranges, function
+// calls, grouping clauses and variables are copied as is.
+func injectLabelMatcher(expr string, label string, value string) string {
+	matcher := fmt.Sprintf("%s=%q", label, value)
+	var result strings.Builder
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == '"' || c == '\'' || c == '`':
+			end := endOfString(expr, i)
+			result.WriteString(expr[i:end])
+			i = end
+		case c == '$':
+			// Variable, e.g. $namespace or ${namespace}.
+			end := i + 1
+			if end < len(expr) && expr[end] == '{' {
+				end = endOfBlock(expr, end, '{', '}')
+			} else {
+				end = endOfIdentifier(expr, end)
+			}
+			result.WriteString(expr[i:end])
+			i = end
+		case c == '[':
+			end := endOfBlock(expr, i, '[', ']')
+			result.WriteString(expr[i:end])
+			i = end
+		case c == '{':
+			end := endOfBlock(expr, i, '{', '}')
+			result.WriteString(addMatcher(expr[i:end], label, matcher))
+			i = end
+		case c >= '0' && c <= '9' || c == '.':
+			// Number or duration, e.g. 1e3 or 5m.
+			end := i
+			for end < len(expr) && (isIdentifierChar(expr[end]) || expr[end] == '.') {
+				end++
+			}
+			result.WriteString(expr[i:end])
+			i = end
+		case isIdentifierStart(c):
+			end := endOfIdentifier(expr, i)
+			identifier := expr[i:end]
+			result.WriteString(identifier)
+			i = end
+			next := nextNonSpace(expr, i)
+			switch {
+			case promGroupingKeywords[strings.ToLower(identifier)]:
+				if next < len(expr) && expr[next] == '(' {
+					end = endOfBlock(expr, next, '(', ')')
+					result.WriteString(expr[i:end])
+					i = end
+				}
+			case promNotSelectors[strings.ToLower(identifier)]:
+			case next < len(expr) && (expr[next] == '(' || expr[next] == '{'):
+				// Function call, or metric followed by its matchers.
+			default:
+				result.WriteString("{" + matcher + "}")
+			}
+		default:
+			result.WriteByte(c)
+			i++
+		}
+	}
+	return result.String()
+}
+
+// addMatcher adds the matcher to the block of matchers "{...}" unless it already has a matcher on the label.
+func addMatcher(block string, label string, matcher string) string {
+	content := strings.TrimSpace(block[1 : len(block)-1])
+	existingRegexp := regexp.MustCompile(`(^|[,\s])` + regexp.QuoteMeta(label) + `\s*(=~|!~|!=|=)`)
+	if existingRegexp.MatchString(promStringRegexp.ReplaceAllString(content, `""`)) {
+		return block
+	}
+	if len(content) == 0 {
+		return "{" + matcher + "}"
+	}
+	return "{" + strings.TrimSuffix(content, ",") + "," + matcher + "}"
+}
+
+func isIdentifierStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c == ':'
+}
+
+func isIdentifierChar(c byte) bool {
+	return isIdentifierStart(c) || c >= '0' && c <= '9'
+}
+
+func endOfIdentifier(expr string, start int) int {
+	end := start
+	for end < len(expr) && isIdentifierChar(expr[end]) {
+		end++
+	}
+	return end
+}
+
+func nextNonSpace(expr string, start int) int {
+	for start < len(expr) && strings.ContainsRune(" \t\n\r", rune(expr[start])) {
+		start++
+	}
+	return start
+}
+
+// endOfString returns the index following the string literal starting at start.
+func endOfString(expr string, start int) int {
+	quote := expr[start]
+	for i := start + 1; i < len(expr); i++ {
+		if expr[i] == '\\' && quote != '`' {
+			i++
+			continue
+		}
+		if expr[i] == quote {
+			return i + 1
+		}
+	}
+	return len(expr)
+}
+
+// endOfBlock returns the index following the block opened at start, skipping the strings it contains.
+func endOfBlock(expr string, start int, open byte, closing byte) int {
+	depth := 0
+	for i := start; i < len(expr); i++ {
+		switch expr[i] {
+		case '"', '\'', '`':
+			i = endOfString(expr, i) - 1
+		case open:
+			depth++
+		case closing:
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(expr)
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	promStringRegexp = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'|` + "`[^`]*`")
+	// promNameMatcherRegexp captures the metric name of a selector like {__name__="up"}.
+	promNameMatcherRegexp   = regexp.MustCompile(`__name__\s*=\s*"([a-zA-Z_:][a-zA-Z0-9_:]*)"`)
+	promMatchersRegexp      = regexp.MustCompile(`\{[^}]*}|\[[^\]]*]`)
+	promLabelMatchersRegexp = regexp.MustCompile(`\{[^}]*}`)
+	promGroupingRegexp      = regexp.MustCompile(`\b(?:by|without|on|ignoring|group_left|group_right)\s*\([^)]*\)`)
+	// promLabelRegexp captures the label names of a label matcher or of a grouping clause.
+	promLabelRegexp = regexp.MustCompile(`([a-zA-Z_][a-zA-Z0-9_]*)\s*(?:=~|!~|!=|=|,|\))`)
+	// promIdentifierRegexp captures an identifier, and the "(" following it if it is a function call.
+	promIdentifierRegexp = regexp.MustCompile(`[a-zA-Z_:][a-zA-Z0-9_:]*(\s*\()?`)
+	promKeywords         = map[string]bool{
+		"and": true, "or": true, "unless": true, "bool": true, "offset": true, "by": true, "without": true, "on": true,
+		"ignoring": true, "group_left": true, "group_right": true, "atan2": true, "inf": true, "nan": true,
+	}
+)
+
+// ReferencedMetrics returns the sorted list of the metric names used by the Prometheus queries of the panels.
+// Queries are not fully parsed: the names are extracted from the selectors of the PromQL expressions, so the metrics
+// built from a variable (e.g. "$metric") are not listed.
+func (b Builder) ReferencedMetrics() []string {
+	metrics := make(map[string]bool)
+	for _, expr := range b.promQLExpressions() {
+		for _, metric := range promQLMetrics(expr) {
+			metrics[metric] = true
+		}
+	}
+	return sortedKeys(metrics)
+}
+
+// promQLExpressions returns the PromQL expressions of the Prometheus queries of the panels.
+func (b Builder) promQLExpressions() []string {
+	var result []string
+	for _, key := range sortedKeys(b.Dashboard.Spec.Panels) {
+		for _, q := range b.Dashboard.Spec.Panels[key].Spec.Queries {
+			if q.Spec.Plugin.Kind != promTimeSeriesQueryKind {
+				continue
+			}
+			spec, err := decodePluginSpec(q.Spec.Plugin)
+			if err != nil {
+				continue
+			}
+			if expr, ok := spec["query"].(string); ok {
+				result = append(result, expr)
+			}
+		}
+	}
+	return result
+}
+
+// promQLMetrics returns the metric names found in a PromQL expression.
+func promQLMetrics(expr string) []string {
+	var result []string
+	for _, match := range promNameMatcherRegexp.FindAllStringSubmatch(expr, -1) {
+		result = append(result, match[1])
+	}
+	// Label matchers, ranges, strings and grouping clauses contain identifiers that are not metric names.
+	expr = promMatchersRegexp.ReplaceAllString(promStringRegexp.ReplaceAllString(expr, `""`), " ")
+	expr = promGroupingRegexp.ReplaceAllString(expr, " ")
+	for _, match := range promIdentifierRegexp.FindAllStringSubmatchIndex(expr, -1) {
+		identifier := strings.TrimRight(expr[match[0]:match[1]], " \t\n(")
+		isFunction := match[2] >= 0
+		isVariable := match[0] > 0 && expr[match[0]-1] == '$'
+		isDuration := match[0] > 0 && expr[match[0]-1] >= '0' && expr[match[0]-1] <= '9'
+		if isFunction || isVariable || isDuration || promKeywords[strings.ToLower(identifier)] {
+			continue
+		}
+		result = append(result, identifier)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// ReferencedLabels returns the sorted list of the label names used by the Prometheus queries of the panels, in the
+// label matchers and in the grouping clauses (by, without, on, ignoring...).
+func (b Builder) ReferencedLabels() []string {
+	labels := make(map[string]bool)
+	for _, expr := range b.promQLExpressions() {
+		for _, label := range promQLLabels(expr) {
+			labels[label] = true
+		}
+	}
+	return sortedKeys(labels)
+}
+
+// promQLLabels returns the label names found in the label matchers and in the grouping clauses of a PromQL expression.
+func promQLLabels(expr string) []string {
+	var result []string
+	expr = promStringRegexp.ReplaceAllString(expr, `""`)
+	for _, clauseRegexp := range []*regexp.Regexp{promLabelMatchersRegexp, promGroupingRegexp} {
+		for _, clause := range clauseRegexp.FindAllString(expr, -1) {
+			for _, match := range promLabelRegexp.FindAllStringSubmatch(clause, -1) {
+				if match[1] != "__name__" {
+					result = append(result, match[1])
+				}
+			}
+		}
+	}
+	return result
+}
@@ -15,15 +15,33 @@ package dashboard
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 
+	sdk "github.com/perses/perses/go-sdk/common"
 	"github.com/perses/perses/go-sdk/datasource"
+	"github.com/perses/perses/go-sdk/link"
+	"github.com/perses/perses/go-sdk/panel"
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
+	"github.com/perses/perses/go-sdk/secret"
 	"github.com/perses/perses/go-sdk/variable"
 	variablegroup "github.com/perses/perses/go-sdk/variable-group"
+	listvariable "github.com/perses/perses/go-sdk/variable/list-variable"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/perses/pkg/model/api/v1/common"
 	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+	variable2 "github.com/perses/perses/pkg/model/api/v1/variable"
+	"golang.org/x/text/language"
+)
+
+const (
+	markdownPanelKind = "Markdown"
+	footerTitle       = "Footer"
+	footerHeight      = 4
+	// provenanceAnnotationPrefix prefixes the annotations set by WithBuildProvenance.
+	provenanceAnnotationPrefix = "provenance."
+	// variableAnnotationFormat is the key of the annotations of the variables once set on the dashboard.
+	variableAnnotationFormat = "variable.%s.%s"
 )
 
 func Name(name string) Option {
@@ -52,6 +70,57 @@ func Description(description string) Option {
 	}
 }
 
+// DisplayNameI18n sets alternate display names per locale. Keys must be valid BCP 47 language tags (e.g. "fr-FR").
+// The display name set with Name remains the one used when no localized name matches the locale of the user.
+func DisplayNameI18n(names map[string]string) Option {
+	return func(builder *Builder) error {
+		localizedNames := make(map[string]string, len(names))
+		for locale, name := range names {
+			if _, err := language.Parse(locale); err != nil {
+				return fmt.Errorf("invalid locale %q: %w", locale, err)
+			}
+			if len(name) == 0 {
+				return fmt.Errorf("display name for locale %q cannot be empty", locale)
+			}
+			localizedNames[locale] = name
+		}
+		if builder.Dashboard.Spec.Display == nil {
+			builder.Dashboard.Spec.Display = &common.Display{}
+		}
+		builder.Dashboard.Spec.Display.LocalizedNames = localizedNames
+		return nil
+	}
+}
+
+// WithBuildProvenance stamps the provenance of the dashboard (e.g. commit, author, timestamp) into the annotations of
+// its metadata. Each key is prefixed with "provenance.". The provenance is given by the caller, so the build stays
+// deterministic. An empty provenance does nothing.
+func WithBuildProvenance(provenance map[string]string) Option {
+	return func(builder *Builder) error {
+		if len(provenance) == 0 {
+			return nil
+		}
+		if builder.Dashboard.Metadata.Annotations == nil {
+			builder.Dashboard.Metadata.Annotations = make(map[string]string, len(provenance))
+		}
+		for key, value := range provenance {
+			if len(key) == 0 {
+				return fmt.Errorf("provenance key cannot be empty")
+			}
+			builder.Dashboard.Metadata.Annotations[provenanceAnnotationPrefix+key] = value
+		}
+		return nil
+	}
+}
+
+// Extra stores an organization specific metadata (e.g. the owner of the dashboard) in the extensions of the dashboard.
+// Perses ignores the extensions.
+func Extra(key string, value interface{}) Option {
+	return func(builder *Builder) error {
+		return sdk.SetExtension(&builder.Dashboard.Spec.Extensions, key, value)
+	}
+}
+
 func ProjectName(name string) Option {
 	return func(builder *Builder) error {
 		builder.Dashboard.Metadata.Project = name
@@ -66,6 +135,70 @@ func RefreshInterval(seconds time.Duration) Option {
 	}
 }
 
+// RefreshIntervals sets the list of refresh intervals selectable on the dashboard.
+// The go-sdk/presets package provides common lists.
+func RefreshIntervals(intervals ...time.Duration) Option {
+	return func(builder *Builder) error {
+		result := make([]common.Duration, 0, len(intervals))
+		for _, interval := range intervals {
+			if interval < 0 {
+				return fmt.Errorf("refresh interval %s cannot be negative", interval)
+			}
+			result = append(result, common.Duration(interval))
+		}
+		builder.Dashboard.Spec.RefreshIntervals = result
+		return nil
+	}
+}
+
+// MinRefreshInterval makes Lint warn about the default or the selectable refresh intervals smaller than the given
+// minimum. "Off" (0) is always accepted.
+func MinRefreshInterval(minimum time.Duration) Option {
+	return func(builder *Builder) error {
+		if minimum < 0 {
+			return fmt.Errorf("minimum refresh interval %s cannot be negative", minimum)
+		}
+		builder.minRefreshInterval = minimum
+		return nil
+	}
+}
+
+// MaxDimensionBudget makes Lint warn when the queries of the dashboard use more distinct label names than the given
+// budget, as a hint that the dashboard may be slow.
+func MaxDimensionBudget(budget int) Option {
+	return func(builder *Builder) error {
+		if budget < 1 {
+			return fmt.Errorf("dimension budget must be at least 1")
+		}
+		builder.maxDimensionBudget = budget
+		return nil
+	}
+}
+
+// DisableAutoRefresh turns off the auto-refresh: "Off" is the default and only selectable refresh interval.
+func DisableAutoRefresh() Option {
+	return func(builder *Builder) error {
+		builder.Dashboard.Spec.RefreshInterval = 0
+		builder.Dashboard.Spec.RefreshIntervals = []common.Duration{0}
+		return nil
+	}
+}
+
+// Timezone pins the dashboard to the given IANA timezone (e.g. "UTC", "Europe/Paris").
+// When not set, the dashboard is displayed using the timezone of the browser.
+func Timezone(timezone string) Option {
+	return func(builder *Builder) error {
+		if len(timezone) == 0 || timezone == "Local" {
+			return fmt.Errorf("invalid timezone %q: must be an IANA timezone name", timezone)
+		}
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", timezone, err)
+		}
+		builder.Dashboard.Spec.Timezone = timezone
+		return nil
+	}
+}
+
 func Duration(seconds time.Duration) Option {
 	return func(builder *Builder) error {
 		builder.Dashboard.Spec.Duration = common.Duration(seconds)
@@ -73,124 +206,411 @@ func Duration(seconds time.Duration) Option {
 	}
 }
 
-func AddPanelGroup(title string, options ...panelgroup.Option) Option {
+// TimeRange sets a fixed time range displayed when landing on the dashboard, e.g. the window of an incident. It takes
+// precedence over Duration.
+func TimeRange(start time.Time, end time.Time) Option {
 	return func(builder *Builder) error {
+		if !end.After(start) {
+			return fmt.Errorf("end of the time range (%s) must be after its start (%s)", end.Format(time.RFC3339), start.Format(time.RFC3339))
+		}
+		builder.Dashboard.Spec.TimeRange = &v1.AbsoluteTimeRange{Start: start.UTC(), End: end.UTC()}
+		return nil
+	}
+}
+
+func AddPanelGroup(title string, options ...panelgroup.Option) Option {
+	return inPhase(phasePanels, func(builder *Builder) error {
 		r, err := panelgroup.New(title, options...)
 		if err != nil {
-			return err
+			return sdk.WithPath(fmt.Sprintf("panelGroup[%s]", title), err)
 		}
-
-		if builder.Dashboard.Spec.Layouts == nil {
-			builder.Dashboard.Spec.Layouts = []dashboard.Layout{}
+		if len(r.RepeatVariable) > 0 {
+			return addRepeatedPanelGroup(builder, r)
 		}
+		addPanelGroup(builder, r)
+		return nil
+	})
+}
 
-		if builder.Dashboard.Spec.Panels == nil {
-			builder.Dashboard.Spec.Panels = make(map[string]*v1.Panel)
-		}
+// AddPanelRef adds a panel group, titled with the name, made of a single panel referring to the LibraryPanel with the
+// given name (see panelgroup.AddPanelRef). The options customize the group, e.g. panelgroup.PanelHeight or
+// panelgroup.Collapsed.
+func AddPanelRef(name string, options ...panelgroup.Option) Option {
+	return AddPanelGroup(name, append([]panelgroup.Option{panelgroup.PanelsPerLine(1), panelgroup.AddPanelRef(name)}, options...)...)
+}
 
-		gridLayoutSpec := dashboard.GridLayoutSpec{
-			Display: &dashboard.GridLayoutDisplay{
-				Title: r.Title,
-			},
-			Items: []dashboard.GridItem{},
+// AddPanelGroupPerValue adds a panel group per value, in the order of the values. The factory returns the options of
+// the panel group of a value. The group is titled with the value, unless the factory sets another title with
+// panelgroup.Title. A group without panel returns an error.
+func AddPanelGroupPerValue(values []string, factory func(value string) []panelgroup.Option) Option {
+	return inPhase(phasePanels, func(builder *Builder) error {
+		seen := make(map[string]bool, len(values))
+		for _, value := range values {
+			if seen[value] {
+				return fmt.Errorf("value %q is given more than once", value)
+			}
+			seen[value] = true
+			r, err := panelgroup.New(value, factory(value)...)
+			if err != nil {
+				return fmt.Errorf("panel group for the value %q: %w", value, err)
+			}
+			if len(r.Panels) == 0 {
+				return fmt.Errorf("panel group for the value %q has no panel", value)
+			}
+			addPanelGroup(builder, r)
 		}
+		return nil
+	})
+}
+
+func addPanelGroup(builder *Builder, r panelgroup.Builder) {
+	if builder.Dashboard.Spec.Layouts == nil {
+		builder.Dashboard.Spec.Layouts = []dashboard.Layout{}
+	}
+
+	if builder.Dashboard.Spec.Panels == nil {
+		builder.Dashboard.Spec.Panels = make(map[string]*v1.Panel)
+	}
+
+	gridLayoutSpec := dashboard.GridLayoutSpec{
+		Display: &dashboard.GridLayoutDisplay{
+			Title: r.Title,
+		},
+		Items: []dashboard.GridItem{},
+	}
+
+	// An open group is collapsible even if Collapsible is not set, as it was before the field existed.
+	if r.Collapsible || !r.IsCollapsed {
+		gridLayoutSpec.Display.Collapse = &dashboard.GridLayoutCollapse{Open: !r.IsCollapsed}
+	}
 
-		if !r.IsCollapsed {
-			gridLayoutSpec.Display.Collapse = &dashboard.GridLayoutCollapse{Open: true}
+	items := panelGroupLayout(r)
+	for i := range r.Panels {
+		panelRef := fmt.Sprintf("%d_%d", len(builder.Dashboard.Spec.Layouts), i)
+		item := items[i]
+		item.Content = &common.JSONRef{
+			Ref: fmt.Sprintf("#/spec/panels/%s", panelRef),
 		}
+		gridLayoutSpec.Items = append(gridLayoutSpec.Items, item)
+		builder.Dashboard.Spec.Panels[panelRef] = &r.Panels[i]
+	}
+
+	builder.Dashboard.Spec.Layouts = append(builder.Dashboard.Spec.Layouts, dashboard.Layout{
+		Kind: "Grid",
+		Spec: gridLayoutSpec,
+	})
 
-		for i := range r.Panels {
-			panelRef := fmt.Sprintf("%d_%d", len(builder.Dashboard.Spec.Layouts), i)
-			x := (len(gridLayoutSpec.Items) * r.PanelsWidth) % 24
-			y := (len(gridLayoutSpec.Items) * r.PanelsWidth) / 24 * r.PanelsHeight
-			gridLayoutSpec.Items = append(gridLayoutSpec.Items, dashboard.GridItem{
-				X:      x,
-				Y:      y,
-				Width:  r.PanelsWidth,
-				Height: r.PanelsHeight,
-				Content: &common.JSONRef{
-					Ref: fmt.Sprintf("#/spec/panels/%s", panelRef),
-				},
-			})
-			builder.Dashboard.Spec.Panels[panelRef] = &r.Panels[i]
+	if len(r.RequiredVariables) > 0 {
+		if builder.requiredVariables == nil {
+			builder.requiredVariables = make(map[string][]string)
 		}
+		builder.requiredVariables[r.Title] = append(builder.requiredVariables[r.Title], r.RequiredVariables...)
+	}
+}
 
-		builder.Dashboard.Spec.Layouts = append(builder.Dashboard.Spec.Layouts, dashboard.Layout{
-			Kind: "Grid",
-			Spec: gridLayoutSpec,
-		})
+// AddFooter appends a full-width markdown panel in a last panel group.
+// The panel group is added once all the other options have been applied, so it always remains the last one.
+func AddFooter(markdown string) Option {
+	return func(builder *Builder) error {
+		if len(markdown) == 0 {
+			return fmt.Errorf("footer content cannot be empty")
+		}
+		return builder.runInPhase(phaseDerived, AddPanelGroup(footerTitle,
+			panelgroup.PanelsPerLine(1),
+			panelgroup.PanelHeight(footerHeight),
+			panelgroup.Collapsed(false),
+			panelgroup.AddPanel(footerTitle, panel.Plugin(common.Plugin{
+				Kind: markdownPanelKind,
+				Spec: map[string]interface{}{"text": markdown},
+			})),
+		))
+	}
+}
 
+// AddLink appends a link displayed in the toolbar of the dashboard, e.g. to a runbook or to a related dashboard.
+func AddLink(title string, url string, options ...link.Option) Option {
+	return func(builder *Builder) error {
+		if len(url) == 0 {
+			return fmt.Errorf("url of the link %q cannot be empty", title)
+		}
+		l, err := link.New(url, append([]link.Option{link.Name(title)}, options...)...)
+		if err != nil {
+			return err
+		}
+		builder.Dashboard.Spec.Links = append(builder.Dashboard.Spec.Links, l.Link)
 		return nil
 	}
 }
 
+// AddDatasource adds a local datasource to the dashboard.
+// Only one datasource per plugin kind can be the default one. Having no default datasource is fine: the project or the
+// global default datasource is then used.
 func AddDatasource(name string, options ...datasource.Option) Option {
-	return func(builder *Builder) error {
+	return inPhase(phaseResources, func(builder *Builder) error {
 		ds, err := datasource.New(name, options...)
 		if err != nil {
-			return err
+			return sdk.WithPath(fmt.Sprintf("datasource[%s]", name), err)
 		}
 		if builder.Dashboard.Spec.Datasources == nil {
 			builder.Dashboard.Spec.Datasources = make(map[string]*v1.DatasourceSpec)
 		}
+		if ds.Spec.Default {
+			for _, existingName := range sortedKeys(builder.Dashboard.Spec.Datasources) {
+				existing := builder.Dashboard.Spec.Datasources[existingName]
+				if existingName != name && existing.Default && existing.Plugin.Kind == ds.Spec.Plugin.Kind {
+					return fmt.Errorf("datasources %q and %q are both defined as the default %s", existingName, name, ds.Spec.Plugin.Kind)
+				}
+			}
+		}
 		builder.Dashboard.Spec.Datasources[name] = &ds.Spec
 		return nil
-	}
+	})
 }
 
+// AddSecret declares a secret used by the datasources of the dashboard, e.g. through the secret of their HTTP proxy.
+// A secret is a resource of the project, it is not part of the dashboard: it is returned by Secrets to be applied
+// along with the dashboard. Its project is the one of the dashboard.
+func AddSecret(name string, options ...secret.Option) Option {
+	return inPhase(phaseResources, func(builder *Builder) error {
+		s, err := secret.New(name, append(options, secret.ProjectName(builder.Dashboard.Metadata.Project))...)
+		if err != nil {
+			return err
+		}
+		for _, existing := range builder.secrets {
+			if existing.Metadata.Name == name {
+				return fmt.Errorf("secret %q already exists", name)
+			}
+		}
+		builder.secrets = append(builder.secrets, s.Secret)
+		return nil
+	})
+}
+
+// AddVariable appends a variable to the dashboard. It can be mixed with AddVariableGroup: variables are kept in the
+// order the options are declared, and declaring twice the same variable name returns an error.
 func AddVariable(name string, options ...variable.Option) Option {
-	return func(builder *Builder) error {
+	return inPhase(phaseResources, func(builder *Builder) error {
 		v, err := variable.New(name, options...)
 		if err != nil {
-			return err
+			return sdk.WithPath(fmt.Sprintf("variable[%s]", name), err)
 		}
+		return addVariable(builder, v.Variable)
+	})
+}
 
-		if spec, ok := v.Variable.Spec.Spec.(dashboard.ListVariableSpec); ok {
-			spec.Name = v.Variable.Metadata.Name
-			builder.Dashboard.Spec.Variables = append(builder.Dashboard.Spec.Variables, dashboard.Variable{
-				Kind: v.Variable.Spec.Kind,
-				Spec: &spec,
-			})
-			return nil
+// AddVariableRef adds a variable referring to the project variable with the given name. The server replaces the
+// variable by the project variable each time the dashboard is saved or retrieved, so the dashboards sharing a set of
+// variables stay in sync without duplicating them. Until then, the variable is an empty text variable placeholder.
+func AddVariableRef(name string) Option {
+	return addVariableRef(v1.KindVariable, name)
+}
+
+// AddGlobalVariableRef adds a variable referring to the global variable with the given name. See AddVariableRef.
+func AddGlobalVariableRef(name string) Option {
+	return addVariableRef(v1.KindGlobalVariable, name)
+}
+
+func addVariableRef(kind v1.Kind, name string) Option {
+	return inPhase(phaseResources, func(builder *Builder) error {
+		if err := common.ValidateID(name); err != nil {
+			return fmt.Errorf("invalid variable name %q: %w", name, err)
 		}
+		for _, existing := range builder.Dashboard.Spec.Variables {
+			if existing.Spec.GetName() == name {
+				return fmt.Errorf("variable %q is declared more than once", name)
+			}
+		}
+		ref := common.NewExternalRef(v1.PluralKindMap[kind], name)
+		builder.Dashboard.Spec.Variables = append(builder.Dashboard.Spec.Variables, dashboard.Variable{
+			Kind: variable2.KindText,
+			Ref:  &ref,
+			Spec: &dashboard.TextVariableSpec{Name: name},
+		})
+		return nil
+	})
+}
 
-		if spec, ok := v.Variable.Spec.Spec.(dashboard.TextVariableSpec); ok {
-			spec.Name = v.Variable.Metadata.Name
-			builder.Dashboard.Spec.Variables = append(builder.Dashboard.Spec.Variables, dashboard.Variable{
-				Kind: v.Variable.Spec.Kind,
-				Spec: &spec,
-			})
-			return nil
+// ExternalVariables declares the project and global variables referenced by the dashboard, so they are not reported as
+// undeclared.
+func ExternalVariables(names ...string) Option {
+	return func(builder *Builder) error {
+		for _, name := range names {
+			if err := common.ValidateID(name); err != nil {
+				return fmt.Errorf("invalid variable name %q: %w", name, err)
+			}
 		}
-		return fmt.Errorf("unknown variable spec %+v", v.Variable.Spec.Spec)
+		builder.externalVariables = append(builder.externalVariables, names...)
+		return nil
 	}
 }
 
-func AddVariableGroup(options ...variablegroup.Option) Option {
+// StrictVariables makes the build fail when a query of a panel references a variable that is neither declared in the
+// dashboard nor with ExternalVariables. Without it, only the variables are checked.
+func StrictVariables() Option {
 	return func(builder *Builder) error {
+		builder.strictVariables = true
+		return nil
+	}
+}
 
+// AddVariableGroup appends a group of variables to the dashboard. See AddVariable for the ordering and the unicity of
+// the variable names.
+func AddVariableGroup(options ...variablegroup.Option) Option {
+	return inPhase(phaseResources, func(builder *Builder) error {
 		g, err := variablegroup.New(options...)
 		if err != nil {
 			return err
 		}
 
 		for _, v := range g.Variables {
-			if spec, ok := v.Spec.Spec.(dashboard.ListVariableSpec); ok {
-				spec.Name = v.Metadata.Name
-				builder.Dashboard.Spec.Variables = append(builder.Dashboard.Spec.Variables, dashboard.Variable{
-					Kind: v.Spec.Kind,
-					Spec: &spec,
-				})
-			} else if spec, ok := v.Spec.Spec.(dashboard.TextVariableSpec); ok {
-				spec.Name = v.Metadata.Name
-				builder.Dashboard.Spec.Variables = append(builder.Dashboard.Spec.Variables, dashboard.Variable{
-					Kind: v.Spec.Kind,
-					Spec: &spec,
-				})
-			} else {
-				return fmt.Errorf("unknown variable spec %+v", v.Spec.Spec)
+			if err := addVariable(builder, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// AddMetricsAuditVariable adds a hidden static list variable listing the metrics used by the panels of the dashboard
+// (see ReferencedMetrics). The variable is added once all the other options have been applied, so every panel is
+// taken into account.
+func AddMetricsAuditVariable(name string) Option {
+	return inPhase(phaseDerived, func(builder *Builder) error {
+		metrics := builder.ReferencedMetrics()
+		values := make([]interface{}, 0, len(metrics))
+		for _, metric := range metrics {
+			values = append(values, metric)
+		}
+		return AddVariable(name, listvariable.List(
+			listvariable.Hidden(true),
+			listvariable.AllowMultiple(true),
+			listvariable.AllowAllValue(true),
+			func(builder *listvariable.Builder) error {
+				builder.ListVariableSpec.Plugin = common.Plugin{
+					Kind: staticListVariableKind,
+					Spec: map[string]interface{}{"values": values},
+				}
+				return nil
+			},
+		))(builder)
+	})
+}
+
+// DefaultSelectionFromQuery sets the default values of the variables from a URL query string like
+// "stack=prod&namespace=payments". A variable can be repeated to select several values. The query is applied once all
+// the other options have been applied, so it doesn't depend on the order of the options. It returns an error when a
+// variable is not declared in the dashboard.
+func DefaultSelectionFromQuery(query string) Option {
+	return func(builder *Builder) error {
+		values, err := url.ParseQuery(query)
+		if err != nil {
+			return fmt.Errorf("invalid selection query %q: %w", query, err)
+		}
+		return builder.runInPhase(phaseDerived, func(builder *Builder) error {
+			for _, name := range sortedKeys(values) {
+				v, ok := builder.findVariable(name)
+				if !ok {
+					return fmt.Errorf("cannot select a default value for the variable %q: variable is not declared", name)
+				}
+				if err := setDefaultSelection(v, values[name]); err != nil {
+					return err
+				}
 			}
+			return nil
+		})
+	}
+}
+
+func setDefaultSelection(v dashboard.Variable, values []string) error {
+	switch spec := v.Spec.(type) {
+	case *dashboard.TextVariableSpec:
+		if len(values) > 1 {
+			return fmt.Errorf("text variable %q cannot have several values", spec.Name)
+		}
+		if spec.Constant && values[0] != spec.Value {
+			return fmt.Errorf("variable %q is a constant, its value cannot be changed", spec.Name)
 		}
+		spec.Value = values[0]
+	case *dashboard.ListVariableSpec:
+		for _, value := range values {
+			if value == AllValue && !spec.AllowAllValue {
+				return fmt.Errorf("variable %q doesn't allow the all value", spec.Name)
+			}
+		}
+		if !spec.AllowMultiple {
+			if len(values) > 1 {
+				return fmt.Errorf("variable %q doesn't allow multiple values", spec.Name)
+			}
+			spec.DefaultValue = &variable2.DefaultValue{SingleValue: values[0]}
+			return nil
+		}
+		spec.DefaultValue = &variable2.DefaultValue{SliceValues: values}
+	default:
+		return fmt.Errorf("unknown variable spec %+v", v.Spec)
+	}
+	return nil
+}
+
+// AddFinalizer adds a hook run once the dashboard is complete: after the datasources, the variables, the panels and
+// what is derived from them (footer, audit variable...) have been added. Finalizers run in the order they are added,
+// before the dashboard is validated.
+func AddFinalizer(finalizer func(dashboard *v1.Dashboard) error) Option {
+	return inPhase(phaseFinalizers, func(builder *Builder) error {
+		return finalizer(&builder.Dashboard)
+	})
+}
+
+func addVariable(builder *Builder, v v1.Variable) error {
+	for _, existing := range builder.Dashboard.Spec.Variables {
+		if existing.Spec.GetName() == v.Metadata.Name {
+			return fmt.Errorf("variable %q is declared more than once", v.Metadata.Name)
+		}
+	}
+
+	if spec, ok := v.Spec.Spec.(dashboard.ListVariableSpec); ok {
+		spec.Name = v.Metadata.Name
+		builder.Dashboard.Spec.Variables = append(builder.Dashboard.Spec.Variables, dashboard.Variable{
+			Kind: v.Spec.Kind,
+			Spec: &spec,
+		})
+		addVariableAnnotations(builder, v)
 		return nil
 	}
+
+	if spec, ok := v.Spec.Spec.(dashboard.TextVariableSpec); ok {
+		spec.Name = v.Metadata.Name
+		builder.Dashboard.Spec.Variables = append(builder.Dashboard.Spec.Variables, dashboard.Variable{
+			Kind: v.Spec.Kind,
+			Spec: &spec,
+		})
+		addVariableAnnotations(builder, v)
+		return nil
+	}
+	return fmt.Errorf("unknown variable spec %+v", v.Spec.Spec)
+}
+
+// addVariableAnnotations sets the annotations of the variable on the dashboard, as the variables of a dashboard have no
+// metadata.
+func addVariableAnnotations(builder *Builder, v v1.Variable) {
+	for key, value := range v.Metadata.Annotations {
+		if builder.Dashboard.Metadata.Annotations == nil {
+			builder.Dashboard.Metadata.Annotations = make(map[string]string)
+		}
+		builder.Dashboard.Metadata.Annotations[fmt.Sprintf(variableAnnotationFormat, v.Metadata.Name, key)] = value
+	}
+}
+
+// If applies the options only when the condition is true, e.g. to add some panels to the production dashboards only.
+func If(condition bool, options ...Option) Option {
+	return func(builder *Builder) error {
+		if !condition {
+			return nil
+		}
+		return sdk.ApplyOptions(builder, options)
+	}
+}
+
+// Unless applies the options only when the condition is false. See If.
+func Unless(condition bool, options ...Option) Option {
+	return If(!condition, options...)
 }
@@ -0,0 +1,198 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+)
+
+const (
+	// AllValue is the value selected when "All" is picked in a list variable.
+	AllValue                = "$__all"
+	staticListVariableKind  = "StaticListVariable"
+	selectionValueSeparator = ","
+)
+
+// PreviewVariableExpansion returns what the UI substitutes to the variable `name` when `selected` is selected.
+// `selected` is either AllValue or a list of values separated by commas.
+// As in the UI, several values (or a single value of a variable allowing multiple values) are expanded as a regexp
+// (e.g. "(a|b)"), and "All" is expanded to the custom all value if any, or to all the values of the list otherwise.
+// The values of a list are only known for static lists. For the other lists, "All" can only be previewed when a custom
+// all value is set.
+func (b Builder) PreviewVariableExpansion(name string, selected string) (string, error) {
+	v, ok := b.findVariable(name)
+	if !ok {
+		return "", fmt.Errorf("variable %q is not declared", name)
+	}
+	switch spec := v.Spec.(type) {
+	case *dashboard.TextVariableSpec:
+		if len(selected) == 0 {
+			return spec.Value, nil
+		}
+		if spec.Constant && selected != spec.Value {
+			return "", fmt.Errorf("variable %q is a constant, its value cannot be changed", name)
+		}
+		return selected, nil
+	case *dashboard.ListVariableSpec:
+		return previewListExpansion(spec, selected)
+	default:
+		return "", fmt.Errorf("unknown variable spec %+v", v.Spec)
+	}
+}
+
+// VariablesSpec returns a copy of the variables of the dashboard, e.g. to render the variable bar alone.
+// Modifying the result doesn't modify the builder.
+func (b Builder) VariablesSpec() ([]dashboard.Variable, error) {
+	result := make([]dashboard.Variable, 0, len(b.Dashboard.Spec.Variables))
+	for _, v := range b.Dashboard.Spec.Variables {
+		switch spec := v.Spec.(type) {
+		case *dashboard.TextVariableSpec:
+			c := *spec
+			if spec.Display != nil {
+				display := *spec.Display
+				c.Display = &display
+			}
+			c.Extensions = copyExtensions(spec.Extensions)
+			result = append(result, dashboard.Variable{Kind: v.Kind, Spec: &c})
+		case *dashboard.ListVariableSpec:
+			c := *spec
+			if spec.Display != nil {
+				display := *spec.Display
+				c.Display = &display
+			}
+			c.Extensions = copyExtensions(spec.Extensions)
+			if spec.DefaultValue != nil {
+				defaultValue := *spec.DefaultValue
+				defaultValue.SliceValues = append([]string(nil), spec.DefaultValue.SliceValues...)
+				c.DefaultValue = &defaultValue
+			}
+			if spec.Sort != nil {
+				sort := *spec.Sort
+				c.Sort = &sort
+			}
+			if spec.Plugin.Spec != nil {
+				// The plugin spec is copied through JSON since its type is only known by the plugin.
+				pluginSpec, err := decodePluginSpec(spec.Plugin)
+				if err != nil {
+					return nil, fmt.Errorf("variable %q: %w", spec.Name, err)
+				}
+				c.Plugin.Spec = pluginSpec
+			}
+			result = append(result, dashboard.Variable{Kind: v.Kind, Spec: &c})
+		default:
+			return nil, fmt.Errorf("unknown variable spec %+v", v.Spec)
+		}
+	}
+	return result, nil
+}
+
+func copyExtensions(extensions map[string]interface{}) map[string]interface{} {
+	if extensions == nil {
+		return nil
+	}
+	return copyJSONValue(extensions).(map[string]interface{})
+}
+
+// copyJSONValue deep copies a value decoded from JSON (maps, slices and scalars).
+func copyJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[key] = copyJSONValue(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = copyJSONValue(item)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+func (b Builder) findVariable(name string) (dashboard.Variable, bool) {
+	for _, v := range b.Dashboard.Spec.Variables {
+		if v.Spec.GetName() == name {
+			return v, true
+		}
+	}
+	return dashboard.Variable{}, false
+}
+
+func previewListExpansion(spec *dashboard.ListVariableSpec, selected string) (string, error) {
+	if selected == AllValue {
+		if !spec.AllowAllValue {
+			return "", fmt.Errorf("variable %q doesn't allow the all value", spec.Name)
+		}
+		if len(spec.CustomAllValue) > 0 {
+			return spec.CustomAllValue, nil
+		}
+		values, err := staticListValues(spec)
+		if err != nil {
+			return "", err
+		}
+		return regexpExpansion(values), nil
+	}
+
+	var values []string
+	for _, value := range strings.Split(selected, selectionValueSeparator) {
+		if value = strings.TrimSpace(value); len(value) > 0 {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return "", fmt.Errorf("no value selected for the variable %q", spec.Name)
+	}
+	if !spec.AllowMultiple {
+		if len(values) > 1 {
+			return "", fmt.Errorf("variable %q doesn't allow multiple values", spec.Name)
+		}
+		return values[0], nil
+	}
+	return regexpExpansion(values), nil
+}
+
+// staticListValues returns the values of a StaticListVariable. Values of the other lists are only known at runtime.
+func staticListValues(spec *dashboard.ListVariableSpec) ([]string, error) {
+	if spec.Plugin.Kind != staticListVariableKind {
+		return nil, fmt.Errorf("values of the variable %q are only known at runtime, set a custom all value to preview the all value", spec.Name)
+	}
+	pluginSpec, err := decodePluginSpec(spec.Plugin)
+	if err != nil {
+		return nil, err
+	}
+	items, _ := pluginSpec["values"].([]interface{})
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		switch value := item.(type) {
+		case string:
+			values = append(values, value)
+		case map[string]interface{}:
+			if s, ok := value["value"].(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+	return values, nil
+}
+
+func regexpExpansion(values []string) string {
+	return "(" + strings.Join(values, "|") + ")"
+}
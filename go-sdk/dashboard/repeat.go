@@ -0,0 +1,82 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	panelgroup "github.com/perses/perses/go-sdk/panel-group"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+)
+
+// addRepeatedPanelGroup adds a copy of the panel group per value of its repeat variable.
+func addRepeatedPanelGroup(builder *Builder, r panelgroup.Builder) error {
+	name := r.RepeatVariable
+	v, ok := builder.findVariable(name)
+	if !ok {
+		return fmt.Errorf("panel group %q is repeated by the variable %q which is not declared", r.Title, name)
+	}
+	spec, isList := v.Spec.(*dashboard.ListVariableSpec)
+	if !isList {
+		return fmt.Errorf("panel group %q can only be repeated by a list variable, %q is not", r.Title, name)
+	}
+	values, err := staticListValues(spec)
+	if err != nil {
+		return fmt.Errorf("panel group %q: %w", r.Title, err)
+	}
+	// $name must not match a longer variable like $namespace when name is "name".
+	reference := regexp.MustCompile(fmt.Sprintf(`\$\{%[1]s(?::\w+)?}|\$%[1]s\b`, regexp.QuoteMeta(name)))
+	for _, value := range values {
+		repeated := r
+		repeated.RepeatVariable = ""
+		repeated.Title = reference.ReplaceAllLiteralString(r.Title, value)
+		if repeated.Title == r.Title {
+			repeated.Title = fmt.Sprintf("%s (%s)", r.Title, value)
+		}
+		repeated.Panels = make([]v1.Panel, 0, len(r.Panels))
+		for _, p := range r.Panels {
+			expanded, expandErr := expandPanel(p, reference, value)
+			if expandErr != nil {
+				return fmt.Errorf("panel group %q, panel %q: %w", r.Title, p.Spec.Display.Name, expandErr)
+			}
+			repeated.Panels = append(repeated.Panels, expanded)
+		}
+		addPanelGroup(builder, repeated)
+	}
+	return nil
+}
+
+// expandPanel returns a copy of the panel where the references to the variable are replaced by the value.
+// The panel goes through JSON, since the specs of the plugins are only known by the plugins.
+func expandPanel(p v1.Panel, reference *regexp.Regexp, value string) (v1.Panel, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return v1.Panel{}, err
+	}
+	// The value is escaped as a JSON string, without the surrounding quotes.
+	escaped, err := json.Marshal(value)
+	if err != nil {
+		return v1.Panel{}, err
+	}
+	replacement := strings.TrimSuffix(strings.TrimPrefix(string(escaped), `"`), `"`)
+	var result v1.Panel
+	if err := json.Unmarshal(reference.ReplaceAllLiteral(data, []byte(replacement)), &result); err != nil {
+		return v1.Panel{}, err
+	}
+	return result, nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+)
+
+// rangeVariableRegexp captures the variable used as the range of a PromQL range selector or subquery, e.g. [$interval],
+// [${interval}] or [$interval:1m].
+var rangeVariableRegexp = regexp.MustCompile(`\[\s*\$\{?(\w+)}?\s*[:\]]`)
+
+const (
+	builtinVariablePrefix    = "__"
+	datasourceVariableKind   = "DatasourceVariable"
+	datasourceKindSuffix     = "Datasource"
+	variableReferencePrefix  = "$"
+	datasourceKindSpecField  = "datasourcePluginKind"
+	queryDatasourceSpecField = "datasource"
+)
+
+// validateQueryDatasources checks that the query plugins are compatible with the datasources they target (e.g. a
+// PrometheusTimeSeriesQuery requires a PrometheusDatasource).
+// When the datasource is a variable reference (e.g. "$datasource"), the variable must be a list variable with a single
+// value, and when it is a DatasourceVariable, the kind of datasource it lists is checked.
+func (b *Builder) validateQueryDatasources() error {
+	for _, panelKey := range sortedKeys(b.Dashboard.Spec.Panels) {
+		p := b.Dashboard.Spec.Panels[panelKey]
+		for i, q := range p.Spec.Queries {
+			if len(q.Spec.Datasource) > 0 {
+				// The datasource of the query takes precedence over the one of the plugin spec.
+				if err := b.validateDatasourceSelector(map[string]interface{}{"name": q.Spec.Datasource}, q.Spec.Plugin.Kind); err != nil {
+					return fmt.Errorf("panel %q, query %d: %w", p.Spec.Display.Name, i, err)
+				}
+				continue
+			}
+			spec, err := decodePluginSpec(q.Spec.Plugin)
+			if err != nil {
+				return fmt.Errorf("panel %q, query %d: %w", p.Spec.Display.Name, i, err)
+			}
+			switch ref := spec[queryDatasourceSpecField].(type) {
+			case string:
+				if !strings.HasPrefix(ref, variableReferencePrefix) {
+					continue
+				}
+				err = b.validateDatasourceVariable(ref, q.Spec.Plugin.Kind)
+			case map[string]interface{}:
+				err = b.validateDatasourceSelector(ref, q.Spec.Plugin.Kind)
+			}
+			if err != nil {
+				return fmt.Errorf("panel %q, query %d: %w", p.Spec.Display.Name, i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateDatasourceSelector checks the kind of datasource selected by a selector {kind, name}. When the selector has
+// no kind, the kind is the one of the datasource of the dashboard with the same name, if any.
+func (b *Builder) validateDatasourceSelector(selector map[string]interface{}, queryKind string) error {
+	datasourceKind, _ := selector["kind"].(string)
+	if name, _ := selector["name"].(string); len(datasourceKind) == 0 && len(name) > 0 {
+		if ds, ok := b.Dashboard.Spec.Datasources[name]; ok {
+			datasourceKind = ds.Plugin.Kind
+		}
+	}
+	if len(datasourceKind) > 0 && !isQueryCompatible(queryKind, datasourceKind) {
+		return fmt.Errorf("query %s is not compatible with the datasource %s", queryKind, datasourceKind)
+	}
+	return nil
+}
+
+func (b *Builder) validateDatasourceVariable(ref string, queryKind string) error {
+	name := strings.Trim(strings.TrimPrefix(ref, variableReferencePrefix), "{}")
+	v, ok := b.findVariable(name)
+	if !ok {
+		return fmt.Errorf("datasource variable %q is not declared", name)
+	}
+	spec, isList := v.Spec.(*dashboard.ListVariableSpec)
+	if !isList {
+		return fmt.Errorf("datasource variable %q must be a list variable", name)
+	}
+	if spec.AllowMultiple {
+		return fmt.Errorf("datasource variable %q cannot allow multiple values", name)
+	}
+	if spec.Plugin.Kind != datasourceVariableKind {
+		return nil
+	}
+	pluginSpec, err := decodePluginSpec(spec.Plugin)
+	if err != nil {
+		return err
+	}
+	datasourceKind, _ := pluginSpec[datasourceKindSpecField].(string)
+	if len(datasourceKind) > 0 && !isQueryCompatible(queryKind, datasourceKind) {
+		return fmt.Errorf("query %s is not compatible with the %s listed by the variable %q", queryKind, datasourceKind, name)
+	}
+	return nil
+}
+
+// isQueryCompatible returns true if the query plugin can be used against the datasource plugin.
+// Plugins follow the naming convention <Name>Datasource / <Name>...Query, e.g. PrometheusDatasource and
+// PrometheusTimeSeriesQuery.
+func isQueryCompatible(queryKind string, datasourceKind string) bool {
+	prefix := strings.TrimSuffix(datasourceKind, datasourceKindSuffix)
+	return len(prefix) > 0 && strings.HasPrefix(queryKind, prefix)
+}
+
+// validateRangeVariables checks that the variables used as the range of the PromQL range selectors (e.g.
+// rate(x[$interval])) are declared. The builtin variables like $__rate_interval are always available.
+func (b *Builder) validateRangeVariables() error {
+	for _, expr := range b.promQLExpressions() {
+		for _, match := range rangeVariableRegexp.FindAllStringSubmatch(expr, -1) {
+			name := match[1]
+			if strings.HasPrefix(name, builtinVariablePrefix) {
+				continue
+			}
+			if !b.isVariableDeclared(name) {
+				return fmt.Errorf("query %q uses the variable %q as range, but this variable is not declared", expr, name)
+			}
+		}
+	}
+	return nil
+}
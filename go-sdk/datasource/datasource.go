@@ -14,9 +14,19 @@
 package datasource
 
 import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/perses/perses/go-sdk/common"
+	"github.com/perses/perses/go-sdk/http"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 )
 
+const (
+	proxySpecField     = "proxy"
+	directURLSpecField = "directUrl"
+)
+
 type Option func(datasource *Builder) error
 
 func New(name string, options ...Option) (Builder, error) {
@@ -30,10 +40,12 @@ func New(name string, options ...Option) (Builder, error) {
 		Name(name),
 	}
 
-	for _, opt := range append(defaults, options...) {
-		if err := opt(builder); err != nil {
-			return *builder, err
-		}
+	if err := sdk.ApplyOptions(builder, append(defaults, options...)); err != nil {
+		return *builder, err
+	}
+
+	if err := builder.applyProxy(); err != nil {
+		return *builder, err
 	}
 
 	return *builder, nil
@@ -41,4 +53,48 @@ func New(name string, options ...Option) (Builder, error) {
 
 type Builder struct {
 	v1.Datasource `json:",inline" yaml:",inline"`
+	// proxyURL and proxyOptions define the HTTP proxy set in the plugin spec once all the options are applied, since
+	// the plugin can be set after the proxy options. See ProxyURL.
+	proxyURL     string
+	proxyOptions []http.Option
+}
+
+// applyProxy sets the HTTP proxy in the proxy field of the plugin spec, in place of the direct URL if any.
+func (b *Builder) applyProxy() error {
+	if len(b.proxyURL) == 0 {
+		if len(b.proxyOptions) > 0 {
+			return fmt.Errorf("the proxy options require the proxy url, see datasource.ProxyURL")
+		}
+		return nil
+	}
+	if len(b.Spec.Plugin.Kind) == 0 {
+		return fmt.Errorf("the datasource plugin must be set to use a proxy")
+	}
+	proxy, err := http.New(b.proxyURL, b.proxyOptions...)
+	if err != nil {
+		return fmt.Errorf("invalid proxy: %w", err)
+	}
+	// The plugin spec is updated through JSON since its type is only known by the plugin.
+	spec := make(map[string]interface{})
+	if b.Spec.Plugin.Spec != nil {
+		data, err := json.Marshal(b.Spec.Plugin.Spec)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &spec); err != nil {
+			return fmt.Errorf("the spec of the datasource plugin %s must be an object: %w", b.Spec.Plugin.Kind, err)
+		}
+	}
+	proxyData, err := json.Marshal(proxy.Proxy)
+	if err != nil {
+		return err
+	}
+	var proxySpec interface{}
+	if err := json.Unmarshal(proxyData, &proxySpec); err != nil {
+		return err
+	}
+	delete(spec, directURLSpecField)
+	spec[proxySpecField] = proxySpec
+	b.Spec.Plugin.Spec = spec
+	return nil
 }
@@ -13,7 +13,11 @@
 
 package datasource
 
-import "github.com/perses/perses/pkg/model/api/v1/common"
+import (
+	"github.com/perses/perses/go-sdk/http"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	httpModel "github.com/perses/perses/pkg/model/api/v1/datasource/http"
+)
 
 func Name(name string) Option {
 	return func(datasource *Builder) error {
@@ -42,3 +46,45 @@ func Plugin(plugin common.Plugin) Option {
 		return nil
 	}
 }
+
+// ProxyURL makes the datasource reached through the Perses server (the proxy field of the plugin spec) instead of
+// directly by the browser. The direct URL of the plugin spec, if any, is removed.
+func ProxyURL(url string) Option {
+	return func(datasource *Builder) error {
+		datasource.proxyURL = url
+		return nil
+	}
+}
+
+// AllowedEndpoints restricts the requests forwarded by the proxy. When not set, every endpoint is accessible.
+func AllowedEndpoints(endpoints ...httpModel.AllowedEndpoint) Option {
+	return func(datasource *Builder) error {
+		datasource.proxyOptions = append(datasource.proxyOptions, http.AllowedEndpoints(endpoints...))
+		return nil
+	}
+}
+
+// AddAllowedEndpoint allows the requests with the given method on the endpoints matching the pattern. See
+// AllowedEndpoints.
+func AddAllowedEndpoint(method string, endpointPattern string) Option {
+	return func(datasource *Builder) error {
+		datasource.proxyOptions = append(datasource.proxyOptions, http.AddAllowedEndpoint(method, endpointPattern))
+		return nil
+	}
+}
+
+// AddProxyHeader adds a header to the requests forwarded by the proxy, e.g. a tenant ID.
+func AddProxyHeader(key string, value string) Option {
+	return func(datasource *Builder) error {
+		datasource.proxyOptions = append(datasource.proxyOptions, http.AddHeader(key, value))
+		return nil
+	}
+}
+
+// ProxySecret sets the secret holding the credentials used by the proxy to reach the datasource.
+func ProxySecret(name string) Option {
+	return func(datasource *Builder) error {
+		datasource.proxyOptions = append(datasource.proxyOptions, http.Secret(name))
+		return nil
+	}
+}
@@ -13,7 +13,73 @@
 
 package datasource
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
 type Selector struct {
 	Kind string `json:"kind" yaml:"kind"`
 	Name string `json:"name,omitempty" yaml:"name,omitempty"`
 }
+
+// LabelResolver returns the name of the datasource matching the given labels.
+type LabelResolver func(labels map[string]string) (string, error)
+
+// SelectorFromLabels returns a selector on the datasource matching the given labels.
+// Perses only references datasources by kind and name, so the labels are resolved to a concrete name when the
+// dashboard is built, using the resolver provided.
+func SelectorFromLabels(kind string, labels map[string]string, resolver LabelResolver) (Selector, error) {
+	if len(labels) == 0 {
+		return Selector{}, fmt.Errorf("at least one label is required to select a datasource")
+	}
+	if resolver == nil {
+		return Selector{}, fmt.Errorf("a resolver is required to select a datasource by labels")
+	}
+	name, err := resolver(labels)
+	if err != nil {
+		return Selector{}, err
+	}
+	return Selector{Kind: kind, Name: name}, nil
+}
+
+// StaticLabelResolver resolves the labels against a fixed list of datasources, where the key is the name of the
+// datasource and the value its labels. Exactly one datasource must have all the labels requested.
+func StaticLabelResolver(datasources map[string]map[string]string) LabelResolver {
+	return func(labels map[string]string) (string, error) {
+		var matches []string
+		for name, dsLabels := range datasources {
+			if containsLabels(dsLabels, labels) {
+				matches = append(matches, name)
+			}
+		}
+		sort.Strings(matches)
+		switch len(matches) {
+		case 0:
+			return "", fmt.Errorf("no datasource matches the labels %s", formatLabels(labels))
+		case 1:
+			return matches[0], nil
+		default:
+			return "", fmt.Errorf("several datasources match the labels %s: %s", formatLabels(labels), strings.Join(matches, ", "))
+		}
+	}
+}
+
+func containsLabels(labels map[string]string, expected map[string]string) bool {
+	for k, v := range expected {
+		if value, ok := labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", k, v))
+	}
+	sort.Strings(pairs)
+	return "{" + strings.Join(pairs, ",") + "}"
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ephemeraldashboard
+
+import (
+	"encoding/json"
+	"time"
+
+	sdk "github.com/perses/perses/go-sdk/common"
+	"github.com/perses/perses/go-sdk/dashboard"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type Option func(builder *Builder) error
+
+func New(name string, options ...Option) (Builder, error) {
+	builder := &Builder{
+		EphemeralDashboard: v1.EphemeralDashboard{
+			Kind: v1.KindEphemeralDashboard,
+		},
+	}
+
+	defaults := []Option{
+		Name(name),
+		TTL(24 * time.Hour),
+	}
+
+	if err := sdk.ApplyOptions(builder, append(defaults, options...)); err != nil {
+		return *builder, err
+	}
+
+	if err := builder.buildDashboard(); err != nil {
+		return *builder, err
+	}
+
+	return *builder, nil
+}
+
+type Builder struct {
+	EphemeralDashboard v1.EphemeralDashboard `json:"-" yaml:"-"`
+	// dashboardOptions are the options of the dashboard builder given with Dashboard. The dashboard is built once all
+	// the options are applied, so it gets the final name and project.
+	dashboardOptions []dashboard.Option
+}
+
+// MarshalJSON encodes the ephemeral dashboard, so the builder itself can be given to json.Marshal.
+func (b Builder) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.EphemeralDashboard)
+}
+
+// MarshalYAML returns the ephemeral dashboard, so the builder itself can be given to yaml.Marshal.
+func (b Builder) MarshalYAML() (interface{}, error) {
+	return b.EphemeralDashboard, nil
+}
+
+func (b *Builder) buildDashboard() error {
+	options := append(b.dashboardOptions, dashboard.ProjectName(b.EphemeralDashboard.Metadata.Project))
+	d, err := dashboard.New(b.EphemeralDashboard.Metadata.Name, options...)
+	if err != nil {
+		return err
+	}
+	b.EphemeralDashboard.Spec.DashboardSpec = d.Dashboard.Spec
+	return nil
+}
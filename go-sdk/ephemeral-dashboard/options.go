@@ -0,0 +1,60 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ephemeraldashboard
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+)
+
+func Name(name string) Option {
+	return func(builder *Builder) error {
+		if err := common.ValidateID(name); err != nil {
+			return fmt.Errorf("invalid ephemeral dashboard name %q: %w", name, err)
+		}
+		builder.EphemeralDashboard.Metadata.Name = name
+		return nil
+	}
+}
+
+func ProjectName(name string) Option {
+	return func(builder *Builder) error {
+		builder.EphemeralDashboard.Metadata.Project = name
+		return nil
+	}
+}
+
+// TTL defines how long the ephemeral dashboard is kept after its last update, before the server deletes it.
+func TTL(ttl time.Duration) Option {
+	return func(builder *Builder) error {
+		if ttl <= 0 {
+			return fmt.Errorf("ttl must be positive, got %s", ttl)
+		}
+		builder.EphemeralDashboard.Spec.TTL = common.Duration(ttl)
+		return nil
+	}
+}
+
+// Dashboard defines the content of the ephemeral dashboard with the options of the dashboard builder. It can be given
+// several times, the options are then applied in order.
+// The name and the project of the dashboard are the ones of the ephemeral dashboard.
+func Dashboard(options ...dashboard.Option) Option {
+	return func(builder *Builder) error {
+		builder.dashboardOptions = append(builder.dashboardOptions, options...)
+		return nil
+	}
+}
@@ -25,8 +25,8 @@ import (
 )
 
 const (
-	JSONOutput = "json"
-	YAMLOutput = "yaml"
+	JSONOutput = string(dashboard.JSONFormat)
+	YAMLOutput = string(dashboard.YAMLFormat)
 )
 
 func init() {
@@ -39,9 +39,9 @@ func executeDashboardBuilder(builder dashboard.Builder, outputFormat string, wri
 
 	switch outputFormat {
 	case YAMLOutput:
-		output, err = yaml.Marshal(builder.Dashboard)
+		output, err = yaml.Marshal(builder)
 	case JSONOutput:
-		output, err = json.Marshal(builder.Dashboard)
+		output, err = json.Marshal(builder)
 	default:
 		err = fmt.Errorf("--output must be %q or %q", JSONOutput, YAMLOutput)
 	}
@@ -0,0 +1,78 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"fmt"
+	"slices"
+
+	sdk "github.com/perses/perses/go-sdk/common"
+)
+
+// unitsWithoutShortValues are the units for which the values cannot be abbreviated.
+var unitsWithoutShortValues = []string{
+	string(sdk.MilliSecondsUnit), string(sdk.SecondsUnit), string(sdk.MinutesUnit), string(sdk.HoursUnit),
+	string(sdk.DaysUnit), string(sdk.WeeksUnit), string(sdk.MonthsUnit), string(sdk.YearsUnit),
+	string(sdk.PercentUnit), string(sdk.PercentDecimalUnit),
+}
+
+type Option func(format *Builder) error
+
+// New builds the format from the given options, to give it to the builder of a panel plugin having a format, e.g. the
+// stat or the time series panels.
+func New(options ...Option) (sdk.Format, error) {
+	builder := &Builder{}
+	if err := sdk.ApplyOptions(builder, options); err != nil {
+		return builder.Format, err
+	}
+	if builder.ShortValues && builder.Unit != nil && slices.Contains(unitsWithoutShortValues, *builder.Unit) {
+		return builder.Format, fmt.Errorf("short values are not available for the unit %q", *builder.Unit)
+	}
+	return builder.Format, nil
+}
+
+type Builder struct {
+	sdk.Format `json:",inline" yaml:",inline"`
+}
+
+// Unit sets the unit of the values, e.g. "bytes" or "requests/sec". It must be one of the supported units.
+func Unit(unit string) Option {
+	return func(builder *Builder) error {
+		if !slices.Contains(sdk.SupportedUnits, unit) {
+			return fmt.Errorf("unknown unit %q, must be one of %v", unit, sdk.SupportedUnits)
+		}
+		builder.Format.Unit = &unit
+		return nil
+	}
+}
+
+// Decimals sets the number of decimal places of the values.
+func Decimals(decimals int) Option {
+	return func(builder *Builder) error {
+		if decimals < 0 {
+			return fmt.Errorf("the number of decimal places cannot be negative")
+		}
+		builder.DecimalPlaces = decimals
+		return nil
+	}
+}
+
+// ShortValues abbreviates the large values, e.g. 1.2K instead of 1200. It is not available for the time and percent
+// units.
+func ShortValues(enabled bool) Option {
+	return func(builder *Builder) error {
+		builder.Format.ShortValues = enabled
+		return nil
+	}
+}
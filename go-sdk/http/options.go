@@ -14,6 +14,9 @@
 package http
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/perses/perses/pkg/model/api/v1/common"
 	"github.com/perses/perses/pkg/model/api/v1/datasource/http"
 )
@@ -75,3 +78,16 @@ func Secret(name string) Option {
 		return nil
 	}
 }
+
+// CacheTTL sets the duration during which the proxy caches the responses of the queries. 0 disables the cache for this
+// datasource.
+func CacheTTL(ttl time.Duration) Option {
+	return func(builder *Builder) error {
+		if ttl < 0 {
+			return fmt.Errorf("cache TTL cannot be negative")
+		}
+		d := common.Duration(ttl)
+		builder.Spec.CacheTTL = &d
+		return nil
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mixin lets a set of panels and variables be defined once and instantiated in several dashboards, each
+// instance overriding the datasource, the label selectors or the parameters declared by the mixin.
+package mixin
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+)
+
+const selectorSeparator = ","
+
+// Params are the values a mixin is instantiated with: the defaults of the mixin, overridden by the dashboard.
+type Params struct {
+	// Datasource is the name of the datasource targeted by the queries of the mixin. Empty stands for the default
+	// datasource.
+	Datasource string
+	// Selectors are the label matchers the queries of the mixin are restricted to, e.g. namespace="$namespace".
+	Selectors []string
+	// Values are the parameters declared by the mixin with Param.
+	Values map[string]string
+}
+
+// Selector returns the selectors separated by commas, to be inserted in a series selector, e.g.
+// fmt.Sprintf("up{%s}", params.Selector()).
+func (p Params) Selector() string {
+	return strings.Join(p.Selectors, selectorSeparator)
+}
+
+// Value returns the value of a parameter declared with Param.
+func (p Params) Value(name string) string {
+	return p.Values[name]
+}
+
+func (p Params) copy() Params {
+	result := Params{
+		Datasource: p.Datasource,
+		Selectors:  slices.Clone(p.Selectors),
+		Values:     make(map[string]string, len(p.Values)),
+	}
+	for name, value := range p.Values {
+		result.Values[name] = value
+	}
+	return result
+}
+
+// Content returns the dashboard options (e.g. variables and panel groups) making the mixin for the given parameters.
+type Content func(params Params) ([]dashboard.Option, error)
+
+type Mixin struct {
+	Name     string
+	Defaults Params
+	Content  Content
+}
+
+type Option func(builder *Builder) error
+
+type Builder struct {
+	Mixin `json:",inline" yaml:",inline"`
+}
+
+func New(name string, content Content, options ...Option) (Builder, error) {
+	builder := &Builder{
+		Mixin: Mixin{
+			Defaults: Params{Values: make(map[string]string)},
+		},
+	}
+
+	defaults := []Option{
+		Name(name),
+		WithContent(content),
+	}
+
+	for _, opt := range append(defaults, options...) {
+		if err := opt(builder); err != nil {
+			return *builder, err
+		}
+	}
+
+	return *builder, nil
+}
+
+// Override changes a parameter of the mixin for one instance.
+type Override func(params *Params) error
+
+// Apply returns a dashboard option adding an instance of the mixin to the dashboard. The overrides are applied on top
+// of the defaults of the mixin. Instantiating twice a mixin declaring variables in the same dashboard fails, since a
+// variable cannot be declared twice.
+func (b Builder) Apply(overrides ...Override) dashboard.Option {
+	return func(dashboardBuilder *dashboard.Builder) error {
+		params := b.Defaults.copy()
+		for _, override := range overrides {
+			if err := override(&params); err != nil {
+				return fmt.Errorf("mixin %q: %w", b.Name, err)
+			}
+		}
+		for name := range params.Values {
+			if _, ok := b.Defaults.Values[name]; !ok {
+				return fmt.Errorf("mixin %q: parameter %q is not declared", b.Name, name)
+			}
+		}
+		options, err := b.Content(params)
+		if err != nil {
+			return fmt.Errorf("mixin %q: %w", b.Name, err)
+		}
+		for _, opt := range options {
+			if err := opt(dashboardBuilder); err != nil {
+				return fmt.Errorf("mixin %q: %w", b.Name, err)
+			}
+		}
+		return nil
+	}
+}
+
+// Datasource overrides the datasource targeted by the queries of the mixin.
+func Datasource(name string) Override {
+	return func(params *Params) error {
+		if len(name) > 0 {
+			if err := common.ValidateID(name); err != nil {
+				return fmt.Errorf("invalid datasource name %q: %w", name, err)
+			}
+		}
+		params.Datasource = name
+		return nil
+	}
+}
+
+// Selectors replaces the label matchers the queries of the mixin are restricted to.
+func Selectors(selectors ...string) Override {
+	return func(params *Params) error {
+		params.Selectors = slices.Clone(selectors)
+		return nil
+	}
+}
+
+// Set overrides the value of a parameter declared by the mixin with Param.
+func Set(name string, value string) Override {
+	return func(params *Params) error {
+		params.Values[name] = value
+		return nil
+	}
+}
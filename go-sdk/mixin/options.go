@@ -0,0 +1,67 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mixin
+
+import (
+	"fmt"
+	"slices"
+)
+
+func Name(name string) Option {
+	return func(builder *Builder) error {
+		if len(name) == 0 {
+			return fmt.Errorf("mixin name cannot be empty")
+		}
+		builder.Name = name
+		return nil
+	}
+}
+
+func WithContent(content Content) Option {
+	return func(builder *Builder) error {
+		if content == nil {
+			return fmt.Errorf("content of the mixin %q cannot be nil", builder.Name)
+		}
+		builder.Content = content
+		return nil
+	}
+}
+
+// DefaultDatasource sets the datasource targeted by the queries of the mixin when the instance doesn't override it.
+func DefaultDatasource(name string) Option {
+	return func(builder *Builder) error {
+		builder.Defaults.Datasource = name
+		return nil
+	}
+}
+
+// DefaultSelectors sets the label matchers the queries of the mixin are restricted to when the instance doesn't
+// override them.
+func DefaultSelectors(selectors ...string) Option {
+	return func(builder *Builder) error {
+		builder.Defaults.Selectors = slices.Clone(selectors)
+		return nil
+	}
+}
+
+// Param declares a parameter of the mixin with its default value. Only the declared parameters can be overridden.
+func Param(name string, defaultValue string) Option {
+	return func(builder *Builder) error {
+		if len(name) == 0 {
+			return fmt.Errorf("parameter name of the mixin %q cannot be empty", builder.Name)
+		}
+		builder.Defaults.Values[name] = defaultValue
+		return nil
+	}
+}
@@ -15,8 +15,17 @@ package panelgroup
 
 import (
 	"fmt"
+	"slices"
 
+	sdk "github.com/perses/perses/go-sdk/common"
 	"github.com/perses/perses/go-sdk/panel"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+)
+
+const (
+	gridColumns             = 24
+	libraryPanelTextPattern = "Library panel `%s`"
 )
 
 func Title(title string) Option {
@@ -46,9 +55,26 @@ func PanelHeight(height int) Option {
 	}
 }
 
+// Collapsed makes the group collapsible, and tells if it is collapsed on the initial load. Without this option, the
+// group is always open and cannot be collapsed.
 func Collapsed(isCollapsed bool) Option {
 	return func(builder *Builder) error {
 		builder.IsCollapsed = isCollapsed
+		builder.Collapsible = true
+		return nil
+	}
+}
+
+// RepeatByVariable repeats the panel group for each value of the variable, like a repeated row in Grafana. The group is
+// expanded when the dashboard is built: the variable must be a StaticListVariable declared in the dashboard, since the
+// values of the other variables are only known at runtime. In each copy, the references to the variable ($name or
+// ${name}) in the title and in the panels are replaced by the value.
+func RepeatByVariable(name string) Option {
+	return func(builder *Builder) error {
+		if err := common.ValidateID(name); err != nil {
+			return fmt.Errorf("invalid variable name %q: %w", name, err)
+		}
+		builder.RepeatVariable = name
 		return nil
 	}
 }
@@ -63,13 +89,119 @@ func PanelsPerLine(panelsPerLine int) Option {
 	}
 }
 
+// PanelWeight sets the share of the line taken by the panel, e.g. a panel of weight 2 is twice as wide as a panel of
+// weight 1 on the same line. It is a panel option: panelgroup.AddPanel("CPU", panelgroup.PanelWeight(2), ...).
+// When a panel of the group has a weight, the panels of a line (see PanelsPerLine) share the 24 columns according to
+// their weight, instead of all having the same width. The panels without weight have a weight of 1.
+func PanelWeight(weight int) panel.Option {
+	return func(builder *panel.Builder) error {
+		if weight < 1 || weight > 24 {
+			return fmt.Errorf("panel weight is contained to 1 and 24")
+		}
+		builder.Weight = weight
+		return nil
+	}
+}
+
+// PanelAt places the panel at the given position of the grid of the group: x and y are the column and the line of the
+// top left corner of the panel, the grid having 24 columns. It is a panel option:
+// panelgroup.AddPanel("CPU", panelgroup.PanelAt(0, 0, 16, 6), ...).
+// The panels placed with PanelAt keep their position and cannot overlap. The other panels of the group follow the
+// automatic flow (see PanelsPerLine and PanelWeight) below them.
+func PanelAt(x int, y int, width int, height int) panel.Option {
+	return func(builder *panel.Builder) error {
+		if x < 0 || y < 0 {
+			return fmt.Errorf("panel position cannot be negative, got x=%d and y=%d", x, y)
+		}
+		if width < 1 || x+width > gridColumns {
+			return fmt.Errorf("panel must fit in the %d columns of the grid, got x=%d and width=%d", gridColumns, x, width)
+		}
+		if height < 1 {
+			return fmt.Errorf("panel height must be positive, got %d", height)
+		}
+		builder.Position = &dashboard.GridItem{X: x, Y: y, Width: width, Height: height}
+		return nil
+	}
+}
+
 func AddPanel(title string, options ...panel.Option) Option {
 	return func(builder *Builder) error {
+		index := builder.addedPanels
+		builder.addedPanels++
 		p, err := panel.New(title, options...)
 		if err != nil {
-			return err
+			return sdk.WithPath(fmt.Sprintf("panel[%d]", index), err)
+		}
+		if p.Weight > 0 {
+			if builder.PanelWeights == nil {
+				builder.PanelWeights = make(map[int]int)
+			}
+			builder.PanelWeights[len(builder.Panels)] = p.Weight
+		}
+		if p.Position != nil {
+			if err := builder.addPanelPosition(len(builder.Panels), *p.Position); err != nil {
+				return sdk.WithPath(fmt.Sprintf("panel[%d]", index), err)
+			}
+		}
+		for _, name := range p.RequiredVariables {
+			if !slices.Contains(builder.RequiredVariables, name) {
+				builder.RequiredVariables = append(builder.RequiredVariables, name)
+			}
 		}
 		builder.Panels = append(builder.Panels, p.Panel)
 		return nil
 	}
 }
+
+// AddPanelRef adds a panel referring to the LibraryPanel with the given name, defined in the project of the dashboard.
+// The server replaces the panel by the LibraryPanel each time the dashboard is saved or retrieved, so updating the
+// LibraryPanel updates all the dashboards referring to it. Until then, the panel is a markdown placeholder titled with
+// the name. The layout options of the panels (PanelWeight, PanelAt) can be given as for AddPanel.
+func AddPanelRef(name string, options ...panel.Option) Option {
+	return func(builder *Builder) error {
+		if err := common.ValidateID(name); err != nil {
+			return fmt.Errorf("invalid library panel name %q: %w", name, err)
+		}
+		placeholder := panel.Plugin(common.Plugin{
+			Kind: markdownPanelKind,
+			Spec: map[string]interface{}{"text": fmt.Sprintf(libraryPanelTextPattern, name)},
+		})
+		reference := func(b *panel.Builder) error {
+			b.LibraryPanel = name
+			return nil
+		}
+		return AddPanel(name, append([]panel.Option{placeholder, reference}, options...)...)(builder)
+	}
+}
+
+// addPanelPosition records the position of the panel at the given index, checking it doesn't overlap the panels
+// already placed.
+func (b *Builder) addPanelPosition(index int, position dashboard.GridItem) error {
+	for i := range b.Panels {
+		other, ok := b.PanelPositions[i]
+		if ok && position.X < other.X+other.Width && other.X < position.X+position.Width &&
+			position.Y < other.Y+other.Height && other.Y < position.Y+position.Height {
+			return fmt.Errorf("panel overlaps the panel %q", b.Panels[i].Spec.Display.Name)
+		}
+	}
+	if b.PanelPositions == nil {
+		b.PanelPositions = make(map[int]dashboard.GridItem)
+	}
+	b.PanelPositions[index] = position
+	return nil
+}
+
+// If applies the options only when the condition is true, e.g. to add some panels to the production dashboards only.
+func If(condition bool, options ...Option) Option {
+	return func(builder *Builder) error {
+		if !condition {
+			return nil
+		}
+		return sdk.ApplyOptions(builder, options)
+	}
+}
+
+// Unless applies the options only when the condition is false. See If.
+func Unless(condition bool, options ...Option) Option {
+	return If(!condition, options...)
+}
@@ -13,38 +13,53 @@
 
 package panelgroup
 
-import v1 "github.com/perses/perses/pkg/model/api/v1"
+import (
+	sdk "github.com/perses/perses/go-sdk/common"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+)
 
 type PanelGroup struct {
 	Title        string
 	PanelsWidth  int
 	PanelsHeight int
 	IsCollapsed  bool
-	Panels       []v1.Panel
+	// Collapsible tells if the group can be collapsed. When true, IsCollapsed tells if it is collapsed on the initial
+	// load. Otherwise, the group is always open, unless IsCollapsed is false (see Collapsed).
+	Collapsible bool
+	Panels      []v1.Panel
+	// RequiredVariables is the list of the dashboard variables the panels of the group rely on.
+	RequiredVariables []string
+	// PanelWeights maps the indexes (in Panels) of the panels using PanelWeight to their weight.
+	PanelWeights map[int]int
+	// PanelPositions maps the indexes (in Panels) of the panels using PanelAt to their position.
+	PanelPositions map[int]dashboard.GridItem
+	// RepeatVariable is the name of the variable the group is repeated for. See RepeatByVariable.
+	RepeatVariable string
 }
 
 type Option func(plugin *Builder) error
 
 type Builder struct {
 	PanelGroup `json:",inline" yaml:",inline"`
+	// addedPanels counts the panels added so far, failed ones included, to locate the errors of the panels.
+	addedPanels int
 }
 
 func New(title string, options ...Option) (Builder, error) {
 	builder := &Builder{
-		PanelGroup: PanelGroup{},
+		// A group without the option Collapsed is always open.
+		PanelGroup: PanelGroup{IsCollapsed: true},
 	}
 
 	defaults := []Option{
 		Title(title),
 		PanelWidth(12),
 		PanelHeight(8),
-		Collapsed(true),
 	}
 
-	for _, opt := range append(defaults, options...) {
-		if err := opt(builder); err != nil {
-			return *builder, err
-		}
+	if err := sdk.ApplyOptions(builder, append(defaults, options...)); err != nil {
+		return *builder, err
 	}
 
 	return *builder, nil
@@ -0,0 +1,60 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package panelgroup
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/perses/perses/go-sdk/panel"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+)
+
+const (
+	markdownPanelKind          = "Markdown"
+	variableSummaryPanelTitle  = "Viewing"
+	variableSummarySeparator   = " / "
+	variableSummaryTextPattern = "**Viewing:** %s"
+)
+
+// VariableSummary adds a markdown panel showing the values currently selected for the given variables, e.g.
+// "Viewing: prod / payments / pod-xyz". The variables are interpolated by the markdown panel when the dashboard is
+// displayed. They must be declared in the dashboard, otherwise the dashboard fails to build.
+func VariableSummary(variables ...string) Option {
+	return func(builder *Builder) error {
+		if len(variables) == 0 {
+			return fmt.Errorf("variable summary requires at least one variable")
+		}
+		tokens := make([]string, 0, len(variables))
+		for _, v := range variables {
+			if err := common.ValidateID(v); err != nil {
+				return fmt.Errorf("invalid variable name %q: %w", v, err)
+			}
+			tokens = append(tokens, fmt.Sprintf("${%s}", v))
+		}
+		builder.RequiredVariables = append(builder.RequiredVariables, variables...)
+
+		result, err := panel.New(variableSummaryPanelTitle, panel.Plugin(common.Plugin{
+			Kind: markdownPanelKind,
+			Spec: map[string]interface{}{
+				"text": fmt.Sprintf(variableSummaryTextPattern, strings.Join(tokens, variableSummarySeparator)),
+			},
+		}))
+		if err != nil {
+			return err
+		}
+		builder.Panels = append(builder.Panels, result.Panel)
+		return nil
+	}
+}
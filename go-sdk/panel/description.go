@@ -0,0 +1,76 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package panel
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// variableReferenceRegexp captures the variables referenced as $name, ${name} or ${name:format}.
+var variableReferenceRegexp = regexp.MustCompile(`\$\{?(\w+)`)
+
+// descriptionData is the data given to the template of DescriptionTemplate.
+type descriptionData struct {
+	// Title is the title of the panel.
+	Title string
+	// Extra holds the values set with Extra, e.g. {{ .Extra.owner }}.
+	Extra map[string]interface{}
+	// Vars maps the variables given to DescriptionTemplate to their reference, e.g. {{ .Vars.namespace }} renders
+	// ${namespace}, interpolated when the dashboard is displayed.
+	Vars map[string]string
+}
+
+// renderDescription renders the template given to DescriptionTemplate once all the options are applied, so the
+// template can use the title and the extra values whatever the order of the options.
+func (b *Builder) renderDescription() error {
+	if b.descriptionTemplate == nil {
+		return nil
+	}
+	tmpl, err := template.New("description").Option("missingkey=error").Parse(b.descriptionTemplate.text)
+	if err != nil {
+		return fmt.Errorf("invalid description template: %w", err)
+	}
+	data := descriptionData{
+		Title: b.Spec.Display.Name,
+		Extra: b.Spec.Extensions,
+		Vars:  make(map[string]string, len(b.descriptionTemplate.variables)),
+	}
+	for _, name := range b.descriptionTemplate.variables {
+		data.Vars[name] = fmt.Sprintf("${%s}", name)
+	}
+	var description strings.Builder
+	if err := tmpl.Execute(&description, data); err != nil {
+		return fmt.Errorf("unable to render the description template: %w", err)
+	}
+	b.Spec.Display.Description = description.String()
+
+	// The variables referenced directly in the template, e.g. $namespace, must be declared as well.
+	required := slices.Clone(b.descriptionTemplate.variables)
+	for _, match := range variableReferenceRegexp.FindAllStringSubmatch(b.Spec.Display.Description, -1) {
+		name := match[1]
+		if _, err := strconv.Atoi(name); err == nil || v1.IsBuiltinVariable(name) || slices.Contains(required, name) {
+			continue
+		}
+		required = append(required, name)
+	}
+	b.RequiredVariables = append(b.RequiredVariables, required...)
+	return nil
+}
@@ -14,8 +14,13 @@
 package panel
 
 import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/perses/perses/go-sdk/common"
 	"github.com/perses/perses/go-sdk/link"
 	"github.com/perses/perses/go-sdk/query"
+	"github.com/perses/perses/go-sdk/transform"
 	"github.com/perses/perses/pkg/model/api/v1/common"
 )
 
@@ -33,6 +38,20 @@ func Description(description string) Option {
 	}
 }
 
+// DescriptionTemplate sets the description of the panel from a Go template, e.g. to embed the owner or the runbook of
+// the panel set with Extra. The template can use the title of the panel ({{ .Title }}), the extra values
+// ({{ .Extra.owner }}) and the given dashboard variables ({{ .Vars.namespace }} renders ${namespace}). The variables
+// referenced by the description must be declared in the dashboard, otherwise the dashboard fails to build.
+func DescriptionTemplate(tmpl string, variables ...string) Option {
+	return func(builder *Builder) error {
+		builder.descriptionTemplate = &descriptionTemplate{
+			text:      tmpl,
+			variables: variables,
+		}
+		return nil
+	}
+}
+
 func Plugin(plugin common.Plugin) Option {
 	return func(builder *Builder) error {
 		builder.Spec.Plugin = plugin
@@ -40,17 +59,51 @@ func Plugin(plugin common.Plugin) Option {
 	}
 }
 
+// AlertRuleRef references the alert rule visualized by the panel.
+func AlertRuleRef(name string) Option {
+	return func(builder *Builder) error {
+		if len(strings.TrimSpace(name)) == 0 {
+			return fmt.Errorf("alert rule reference cannot be empty")
+		}
+		builder.Spec.AlertRule = name
+		return nil
+	}
+}
+
+// Extra stores an organization specific metadata (e.g. the owner of the panel) in the extensions of the panel.
+// Perses ignores the extensions.
+func Extra(key string, value interface{}) Option {
+	return func(builder *Builder) error {
+		return sdk.SetExtension(&builder.Spec.Extensions, key, value)
+	}
+}
+
 func AddQuery(options ...query.Option) Option {
 	return func(builder *Builder) error {
+		index := builder.addedQueries
+		builder.addedQueries++
 		q, err := query.New(options...)
 		if err != nil {
-			return err
+			return sdk.WithPath(fmt.Sprintf("query[%d]", index), err)
 		}
 		builder.Spec.Queries = append(builder.Spec.Queries, q.Query)
 		return nil
 	}
 }
 
+// Transform appends the given transforms to the panel. They are applied, in order, to the series returned by the
+// queries of the panel. The queries referenced by the Calculate transforms must be added to the panel.
+func Transform(options ...transform.Option) Option {
+	return func(builder *Builder) error {
+		t := &transform.Builder{}
+		if err := sdk.ApplyOptions(t, options); err != nil {
+			return err
+		}
+		builder.Spec.Transforms = append(builder.Spec.Transforms, t.Transforms...)
+		return nil
+	}
+}
+
 func AddLink(url string, options ...link.Option) Option {
 	return func(builder *Builder) error {
 		l, err := link.New(url, options...)
@@ -61,3 +114,18 @@ func AddLink(url string, options ...link.Option) Option {
 		return nil
 	}
 }
+
+// If applies the options only when the condition is true, e.g. to add some panels to the production dashboards only.
+func If(condition bool, options ...Option) Option {
+	return func(builder *Builder) error {
+		if !condition {
+			return nil
+		}
+		return sdk.ApplyOptions(builder, options)
+	}
+}
+
+// Unless applies the options only when the condition is false. See If.
+func Unless(condition bool, options ...Option) Option {
+	return If(!condition, options...)
+}
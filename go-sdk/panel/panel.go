@@ -13,7 +13,11 @@
 
 package panel
 
-import v1 "github.com/perses/perses/pkg/model/api/v1"
+import (
+	sdk "github.com/perses/perses/go-sdk/common"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+)
 
 type Option func(panel *Builder) error
 
@@ -29,10 +33,17 @@ func New(title string, options ...Option) (Builder, error) {
 		Title(title),
 	}
 
-	for _, opt := range append(defaults, options...) {
-		if err := opt(builder); err != nil {
-			return *builder, err
-		}
+	if err := sdk.ApplyOptions(builder, append(defaults, options...)); err != nil {
+		return *builder, err
+	}
+
+	if err := builder.renderDescription(); err != nil {
+		return *builder, err
+	}
+
+	// The transforms can reference the queries added after them, so they are checked once all the options are applied.
+	if err := dashboard.ValidateTransforms(builder.Spec.Transforms, len(builder.Spec.Queries)); err != nil {
+		return *builder, err
 	}
 
 	return *builder, nil
@@ -40,4 +51,20 @@ func New(title string, options ...Option) (Builder, error) {
 
 type Builder struct {
 	v1.Panel `json:",inline" yaml:",inline"`
+	// Weight is the share of the line taken by the panel in its panel group. 0 means no weight was set.
+	Weight int `json:"-" yaml:"-"`
+	// Position is the position of the panel in its panel group. nil means the panel follows the automatic flow of the
+	// group.
+	Position *dashboard.GridItem `json:"-" yaml:"-"`
+	// RequiredVariables is the list of the dashboard variables the panel relies on. See DescriptionTemplate.
+	RequiredVariables []string `json:"-" yaml:"-"`
+	// descriptionTemplate is the template given to DescriptionTemplate, rendered once all the options are applied.
+	descriptionTemplate *descriptionTemplate
+	// addedQueries counts the queries added so far, failed ones included, to locate the errors of the queries.
+	addedQueries int
+}
+
+type descriptionTemplate struct {
+	text      string
+	variables []string
 }
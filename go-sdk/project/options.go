@@ -0,0 +1,159 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/perses/perses/go-sdk/datasource"
+	"github.com/perses/perses/go-sdk/role"
+	"github.com/perses/perses/go-sdk/rolebinding"
+	"github.com/perses/perses/go-sdk/secret"
+	"github.com/perses/perses/go-sdk/variable"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+)
+
+func Name(name string) Option {
+	return func(builder *Builder) error {
+		if err := common.ValidateID(name); err != nil {
+			return fmt.Errorf("invalid project name %q: %w", name, err)
+		}
+		builder.Project.Metadata.Name = name
+		return nil
+	}
+}
+
+func DisplayName(name string) Option {
+	return func(builder *Builder) error {
+		if builder.Project.Spec.Display == nil {
+			builder.Project.Spec.Display = &common.Display{}
+		}
+		builder.Project.Spec.Display.Name = name
+		return nil
+	}
+}
+
+func Description(description string) Option {
+	return func(builder *Builder) error {
+		if builder.Project.Spec.Display == nil {
+			builder.Project.Spec.Display = &common.Display{}
+		}
+		builder.Project.Spec.Display.Description = description
+		return nil
+	}
+}
+
+// AddDashboard builds a dashboard of the project. The secrets declared in the dashboard with dashboard.AddSecret are
+// added to the project.
+func AddDashboard(name string, options ...dashboard.Option) Option {
+	return func(builder *Builder) error {
+		if slices.ContainsFunc(builder.Dashboards, func(d v1.Dashboard) bool { return d.Metadata.Name == name }) {
+			return fmt.Errorf("dashboard %q is already declared", name)
+		}
+		b, err := dashboard.New(name, append(options, dashboard.ProjectName(builder.Project.Metadata.Name))...)
+		if err != nil {
+			return fmt.Errorf("dashboard %q: %w", name, err)
+		}
+		for _, s := range b.Secrets() {
+			if err := builder.addSecret(s); err != nil {
+				return fmt.Errorf("dashboard %q: %w", name, err)
+			}
+		}
+		builder.Dashboards = append(builder.Dashboards, b.Dashboard)
+		return nil
+	}
+}
+
+// AddDatasource builds a datasource available to all the dashboards of the project.
+func AddDatasource(name string, options ...datasource.Option) Option {
+	return func(builder *Builder) error {
+		if slices.ContainsFunc(builder.Datasources, func(d v1.Datasource) bool { return d.Metadata.Name == name }) {
+			return fmt.Errorf("datasource %q is already declared", name)
+		}
+		b, err := datasource.New(name, append(options, datasource.ProjectName(builder.Project.Metadata.Name))...)
+		if err != nil {
+			return fmt.Errorf("datasource %q: %w", name, err)
+		}
+		builder.Datasources = append(builder.Datasources, b.Datasource)
+		return nil
+	}
+}
+
+// AddVariable builds a variable available to all the dashboards of the project.
+func AddVariable(name string, options ...variable.Option) Option {
+	return func(builder *Builder) error {
+		if slices.ContainsFunc(builder.Variables, func(v v1.Variable) bool { return v.Metadata.Name == name }) {
+			return fmt.Errorf("variable %q is already declared", name)
+		}
+		b, err := variable.New(name, options...)
+		if err != nil {
+			return fmt.Errorf("variable %q: %w", name, err)
+		}
+		b.Variable.Metadata.Project = builder.Project.Metadata.Name
+		builder.Variables = append(builder.Variables, b.Variable)
+		return nil
+	}
+}
+
+// AddSecret builds a secret of the project, e.g. for the HTTP proxy of its datasources.
+func AddSecret(name string, options ...secret.Option) Option {
+	return func(builder *Builder) error {
+		b, err := secret.New(name, append(options, secret.ProjectName(builder.Project.Metadata.Name))...)
+		if err != nil {
+			return fmt.Errorf("secret %q: %w", name, err)
+		}
+		return builder.addSecret(b.Secret)
+	}
+}
+
+// AddRole builds a role of the project, more info in the role package.
+func AddRole(name string, options ...role.Option) Option {
+	return func(builder *Builder) error {
+		if slices.ContainsFunc(builder.Roles, func(r v1.Role) bool { return r.Metadata.Name == name }) {
+			return fmt.Errorf("role %q is already declared", name)
+		}
+		b, err := role.New(name, append(options, role.ProjectName(builder.Project.Metadata.Name))...)
+		if err != nil {
+			return err
+		}
+		builder.Roles = append(builder.Roles, b.Role)
+		return nil
+	}
+}
+
+// AddRoleBinding builds a role binding of the project, more info in the rolebinding package.
+func AddRoleBinding(name string, options ...rolebinding.Option) Option {
+	return func(builder *Builder) error {
+		if slices.ContainsFunc(builder.RoleBindings, func(r v1.RoleBinding) bool { return r.Metadata.Name == name }) {
+			return fmt.Errorf("role binding %q is already declared", name)
+		}
+		b, err := rolebinding.New(name, append(options, rolebinding.ProjectName(builder.Project.Metadata.Name))...)
+		if err != nil {
+			return err
+		}
+		builder.RoleBindings = append(builder.RoleBindings, b.RoleBinding)
+		return nil
+	}
+}
+
+func (b *Builder) addSecret(s v1.Secret) error {
+	if slices.ContainsFunc(b.Secrets, func(existing v1.Secret) bool { return existing.Metadata.Name == s.Metadata.Name }) {
+		return fmt.Errorf("secret %q is already declared", s.Metadata.Name)
+	}
+	b.Secrets = append(b.Secrets, s)
+	return nil
+}
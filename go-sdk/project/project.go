@@ -0,0 +1,112 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package project builds a whole project as code: the project itself and the resources living in it (secrets,
+// datasources, variables, roles, role bindings and dashboards), so they can be generated from one Go program and
+// applied together with percli apply.
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"gopkg.in/yaml.v3"
+)
+
+// OutputFormat is a format the resources of the project can be written in.
+type OutputFormat string
+
+const (
+	JSONFormat OutputFormat = "json"
+	YAMLFormat OutputFormat = "yaml"
+)
+
+type Option func(project *Builder) error
+
+func New(name string, options ...Option) (Builder, error) {
+	builder := &Builder{
+		Project: v1.Project{
+			Kind: v1.KindProject,
+		},
+	}
+
+	defaults := []Option{
+		Name(name),
+	}
+
+	for _, opt := range append(defaults, options...) {
+		if err := opt(builder); err != nil {
+			return *builder, err
+		}
+	}
+
+	return *builder, nil
+}
+
+// Builder holds the project and its resources. The resources added through the options are already bound to the
+// project.
+type Builder struct {
+	Project      v1.Project       `json:"-" yaml:"-"`
+	Secrets      []v1.Secret      `json:"-" yaml:"-"`
+	Datasources  []v1.Datasource  `json:"-" yaml:"-"`
+	Variables    []v1.Variable    `json:"-" yaml:"-"`
+	Roles        []v1.Role        `json:"-" yaml:"-"`
+	RoleBindings []v1.RoleBinding `json:"-" yaml:"-"`
+	Dashboards   []v1.Dashboard   `json:"-" yaml:"-"`
+}
+
+// Resources returns the project followed by its resources, in an order they can be applied in: the secrets before the
+// datasources using them, the roles before their bindings, and the dashboards last.
+func (b Builder) Resources() []modelAPI.Entity {
+	project := b.Project
+	result := []modelAPI.Entity{&project}
+	for i := range b.Secrets {
+		result = append(result, &b.Secrets[i])
+	}
+	for i := range b.Datasources {
+		result = append(result, &b.Datasources[i])
+	}
+	for i := range b.Variables {
+		result = append(result, &b.Variables[i])
+	}
+	for i := range b.Roles {
+		result = append(result, &b.Roles[i])
+	}
+	for i := range b.RoleBindings {
+		result = append(result, &b.RoleBindings[i])
+	}
+	for i := range b.Dashboards {
+		result = append(result, &b.Dashboards[i])
+	}
+	return result
+}
+
+// Write encodes the resources as a list in the given format, which can be given as is to percli apply.
+func (b Builder) Write(w io.Writer, format OutputFormat) error {
+	switch format {
+	case JSONFormat:
+		return json.NewEncoder(w).Encode(b.Resources())
+	case YAMLFormat:
+		encoder := yaml.NewEncoder(w)
+		if err := encoder.Encode(b.Resources()); err != nil {
+			_ = encoder.Close()
+			return err
+		}
+		return encoder.Close()
+	default:
+		return fmt.Errorf("output format must be %q or %q, not %q", JSONFormat, YAMLFormat, format)
+	}
+}
@@ -14,6 +14,9 @@
 package query
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/perses/perses/pkg/model/api/v1/common"
 )
 
@@ -23,3 +26,39 @@ func Plugin(plugin common.Plugin) Option {
 		return nil
 	}
 }
+
+// Datasource sets the name of the datasource targeted by the query. It takes precedence over the datasource of the
+// plugin spec, so the queries of a single panel can target different datasources, e.g. to compare staging and prod.
+func Datasource(name string) Option {
+	return func(builder *Builder) error {
+		if err := common.ValidateID(name); err != nil {
+			return fmt.Errorf("invalid datasource name %q: %w", name, err)
+		}
+		builder.Spec.Datasource = name
+		return nil
+	}
+}
+
+// MinStep sets the lower bound of the step of the query (the minStep field of the plugin spec), e.g. to keep the queries
+// on high-cardinality metrics responsive.
+func MinStep(step time.Duration) Option {
+	return func(builder *Builder) error {
+		if step <= 0 {
+			return fmt.Errorf("min step must be positive, got %s", step)
+		}
+		builder.setSpecOverride(minStepSpecField, common.Duration(step).String())
+		return nil
+	}
+}
+
+// Resolution sets the resolution of the query (the resolution field of the plugin spec): the query returns one point
+// every n pixels instead of one point per pixel.
+func Resolution(n int) Option {
+	return func(builder *Builder) error {
+		if n < 1 {
+			return fmt.Errorf("resolution must be at least 1, got %d", n)
+		}
+		builder.setSpecOverride(resolutionSpecField, n)
+		return nil
+	}
+}
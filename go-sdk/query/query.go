@@ -13,7 +13,18 @@
 
 package query
 
-import v1 "github.com/perses/perses/pkg/model/api/v1"
+import (
+	"encoding/json"
+	"fmt"
+	sdk "github.com/perses/perses/go-sdk/common"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const (
+	minStepSpecField    = "minStep"
+	resolutionSpecField = "resolution"
+)
 
 type Option func(panel *Builder) error
 
@@ -26,10 +37,12 @@ func New(options ...Option) (Builder, error) {
 
 	defaults := []Option{}
 
-	for _, opt := range append(defaults, options...) {
-		if err := opt(builder); err != nil {
-			return *builder, err
-		}
+	if err := sdk.ApplyOptions(builder, append(defaults, options...)); err != nil {
+		return *builder, err
+	}
+
+	if err := builder.applySpecOverrides(); err != nil {
+		return *builder, err
 	}
 
 	return *builder, nil
@@ -37,4 +50,39 @@ func New(options ...Option) (Builder, error) {
 
 type Builder struct {
 	v1.Query
+	// specOverrides are set in the plugin spec once all the options are applied, since the plugin can be set after
+	// the options overriding its fields (e.g. MinStep).
+	specOverrides map[string]interface{}
+}
+
+func (b *Builder) setSpecOverride(field string, value interface{}) {
+	if b.specOverrides == nil {
+		b.specOverrides = make(map[string]interface{})
+	}
+	b.specOverrides[field] = value
+}
+
+func (b *Builder) applySpecOverrides() error {
+	if len(b.specOverrides) == 0 {
+		return nil
+	}
+	if len(b.Spec.Plugin.Kind) == 0 {
+		return fmt.Errorf("the query plugin must be set to use the min step or the resolution")
+	}
+	// The plugin spec is updated through JSON since its type is only known by the plugin.
+	spec := make(map[string]interface{})
+	if b.Spec.Plugin.Spec != nil {
+		data, err := json.Marshal(b.Spec.Plugin.Spec)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &spec); err != nil {
+			return fmt.Errorf("the spec of the query plugin %s must be an object: %w", b.Spec.Plugin.Kind, err)
+		}
+	}
+	for field, value := range b.specOverrides {
+		spec[field] = value
+	}
+	b.Spec.Plugin.Spec = spec
+	return nil
 }
@@ -0,0 +1,58 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package role
+
+import (
+	"fmt"
+
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+func Name(name string) Option {
+	return func(builder *Builder) error {
+		if err := common.ValidateID(name); err != nil {
+			return fmt.Errorf("invalid role name %q: %w", name, err)
+		}
+		builder.Metadata.Name = name
+		return nil
+	}
+}
+
+func ProjectName(name string) Option {
+	return func(builder *Builder) error {
+		builder.Metadata.Project = name
+		return nil
+	}
+}
+
+// AddPermission allows the actions on the kinds of resources of the scopes. Global scopes (e.g. GlobalDatasource)
+// cannot be used in the role of a project.
+func AddPermission(actions []role.Action, scopes []role.Scope) Option {
+	return func(builder *Builder) error {
+		if len(actions) == 0 || len(scopes) == 0 {
+			return fmt.Errorf("role %q: a permission requires at least one action and one scope", builder.Metadata.Name)
+		}
+		for _, scope := range scopes {
+			if role.IsGlobalScope(scope) {
+				return fmt.Errorf("role %q cannot use the global scope %q", builder.Metadata.Name, scope)
+			}
+		}
+		builder.Spec.Permissions = append(builder.Spec.Permissions, role.Permission{
+			Actions: actions,
+			Scopes:  scopes,
+		})
+		return nil
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rolebinding
+
+import (
+	"fmt"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+)
+
+func Name(name string) Option {
+	return func(builder *Builder) error {
+		if err := common.ValidateID(name); err != nil {
+			return fmt.Errorf("invalid role binding name %q: %w", name, err)
+		}
+		builder.Metadata.Name = name
+		return nil
+	}
+}
+
+func ProjectName(name string) Option {
+	return func(builder *Builder) error {
+		builder.Metadata.Project = name
+		return nil
+	}
+}
+
+// Role defines the role of the project granted by the role binding.
+func Role(name string) Option {
+	return func(builder *Builder) error {
+		if err := common.ValidateID(name); err != nil {
+			return fmt.Errorf("invalid role name %q: %w", name, err)
+		}
+		builder.Spec.Role = name
+		return nil
+	}
+}
+
+// AddUser grants the role to the users.
+func AddUser(names ...string) Option {
+	return func(builder *Builder) error {
+		for _, name := range names {
+			if len(name) == 0 {
+				return fmt.Errorf("role binding %q: the name of a user cannot be empty", builder.Metadata.Name)
+			}
+			if builder.Spec.Has(v1.KindUser, name) {
+				return fmt.Errorf("role binding %q: user %q is already bound", builder.Metadata.Name, name)
+			}
+			builder.Spec.Subjects = append(builder.Spec.Subjects, v1.Subject{Kind: v1.KindUser, Name: name})
+		}
+		return nil
+	}
+}
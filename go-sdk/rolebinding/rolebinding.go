@@ -0,0 +1,53 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rolebinding
+
+import (
+	"fmt"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type Option func(roleBinding *Builder) error
+
+func New(name string, options ...Option) (Builder, error) {
+	builder := &Builder{
+		RoleBinding: v1.RoleBinding{
+			Kind: v1.KindRoleBinding,
+		},
+	}
+
+	defaults := []Option{
+		Name(name),
+	}
+
+	for _, opt := range append(defaults, options...) {
+		if err := opt(builder); err != nil {
+			return *builder, err
+		}
+	}
+
+	if len(builder.Spec.Role) == 0 {
+		return *builder, fmt.Errorf("role binding %q requires a role", name)
+	}
+	if len(builder.Spec.Subjects) == 0 {
+		return *builder, fmt.Errorf("role binding %q requires at least one user", name)
+	}
+
+	return *builder, nil
+}
+
+type Builder struct {
+	v1.RoleBinding `json:",inline" yaml:",inline"`
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"fmt"
+
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/secret"
+)
+
+func Name(name string) Option {
+	return func(builder *Builder) error {
+		if err := common.ValidateID(name); err != nil {
+			return fmt.Errorf("invalid secret name %q: %w", name, err)
+		}
+		builder.Metadata.Name = name
+		return nil
+	}
+}
+
+func ProjectName(name string) Option {
+	return func(builder *Builder) error {
+		builder.Metadata.Project = name
+		return nil
+	}
+}
+
+// BasicAuth authenticates with a username and the password stored in a file of the Perses server, so the password
+// doesn't end up in the code.
+func BasicAuth(username string, passwordFile string) Option {
+	return func(builder *Builder) error {
+		if err := builder.checkNoAuth("basic auth"); err != nil {
+			return err
+		}
+		if len(username) == 0 || len(passwordFile) == 0 {
+			return fmt.Errorf("basic auth requires a username and a password file")
+		}
+		builder.Spec.BasicAuth = &secret.BasicAuth{
+			Username:     username,
+			PasswordFile: passwordFile,
+		}
+		return nil
+	}
+}
+
+// Authorization sets the Authorization header with the given type (e.g. "Bearer") and the credentials stored in a file
+// of the Perses server.
+func Authorization(authType string, credentialsFile string) Option {
+	return func(builder *Builder) error {
+		if err := builder.checkNoAuth("authorization"); err != nil {
+			return err
+		}
+		if len(credentialsFile) == 0 {
+			return fmt.Errorf("authorization requires a credentials file")
+		}
+		builder.Spec.Authorization = &secret.Authorization{
+			Type:            authType,
+			CredentialsFile: credentialsFile,
+		}
+		return nil
+	}
+}
+
+// OAuth authenticates with the OAuth client credentials flow, the client secret being stored in a file of the Perses
+// server.
+func OAuth(clientID string, clientSecretFile string, tokenURL string, scopes ...string) Option {
+	return func(builder *Builder) error {
+		if err := builder.checkNoAuth("oauth"); err != nil {
+			return err
+		}
+		if len(clientID) == 0 || len(clientSecretFile) == 0 || len(tokenURL) == 0 {
+			return fmt.Errorf("oauth requires a client ID, a client secret file and a token URL")
+		}
+		builder.Spec.OAuth = &secret.OAuth{
+			ClientID:         clientID,
+			ClientSecretFile: clientSecretFile,
+			TokenURL:         tokenURL,
+			Scopes:           scopes,
+		}
+		return nil
+	}
+}
+
+// TLSConfig sets the TLS configuration used to connect to the datasource, e.g. the CA file or a client certificate.
+func TLSConfig(config secret.TLSConfig) Option {
+	return func(builder *Builder) error {
+		builder.Spec.TLSConfig = &config
+		return nil
+	}
+}
+
+// checkNoAuth returns an error if an authentication is already set: basic auth, authorization and oauth are mutually
+// exclusive.
+func (b *Builder) checkNoAuth(auth string) error {
+	if b.Spec.BasicAuth != nil || b.Spec.Authorization != nil || b.Spec.OAuth != nil {
+		return fmt.Errorf("secret %q: %s cannot be combined with another authentication", b.Metadata.Name, auth)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+
+	"github.com/perses/perses/pkg/model/api/v1/datasource/sql"
+)
+
+func Driver(driver sql.Driver) Option {
+	return func(builder *Builder) error {
+		switch driver {
+		case sql.DriverMySQL, sql.DriverPostgreSQL, sql.DriverClickHouse:
+		default:
+			return fmt.Errorf("driver %q is not supported", driver)
+		}
+		builder.Spec.Driver = driver
+		return nil
+	}
+}
+
+func Host(host string) Option {
+	return func(builder *Builder) error {
+		if len(host) == 0 {
+			return fmt.Errorf("host cannot be empty")
+		}
+		builder.Spec.Host = host
+		return nil
+	}
+}
+
+func Database(database string) Option {
+	return func(builder *Builder) error {
+		if len(database) == 0 {
+			return fmt.Errorf("database cannot be empty")
+		}
+		builder.Spec.Database = database
+		return nil
+	}
+}
+
+func Secret(name string) Option {
+	return func(builder *Builder) error {
+		builder.Spec.Secret = name
+		return nil
+	}
+}
+
+func MySQL(config sql.MySQLConfig) Option {
+	return func(builder *Builder) error {
+		builder.Spec.MySQL = &config
+		return nil
+	}
+}
+
+func Postgres(config sql.PostgresConfig) Option {
+	return func(builder *Builder) error {
+		builder.Spec.Postgres = &config
+		return nil
+	}
+}
+
+// ClickHouse sets the ClickHouse specific config, e.g. to use HTTPS or to apply settings to the queries.
+func ClickHouse(config sql.ClickHouseConfig) Option {
+	return func(builder *Builder) error {
+		builder.Spec.ClickHouse = &config
+		return nil
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+
+	"github.com/perses/perses/pkg/model/api/v1/datasource/sql"
+)
+
+type Option func(proxy *Builder) error
+
+type Builder struct {
+	sql.Proxy `json:",inline" yaml:",inline"`
+}
+
+func New(driver sql.Driver, host string, database string, options ...Option) (Builder, error) {
+	var builder = &Builder{
+		Proxy: sql.Proxy{
+			Kind: "SQLProxy",
+			Spec: sql.Config{},
+		},
+	}
+
+	defaults := []Option{
+		Driver(driver),
+		Host(host),
+		Database(database),
+	}
+
+	for _, opt := range append(defaults, options...) {
+		if err := opt(builder); err != nil {
+			return *builder, err
+		}
+	}
+
+	if builder.Spec.MySQL != nil && builder.Spec.Driver != sql.DriverMySQL {
+		return *builder, fmt.Errorf("the mysql config can only be used with the driver %q", sql.DriverMySQL)
+	}
+	if builder.Spec.Postgres != nil && builder.Spec.Driver != sql.DriverPostgreSQL {
+		return *builder, fmt.Errorf("the postgres config can only be used with the driver %q", sql.DriverPostgreSQL)
+	}
+	if builder.Spec.ClickHouse != nil && builder.Spec.Driver != sql.DriverClickHouse {
+		return *builder, fmt.Errorf("the clickhouse config can only be used with the driver %q", sql.DriverClickHouse)
+	}
+
+	return *builder, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dac
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/perses/perses/go-sdk/client"
+	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/perses/perses/pkg/client/config"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDashboardClient(t *testing.T) {
+	stored := map[string][]byte{}
+	var calls []string
+	mux := http.NewServeMux()
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		name := r.PathValue("name")
+		switch r.Method {
+		case http.MethodGet:
+			data, ok := stored[name]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		case http.MethodPost, http.MethodPut:
+			var d struct {
+				Metadata struct {
+					Name string `json:"name"`
+				} `json:"metadata"`
+			}
+			data, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(data, &d))
+			stored[d.Metadata.Name] = data
+			_, _ = w.Write(data)
+		case http.MethodDelete:
+			delete(stored, name)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+	mux.HandleFunc("/api/v1/projects/infra/dashboards", handler)
+	mux.HandleFunc("/api/v1/projects/infra/dashboards/{name}", handler)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c, err := client.New(config.RestConfigClient{URL: common.MustParseURL(server.URL)})
+	require.NoError(t, err)
+	dashboards := c.Dashboard("infra")
+	ctx := context.Background()
+
+	b, err := dashboard.New("nodes")
+	require.NoError(t, err)
+	created, err := dashboards.Apply(ctx, b.Dashboard)
+	require.NoError(t, err)
+	assert.Equal(t, "infra", created.Metadata.Project)
+	_, err = dashboards.Apply(ctx, b.Dashboard)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"GET /api/v1/projects/infra/dashboards/nodes",
+		"POST /api/v1/projects/infra/dashboards",
+		"GET /api/v1/projects/infra/dashboards/nodes",
+		"PUT /api/v1/projects/infra/dashboards/nodes",
+	}, calls)
+
+	fetched, err := dashboards.Get(ctx, "nodes")
+	require.NoError(t, err)
+	assert.Equal(t, "nodes", fetched.Metadata.Name)
+	require.NoError(t, dashboards.Delete(ctx, "nodes"))
+	_, err = dashboards.Get(ctx, "nodes")
+	assert.Error(t, err)
+
+	b.Dashboard.Metadata.Project = "other"
+	_, err = dashboards.Apply(ctx, b.Dashboard)
+	assert.Error(t, err)
+}
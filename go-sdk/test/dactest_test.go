@@ -0,0 +1,64 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dac
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/perses/perses/go-sdk/dactest"
+	"github.com/perses/perses/go-sdk/dashboard"
+	txtVar "github.com/perses/perses/go-sdk/variable/text-variable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingT records the errors reported by an assertion instead of failing the test.
+type recordingT struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertBuildersEqual(t *testing.T) {
+	build := func(namespace string) dashboard.Builder {
+		b, err := dashboard.New("Equal",
+			dashboard.Duration(3*time.Hour),
+			dashboard.AddVariable("namespace", txtVar.Text(namespace)),
+		)
+		require.NoError(t, err)
+		return b
+	}
+
+	expected := build("payments")
+	actual := build("payments")
+	// The metadata set by the server are ignored.
+	actual.Dashboard.Metadata.CreatedAt = time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	actual.Dashboard.Metadata.Version = 3
+	recorder := &recordingT{TB: t}
+	assert.True(t, dactest.AssertBuildersEqual(recorder, expected, actual))
+	assert.Empty(t, recorder.errors)
+
+	recorder = &recordingT{TB: t}
+	assert.False(t, dactest.AssertBuildersEqual(recorder, expected, build("billing")))
+	require.Len(t, recorder.errors, 1)
+	assert.Contains(t, recorder.errors[0], "dashboards are not equal (-expected +actual)")
+	assert.Contains(t, recorder.errors[0], "namespace")
+}
@@ -14,18 +14,31 @@
 package dac
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	sdk "github.com/perses/perses/go-sdk/common"
 	"github.com/perses/perses/go-sdk/dashboard"
 	"github.com/perses/perses/go-sdk/datasource"
+	"github.com/perses/perses/go-sdk/link"
+	"github.com/perses/perses/go-sdk/panel"
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
+	"github.com/perses/perses/go-sdk/presets"
+	"github.com/perses/perses/go-sdk/query"
+	"github.com/perses/perses/go-sdk/secret"
 	variablegroup "github.com/perses/perses/go-sdk/variable-group"
 	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
 	txtVar "github.com/perses/perses/go-sdk/variable/text-variable"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	dashboard2 "github.com/perses/perses/pkg/model/api/v1/dashboard"
+	secretModel "github.com/perses/perses/pkg/model/api/v1/secret"
 	promDs "github.com/perses/plugins/prometheus/sdk/go/datasource"
 	labelNamesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-names"
 	labelValuesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-values"
@@ -33,6 +46,7 @@ import (
 	staticlist "github.com/perses/plugins/staticlistvariable/sdk/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestDashboardBuilder(t *testing.T) {
@@ -246,3 +260,809 @@ func TestDashboardBuilderWithGroupedVariables(t *testing.T) {
 		require.JSONEq(t, string(expectedOutput), string(builderOutput))
 	})
 }
+
+func TestDashboardBuilderPhases(t *testing.T) {
+	var layoutTitles, variableNames []string
+	_, buildErr := dashboard.New("Phases",
+		dashboard.AddFinalizer(func(d *v1.Dashboard) error {
+			for _, layout := range d.Spec.Layouts {
+				layoutTitles = append(layoutTitles, layout.Spec.(dashboard2.GridLayoutSpec).Display.Title)
+			}
+			for _, v := range d.Spec.Variables {
+				variableNames = append(variableNames, v.Spec.GetName())
+			}
+			return nil
+		}),
+		dashboard.AddFooter("Maintained by the SRE team"),
+		dashboard.AddPanelGroup("Resource usage", panelgroup.VariableSummary("namespace", "pod")),
+		dashboard.AddVariable("namespace", txtVar.Text("payments")),
+		dashboard.AddVariable("pod", txtVar.Text("api")),
+	)
+	assert.NoError(t, buildErr)
+	assert.Equal(t, []string{"Resource usage", "Footer"}, layoutTitles)
+	assert.Equal(t, []string{"namespace", "pod"}, variableNames)
+}
+
+func TestDashboardBuilderDisplayNameI18n(t *testing.T) {
+	b, buildErr := dashboard.New("overview",
+		dashboard.Name("Services overview"),
+		dashboard.DisplayNameI18n(map[string]string{"fr-FR": "Vue d'ensemble", "de": "Übersicht"}),
+	)
+	require.NoError(t, buildErr)
+
+	data, err := json.Marshal(b.Dashboard.Spec.Display)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name": "Services overview", "localizedNames": {"fr-FR": "Vue d'ensemble", "de": "Übersicht"}}`, string(data))
+
+	_, err = dashboard.New("overview", dashboard.DisplayNameI18n(map[string]string{"not a locale!": "Overview"}))
+	assert.ErrorContains(t, err, `invalid locale "not a locale!"`)
+	_, err = dashboard.New("overview", dashboard.DisplayNameI18n(map[string]string{"fr": ""}))
+	assert.ErrorContains(t, err, `display name for locale "fr" cannot be empty`)
+}
+
+func TestDashboardBuilderBuildProvenance(t *testing.T) {
+	b, buildErr := dashboard.New("Provenance",
+		dashboard.WithBuildProvenance(map[string]string{"commit": "4f2a9c1", "author": "alice"}),
+	)
+	require.NoError(t, buildErr)
+
+	data, err := json.Marshal(b.Dashboard.Metadata)
+	require.NoError(t, err)
+	var metadata map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &metadata))
+	assert.Equal(t, map[string]interface{}{"provenance.commit": "4f2a9c1", "provenance.author": "alice"}, metadata["annotations"])
+
+	b, buildErr = dashboard.New("Provenance", dashboard.WithBuildProvenance(nil))
+	require.NoError(t, buildErr)
+	assert.Nil(t, b.Dashboard.Metadata.Annotations)
+
+	_, err = dashboard.New("Provenance", dashboard.WithBuildProvenance(map[string]string{"": "4f2a9c1"}))
+	assert.Error(t, err)
+}
+
+func TestDashboardBuilderVariableSummary(t *testing.T) {
+	b, buildErr := dashboard.New("Summary",
+		dashboard.AddVariable("env", txtVar.Text("prod")),
+		dashboard.AddVariable("namespace", txtVar.Text("payments")),
+		dashboard.AddPanelGroup("Overview", panelgroup.VariableSummary("env", "namespace")),
+	)
+	require.NoError(t, buildErr)
+	summary := b.Dashboard.Spec.Panels["0_0"]
+	assert.Equal(t, "Viewing", summary.Spec.Display.Name)
+	assert.Equal(t, common.Plugin{
+		Kind: "Markdown",
+		Spec: map[string]interface{}{"text": "**Viewing:** ${env} / ${namespace}"},
+	}, summary.Spec.Plugin)
+
+	_, err := dashboard.New("Summary",
+		dashboard.AddVariable("env", txtVar.Text("prod")),
+		dashboard.AddPanelGroup("Overview", panelgroup.VariableSummary("env", "pod")),
+	)
+	assert.EqualError(t, err, `panel group "Overview" requires the variable "pod" which is not declared`)
+	_, err = dashboard.New("Summary", dashboard.AddPanelGroup("Overview", panelgroup.VariableSummary()))
+	assert.Error(t, err)
+}
+
+func TestDashboardBuilderFooter(t *testing.T) {
+	b, buildErr := dashboard.New("Footer",
+		dashboard.AddFooter("Maintained by the [SRE team](https://example.com/sre)"),
+		dashboard.AddPanelGroup("Overview",
+			panelgroup.AddPanel("Readme", panel.Plugin(common.Plugin{Kind: "Markdown", Spec: map[string]interface{}{"text": "hello"}})),
+		),
+	)
+	require.NoError(t, buildErr)
+	require.Len(t, b.Dashboard.Spec.Layouts, 2)
+
+	footer := b.Dashboard.Spec.Layouts[1].Spec.(dashboard2.GridLayoutSpec)
+	assert.Equal(t, "Footer", footer.Display.Title)
+	assert.Equal(t, &dashboard2.GridLayoutCollapse{Open: true}, footer.Display.Collapse)
+	require.Len(t, footer.Items, 1)
+	assert.Equal(t, 24, footer.Items[0].Width)
+	assert.Equal(t, 4, footer.Items[0].Height)
+	assert.Equal(t, common.Plugin{
+		Kind: "Markdown",
+		Spec: map[string]interface{}{"text": "Maintained by the [SRE team](https://example.com/sre)"},
+	}, b.Dashboard.Spec.Panels["1_0"].Spec.Plugin)
+
+	_, err := dashboard.New("Footer", dashboard.AddFooter(""))
+	assert.Error(t, err)
+}
+
+func TestDashboardBuilderExtensions(t *testing.T) {
+	b, buildErr := dashboard.New("Extensions",
+		dashboard.Extra("ticket", map[string]string{"id": "OPS-1234"}),
+		dashboard.AddVariable("namespace", txtVar.Text("payments", txtVar.Extra("owner", "team-payments"))),
+		dashboard.AddPanelGroup("Notes",
+			panelgroup.AddPanel("Readme",
+				panel.Plugin(common.Plugin{Kind: "Markdown", Spec: map[string]interface{}{"text": "hello"}}),
+				panel.Extra("owner", "team-sre"),
+				panel.Extra("reviewers", []string{"alice", "bob"}),
+			),
+		),
+	)
+	require.NoError(t, buildErr)
+
+	data, err := json.Marshal(b.Dashboard)
+	require.NoError(t, err)
+	var decoded v1.Dashboard
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, b.Dashboard.Spec.Extensions, decoded.Spec.Extensions)
+	assert.Equal(t, map[string]interface{}{"id": "OPS-1234"}, decoded.Spec.Extensions["ticket"])
+	assert.Equal(t, b.Dashboard.Spec.Panels["0_0"].Spec.Extensions, decoded.Spec.Panels["0_0"].Spec.Extensions)
+	assert.Equal(t, []interface{}{"alice", "bob"}, decoded.Spec.Panels["0_0"].Spec.Extensions["reviewers"])
+	assert.Equal(t, "team-payments", decoded.Spec.Variables[0].Spec.(*dashboard2.TextVariableSpec).Extensions["owner"])
+
+	_, err = dashboard.New("Extensions", dashboard.Extra("", "value"))
+	assert.Error(t, err)
+	_, err = dashboard.New("Extensions", dashboard.Extra("callback", func() {}))
+	assert.Error(t, err)
+}
+
+func TestDashboardBuilderSecrets(t *testing.T) {
+	b, buildErr := dashboard.New("Secrets",
+		dashboard.AddSecret("prometheus-auth",
+			secret.BasicAuth("perses", "/etc/perses/secrets/prometheus-password"),
+			secret.TLSConfig(secretModel.TLSConfig{CAFile: "/etc/perses/secrets/ca.crt"}),
+		),
+		dashboard.ProjectName("infra"),
+	)
+	require.NoError(t, buildErr)
+	assert.Equal(t, []v1.Secret{
+		{
+			Kind:     v1.KindSecret,
+			Metadata: v1.ProjectMetadata{Metadata: v1.Metadata{Name: "prometheus-auth"}, ProjectMetadataWrapper: v1.ProjectMetadataWrapper{Project: "infra"}},
+			Spec: v1.SecretSpec{
+				BasicAuth: &secretModel.BasicAuth{Username: "perses", PasswordFile: "/etc/perses/secrets/prometheus-password"},
+				TLSConfig: &secretModel.TLSConfig{CAFile: "/etc/perses/secrets/ca.crt"},
+			},
+		},
+	}, b.Secrets())
+
+	_, err := dashboard.New("Secrets", dashboard.AddSecret("prometheus-auth",
+		secret.BasicAuth("perses", "/etc/perses/secrets/prometheus-password"),
+		secret.Authorization("Bearer", "/etc/perses/secrets/prometheus-token"),
+	))
+	assert.Error(t, err)
+	_, err = dashboard.New("Secrets",
+		dashboard.AddSecret("prometheus-auth"),
+		dashboard.AddSecret("prometheus-auth"),
+	)
+	assert.Error(t, err)
+}
+
+func TestDashboardBuilderOutputFormats(t *testing.T) {
+	b, buildErr := dashboard.New("Formats", dashboard.Duration(3*time.Hour))
+	require.NoError(t, buildErr)
+
+	expectedYAML, err := yaml.Marshal(b.Dashboard)
+	require.NoError(t, err)
+	builderYAML, err := yaml.Marshal(b)
+	require.NoError(t, err)
+	assert.Equal(t, string(expectedYAML), string(builderYAML))
+	var buffer bytes.Buffer
+	require.NoError(t, b.Write(&buffer, dashboard.YAMLFormat))
+	assert.Equal(t, string(expectedYAML), buffer.String())
+
+	expectedJSON, err := json.Marshal(b.Dashboard)
+	require.NoError(t, err)
+	builderJSON, err := json.Marshal(b)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expectedJSON), string(builderJSON))
+	buffer.Reset()
+	require.NoError(t, b.Write(&buffer, dashboard.JSONFormat))
+	assert.JSONEq(t, string(expectedJSON), buffer.String())
+
+	assert.Error(t, b.Write(&buffer, "toml"))
+}
+
+// failingWriter accepts the given number of bytes, then fails.
+type failingWriter struct {
+	remaining int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.remaining {
+		n := w.remaining
+		w.remaining = 0
+		return n, errors.New("disk full")
+	}
+	w.remaining -= len(p)
+	return len(p), nil
+}
+
+func TestDashboardBuilderWriteJSONAndYAML(t *testing.T) {
+	b, buildErr := dashboard.New("Stream",
+		dashboard.AddVariable("namespace", txtVar.Text("payments")),
+		dashboard.AddPanelGroup("Notes",
+			panelgroup.AddPanel("Readme", panel.Plugin(common.Plugin{Kind: "Markdown", Spec: map[string]interface{}{"text": "hello"}})),
+		),
+	)
+	require.NoError(t, buildErr)
+
+	var buffer bytes.Buffer
+	require.NoError(t, b.WriteJSON(&buffer))
+	var decoded v1.Dashboard
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &decoded))
+	assert.Equal(t, b.Dashboard.Spec.Panels, decoded.Spec.Panels)
+
+	buffer.Reset()
+	require.NoError(t, b.WriteYAML(&buffer))
+	decoded = v1.Dashboard{}
+	require.NoError(t, yaml.Unmarshal(buffer.Bytes(), &decoded))
+	assert.Equal(t, b.Dashboard.Spec.Panels, decoded.Spec.Panels)
+
+	assert.EqualError(t, b.WriteJSON(&failingWriter{remaining: 10}), "disk full")
+	assert.ErrorContains(t, b.WriteYAML(&failingWriter{remaining: 10}), "disk full")
+}
+
+func TestDashboardBuilderExportByGroup(t *testing.T) {
+	readme := func(title string) panelgroup.Option {
+		return panelgroup.AddPanel(title, panel.Plugin(common.Plugin{Kind: "Markdown", Spec: map[string]interface{}{"text": title}}))
+	}
+	b, buildErr := dashboard.New("Services",
+		dashboard.AddPanelGroup("API / Gateway", readme("Requests"), readme("Errors")),
+		dashboard.AddPanelGroup("Storage", readme("Disk")),
+	)
+	require.NoError(t, buildErr)
+
+	dir := t.TempDir()
+	require.NoError(t, b.ExportByGroup(dir))
+
+	data, err := os.ReadFile(filepath.Join(dir, "Services.json"))
+	require.NoError(t, err)
+	var decoded v1.Dashboard
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Len(t, decoded.Spec.Panels, 3)
+
+	entries, err := os.ReadDir(filepath.Join(dir, "Services-groups"))
+	require.NoError(t, err)
+	var files []string
+	for _, entry := range entries {
+		files = append(files, entry.Name())
+	}
+	assert.Equal(t, []string{"00_api-gateway.json", "01_storage.json"}, files)
+
+	data, err = os.ReadFile(filepath.Join(dir, "Services-groups", "00_api-gateway.json"))
+	require.NoError(t, err)
+	var fragment map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &fragment))
+	assert.Equal(t, "API / Gateway", fragment["title"])
+	assert.Len(t, fragment["panels"], 2)
+	assert.Contains(t, fragment["panels"], "0_0")
+	assert.Contains(t, fragment["panels"], "0_1")
+
+	assert.Error(t, dashboard.Builder{}.ExportByGroup(dir))
+}
+
+func TestDashboardBuilderVariableDependencies(t *testing.T) {
+	_, err := dashboard.New("Dependencies",
+		dashboard.AddVariable("cluster", txtVar.Text("eu-1")),
+		dashboard.AddVariable("namespace", txtVar.Text("$clustre-payments")),
+	)
+	assert.ErrorContains(t, err, `variable "namespace" uses the variable "clustre" which is not declared`)
+
+	_, err = dashboard.New("Dependencies",
+		dashboard.AddVariable("a", txtVar.Text("${b}")),
+		dashboard.AddVariable("b", txtVar.Text("$c")),
+		dashboard.AddVariable("c", txtVar.Text("${a:csv}")),
+	)
+	assert.ErrorContains(t, err, "circular dependency between the variables a -> b -> c -> a")
+
+	_, err = dashboard.New("Dependencies",
+		dashboard.ExternalVariables("region"),
+		dashboard.AddVariable("cluster", txtVar.Text("$region-1")),
+	)
+	assert.NoError(t, err)
+
+	upQuery := panel.AddQuery(query.Plugin(common.Plugin{Kind: "PrometheusTimeSeriesQuery", Spec: map[string]interface{}{
+		"query": `label_replace(up{cluster="$cluster",job="$jbo"}[$__range], "host", "$1", "instance", "(.*)")`,
+	}}))
+	_, err = dashboard.New("Dependencies",
+		dashboard.AddVariable("cluster", txtVar.Text("eu-1")),
+		dashboard.AddPanelGroup("Status", panelgroup.AddPanel("Up", upQuery)),
+	)
+	assert.NoError(t, err)
+	_, err = dashboard.New("Dependencies",
+		dashboard.StrictVariables(),
+		dashboard.AddVariable("cluster", txtVar.Text("eu-1")),
+		dashboard.AddPanelGroup("Status", panelgroup.AddPanel("Up", upQuery)),
+	)
+	assert.ErrorContains(t, err, `panel "Up", query 0: the variable "jbo" is not declared`)
+}
+
+func TestDashboardBuilderPanelAlertRule(t *testing.T) {
+	markdown := panel.Plugin(common.Plugin{Kind: "Markdown", Spec: map[string]interface{}{"text": "hello"}})
+	b, buildErr := dashboard.New("Alerts",
+		dashboard.AddPanelGroup("Overview",
+			panelgroup.AddPanel("Error rate", markdown, panel.AlertRuleRef("HighErrorRate")),
+			panelgroup.AddPanel("Latency", markdown),
+		),
+	)
+	require.NoError(t, buildErr)
+
+	data, err := json.Marshal(b.Dashboard.Spec.Panels)
+	require.NoError(t, err)
+	var panels map[string]struct {
+		Spec map[string]interface{} `json:"spec"`
+	}
+	require.NoError(t, json.Unmarshal(data, &panels))
+	assert.Equal(t, "HighErrorRate", panels["0_0"].Spec["alertRule"])
+	assert.NotContains(t, panels["0_1"].Spec, "alertRule")
+
+	_, err = dashboard.New("Alerts", dashboard.AddPanelGroup("Overview", panelgroup.AddPanel("Error rate", markdown, panel.AlertRuleRef(" "))))
+	assert.Error(t, err)
+}
+
+func TestDashboardBuilderPanelWeights(t *testing.T) {
+	markdown := panel.Plugin(common.Plugin{Kind: "Markdown", Spec: map[string]interface{}{"text": "hello"}})
+	b, buildErr := dashboard.New("Weights",
+		dashboard.AddPanelGroup("Overview",
+			panelgroup.PanelsPerLine(3),
+			panelgroup.PanelHeight(6),
+			panelgroup.AddPanel("Requests", panelgroup.PanelWeight(2), markdown),
+			panelgroup.AddPanel("Errors", markdown),
+			panelgroup.AddPanel("Latency", markdown),
+			panelgroup.AddPanel("Saturation", panelgroup.PanelWeight(3), markdown),
+			panelgroup.AddPanel("Traffic", panelgroup.PanelWeight(4), markdown),
+		),
+	)
+	require.NoError(t, buildErr)
+
+	var positions [][4]int
+	for _, item := range b.Dashboard.Spec.Layouts[0].Spec.(dashboard2.GridLayoutSpec).Items {
+		positions = append(positions, [4]int{item.X, item.Y, item.Width, item.Height})
+	}
+	assert.Equal(t, [][4]int{
+		{0, 0, 12, 6},
+		{12, 0, 6, 6},
+		{18, 0, 6, 6},
+		{0, 6, 10, 6},
+		{10, 6, 14, 6},
+	}, positions)
+
+	_, err := dashboard.New("Weights", dashboard.AddPanelGroup("Overview",
+		panelgroup.AddPanel("Requests", panelgroup.PanelWeight(0), markdown),
+	))
+	assert.Error(t, err)
+}
+
+func TestDashboardBuilderPanelAt(t *testing.T) {
+	markdown := panel.Plugin(common.Plugin{Kind: "Markdown", Spec: map[string]interface{}{"text": "hello"}})
+	b, buildErr := dashboard.New("Positions",
+		dashboard.AddPanelGroup("Overview",
+			panelgroup.PanelsPerLine(2),
+			panelgroup.AddPanel("Summary", panelgroup.PanelAt(0, 0, 16, 6), markdown),
+			panelgroup.AddPanel("Errors", markdown),
+			panelgroup.AddPanel("Status", panelgroup.PanelAt(16, 0, 8, 3), markdown),
+			panelgroup.AddPanel("Latency", markdown),
+		),
+	)
+	require.NoError(t, buildErr)
+
+	var positions [][4]int
+	for _, item := range b.Dashboard.Spec.Layouts[0].Spec.(dashboard2.GridLayoutSpec).Items {
+		positions = append(positions, [4]int{item.X, item.Y, item.Width, item.Height})
+	}
+	assert.Equal(t, [][4]int{
+		{0, 0, 16, 6},
+		{0, 6, 12, 8},
+		{16, 0, 8, 3},
+		{12, 6, 12, 8},
+	}, positions)
+
+	_, err := dashboard.New("Positions", dashboard.AddPanelGroup("Overview",
+		panelgroup.AddPanel("Summary", panelgroup.PanelAt(0, 0, 16, 6), markdown),
+		panelgroup.AddPanel("Status", panelgroup.PanelAt(12, 4, 12, 4), markdown),
+	))
+	assert.ErrorContains(t, err, `panel overlaps the panel "Summary"`)
+
+	_, err = dashboard.New("Positions", dashboard.AddPanelGroup("Overview",
+		panelgroup.AddPanel("Summary", panelgroup.PanelAt(20, 0, 8, 6), markdown),
+	))
+	assert.Error(t, err)
+}
+
+func TestDashboardBuilderAddPanelRef(t *testing.T) {
+	b, buildErr := dashboard.New("Shared",
+		dashboard.AddPanelRef("cpu-usage", panelgroup.PanelHeight(10)),
+		dashboard.AddPanelGroup("Memory",
+			panelgroup.PanelsPerLine(2),
+			panelgroup.AddPanelRef("memory-usage", panelgroup.PanelWeight(2)),
+			panelgroup.AddPanel("Notes", panel.Plugin(common.Plugin{Kind: "Markdown", Spec: map[string]interface{}{"text": "hello"}})),
+		),
+	)
+	require.NoError(t, buildErr)
+
+	cpu := b.Dashboard.Spec.Panels["0_0"]
+	assert.Equal(t, "cpu-usage", cpu.LibraryPanel)
+	assert.Equal(t, "cpu-usage", cpu.Spec.Display.Name)
+	assert.Equal(t, "Markdown", cpu.Spec.Plugin.Kind)
+	cpuLayout := b.Dashboard.Spec.Layouts[0].Spec.(dashboard2.GridLayoutSpec)
+	assert.Equal(t, "cpu-usage", cpuLayout.Display.Title)
+	assert.Equal(t, 24, cpuLayout.Items[0].Width)
+	assert.Equal(t, 10, cpuLayout.Items[0].Height)
+
+	assert.Equal(t, "memory-usage", b.Dashboard.Spec.Panels["1_0"].LibraryPanel)
+	assert.Empty(t, b.Dashboard.Spec.Panels["1_1"].LibraryPanel)
+	memoryLayout := b.Dashboard.Spec.Layouts[1].Spec.(dashboard2.GridLayoutSpec)
+	assert.Equal(t, 16, memoryLayout.Items[0].Width)
+
+	_, err := dashboard.New("Shared", dashboard.AddPanelRef("CPU usage"))
+	assert.ErrorContains(t, err, `invalid library panel name "CPU usage"`)
+}
+
+func TestDashboardBuilderAddVariableRef(t *testing.T) {
+	b, buildErr := dashboard.New("Shared",
+		dashboard.AddGlobalVariableRef("cluster"),
+		dashboard.AddVariableRef("namespace"),
+	)
+	require.NoError(t, buildErr)
+
+	data, err := json.Marshal(b.Dashboard.Spec.Variables)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+  {"kind": "TextVariable", "$ref": "globalvariables/cluster", "spec": {"name": "cluster", "value": ""}},
+  {"kind": "TextVariable", "$ref": "variables/namespace", "spec": {"name": "namespace", "value": ""}}
+]`, string(data))
+
+	_, err = dashboard.New("Shared", dashboard.AddVariableRef("cluster"), dashboard.AddGlobalVariableRef("cluster"))
+	assert.ErrorContains(t, err, `variable "cluster" is declared more than once`)
+}
+
+func TestDashboardBuilderTimeRange(t *testing.T) {
+	start := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(150 * time.Minute)
+	b, buildErr := dashboard.New("Incident", dashboard.TimeRange(start, end))
+	require.NoError(t, buildErr)
+
+	data, err := json.Marshal(b.Dashboard)
+	require.NoError(t, err)
+	var decoded v1.Dashboard
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, &v1.AbsoluteTimeRange{Start: start, End: end}, decoded.Spec.TimeRange)
+
+	_, err = dashboard.New("Incident", dashboard.TimeRange(end, start))
+	assert.Error(t, err)
+}
+
+func TestDashboardBuilderTimezone(t *testing.T) {
+	b, buildErr := dashboard.New("Timezone", dashboard.Timezone("Europe/Paris"))
+	require.NoError(t, buildErr)
+	assert.Equal(t, "Europe/Paris", b.Dashboard.Spec.Timezone)
+
+	b, buildErr = dashboard.New("Timezone")
+	require.NoError(t, buildErr)
+	data, err := json.Marshal(b.Dashboard.Spec)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "timezone")
+
+	for _, timezone := range []string{"", "Local", "Mars/Olympus_Mons"} {
+		_, err = dashboard.New("Timezone", dashboard.Timezone(timezone))
+		assert.Error(t, err, timezone)
+	}
+}
+
+func TestDashboardBuilderRefreshIntervals(t *testing.T) {
+	b, buildErr := dashboard.New("Refresh",
+		dashboard.RefreshInterval(30*time.Second),
+		dashboard.RefreshIntervals(presets.SlowRefresh...),
+	)
+	require.NoError(t, buildErr)
+
+	data, err := json.Marshal(b.Dashboard.Spec)
+	require.NoError(t, err)
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(data, &spec))
+	assert.Equal(t, "30s", spec["refreshInterval"])
+	assert.Equal(t, []any{"0s", "1m", "5m", "15m", "30m", "1h"}, spec["refreshIntervals"])
+
+	b, buildErr = dashboard.New("Refresh",
+		dashboard.RefreshInterval(30*time.Second),
+		dashboard.RefreshIntervals(presets.StandardRefresh...),
+		dashboard.DisableAutoRefresh(),
+	)
+	require.NoError(t, buildErr)
+	assert.Equal(t, common.Duration(0), b.Dashboard.Spec.RefreshInterval)
+	assert.Equal(t, []common.Duration{0}, b.Dashboard.Spec.RefreshIntervals)
+
+	_, err = dashboard.New("Refresh", dashboard.RefreshIntervals(0, -time.Minute))
+	assert.Error(t, err)
+}
+
+func TestDashboardBuilderLinks(t *testing.T) {
+	b, buildErr := dashboard.New("Links",
+		dashboard.AddLink("Runbook", "https://runbooks.example.com/node?instance=$instance",
+			link.Tooltip("What to do when a node is down"),
+			link.TargetBlank(true),
+			link.RenderVariable(true),
+		),
+		dashboard.AddLink("Overview", "/projects/infra/dashboards/overview"),
+	)
+	require.NoError(t, buildErr)
+	assert.Equal(t, []v1.Link{
+		{
+			Name:            "Runbook",
+			URL:             "https://runbooks.example.com/node?instance=$instance",
+			Tooltip:         "What to do when a node is down",
+			RenderVariables: true,
+			TargetBlank:     true,
+		},
+		{Name: "Overview", URL: "/projects/infra/dashboards/overview"},
+	}, b.Dashboard.Spec.Links)
+
+	_, err := dashboard.New("Links", dashboard.AddLink("Runbook", ""))
+	assert.Error(t, err)
+}
+
+func TestDashboardBuilderRepeatedPanelGroup(t *testing.T) {
+	b, buildErr := dashboard.New("Repeat",
+		dashboard.AddVariable("env", listVar.List(staticlist.StaticList(staticlist.Values("dev", "prod")))),
+		dashboard.AddPanelGroup("Environment $env",
+			panelgroup.RepeatByVariable("env"),
+			panelgroup.AddPanel("Readme",
+				panel.Plugin(common.Plugin{Kind: "Markdown", Spec: map[string]interface{}{"text": "Dashboards of ${env}, see $environment"}}),
+			),
+		),
+	)
+	require.NoError(t, buildErr)
+	require.Len(t, b.Dashboard.Spec.Layouts, 2)
+	assert.Equal(t, "Environment dev", b.Dashboard.Spec.Layouts[0].Spec.(dashboard2.GridLayoutSpec).Display.Title)
+	assert.Equal(t, "Environment prod", b.Dashboard.Spec.Layouts[1].Spec.(dashboard2.GridLayoutSpec).Display.Title)
+	assert.Equal(t, map[string]interface{}{"text": "Dashboards of prod, see $environment"}, b.Dashboard.Spec.Panels["1_0"].Spec.Plugin.Spec)
+
+	_, buildErr = dashboard.New("Repeat",
+		dashboard.AddVariable("env", txtVar.Text("dev")),
+		dashboard.AddPanelGroup("Environment $env", panelgroup.RepeatByVariable("env")),
+	)
+	assert.Error(t, buildErr)
+}
+
+func TestDashboardBuilderPanelGroupPerValue(t *testing.T) {
+	factory := func(value string) []panelgroup.Option {
+		options := []panelgroup.Option{
+			panelgroup.AddPanel("Readme", panel.Plugin(common.Plugin{Kind: "Markdown", Spec: map[string]interface{}{"text": "Services of " + value}})),
+		}
+		if value == "prod" {
+			options = append(options, panelgroup.Title("Production"))
+		}
+		return options
+	}
+	b, buildErr := dashboard.New("PerValue",
+		dashboard.AddPanelGroup("Overview",
+			panelgroup.AddPanel("Summary", panel.Plugin(common.Plugin{Kind: "Markdown", Spec: map[string]interface{}{"text": "hello"}})),
+		),
+		dashboard.AddPanelGroupPerValue([]string{"dev", "prod"}, factory),
+	)
+	require.NoError(t, buildErr)
+
+	var titles []string
+	for _, layout := range b.Dashboard.Spec.Layouts {
+		titles = append(titles, layout.Spec.(dashboard2.GridLayoutSpec).Display.Title)
+	}
+	assert.Equal(t, []string{"Overview", "dev", "Production"}, titles)
+	assert.Equal(t, map[string]interface{}{"text": "Services of prod"}, b.Dashboard.Spec.Panels["2_0"].Spec.Plugin.Spec)
+
+	_, err := dashboard.New("PerValue", dashboard.AddPanelGroupPerValue([]string{"dev", "dev"}, factory))
+	assert.EqualError(t, err, `value "dev" is given more than once`)
+	_, err = dashboard.New("PerValue", dashboard.AddPanelGroupPerValue([]string{"dev"}, func(string) []panelgroup.Option { return nil }))
+	assert.EqualError(t, err, `panel group for the value "dev" has no panel`)
+}
+
+func TestDashboardDiff(t *testing.T) {
+	readme := func(text string) panelgroup.Option {
+		return panelgroup.AddPanel("Readme", panel.Plugin(common.Plugin{Kind: "Markdown", Spec: map[string]interface{}{"text": text}}))
+	}
+	before, err := dashboard.New("Compare",
+		dashboard.AddVariable("namespace", txtVar.Text("payments")),
+		dashboard.AddVariable("pod", txtVar.Text("api")),
+		dashboard.AddPanelGroup("Notes", readme("hello")),
+	)
+	require.NoError(t, err)
+	after, err := dashboard.New("Compare",
+		dashboard.Duration(6*time.Hour),
+		dashboard.AddVariable("namespace", txtVar.Text("payments")),
+		dashboard.AddVariable("cluster", txtVar.Text("eu")),
+		dashboard.AddPanelGroup("Notes", readme("hello world")),
+	)
+	require.NoError(t, err)
+
+	changes, err := dashboard.Diff(&before.Dashboard, &after.Dashboard)
+	require.NoError(t, err)
+	var summary []string
+	for _, change := range changes {
+		summary = append(summary, fmt.Sprintf("%s %s %s", change.Type, change.Kind, change.Name))
+	}
+	assert.Equal(t, []string{
+		"modified settings Compare",
+		"removed variable pod",
+		"added variable cluster",
+		"modified panel 0_0",
+	}, summary)
+	assert.Contains(t, changes[3].Diff, `+        "text": "hello world"`)
+
+	changes, err = dashboard.Diff(&before.Dashboard, &before.Dashboard)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	changes, err = dashboard.Diff(&before.Dashboard, &after.Dashboard, dashboard.IgnorePaths("$.spec.duration", "spec.variables.*.spec.name", "spec.panels.*.spec.plugin.spec.text"))
+	require.NoError(t, err)
+	summary = nil
+	for _, change := range changes {
+		summary = append(summary, fmt.Sprintf("%s %s %s", change.Type, change.Kind, change.Name))
+	}
+	assert.Equal(t, []string{"modified variable #1"}, summary)
+
+	renamed := before.Dashboard
+	renamed.Metadata.Name = "Renamed"
+	changes, err = dashboard.Diff(&before.Dashboard, &renamed)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, dashboard.ChangeKindMetadata, changes[0].Kind)
+	assert.Contains(t, changes[0].Diff, `+  "name": "Renamed"`)
+
+	changes, err = dashboard.Diff(&before.Dashboard, &renamed, dashboard.IgnorePaths("metadata"))
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestDashboardBuilderPluginInventory(t *testing.T) {
+	b, buildErr := dashboard.New("Inventory",
+		dashboard.AddDatasource("prom", datasource.Plugin(common.Plugin{Kind: "PrometheusDatasource", Spec: map[string]interface{}{"directUrl": "http://prometheus:9090"}})),
+		dashboard.AddVariable("cluster", txtVar.Text("eu-1")),
+		dashboard.AddVariable("namespace", listVar.List(listVar.Plugin(common.Plugin{Kind: "PrometheusLabelValuesVariable", Spec: map[string]interface{}{"labelName": "namespace"}}))),
+		dashboard.AddPanelGroup("Overview",
+			panelgroup.AddPanel("Up",
+				panel.Plugin(common.Plugin{Kind: "TimeSeriesChart", Spec: map[string]interface{}{}}),
+				panel.AddQuery(query.Plugin(common.Plugin{Kind: "PrometheusTimeSeriesQuery", Spec: map[string]interface{}{"query": "up"}})),
+			),
+			panelgroup.AddPanel("Readme", panel.Plugin(common.Plugin{Kind: "Markdown", Spec: map[string]interface{}{"text": "hello"}})),
+		),
+	)
+	require.NoError(t, buildErr)
+	assert.Equal(t, []dashboard.PluginUse{
+		{Kind: "PrometheusDatasource", Path: "/spec/datasources/prom/plugin"},
+		{Kind: "PrometheusLabelValuesVariable", Path: "/spec/variables/1/spec/plugin"},
+		{Kind: "TimeSeriesChart", Path: "/spec/panels/0_0/spec/plugin"},
+		{Kind: "PrometheusTimeSeriesQuery", Path: "/spec/panels/0_0/spec/queries/0/spec/plugin"},
+		{Kind: "Markdown", Path: "/spec/panels/0_1/spec/plugin"},
+	}, b.PluginInventory())
+}
+
+func TestDashboardBuilderCollapsedPanelGroups(t *testing.T) {
+	readme := panelgroup.AddPanel("Readme", panel.Plugin(common.Plugin{Kind: "Markdown", Spec: map[string]interface{}{"text": "hello"}}))
+	b, buildErr := dashboard.New("Collapsed",
+		dashboard.AddPanelGroup("Default", readme),
+		dashboard.AddPanelGroup("Collapsed", readme, panelgroup.Collapsed(true)),
+		dashboard.AddPanelGroup("Open", readme, panelgroup.Collapsed(false)),
+	)
+	require.NoError(t, buildErr)
+	var collapses []*dashboard2.GridLayoutCollapse
+	for _, layout := range b.Dashboard.Spec.Layouts {
+		collapses = append(collapses, layout.Spec.(dashboard2.GridLayoutSpec).Display.Collapse)
+	}
+	assert.Equal(t, []*dashboard2.GridLayoutCollapse{nil, {Open: false}, {Open: true}}, collapses)
+}
+
+func TestDashboardBuilderConditionalOptions(t *testing.T) {
+	markdown := panel.Plugin(common.Plugin{Kind: "Markdown", Spec: map[string]interface{}{"text": "hello"}})
+	build := func(prod bool) (dashboard.Builder, error) {
+		return dashboard.New("Conditional",
+			dashboard.AddPanelGroup("Overview",
+				panelgroup.AddPanel("Requests", markdown,
+					panel.If(prod, panel.Description("Production traffic")),
+				),
+				panelgroup.If(prod, panelgroup.AddPanel("Error budget", markdown)),
+				panelgroup.Unless(prod, panelgroup.Collapsed(true)),
+			),
+			dashboard.Unless(prod, dashboard.AddPanelGroup("Debug", panelgroup.AddPanel("Logs", markdown))),
+		)
+	}
+
+	prod, err := build(true)
+	require.NoError(t, err)
+	assert.Len(t, prod.Dashboard.Spec.Layouts, 1)
+	assert.Len(t, prod.Dashboard.Spec.Panels, 2)
+	assert.Equal(t, "Production traffic", prod.Dashboard.Spec.Panels["0_0"].Spec.Display.Description)
+	assert.Nil(t, prod.Dashboard.Spec.Layouts[0].Spec.(dashboard2.GridLayoutSpec).Display.Collapse)
+
+	staging, err := build(false)
+	require.NoError(t, err)
+	assert.Len(t, staging.Dashboard.Spec.Layouts, 2)
+	assert.Len(t, staging.Dashboard.Spec.Panels, 2)
+	assert.Empty(t, staging.Dashboard.Spec.Panels["0_0"].Spec.Display.Description)
+	assert.False(t, staging.Dashboard.Spec.Layouts[0].Spec.(dashboard2.GridLayoutSpec).Display.Collapse.Open)
+
+	_, err = dashboard.New("Conditional", dashboard.If(true, dashboard.AddLink("Runbook", "")))
+	assert.Error(t, err)
+	_, err = dashboard.New("Conditional", dashboard.If(false, dashboard.AddLink("Runbook", "")))
+	assert.NoError(t, err)
+}
+
+func TestDashboardBuilderErrorAggregation(t *testing.T) {
+	markdown := panel.Plugin(common.Plugin{Kind: "Markdown", Spec: map[string]interface{}{"text": "hello"}})
+	_, err := dashboard.New("Errors",
+		dashboard.AddLink("Runbook", ""),
+		dashboard.AddPanelGroup("Resource usage",
+			panelgroup.AddPanel("CPU", markdown),
+			panelgroup.AddPanel("Memory", markdown),
+			panelgroup.AddPanel("Network",
+				panel.AddQuery(query.MinStep(time.Minute)),
+				panel.AddQuery(query.Resolution(0), query.Plugin(common.Plugin{Kind: "PrometheusTimeSeriesQuery"})),
+			),
+		),
+	)
+	require.Error(t, err)
+	assert.Equal(t, "url of the link \"Runbook\" cannot be empty\n"+
+		"panelGroup[Resource usage].panel[2].query[0]: the query plugin must be set to use the min step or the resolution\n"+
+		"panelGroup[Resource usage].panel[2].query[1]: resolution must be at least 1, got 0", err.Error())
+
+	var optionErr *sdk.OptionError
+	require.ErrorAs(t, err, &optionErr)
+	assert.Equal(t, "panelGroup[Resource usage].panel[2].query[0]", optionErr.Path)
+}
+
+func TestDashboardBuilderPanelDescriptionTemplate(t *testing.T) {
+	markdown := panel.Plugin(common.Plugin{Kind: "Markdown", Spec: map[string]interface{}{"text": "hello"}})
+	requests := func(tmpl string, variables ...string) dashboard.Option {
+		return dashboard.AddPanelGroup("Overview",
+			panelgroup.AddPanel("Requests", markdown,
+				panel.DescriptionTemplate(tmpl, variables...),
+				panel.Extra("owner", "team-payments"),
+			),
+		)
+	}
+
+	b, err := dashboard.New("Descriptions",
+		dashboard.AddVariable("namespace", txtVar.Text("payments")),
+		dashboard.ExternalVariables("cluster"),
+		requests("{{ .Title }} of {{ .Vars.namespace }} on $cluster, owned by {{ .Extra.owner }}", "namespace"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "Requests of ${namespace} on $cluster, owned by team-payments", b.Dashboard.Spec.Panels["0_0"].Spec.Display.Description)
+
+	_, err = dashboard.New("Descriptions", requests("Requests of {{ .Vars.namespace }}", "namespace"))
+	assert.ErrorContains(t, err, `requires the variable "namespace" which is not declared`)
+	_, err = dashboard.New("Descriptions", requests("Requests of {{ .Vars.namespace }}"))
+	assert.ErrorContains(t, err, "unable to render the description template")
+	_, err = dashboard.New("Descriptions", requests("Requests of {{ .Vars.namespace"))
+	assert.ErrorContains(t, err, "invalid description template")
+}
+
+func TestDashboardBuilderDefaultDatasource(t *testing.T) {
+	lokiValues := listVar.List(listVar.Plugin(common.Plugin{Kind: "LokiLabelValuesVariable", Spec: map[string]interface{}{"labelName": "app"}}))
+	b, err := dashboard.New("Logs",
+		dashboard.DefaultDatasource("LokiDatasource", "loki-prod"),
+		dashboard.AddVariable("app", lokiValues),
+		dashboard.AddPanelGroup("API",
+			panelgroup.AddPanel("Errors",
+				panel.AddQuery(query.Plugin(common.Plugin{Kind: "LokiLogQuery", Spec: map[string]interface{}{"query": `{app="$app"}`}})),
+				panel.AddQuery(query.Plugin(common.Plugin{Kind: "LokiLogQuery", Spec: map[string]interface{}{
+					"query":      `{app="$app"}`,
+					"datasource": map[string]interface{}{"kind": "LokiDatasource", "name": "loki-dev"},
+				}})),
+				panel.AddQuery(query.Plugin(common.Plugin{Kind: "TempoTraceQuery", Spec: map[string]interface{}{"query": "{}"}})),
+			),
+		),
+	)
+	require.NoError(t, err)
+
+	require.Len(t, b.Dashboard.Spec.Panels, 1)
+	for _, p := range b.Dashboard.Spec.Panels {
+		data, marshalErr := json.Marshal(p.Spec.Queries)
+		require.NoError(t, marshalErr)
+		assert.JSONEq(t, `[
+  {"kind": "TimeSeriesQuery", "spec": {"plugin": {"kind": "LokiLogQuery", "spec": {"query": "{app=\"$app\"}", "datasource": {"kind": "LokiDatasource", "name": "loki-prod"}}}}},
+  {"kind": "TimeSeriesQuery", "spec": {"plugin": {"kind": "LokiLogQuery", "spec": {"query": "{app=\"$app\"}", "datasource": {"kind": "LokiDatasource", "name": "loki-dev"}}}}},
+  {"kind": "TimeSeriesQuery", "spec": {"plugin": {"kind": "TempoTraceQuery", "spec": {"query": "{}"}}}}
+]`, string(data))
+	}
+	data, err := json.Marshal(b.Dashboard.Spec.Variables[0].Spec.(*dashboard2.ListVariableSpec).Plugin)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"kind": "LokiLabelValuesVariable", "spec": {"labelName": "app", "datasource": {"kind": "LokiDatasource", "name": "loki-prod"}}}`, string(data))
+
+	_, err = dashboard.New("Logs",
+		dashboard.DefaultDatasource("LokiDatasource", "loki-prod"),
+		dashboard.DefaultDatasource("LokiDatasource", "loki-dev"),
+	)
+	assert.EqualError(t, err, `the datasources "loki-prod" and "loki-dev" are both defined as the default LokiDatasource`)
+}
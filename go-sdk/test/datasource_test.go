@@ -0,0 +1,254 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dac
+
+import (
+	"testing"
+
+	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/perses/perses/go-sdk/datasource"
+	"github.com/perses/perses/go-sdk/panel"
+	panelgroup "github.com/perses/perses/go-sdk/panel-group"
+	"github.com/perses/perses/go-sdk/query"
+	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
+	txtVar "github.com/perses/perses/go-sdk/variable/text-variable"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	promDs "github.com/perses/plugins/prometheus/sdk/go/datasource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDashboardBuilderDefaultDatasources(t *testing.T) {
+	testSuites := []struct {
+		title       string
+		options     []dashboard.Option
+		expectedErr string
+	}{
+		{
+			title: "no default datasource",
+			options: []dashboard.Option{
+				dashboard.AddDatasource("promA", promDs.Prometheus(promDs.DirectURL("http://prometheus-a:9090"))),
+				dashboard.AddDatasource("promB", promDs.Prometheus(promDs.DirectURL("http://prometheus-b:9090"))),
+			},
+		},
+		{
+			title: "single default datasource",
+			options: []dashboard.Option{
+				dashboard.AddDatasource("promA", datasource.Default(true), promDs.Prometheus(promDs.DirectURL("http://prometheus-a:9090"))),
+				dashboard.AddDatasource("promB", promDs.Prometheus(promDs.DirectURL("http://prometheus-b:9090"))),
+			},
+		},
+		{
+			title: "two default datasources",
+			options: []dashboard.Option{
+				dashboard.AddDatasource("promA", datasource.Default(true), promDs.Prometheus(promDs.DirectURL("http://prometheus-a:9090"))),
+				dashboard.AddDatasource("promB", datasource.Default(true), promDs.Prometheus(promDs.DirectURL("http://prometheus-b:9090"))),
+			},
+			expectedErr: `datasources "promA" and "promB" are both defined as the default PrometheusDatasource`,
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			_, err := dashboard.New("Datasources", test.options...)
+			if len(test.expectedErr) > 0 {
+				assert.EqualError(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDashboardBuilderQueryDatasourceCompatibility(t *testing.T) {
+	lokiQuery := func(datasource map[string]interface{}) panel.Option {
+		return panel.AddQuery(query.Plugin(common.Plugin{
+			Kind: "LokiLogQuery",
+			Spec: map[string]interface{}{"query": `{app="api"}`, "datasource": datasource},
+		}))
+	}
+	testSuites := []struct {
+		title       string
+		datasource  map[string]interface{}
+		expectedErr string
+	}{
+		{
+			title:      "compatible datasource kind",
+			datasource: map[string]interface{}{"kind": "LokiDatasource", "name": "loki"},
+		},
+		{
+			title:       "incompatible datasource kind",
+			datasource:  map[string]interface{}{"kind": "PrometheusDatasource", "name": "prom"},
+			expectedErr: `panel "Logs", query 0: query LokiLogQuery is not compatible with the datasource PrometheusDatasource`,
+		},
+		{
+			title:       "incompatible dashboard datasource selected by name",
+			datasource:  map[string]interface{}{"name": "prom"},
+			expectedErr: `panel "Logs", query 0: query LokiLogQuery is not compatible with the datasource PrometheusDatasource`,
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			_, err := dashboard.New("Datasources",
+				dashboard.AddDatasource("prom", promDs.Prometheus(promDs.DirectURL("http://prometheus:9090"))),
+				dashboard.AddPanelGroup("Logs",
+					panelgroup.AddPanel("Logs", lokiQuery(test.datasource)),
+				),
+			)
+			if len(test.expectedErr) > 0 {
+				assert.EqualError(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDashboardBuilderQueryLevelDatasource(t *testing.T) {
+	promQuery := func(datasource string) panel.Option {
+		return panel.AddQuery(
+			query.Datasource(datasource),
+			query.Plugin(common.Plugin{Kind: "PrometheusTimeSeriesQuery", Spec: map[string]interface{}{"query": "up"}}),
+		)
+	}
+	b, err := dashboard.New("Datasources",
+		dashboard.AddDatasource("staging", promDs.Prometheus(promDs.DirectURL("http://prometheus-staging:9090"))),
+		dashboard.AddDatasource("prod", promDs.Prometheus(promDs.DirectURL("http://prometheus-prod:9090"))),
+		dashboard.AddPanelGroup("Compare",
+			panelgroup.AddPanel("Up", promQuery("staging"), promQuery("prod")),
+		),
+	)
+	assert.NoError(t, err)
+	queries := b.Dashboard.Spec.Panels["0_0"].Spec.Queries
+	assert.Equal(t, "staging", queries[0].Spec.Datasource)
+	assert.Equal(t, "prod", queries[1].Spec.Datasource)
+
+	_, err = dashboard.New("Datasources",
+		dashboard.AddDatasource("loki", datasource.Plugin(common.Plugin{Kind: "LokiDatasource"})),
+		dashboard.AddPanelGroup("Compare",
+			panelgroup.AddPanel("Up", promQuery("loki")),
+		),
+	)
+	assert.EqualError(t, err, `panel "Up", query 0: query PrometheusTimeSeriesQuery is not compatible with the datasource LokiDatasource`)
+
+	_, err = query.New(query.Datasource("prometheus prod"))
+	assert.Error(t, err)
+}
+
+func TestDatasourceBuilderProxy(t *testing.T) {
+	ds, err := datasource.New("prom",
+		datasource.ProxyURL("http://prometheus:9090"),
+		datasource.AddAllowedEndpoint("GET", "/api/v1/query"),
+		datasource.AddProxyHeader("X-Scope-OrgID", "payments"),
+		datasource.ProxySecret("prometheus-auth"),
+		datasource.Plugin(common.Plugin{Kind: "PrometheusDatasource", Spec: map[string]interface{}{"directUrl": "http://localhost:9090"}}),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"proxy": map[string]interface{}{
+			"kind": "HTTPProxy",
+			"spec": map[string]interface{}{
+				"url":              "http://prometheus:9090",
+				"allowedEndpoints": []interface{}{map[string]interface{}{"endpointPattern": "/api/v1/query", "method": "GET"}},
+				"headers":          map[string]interface{}{"X-Scope-OrgID": "payments"},
+				"secret":           "prometheus-auth",
+			},
+		},
+	}, ds.Spec.Plugin.Spec)
+
+	_, err = datasource.New("prom",
+		datasource.AddProxyHeader("X-Scope-OrgID", "payments"),
+		datasource.Plugin(common.Plugin{Kind: "PrometheusDatasource"}),
+	)
+	assert.Error(t, err)
+	_, err = datasource.New("prom", datasource.ProxyURL("http://prometheus:9090"))
+	assert.Error(t, err)
+}
+
+func TestDatasourceSelectorFromLabels(t *testing.T) {
+	resolver := datasource.StaticLabelResolver(map[string]map[string]string{
+		"prometheus-eu":  {"env": "prod", "region": "eu"},
+		"prometheus-us":  {"env": "prod", "region": "us"},
+		"prometheus-dev": {"env": "dev", "region": "eu"},
+	})
+
+	selector, err := datasource.SelectorFromLabels("PrometheusDatasource", map[string]string{"env": "prod", "region": "eu"}, resolver)
+	require.NoError(t, err)
+	assert.Equal(t, datasource.Selector{Kind: "PrometheusDatasource", Name: "prometheus-eu"}, selector)
+
+	_, err = datasource.SelectorFromLabels("PrometheusDatasource", map[string]string{"env": "prod"}, resolver)
+	assert.EqualError(t, err, `several datasources match the labels {env="prod"}: prometheus-eu, prometheus-us`)
+	_, err = datasource.SelectorFromLabels("PrometheusDatasource", map[string]string{"env": "staging"}, resolver)
+	assert.EqualError(t, err, `no datasource matches the labels {env="staging"}`)
+	_, err = datasource.SelectorFromLabels("PrometheusDatasource", nil, resolver)
+	assert.Error(t, err)
+	_, err = datasource.SelectorFromLabels("PrometheusDatasource", map[string]string{"env": "prod"}, nil)
+	assert.Error(t, err)
+}
+
+func TestDashboardBuilderQueryDatasourceVariable(t *testing.T) {
+	datasourceVariable := func(datasourceKind string, options ...listVar.Option) dashboard.Option {
+		options = append(options, listVar.Plugin(common.Plugin{
+			Kind: "DatasourceVariable",
+			Spec: map[string]interface{}{"datasourcePluginKind": datasourceKind},
+		}))
+		return dashboard.AddVariable("datasource", listVar.List(options...))
+	}
+	promQuery := panel.AddQuery(query.Plugin(common.Plugin{
+		Kind: "PrometheusTimeSeriesQuery",
+		Spec: map[string]interface{}{"query": "up", "datasource": "$datasource"},
+	}))
+	testSuites := []struct {
+		title       string
+		variable    dashboard.Option
+		expectedErr string
+	}{
+		{
+			title:    "datasource variable listing a compatible kind",
+			variable: datasourceVariable("PrometheusDatasource"),
+		},
+		{
+			title:       "datasource variable listing an incompatible kind",
+			variable:    datasourceVariable("LokiDatasource"),
+			expectedErr: `panel "Up", query 0: query PrometheusTimeSeriesQuery is not compatible with the LokiDatasource listed by the variable "datasource"`,
+		},
+		{
+			title:       "datasource variable allowing multiple values",
+			variable:    datasourceVariable("PrometheusDatasource", listVar.AllowMultiple(true)),
+			expectedErr: `panel "Up", query 0: datasource variable "datasource" cannot allow multiple values`,
+		},
+		{
+			title:       "datasource variable of a text variable",
+			variable:    dashboard.AddVariable("datasource", txtVar.Text("prometheus")),
+			expectedErr: `panel "Up", query 0: datasource variable "datasource" must be a list variable`,
+		},
+		{
+			title:       "datasource variable not declared",
+			variable:    dashboard.AddVariable("cluster", txtVar.Text("eu-1")),
+			expectedErr: `panel "Up", query 0: datasource variable "datasource" is not declared`,
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			_, err := dashboard.New("Datasources",
+				test.variable,
+				dashboard.AddPanelGroup("Status", panelgroup.AddPanel("Up", promQuery)),
+			)
+			if len(test.expectedErr) > 0 {
+				assert.EqualError(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
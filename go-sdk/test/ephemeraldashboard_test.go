@@ -0,0 +1,60 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dac
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/perses/perses/go-sdk/dashboard"
+	ephemeraldashboard "github.com/perses/perses/go-sdk/ephemeral-dashboard"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEphemeralDashboardBuilder(t *testing.T) {
+	b, buildErr := ephemeraldashboard.New("nodes-pr-42",
+		ephemeraldashboard.ProjectName("infra"),
+		ephemeraldashboard.TTL(48*time.Hour),
+		ephemeraldashboard.Dashboard(dashboard.Duration(3*time.Hour)),
+		ephemeraldashboard.Dashboard(dashboard.RefreshInterval(time.Minute)),
+	)
+	require.NoError(t, buildErr)
+
+	d := b.EphemeralDashboard
+	assert.Equal(t, v1.KindEphemeralDashboard, d.Kind)
+	assert.Equal(t, "nodes-pr-42", d.Metadata.Name)
+	assert.Equal(t, "infra", d.Metadata.Project)
+	assert.Equal(t, common.Duration(48*time.Hour), d.Spec.TTL)
+	assert.Equal(t, common.Duration(3*time.Hour), d.Spec.Duration)
+	assert.Equal(t, common.Duration(time.Minute), d.Spec.RefreshInterval)
+
+	data, err := json.Marshal(b)
+	require.NoError(t, err)
+	var decoded v1.EphemeralDashboard
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, d.Spec.TTL, decoded.Spec.TTL)
+
+	defaultTTL, err := ephemeraldashboard.New("nodes-pr-43")
+	require.NoError(t, err)
+	assert.Equal(t, common.Duration(24*time.Hour), defaultTTL.EphemeralDashboard.Spec.TTL)
+
+	_, err = ephemeraldashboard.New("Nodes PR 42")
+	assert.Error(t, err)
+	_, err = ephemeraldashboard.New("nodes-pr-42", ephemeraldashboard.TTL(0))
+	assert.Error(t, err)
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dac
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/perses/perses/go-sdk/format"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	f, err := format.New(format.Unit("bytes"), format.Decimals(2), format.ShortValues(true))
+	require.NoError(t, err)
+	data, err := json.Marshal(f)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"unit":"bytes","decimalPlaces":2,"shortValues":true}`, string(data))
+}
+
+func TestFormatErrors(t *testing.T) {
+	testSuites := []struct {
+		title       string
+		options     []format.Option
+		expectedErr string
+	}{
+		{
+			title:       "unknown unit",
+			options:     []format.Option{format.Unit("kilobytes")},
+			expectedErr: `unknown unit "kilobytes"`,
+		},
+		{
+			title:       "negative decimals",
+			options:     []format.Option{format.Decimals(-1)},
+			expectedErr: "the number of decimal places cannot be negative",
+		},
+		{
+			title:       "short values of a time unit",
+			options:     []format.Option{format.ShortValues(true), format.Unit("seconds")},
+			expectedErr: `short values are not available for the unit "seconds"`,
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			_, err := format.New(test.options...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.expectedErr)
+		})
+	}
+}
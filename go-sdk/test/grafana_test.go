@@ -0,0 +1,96 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dac
+
+import (
+	"testing"
+	"time"
+
+	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	dashboard2 "github.com/perses/perses/pkg/model/api/v1/dashboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const grafanaDashboard = `{
+  "uid": "node-exporter",
+  "title": "Node Exporter",
+  "time": {"from": "now-6h", "to": "now"},
+  "refresh": "30s",
+  "templating": {
+    "list": [
+      {"name": "job", "type": "custom", "query": "node, windows", "current": {"value": "node"}},
+      {"name": "instance", "type": "query", "label": "Instance", "multi": true, "includeAll": true, "query": {"query": "label_values(up{job=\"$job\"}, instance)"}},
+      {"name": "annotations", "type": "adhoc"}
+    ]
+  },
+  "panels": [
+    {"type": "text", "title": "About", "gridPos": {"x": 0, "y": 0, "w": 24, "h": 2}, "options": {"content": "Nodes of [[job]]"}},
+    {"type": "row", "title": "CPU", "collapsed": false, "gridPos": {"x": 0, "y": 2, "w": 24, "h": 1}, "panels": []},
+    {"type": "timeseries", "title": "CPU usage", "gridPos": {"x": 0, "y": 3, "w": 12, "h": 8}, "targets": [{"expr": "rate(node_cpu_seconds_total{instance=~\"$instance\"}[$__rate_interval])", "legendFormat": "{{cpu}}"}]},
+    {"type": "heatmap", "title": "CPU heatmap", "gridPos": {"x": 12, "y": 3, "w": 12, "h": 8}},
+    {"type": "row", "title": "Memory", "collapsed": true, "gridPos": {"x": 0, "y": 11, "w": 24, "h": 1}, "panels": [
+      {"type": "stat", "title": "Available", "gridPos": {"x": 0, "y": 12, "w": 6, "h": 4}, "targets": [{"expr": "node_memory_MemAvailable_bytes"}]}
+    ]}
+  ]
+}`
+
+func TestDashboardFromGrafana(t *testing.T) {
+	b, err := dashboard.FromGrafana([]byte(grafanaDashboard), dashboard.ProjectName("infra"))
+	require.NoError(t, err)
+
+	d := b.Dashboard
+	assert.Equal(t, "node-exporter", d.Metadata.Name)
+	assert.Equal(t, "infra", d.Metadata.Project)
+	assert.Equal(t, "Node Exporter", d.Spec.Display.Name)
+	assert.Equal(t, common.Duration(6*time.Hour), d.Spec.Duration)
+	assert.Equal(t, common.Duration(30*time.Second), d.Spec.RefreshInterval)
+
+	require.Len(t, d.Spec.Variables, 2)
+	job := d.Spec.Variables[0].Spec.(*dashboard2.ListVariableSpec)
+	assert.Equal(t, "StaticListVariable", job.Plugin.Kind)
+	assert.Equal(t, map[string]interface{}{"values": []interface{}{"node", "windows"}}, job.Plugin.Spec)
+	assert.Equal(t, "node", job.DefaultValue.SingleValue)
+	instance := d.Spec.Variables[1].Spec.(*dashboard2.ListVariableSpec)
+	assert.Equal(t, "PrometheusLabelValuesVariable", instance.Plugin.Kind)
+	assert.Equal(t, map[string]interface{}{"labelName": "instance", "matchers": []interface{}{`up{job="$job"}`}}, instance.Plugin.Spec)
+	assert.True(t, instance.AllowMultiple)
+	assert.True(t, instance.AllowAllValue)
+
+	require.Len(t, d.Spec.Layouts, 3)
+	var titles []string
+	for _, layout := range d.Spec.Layouts {
+		titles = append(titles, layout.Spec.(dashboard2.GridLayoutSpec).Display.Title)
+	}
+	assert.Equal(t, []string{"Panels", "CPU", "Memory"}, titles)
+	cpu := d.Spec.Layouts[1].Spec.(dashboard2.GridLayoutSpec)
+	assert.True(t, cpu.Display.Collapse.Open)
+	assert.Equal(t, 0, cpu.Items[0].Y)
+	assert.Equal(t, 12, cpu.Items[1].X)
+	assert.False(t, d.Spec.Layouts[2].Spec.(dashboard2.GridLayoutSpec).Display.Collapse.Open)
+
+	assert.Equal(t, map[string]interface{}{"text": "Nodes of ${job}"}, d.Spec.Panels["0_0"].Spec.Plugin.Spec)
+	usage := d.Spec.Panels["1_0"]
+	assert.Equal(t, "TimeSeriesChart", usage.Spec.Plugin.Kind)
+	require.Len(t, usage.Spec.Queries, 1)
+	assert.Equal(t, "PrometheusTimeSeriesQuery", usage.Spec.Queries[0].Spec.Plugin.Kind)
+	assert.Equal(t, "Markdown", d.Spec.Panels["1_1"].Spec.Plugin.Kind)
+	assert.Equal(t, "StatChart", d.Spec.Panels["2_0"].Spec.Plugin.Kind)
+
+	warnings := b.Lint()
+	require.Len(t, warnings, 2)
+	assert.Equal(t, "annotations", warnings[0].Variable)
+	assert.Contains(t, warnings[1].Message, `"heatmap"`)
+}
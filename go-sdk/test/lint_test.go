@@ -0,0 +1,66 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dac
+
+import (
+	"testing"
+	"time"
+
+	"github.com/perses/perses/go-sdk/dashboard"
+	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
+	labelNamesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-names"
+	labelValuesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-values"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDashboardLintExpensiveVariables(t *testing.T) {
+	builder, err := dashboard.New("LintVariables",
+		dashboard.AddVariable("stack", listVar.List(
+			labelValuesVar.PrometheusLabelValues("stack",
+				labelValuesVar.Matchers("thanos_build_info{}"),
+			),
+		)),
+		dashboard.AddVariable("instance", listVar.List(
+			labelValuesVar.PrometheusLabelValues("instance"),
+		)),
+		dashboard.AddVariable("labels", listVar.List(
+			labelNamesVar.PrometheusLabelNames(
+				labelNamesVar.Matchers("{}"),
+			),
+		)),
+	)
+	require.NoError(t, err)
+
+	warnings := builder.Lint()
+	require.Len(t, warnings, 2)
+	assert.Equal(t, "instance", warnings[0].Variable)
+	assert.Contains(t, warnings[0].Message, "has no matchers")
+	assert.Equal(t, "labels", warnings[1].Variable)
+	assert.Contains(t, warnings[1].Message, `matcher "{}"`)
+}
+
+func TestDashboardLintMinRefreshInterval(t *testing.T) {
+	builder, err := dashboard.New("LintRefresh",
+		dashboard.MinRefreshInterval(15*time.Second),
+		dashboard.RefreshInterval(5*time.Second),
+		dashboard.RefreshIntervals(0, 10*time.Second, 30*time.Second),
+	)
+	require.NoError(t, err)
+
+	warnings := builder.Lint()
+	require.Len(t, warnings, 2)
+	assert.Equal(t, "refresh interval 5s is smaller than the minimum 15s", warnings[0].String())
+	assert.Equal(t, "selectable refresh interval 10s is smaller than the minimum 15s", warnings[1].String())
+}
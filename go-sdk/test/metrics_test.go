@@ -0,0 +1,102 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dac
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/perses/perses/go-sdk/panel"
+	panelgroup "github.com/perses/perses/go-sdk/panel-group"
+	"github.com/perses/plugins/prometheus/sdk/go/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDashboardBuilderReferencedMetrics(t *testing.T) {
+	builder, err := dashboard.New("Metrics",
+		dashboard.AddPanelGroup("Resources",
+			panelgroup.AddPanel("CPU",
+				panel.AddQuery(query.PromQL(`sum by (pod) (rate(container_cpu_usage_seconds_total{namespace="$namespace"}[$__rate_interval])) / on (pod) group_left kube_pod_info offset 5m`)),
+			),
+			panelgroup.AddPanel("Latency",
+				panel.AddQuery(query.PromQL(`histogram_quantile(0.99, sum by (le) (rate(http_request_duration_seconds_bucket[5m]))) > bool 1e3`)),
+				panel.AddQuery(query.PromQL(`{__name__="up"} or $metric`)),
+			),
+		),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"container_cpu_usage_seconds_total",
+		"http_request_duration_seconds_bucket",
+		"kube_pod_info",
+		"up",
+	}, builder.ReferencedMetrics())
+}
+
+func TestDashboardLintDimensionBudget(t *testing.T) {
+	builder, err := dashboard.New("Dimensions",
+		dashboard.MaxDimensionBudget(2),
+		dashboard.AddPanelGroup("Resources",
+			panelgroup.AddPanel("CPU",
+				panel.AddQuery(query.PromQL(`sum by (pod, container) (rate(container_cpu_usage_seconds_total{namespace="$namespace",pod=~"$pod"}[5m]))`)),
+			),
+		),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"container", "namespace", "pod"}, builder.ReferencedLabels())
+	warnings := builder.Lint()
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, "queries use 3 distinct labels (container, namespace, pod), more than the budget of 2", warnings[0].Message)
+}
+
+func TestDashboardBuilderEnforceLabelMatcher(t *testing.T) {
+	testSuites := []struct {
+		expr     string
+		expected string
+	}{
+		{
+			expr:     `up`,
+			expected: `up{tenant="$tenant"}`,
+		},
+		{
+			expr:     `sum by (pod) (rate(container_cpu_usage_seconds_total{namespace="$namespace"}[$__rate_interval]))`,
+			expected: `sum by (pod) (rate(container_cpu_usage_seconds_total{namespace="$namespace",tenant="$tenant"}[$__rate_interval]))`,
+		},
+		{
+			expr:     `histogram_quantile(0.99, sum by (le) (rate(http_request_duration_seconds_bucket{tenant="acme"}[5m])))`,
+			expected: `histogram_quantile(0.99, sum by (le) (rate(http_request_duration_seconds_bucket{tenant="acme"}[5m])))`,
+		},
+		{
+			expr:     `label_replace(kube_pod_info, "node", "$1", "host", "(.*)") * on (pod) group_left {__name__="up"}`,
+			expected: `label_replace(kube_pod_info{tenant="$tenant"}, "node", "$1", "host", "(.*)") * on (pod) group_left {__name__="up",tenant="$tenant"}`,
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.expr, func(t *testing.T) {
+			builder, err := dashboard.New("Tenant",
+				dashboard.EnforceLabelMatcher("tenant", "$tenant"),
+				dashboard.AddPanelGroup("Resources",
+					panelgroup.AddPanel("Panel", panel.AddQuery(query.PromQL(test.expr))),
+				),
+			)
+			assert.NoError(t, err)
+			spec, err := json.Marshal(builder.Dashboard.Spec.Panels["0_0"].Spec.Queries[0].Spec.Plugin.Spec)
+			assert.NoError(t, err)
+			var result map[string]interface{}
+			assert.NoError(t, json.Unmarshal(spec, &result))
+			assert.Equal(t, test.expected, result["query"])
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dac
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/perses/perses/go-sdk/mixin"
+	"github.com/perses/perses/go-sdk/panel"
+	panelgroup "github.com/perses/perses/go-sdk/panel-group"
+	"github.com/perses/perses/go-sdk/query"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPodMixin(t *testing.T) mixin.Builder {
+	m, err := mixin.New("kubernetes-pod", func(params mixin.Params) ([]dashboard.Option, error) {
+		spec := map[string]interface{}{
+			"query": fmt.Sprintf("sum by (pod) (kube_pod_container_status_restarts_total{%s})", params.Selector()),
+		}
+		if len(params.Datasource) > 0 {
+			spec["datasource"] = map[string]interface{}{"kind": "PrometheusDatasource", "name": params.Datasource}
+		}
+		return []dashboard.Option{
+			dashboard.AddPanelGroup(params.Value("title"),
+				panelgroup.AddPanel("Restarts",
+					panel.Plugin(common.Plugin{Kind: "TimeSeriesChart", Spec: map[string]interface{}{}}),
+					panel.AddQuery(query.Plugin(common.Plugin{Kind: "PrometheusTimeSeriesQuery", Spec: spec})),
+				),
+			),
+		}, nil
+	},
+		mixin.DefaultSelectors(`namespace="default"`),
+		mixin.Param("title", "Pods"),
+	)
+	require.NoError(t, err)
+	return m
+}
+
+func TestMixin(t *testing.T) {
+	podMixin := newPodMixin(t)
+	b, err := dashboard.New("Pods",
+		podMixin.Apply(),
+		podMixin.Apply(
+			mixin.Datasource("prometheus-eu"),
+			mixin.Selectors(`namespace="payments"`, `pod=~"api-.*"`),
+			mixin.Set("title", "Payment pods"),
+		),
+	)
+	require.NoError(t, err)
+
+	require.Len(t, b.Dashboard.Spec.Layouts, 2)
+	defaultQuery := b.Dashboard.Spec.Panels["0_0"].Spec.Queries[0].Spec.Plugin.Spec
+	assert.Equal(t, map[string]interface{}{
+		"query": `sum by (pod) (kube_pod_container_status_restarts_total{namespace="default"})`,
+	}, defaultQuery)
+	overriddenQuery := b.Dashboard.Spec.Panels["1_0"].Spec.Queries[0].Spec.Plugin.Spec
+	assert.Equal(t, map[string]interface{}{
+		"query":      `sum by (pod) (kube_pod_container_status_restarts_total{namespace="payments",pod=~"api-.*"})`,
+		"datasource": map[string]interface{}{"kind": "PrometheusDatasource", "name": "prometheus-eu"},
+	}, overriddenQuery)
+
+	// The overrides of an instance don't leak into the defaults of the mixin.
+	assert.Equal(t, []string{`namespace="default"`}, podMixin.Defaults.Selectors)
+	assert.Equal(t, "Pods", podMixin.Defaults.Value("title"))
+
+	_, err = dashboard.New("Pods", podMixin.Apply(mixin.Set("unknown", "value")))
+	assert.ErrorContains(t, err, `parameter "unknown" is not declared`)
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dac
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/perses/perses/go-sdk/datasource"
+	"github.com/perses/perses/go-sdk/project"
+	"github.com/perses/perses/go-sdk/role"
+	"github.com/perses/perses/go-sdk/rolebinding"
+	"github.com/perses/perses/go-sdk/secret"
+	txtVar "github.com/perses/perses/go-sdk/variable/text-variable"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	roleModel "github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectBuilder(t *testing.T) {
+	b, buildErr := project.New("infra",
+		project.DisplayName("Infrastructure"),
+		project.AddDatasource("prometheus",
+			datasource.Default(true),
+			datasource.Plugin(common.Plugin{Kind: "PrometheusDatasource", Spec: map[string]interface{}{"directUrl": "http://localhost:9090"}}),
+		),
+		project.AddVariable("platform", txtVar.Text("linux", txtVar.Constant(true))),
+		project.AddRole("viewer",
+			role.AddPermission([]roleModel.Action{roleModel.ReadAction}, []roleModel.Scope{roleModel.DashboardScope, roleModel.DatasourceScope}),
+		),
+		project.AddRoleBinding("viewers", rolebinding.Role("viewer"), rolebinding.AddUser("alice", "bob")),
+		project.AddDashboard("Nodes",
+			dashboard.AddSecret("prometheus-auth", secret.BasicAuth("perses", "/etc/perses/secrets/prometheus-password")),
+		),
+	)
+	require.NoError(t, buildErr)
+
+	assert.Equal(t, "Infrastructure", b.Project.Spec.Display.Name)
+	require.Len(t, b.Datasources, 1)
+	assert.Equal(t, "infra", b.Datasources[0].Metadata.Project)
+	require.Len(t, b.Variables, 1)
+	assert.Equal(t, "infra", b.Variables[0].Metadata.Project)
+	require.Len(t, b.Dashboards, 1)
+	assert.Equal(t, "infra", b.Dashboards[0].Metadata.Project)
+	require.Len(t, b.Secrets, 1)
+	assert.Equal(t, "infra", b.Secrets[0].Metadata.Project)
+	require.Len(t, b.RoleBindings, 1)
+	assert.Equal(t, []v1.Subject{{Kind: v1.KindUser, Name: "alice"}, {Kind: v1.KindUser, Name: "bob"}}, b.RoleBindings[0].Spec.Subjects)
+
+	var kinds []string
+	for _, resource := range b.Resources() {
+		kinds = append(kinds, resource.GetKind())
+	}
+	assert.Equal(t, []string{"Project", "Secret", "Datasource", "Variable", "Role", "RoleBinding", "Dashboard"}, kinds)
+
+	var buffer bytes.Buffer
+	require.NoError(t, b.Write(&buffer, project.JSONFormat))
+	var written []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &written))
+	assert.Len(t, written, 7)
+
+	_, err := project.New("infra",
+		project.AddDatasource("prometheus"),
+		project.AddDatasource("prometheus"),
+	)
+	assert.Error(t, err)
+	_, err = project.New("infra", project.AddRole("admin",
+		role.AddPermission([]roleModel.Action{roleModel.WildcardAction}, []roleModel.Scope{roleModel.GlobalDatasourceScope}),
+	))
+	assert.Error(t, err)
+}
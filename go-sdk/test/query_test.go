@@ -0,0 +1,68 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dac
+
+import (
+	"testing"
+	"time"
+
+	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/perses/perses/go-sdk/panel"
+	panelgroup "github.com/perses/perses/go-sdk/panel-group"
+	"github.com/perses/perses/go-sdk/query"
+	txtVar "github.com/perses/perses/go-sdk/variable/text-variable"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryBuilderMinStepAndResolution(t *testing.T) {
+	// The min step and the resolution can be set before the plugin.
+	q, err := query.New(
+		query.MinStep(5*time.Minute),
+		query.Resolution(2),
+		query.Plugin(common.Plugin{Kind: "PrometheusTimeSeriesQuery", Spec: map[string]interface{}{"query": "up"}}),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"query": "up", "minStep": "5m", "resolution": 2}, q.Spec.Plugin.Spec)
+
+	_, err = query.New(query.MinStep(time.Minute))
+	assert.Error(t, err)
+	_, err = query.New(query.MinStep(0), query.Plugin(common.Plugin{Kind: "PrometheusTimeSeriesQuery"}))
+	assert.Error(t, err)
+	_, err = query.New(query.Resolution(0), query.Plugin(common.Plugin{Kind: "PrometheusTimeSeriesQuery"}))
+	assert.Error(t, err)
+}
+
+func TestDashboardBuilderRangeVariables(t *testing.T) {
+	build := func(expr string, options ...dashboard.Option) error {
+		options = append(options, dashboard.AddPanelGroup("Overview",
+			panelgroup.AddPanel("Requests", panel.AddQuery(query.Plugin(common.Plugin{
+				Kind: "PrometheusTimeSeriesQuery",
+				Spec: map[string]interface{}{"query": expr},
+			}))),
+		))
+		_, err := dashboard.New("Ranges", options...)
+		return err
+	}
+	interval := dashboard.AddVariable("interval", txtVar.Text("5m"))
+
+	assert.NoError(t, build(`rate(http_requests_total[$interval])`, interval))
+	assert.NoError(t, build(`max_over_time(rate(http_requests_total[${interval}])[1h:$interval])`, interval))
+	assert.NoError(t, build(`rate(http_requests_total[$__rate_interval])`))
+	assert.EqualError(t, build(`rate(http_requests_total[$window])`, interval),
+		`query "rate(http_requests_total[$window])" uses the variable "window" as range, but this variable is not declared`)
+	assert.EqualError(t, build(`max_over_time(up[${window}:1m])`),
+		`query "max_over_time(up[${window}:1m])" uses the variable "window" as range, but this variable is not declared`)
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dac
+
+import (
+	"testing"
+
+	"github.com/perses/perses/go-sdk/role"
+	"github.com/perses/perses/go-sdk/rolebinding"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	roleModel "github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleBuilder(t *testing.T) {
+	b, buildErr := role.New("editor",
+		role.ProjectName("infra"),
+		role.AddPermission([]roleModel.Action{roleModel.WildcardAction}, []roleModel.Scope{roleModel.DashboardScope, roleModel.VariableScope}),
+		role.AddPermission([]roleModel.Action{roleModel.ReadAction}, []roleModel.Scope{roleModel.ProjectScope}),
+	)
+	require.NoError(t, buildErr)
+	assert.Equal(t, v1.KindRole, b.Role.Kind)
+	assert.Equal(t, "infra", b.Role.Metadata.Project)
+	assert.Equal(t, []roleModel.Permission{
+		{Actions: []roleModel.Action{roleModel.WildcardAction}, Scopes: []roleModel.Scope{roleModel.DashboardScope, roleModel.VariableScope}},
+		{Actions: []roleModel.Action{roleModel.ReadAction}, Scopes: []roleModel.Scope{roleModel.ProjectScope}},
+	}, b.Role.Spec.Permissions)
+
+	_, err := role.New("editor")
+	assert.ErrorContains(t, err, `role "editor" requires at least one permission`)
+	_, err = role.New("editor", role.AddPermission([]roleModel.Action{roleModel.ReadAction}, nil))
+	assert.ErrorContains(t, err, "a permission requires at least one action and one scope")
+	_, err = role.New("editor", role.AddPermission([]roleModel.Action{roleModel.ReadAction}, []roleModel.Scope{roleModel.GlobalSecretScope}))
+	assert.ErrorContains(t, err, `role "editor" cannot use the global scope "GlobalSecret"`)
+	_, err = role.New("Editor role")
+	assert.ErrorContains(t, err, `invalid role name "Editor role"`)
+}
+
+func TestRoleBindingBuilder(t *testing.T) {
+	b, buildErr := rolebinding.New("editors",
+		rolebinding.ProjectName("infra"),
+		rolebinding.Role("editor"),
+		rolebinding.AddUser("alice", "bob"),
+		rolebinding.AddUser("carol"),
+	)
+	require.NoError(t, buildErr)
+	assert.Equal(t, v1.KindRoleBinding, b.RoleBinding.Kind)
+	assert.Equal(t, "infra", b.RoleBinding.Metadata.Project)
+	assert.Equal(t, "editor", b.RoleBinding.Spec.Role)
+	assert.Equal(t, []v1.Subject{
+		{Kind: v1.KindUser, Name: "alice"},
+		{Kind: v1.KindUser, Name: "bob"},
+		{Kind: v1.KindUser, Name: "carol"},
+	}, b.RoleBinding.Spec.Subjects)
+
+	_, err := rolebinding.New("editors", rolebinding.AddUser("alice"))
+	assert.ErrorContains(t, err, `role binding "editors" requires a role`)
+	_, err = rolebinding.New("editors", rolebinding.Role("editor"))
+	assert.ErrorContains(t, err, `role binding "editors" requires at least one user`)
+	_, err = rolebinding.New("editors", rolebinding.Role("editor"), rolebinding.AddUser("alice", "alice"))
+	assert.ErrorContains(t, err, `user "alice" is already bound`)
+}
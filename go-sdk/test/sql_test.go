@@ -0,0 +1,79 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dac
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/perses/perses/go-sdk/sql"
+	sqlModel "github.com/perses/perses/pkg/model/api/v1/datasource/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLProxyBuilder(t *testing.T) {
+	b, err := sql.New(sqlModel.DriverClickHouse, "clickhouse:8123", "otel",
+		sql.Secret("clickhouse-auth"),
+		sql.ClickHouse(sqlModel.ClickHouseConfig{Settings: map[string]string{"max_execution_time": "30"}}),
+	)
+	require.NoError(t, err)
+	data, err := json.Marshal(b)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+  "kind": "SQLProxy",
+  "spec": {
+    "driver": "clickhouse",
+    "host": "clickhouse:8123",
+    "database": "otel",
+    "secret": "clickhouse-auth",
+    "clickhouse": {"settings": {"max_execution_time": "30"}}
+  }
+}`, string(data))
+}
+
+func TestSQLProxyBuilderErrors(t *testing.T) {
+	testSuites := []struct {
+		title       string
+		driver      sqlModel.Driver
+		host        string
+		options     []sql.Option
+		expectedErr string
+	}{
+		{
+			title:       "unknown driver",
+			driver:      "oracle",
+			host:        "oracle:1521",
+			expectedErr: `driver "oracle" is not supported`,
+		},
+		{
+			title:       "empty host",
+			driver:      sqlModel.DriverPostgreSQL,
+			expectedErr: "host cannot be empty",
+		},
+		{
+			title:       "config of another driver",
+			driver:      sqlModel.DriverClickHouse,
+			host:        "clickhouse:8123",
+			options:     []sql.Option{sql.Postgres(sqlModel.PostgresConfig{})},
+			expectedErr: `the postgres config can only be used with the driver "postgres"`,
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			_, err := sql.New(test.driver, test.host, "otel", test.options...)
+			assert.EqualError(t, err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dac
+
+import (
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/perses/perses/go-sdk/common"
+	"github.com/perses/perses/go-sdk/thresholds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThresholds(t *testing.T) {
+	th, err := thresholds.New(
+		thresholds.DefaultColor("green"),
+		thresholds.Steps(thresholds.Step(0.8, "orange"), thresholds.NamedStep("critical", 0.9, "#ff0000")),
+	)
+	require.NoError(t, err)
+	data, err := json.Marshal(th)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+  "defaultColor": "green",
+  "steps": [
+    {"value": 0.8, "color": "orange"},
+    {"value": 0.9, "color": "#ff0000", "name": "critical"}
+  ]
+}`, string(data))
+}
+
+func TestThresholdsErrors(t *testing.T) {
+	testSuites := []struct {
+		title       string
+		options     []thresholds.Option
+		expectedErr string
+	}{
+		{
+			title:       "no step",
+			options:     []thresholds.Option{thresholds.Steps()},
+			expectedErr: "thresholds require at least one step",
+		},
+		{
+			title:       "steps not ordered",
+			options:     []thresholds.Option{thresholds.Steps(thresholds.Step(0.9, "red"), thresholds.Step(0.8, "orange"))},
+			expectedErr: "step[1]: the value 0.8 must be greater than the value of the previous step (0.9)",
+		},
+		{
+			title:       "unknown color name",
+			options:     []thresholds.Option{thresholds.Steps(thresholds.Step(0.8, "reddish"))},
+			expectedErr: `step[0]: unknown color name "reddish"`,
+		},
+		{
+			title:       "invalid hexadecimal color",
+			options:     []thresholds.Option{thresholds.DefaultColor("#12345")},
+			expectedErr: `invalid hexadecimal color "#12345"`,
+		},
+		{
+			title:       "percent out of range",
+			options:     []thresholds.Option{thresholds.Steps(thresholds.Step(80, "orange"), thresholds.Step(120, "red")), thresholds.Mode(sdk.PercentMode)},
+			expectedErr: "step[1]: the value 120 must be between 0 and 100 in percent mode",
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			_, err := thresholds.New(test.options...)
+			assert.EqualError(t, err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dac
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/perses/perses/go-sdk/panel"
+	"github.com/perses/perses/go-sdk/query"
+	"github.com/perses/perses/go-sdk/transform"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPanelTransform(t *testing.T) {
+	logQLMetric := func(expr string) query.Option {
+		return query.Plugin(common.Plugin{Kind: "LokiTimeSeriesQuery", Spec: map[string]interface{}{"query": expr}})
+	}
+	chart := panel.Plugin(common.Plugin{Kind: "TimeSeriesChart"})
+	p, err := panel.New("Error ratio", chart,
+		panel.Transform(transform.MergeSeries(), transform.JoinByLabel("pod"), transform.Calculate("ratio", "a/b")),
+		panel.AddQuery(logQLMetric(`sum by (pod) (rate({app="api"} |= "error" [5m]))`)),
+		panel.AddQuery(logQLMetric(`sum by (pod) (rate({app="api"}[5m]))`)),
+	)
+	require.NoError(t, err)
+	data, err := json.Marshal(p.Spec.Transforms)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+  {"kind": "MergeSeries", "spec": {}},
+  {"kind": "JoinByLabel", "spec": {"labels": ["pod"]}},
+  {"kind": "Calculate", "spec": {"name": "ratio", "expression": "a/b"}}
+]`, string(data))
+
+	_, err = panel.New("Error ratio", chart,
+		panel.Transform(transform.Calculate("ratio", "a/b")),
+		panel.AddQuery(logQLMetric(`sum(rate({app="api"}[5m]))`)),
+	)
+	assert.EqualError(t, err, `the Calculate transform "ratio" references "b", which is neither a query nor a previous Calculate transform`)
+
+	_, err = panel.New("Error ratio", chart, panel.Transform(transform.JoinByLabel()))
+	assert.EqualError(t, err, "the JoinByLabel transform requires at least one label")
+}
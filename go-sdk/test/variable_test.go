@@ -0,0 +1,296 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dac
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/perses/perses/go-sdk/dashboard"
+	sdkVariable "github.com/perses/perses/go-sdk/variable"
+	variablegroup "github.com/perses/perses/go-sdk/variable-group"
+	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
+	txtVar "github.com/perses/perses/go-sdk/variable/text-variable"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	dashboard2 "github.com/perses/perses/pkg/model/api/v1/dashboard"
+	"github.com/perses/perses/pkg/model/api/v1/variable"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDashboardBuilderWithMixedVariables(t *testing.T) {
+	testSuites := []struct {
+		title         string
+		options       []dashboard.Option
+		expectedNames []string
+		expectedErr   string
+	}{
+		{
+			title: "standalone variables around a group keep the declaration order",
+			options: []dashboard.Option{
+				dashboard.AddVariable("stack", txtVar.Text("prod")),
+				dashboard.AddVariableGroup(
+					variablegroup.AddVariable("namespace", txtVar.Text("payments")),
+					variablegroup.AddVariable("pod", txtVar.Text("api")),
+				),
+				dashboard.AddVariable("container", txtVar.Text("main")),
+			},
+			expectedNames: []string{"stack", "namespace", "pod", "container"},
+		},
+		{
+			title: "grouped variable colliding with a standalone one",
+			options: []dashboard.Option{
+				dashboard.AddVariable("namespace", txtVar.Text("payments")),
+				dashboard.AddVariableGroup(
+					variablegroup.AddVariable("namespace", txtVar.Text("payments")),
+				),
+			},
+			expectedErr: `variable "namespace" is declared more than once`,
+		},
+		{
+			title: "standalone variable colliding with a grouped one",
+			options: []dashboard.Option{
+				dashboard.AddVariableGroup(
+					variablegroup.AddVariable("stack", txtVar.Text("prod")),
+				),
+				dashboard.AddVariable("stack", txtVar.Text("prod")),
+			},
+			expectedErr: `variable "stack" is declared more than once`,
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			builder, err := dashboard.New("MixedVariables", test.options...)
+			if len(test.expectedErr) > 0 {
+				assert.EqualError(t, err, test.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			names := make([]string, 0, len(builder.Dashboard.Spec.Variables))
+			for _, v := range builder.Dashboard.Spec.Variables {
+				names = append(names, v.Spec.GetName())
+			}
+			assert.Equal(t, test.expectedNames, names)
+		})
+	}
+}
+
+func TestDashboardBuilderWithDefaultSelectionFromQuery(t *testing.T) {
+	builder, err := dashboard.New("DeepLink",
+		dashboard.DefaultSelectionFromQuery("stack=prod&namespace=payments&namespace=billing"),
+		dashboard.AddVariable("stack", txtVar.Text("dev")),
+		dashboard.AddVariable("namespace", listVar.List(listVar.AllowMultiple(true))),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "prod", builder.Dashboard.Spec.Variables[0].Spec.(*dashboard2.TextVariableSpec).Value)
+	assert.Equal(t, &variable.DefaultValue{SliceValues: []string{"payments", "billing"}}, builder.Dashboard.Spec.Variables[1].Spec.(*dashboard2.ListVariableSpec).DefaultValue)
+
+	_, err = dashboard.New("DeepLink",
+		dashboard.DefaultSelectionFromQuery("pod=api"),
+		dashboard.AddVariable("stack", txtVar.Text("dev")),
+	)
+	assert.EqualError(t, err, `cannot select a default value for the variable "pod": variable is not declared`)
+}
+
+func TestDashboardBuilderWithListVariableRefresh(t *testing.T) {
+	plugin := listVar.Plugin(common.Plugin{Kind: "StaticListVariable", Spec: map[string]interface{}{"values": []string{"a"}}})
+	builder, err := dashboard.New("Refresh",
+		dashboard.AddVariable("cluster", listVar.List(plugin, listVar.RefreshOnDashboardLoad())),
+		dashboard.AddVariable("namespace", listVar.List(plugin, listVar.RefreshOnTimeRangeChange())),
+		dashboard.AddVariable("pod", listVar.List(plugin)),
+	)
+	assert.NoError(t, err)
+	data, err := json.Marshal(builder.Dashboard.Spec.Variables)
+	assert.NoError(t, err)
+	var variables []dashboard2.Variable
+	assert.NoError(t, json.Unmarshal(data, &variables))
+	assert.Equal(t, variable.RefreshOnDashboardLoad, *variables[0].Spec.(*dashboard2.ListVariableSpec).Refresh)
+	assert.Equal(t, variable.RefreshOnTimeRangeChange, *variables[1].Spec.(*dashboard2.ListVariableSpec).Refresh)
+	assert.Nil(t, variables[2].Spec.(*dashboard2.ListVariableSpec).Refresh)
+
+	var refresh variable.Refresh
+	assert.EqualError(t, json.Unmarshal([]byte(`"onRefresh"`), &refresh), `unknown refresh policy "onRefresh" used`)
+}
+
+func TestDashboardBuilderWithListVariablePersist(t *testing.T) {
+	plugin := listVar.Plugin(common.Plugin{Kind: "StaticListVariable", Spec: map[string]interface{}{"values": []string{"a"}}})
+	builder, err := dashboard.New("Persist",
+		dashboard.AddVariable("cluster", listVar.List(plugin)),
+		dashboard.AddVariable("namespace", listVar.List(plugin, listVar.Persist(false))),
+		dashboard.AddVariableGroup(
+			variablegroup.AddVariable("pod", listVar.List(plugin, listVar.Persist(true))),
+		),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"variable.namespace.persist": "false",
+		"variable.pod.persist":       "true",
+	}, builder.Dashboard.Metadata.Annotations)
+
+	v, err := sdkVariable.New("namespace", listVar.List(plugin, listVar.Persist(false)))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{sdkVariable.PersistAnnotation: "false"}, v.Variable.Metadata.Annotations)
+}
+
+func TestDashboardBuilderPreviewVariableExpansion(t *testing.T) {
+	envs := listVar.Plugin(common.Plugin{Kind: "StaticListVariable", Spec: map[string]interface{}{
+		"values": []interface{}{"dev", map[string]interface{}{"label": "Production", "value": "prod"}},
+	}})
+	pods := listVar.Plugin(common.Plugin{Kind: "PrometheusLabelValuesVariable", Spec: map[string]interface{}{"labelName": "pod"}})
+	builder, err := dashboard.New("Preview",
+		dashboard.AddVariable("cluster", txtVar.Text("eu-1", txtVar.Constant(true))),
+		dashboard.AddVariable("namespace", txtVar.Text("payments")),
+		dashboard.AddVariable("env", listVar.List(envs, listVar.AllowAllValue(true), listVar.AllowMultiple(true))),
+		dashboard.AddVariable("pod", listVar.List(pods, listVar.AllowAllValue(true), listVar.CustomAllValue(".*"))),
+		dashboard.AddVariable("job", listVar.List(pods)),
+		dashboard.AddVariable("instance", listVar.List(pods, listVar.AllowAllValue(true))),
+	)
+	assert.NoError(t, err)
+
+	testSuites := []struct {
+		name     string
+		selected string
+		expected string
+		err      string
+	}{
+		{name: "cluster", expected: "eu-1"},
+		{name: "cluster", selected: "us-1", err: `variable "cluster" is a constant, its value cannot be changed`},
+		{name: "namespace", selected: "billing", expected: "billing"},
+		{name: "env", selected: "prod", expected: "(prod)"},
+		{name: "env", selected: "dev, prod", expected: "(dev|prod)"},
+		{name: "env", selected: dashboard.AllValue, expected: "(dev|prod)"},
+		{name: "pod", selected: dashboard.AllValue, expected: ".*"},
+		{name: "job", selected: "api", expected: "api"},
+		{name: "job", selected: "api,worker", err: `variable "job" doesn't allow multiple values`},
+		{name: "job", selected: dashboard.AllValue, err: `variable "job" doesn't allow the all value`},
+		{name: "job", selected: " , ", err: `no value selected for the variable "job"`},
+		{name: "instance", selected: dashboard.AllValue, err: `values of the variable "instance" are only known at runtime, set a custom all value to preview the all value`},
+		{name: "region", selected: "eu", err: `variable "region" is not declared`},
+	}
+	for _, test := range testSuites {
+		t.Run(test.name+"="+test.selected, func(t *testing.T) {
+			result, previewErr := builder.PreviewVariableExpansion(test.name, test.selected)
+			if len(test.err) > 0 {
+				assert.EqualError(t, previewErr, test.err)
+				return
+			}
+			assert.NoError(t, previewErr)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestDashboardBuilderWithListVariableHelpURL(t *testing.T) {
+	plugin := listVar.Plugin(common.Plugin{Kind: "StaticListVariable", Spec: map[string]interface{}{"values": []string{"a"}}})
+	builder, err := dashboard.New("Help",
+		dashboard.AddVariable("cluster", listVar.List(plugin,
+			listVar.HelpURL("https://runbooks.example.com/clusters"),
+			listVar.Description("The cluster hosting the service."),
+		)),
+		dashboard.AddVariable("namespace", listVar.List(plugin, listVar.HelpURL("https://runbooks.example.com/namespaces"))),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "The cluster hosting the service.\n\nHelp: https://runbooks.example.com/clusters",
+		builder.Dashboard.Spec.Variables[0].Spec.(*dashboard2.ListVariableSpec).Display.Description)
+	assert.Equal(t, "Help: https://runbooks.example.com/namespaces",
+		builder.Dashboard.Spec.Variables[1].Spec.(*dashboard2.ListVariableSpec).Display.Description)
+
+	for _, helpURL := range []string{"", "runbooks/clusters", "ftp://runbooks.example.com/clusters"} {
+		_, err = dashboard.New("Help", dashboard.AddVariable("cluster", listVar.List(plugin, listVar.HelpURL(helpURL))))
+		assert.Error(t, err, helpURL)
+	}
+}
+
+func TestDashboardBuilderWithComputedVariable(t *testing.T) {
+	plugin := listVar.Plugin(common.Plugin{Kind: "StaticListVariable", Spec: map[string]interface{}{"values": []string{"eu-1", "us-1"}}})
+	region := func(selection map[string]string) (string, error) {
+		cluster := selection["cluster"]
+		if len(cluster) < 2 {
+			return "", errors.New("unknown cluster")
+		}
+		return cluster[:2] + "/" + selection["namespace"], nil
+	}
+	// The computed variable can be declared before the variables it depends on.
+	builder, err := dashboard.New("Computed",
+		dashboard.AddComputedVariable("region", []string{"cluster", "namespace"}, region),
+		dashboard.AddVariable("cluster", listVar.List(plugin, listVar.DefaultValue("eu-1"))),
+		dashboard.AddVariable("namespace", txtVar.Text("payments")),
+	)
+	assert.NoError(t, err)
+	data, err := json.Marshal(builder.Dashboard.Spec.Variables[2])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"kind": "TextVariable", "spec": {"name": "region", "value": "eu/payments", "constant": true, "display": {"hidden": true}}}`, string(data))
+
+	testSuites := []struct {
+		title       string
+		cluster     sdkVariable.Option
+		expectedErr string
+	}{
+		{
+			title:       "dependency without default value",
+			cluster:     listVar.List(plugin),
+			expectedErr: `computed variable "region": variable "cluster" has no default value`,
+		},
+		{
+			title:       "dependency with several default values",
+			cluster:     listVar.List(plugin, listVar.AllowMultiple(true), listVar.DefaultValues("eu-1", "us-1")),
+			expectedErr: `computed variable "region": variable "cluster" has several default values`,
+		},
+		{
+			title:       "compute failure",
+			cluster:     txtVar.Text(""),
+			expectedErr: `unable to compute the variable "region": unknown cluster`,
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			_, buildErr := dashboard.New("Computed",
+				dashboard.AddVariable("cluster", test.cluster),
+				dashboard.AddVariable("namespace", txtVar.Text("payments")),
+				dashboard.AddComputedVariable("region", []string{"cluster", "namespace"}, region),
+			)
+			assert.EqualError(t, buildErr, test.expectedErr)
+		})
+	}
+
+	_, err = dashboard.New("Computed", dashboard.AddComputedVariable("region", []string{"cluster"}, region))
+	assert.EqualError(t, err, `computed variable "region" depends on the variable "cluster" which is not declared`)
+}
+
+func TestDashboardBuilderVariablesSpec(t *testing.T) {
+	plugin := listVar.Plugin(common.Plugin{Kind: "StaticListVariable", Spec: map[string]interface{}{"values": []interface{}{"eu-1", "us-1"}}})
+	builder, err := dashboard.New("Variables",
+		dashboard.AddVariable("cluster", listVar.List(plugin, listVar.DefaultValues("eu-1"), listVar.DisplayName("Cluster"))),
+		dashboard.AddVariable("namespace", txtVar.Text("payments", txtVar.Extra("owner", map[string]interface{}{"team": "payments"}))),
+	)
+	assert.NoError(t, err)
+
+	variables, err := builder.VariablesSpec()
+	assert.NoError(t, err)
+	assert.Equal(t, builder.Dashboard.Spec.Variables, variables)
+
+	// Modifying the copy doesn't modify the builder.
+	cluster := variables[0].Spec.(*dashboard2.ListVariableSpec)
+	cluster.Display.Name = "Kubernetes cluster"
+	cluster.DefaultValue.SliceValues[0] = "us-1"
+	cluster.Plugin.Spec.(map[string]interface{})["values"].([]interface{})[0] = "ap-1"
+	namespace := variables[1].Spec.(*dashboard2.TextVariableSpec)
+	namespace.Extensions["owner"].(map[string]interface{})["team"] = "billing"
+
+	original := builder.Dashboard.Spec.Variables[0].Spec.(*dashboard2.ListVariableSpec)
+	assert.Equal(t, "Cluster", original.Display.Name)
+	assert.Equal(t, []string{"eu-1"}, original.DefaultValue.SliceValues)
+	assert.Equal(t, []interface{}{"eu-1", "us-1"}, original.Plugin.Spec.(map[string]interface{})["values"])
+	assert.Equal(t, map[string]interface{}{"team": "payments"}, builder.Dashboard.Spec.Variables[1].Spec.(*dashboard2.TextVariableSpec).Extensions["owner"])
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package thresholds
+
+import (
+	"fmt"
+
+	sdk "github.com/perses/perses/go-sdk/common"
+)
+
+type Option func(thresholds *Builder) error
+
+// New builds the thresholds from the given options, e.g. to give them to the builder of a stat, a gauge or a time
+// series panel.
+func New(options ...Option) (sdk.Thresholds, error) {
+	builder := &Builder{}
+	if err := sdk.ApplyOptions(builder, options); err != nil {
+		return builder.Thresholds, err
+	}
+	if builder.Mode != sdk.PercentMode {
+		return builder.Thresholds, nil
+	}
+	for i, step := range builder.Thresholds.Steps {
+		if step.Value < 0 || step.Value > 100 {
+			return builder.Thresholds, sdk.WithPath(fmt.Sprintf("step[%d]", i), fmt.Errorf("the value %v must be between 0 and 100 in percent mode", step.Value))
+		}
+	}
+	return builder.Thresholds, nil
+}
+
+type Builder struct {
+	sdk.Thresholds `json:",inline" yaml:",inline"`
+}
+
+// Step returns a step of the thresholds: the values greater than or equal to the given value are displayed with the
+// given color. The color is either a hexadecimal color (e.g. #ff9900) or a CSS color name (e.g. orange).
+func Step(value float64, color string) sdk.StepOption {
+	return sdk.StepOption{Value: value, Color: color}
+}
+
+// NamedStep returns a step of the thresholds with a name, e.g. "warning". See Step.
+func NamedStep(name string, value float64, color string) sdk.StepOption {
+	return sdk.StepOption{Value: value, Color: color, Name: name}
+}
+
+// Steps sets the steps of the thresholds. The values of the steps must be in increasing order.
+func Steps(steps ...sdk.StepOption) Option {
+	return func(builder *Builder) error {
+		if len(steps) == 0 {
+			return fmt.Errorf("thresholds require at least one step")
+		}
+		for i, step := range steps {
+			if err := validateColor(step.Color); err != nil {
+				return sdk.WithPath(fmt.Sprintf("step[%d]", i), err)
+			}
+			if i > 0 && step.Value <= steps[i-1].Value {
+				return sdk.WithPath(fmt.Sprintf("step[%d]", i), fmt.Errorf("the value %v must be greater than the value of the previous step (%v)", step.Value, steps[i-1].Value))
+			}
+		}
+		builder.Thresholds.Steps = steps
+		return nil
+	}
+}
+
+// Mode sets whether the values of the steps are absolute values (default) or percentages of the max of the panel.
+func Mode(mode sdk.Mode) Option {
+	return func(builder *Builder) error {
+		if mode != sdk.AbsoluteMode && mode != sdk.PercentMode {
+			return fmt.Errorf("invalid thresholds mode %q, must be %q or %q", mode, sdk.AbsoluteMode, sdk.PercentMode)
+		}
+		builder.Thresholds.Mode = mode
+		return nil
+	}
+}
+
+// DefaultColor sets the color of the values lower than the first step.
+func DefaultColor(color string) Option {
+	return func(builder *Builder) error {
+		if err := validateColor(color); err != nil {
+			return err
+		}
+		builder.Thresholds.DefaultColor = color
+		return nil
+	}
+}
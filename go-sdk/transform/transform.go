@@ -0,0 +1,68 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+)
+
+type Option func(transform *Builder) error
+
+type Builder struct {
+	Transforms []dashboard.Transform `json:"-" yaml:"-"`
+}
+
+// MergeSeries merges the series of a same query having the same labels, e.g. when the query is sharded.
+func MergeSeries() Option {
+	return func(builder *Builder) error {
+		builder.Transforms = append(builder.Transforms, dashboard.Transform{
+			Kind: dashboard.KindMergeSeriesTransform,
+			Spec: &dashboard.MergeSeriesTransformSpec{},
+		})
+		return nil
+	}
+}
+
+// JoinByLabel sums the series of each query by the values of the given labels, so the series of the different queries
+// can be matched by Calculate.
+func JoinByLabel(labels ...string) Option {
+	return func(builder *Builder) error {
+		spec := &dashboard.JoinByLabelTransformSpec{Labels: labels}
+		if err := spec.Validate(); err != nil {
+			return err
+		}
+		builder.Transforms = append(builder.Transforms, dashboard.Transform{
+			Kind: dashboard.KindJoinByLabelTransform,
+			Spec: spec,
+		})
+		return nil
+	}
+}
+
+// Calculate computes a new series, with the given name, from the series having the same labels. The expression
+// references the queries by their position ("a" for the first query, "b" for the second one, etc.) and the series
+// computed by the previous Calculate, e.g. "a / b * 100".
+func Calculate(name string, expression string) Option {
+	return func(builder *Builder) error {
+		spec := &dashboard.CalculateTransformSpec{Name: name, Expression: expression}
+		if err := spec.Validate(); err != nil {
+			return err
+		}
+		builder.Transforms = append(builder.Transforms, dashboard.Transform{
+			Kind: dashboard.KindCalculateTransform,
+			Spec: spec,
+		})
+		return nil
+	}
+}
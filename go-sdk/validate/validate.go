@@ -0,0 +1,62 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package validate checks the dashboards built with the go-sdk against the schemas of the plugins, locally.
+// It is a separate package so the programs that don't validate the dashboards don't depend on the CUE runtime.
+package validate
+
+import (
+	"fmt"
+
+	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/perses/perses/internal/api/plugin"
+	"github.com/perses/perses/internal/api/plugin/schema"
+	"github.com/perses/perses/internal/api/validate"
+	apiConfig "github.com/perses/perses/pkg/model/api/config"
+)
+
+// Validator holds the schemas of the plugins. The plugins are loaded once, so a Validator can check many dashboards.
+type Validator struct {
+	sch schema.Schema
+}
+
+// New loads the schemas of the plugins stored in pluginPath. The plugins must be unzipped, like in the plugin folder
+// of a Perses server or the one given to `percli lint --plugin.path`.
+func New(pluginPath string) (*Validator, error) {
+	pl := plugin.New(apiConfig.Plugin{
+		Path: pluginPath,
+	})
+	if err := pl.Load(); err != nil {
+		return nil, fmt.Errorf("unable to load the plugins from %q: %w", pluginPath, err)
+	}
+	return &Validator{sch: pl.Schema()}, nil
+}
+
+// Dashboard checks the panels, the queries, the variables and the datasources of the dashboard against the schemas of
+// the plugins, as the Perses server does when the dashboard is applied.
+func (v *Validator) Dashboard(builder dashboard.Builder) error {
+	if err := validate.DashboardSpec(builder.Dashboard.Spec, v.sch); err != nil {
+		return fmt.Errorf("invalid dashboard %q: %w", builder.Dashboard.Metadata.Name, err)
+	}
+	return nil
+}
+
+// Dashboard loads the plugins stored in pluginPath and checks the dashboard against their schemas.
+// To check several dashboards, create a Validator with New to load the plugins only once.
+func Dashboard(builder dashboard.Builder, pluginPath string) error {
+	v, err := New(pluginPath)
+	if err != nil {
+		return err
+	}
+	return v.Dashboard(builder)
+}
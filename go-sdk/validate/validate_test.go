@@ -0,0 +1,110 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/perses/perses/go-sdk/panel"
+	panelgroup "github.com/perses/perses/go-sdk/panel-group"
+	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pluginPath returns a copy of the plugins stored in testdata, as loading them writes the list of the loaded plugins
+// in the plugin folder.
+func pluginPath(t *testing.T) string {
+	dir := t.TempDir()
+	require.NoError(t, os.CopyFS(dir, os.DirFS(filepath.Join("testdata", "plugins"))))
+	return dir
+}
+
+func markdownDashboard(t *testing.T, text interface{}) dashboard.Builder {
+	builder, err := dashboard.New("readme",
+		dashboard.AddPanelGroup("Documentation",
+			panelgroup.AddPanel("Readme", panel.Plugin(common.Plugin{
+				Kind: "Markdown",
+				Spec: map[string]interface{}{"text": text},
+			})),
+		),
+	)
+	require.NoError(t, err)
+	return builder
+}
+
+func TestValidatorDashboard(t *testing.T) {
+	v, err := New(pluginPath(t))
+	require.NoError(t, err)
+
+	assert.NoError(t, v.Dashboard(markdownDashboard(t, "hello")))
+
+	footer, err := dashboard.New("footer", dashboard.AddFooter("Maintained by the SRE team"))
+	require.NoError(t, err)
+	assert.NoError(t, v.Dashboard(footer))
+
+	err = v.Dashboard(markdownDashboard(t, 42))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid dashboard "readme"`)
+}
+
+func TestValidatorDashboardVariables(t *testing.T) {
+	v, err := New(pluginPath(t))
+	require.NoError(t, err)
+
+	withValues := func(values interface{}) dashboard.Builder {
+		builder, buildErr := dashboard.New("environments",
+			dashboard.AddVariable("env", listVar.List(listVar.Plugin(common.Plugin{
+				Kind: "StaticListVariable",
+				Spec: map[string]interface{}{"values": values},
+			}))),
+		)
+		require.NoError(t, buildErr)
+		return builder
+	}
+	assert.NoError(t, v.Dashboard(withValues([]string{"dev", "prod"})))
+	assert.Error(t, v.Dashboard(withValues("prod")))
+}
+
+func TestValidatorDashboardUnknownPlugin(t *testing.T) {
+	v, err := New(pluginPath(t))
+	require.NoError(t, err)
+
+	builder, err := dashboard.New("unknown",
+		dashboard.AddPanelGroup("Metrics",
+			panelgroup.AddPanel("Requests", panel.Plugin(common.Plugin{
+				Kind: "TimeSeriesChart",
+				Spec: map[string]interface{}{},
+			})),
+		),
+	)
+	require.NoError(t, err)
+	assert.Error(t, v.Dashboard(builder))
+}
+
+func TestNewMissingPluginPath(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func TestDashboard(t *testing.T) {
+	path := pluginPath(t)
+	assert.NoError(t, Dashboard(markdownDashboard(t, "hello"), path))
+	assert.Error(t, Dashboard(markdownDashboard(t, 42), path))
+	assert.Error(t, Dashboard(markdownDashboard(t, "hello"), filepath.Join(t.TempDir(), "missing")))
+}
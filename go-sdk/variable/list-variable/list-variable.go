@@ -14,17 +14,24 @@
 package listvariable
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/perses/perses/go-sdk/variable"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/perses/pkg/model/api/v1/dashboard"
 	variable2 "github.com/perses/perses/pkg/model/api/v1/variable"
 )
 
+const helpURLFormat = "Help: %s"
+
 type Option func(listVariableSpec *Builder) error
 
 type Builder struct {
 	ListVariableSpec dashboard.ListVariableSpec `json:",inline" yaml:",inline"`
 	Filters          []v1.Variable              `json:"-" yaml:"-"`
+	HelpURL          string                     `json:"-" yaml:"-"`
+	Persist          *bool                      `json:"-" yaml:"-"`
 }
 
 func create(options ...Option) (Builder, error) {
@@ -44,6 +51,19 @@ func create(options ...Option) (Builder, error) {
 		}
 	}
 
+	// Descriptions are rendered as plain text, so the help link is appended to the description once all the options
+	// have been applied.
+	if len(builder.HelpURL) > 0 {
+		if builder.ListVariableSpec.Display == nil {
+			builder.ListVariableSpec.Display = &variable2.Display{}
+		}
+		description := fmt.Sprintf(helpURLFormat, builder.HelpURL)
+		if len(builder.ListVariableSpec.Display.Description) > 0 {
+			description = builder.ListVariableSpec.Display.Description + "\n\n" + description
+		}
+		builder.ListVariableSpec.Display.Description = description
+	}
+
 	return *builder, nil
 }
 
@@ -56,6 +76,9 @@ func List(options ...Option) variable.Option {
 		}
 		builder.Variable.Spec.Kind = "ListVariable"
 		builder.Variable.Spec.Spec = t.ListVariableSpec
+		if t.Persist != nil {
+			return variable.Annotation(variable.PersistAnnotation, strconv.FormatBool(*t.Persist))(builder)
+		}
 		return nil
 	}
 }
@@ -14,7 +14,12 @@
 package listvariable
 
 import (
+	"fmt"
+	"net/url"
+
+	sdk "github.com/perses/perses/go-sdk/common"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
 	"github.com/perses/perses/pkg/model/api/v1/variable"
 )
 
@@ -57,6 +62,15 @@ func CustomAllValue(value string) Option {
 	}
 }
 
+// Plugin sets the plugin providing the values of the list. The plugins usually provide their own option to set it,
+// this one allows using a plugin without Go SDK.
+func Plugin(plugin common.Plugin) Option {
+	return func(builder *Builder) error {
+		builder.ListVariableSpec.Plugin = plugin
+		return nil
+	}
+}
+
 func CapturingRegexp(regexp string) Option {
 	return func(builder *Builder) error {
 		builder.ListVariableSpec.CapturingRegexp = regexp
@@ -71,6 +85,36 @@ func SortingBy(sort variable.Sort) Option {
 	}
 }
 
+// RefreshOnDashboardLoad loads the values of the variable once, when the dashboard is loaded, instead of each time the
+// dashboard is refreshed.
+func RefreshOnDashboardLoad() Option {
+	return func(builder *Builder) error {
+		refresh := variable.RefreshOnDashboardLoad
+		builder.ListVariableSpec.Refresh = &refresh
+		return nil
+	}
+}
+
+// RefreshOnTimeRangeChange reloads the values of the variable only when the time range of the dashboard changes,
+// instead of each time the dashboard is refreshed.
+func RefreshOnTimeRangeChange() Option {
+	return func(builder *Builder) error {
+		refresh := variable.RefreshOnTimeRangeChange
+		builder.ListVariableSpec.Refresh = &refresh
+		return nil
+	}
+}
+
+// Persist tells whether the value selected for the variable should be kept across the sessions, or reset to the default
+// value at each visit. The model of the variables has no such setting, so it is emitted as the annotation
+// variable.PersistAnnotation. When not set, no annotation is emitted and the selection is meant to be kept.
+func Persist(enabled bool) Option {
+	return func(builder *Builder) error {
+		builder.Persist = &enabled
+		return nil
+	}
+}
+
 func Description(description string) Option {
 	return func(builder *Builder) error {
 		if builder.ListVariableSpec.Display == nil {
@@ -81,6 +125,30 @@ func Description(description string) Option {
 	}
 }
 
+// HelpURL appends a link to the description of the variable, e.g. to a runbook.
+// The description is rendered as plain text, the link is not clickable.
+func HelpURL(helpURL string) Option {
+	return func(builder *Builder) error {
+		u, err := url.ParseRequestURI(helpURL)
+		if err != nil {
+			return fmt.Errorf("invalid help url %q: %w", helpURL, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("invalid help url %q: scheme must be http or https", helpURL)
+		}
+		builder.HelpURL = helpURL
+		return nil
+	}
+}
+
+// Extra stores an organization specific metadata (e.g. the owner of the variable) in the extensions of the variable.
+// Perses ignores the extensions.
+func Extra(key string, value interface{}) Option {
+	return func(builder *Builder) error {
+		return sdk.SetExtension(&builder.ListVariableSpec.Extensions, key, value)
+	}
+}
+
 func DisplayName(displayName string) Option {
 	return func(builder *Builder) error {
 		if builder.ListVariableSpec.Display == nil {
@@ -14,6 +14,8 @@
 package variable
 
 import (
+	"fmt"
+
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/perses/pkg/model/api/v1/common"
 )
@@ -34,3 +36,18 @@ func Filter(variables ...v1.Variable) Option {
 		return nil
 	}
 }
+
+// Annotation adds an annotation to the metadata of the variable. As the variables of a dashboard have no metadata, the
+// annotation is set on the dashboard as "variable.<name>.<key>" when the variable is added to a dashboard.
+func Annotation(key string, value string) Option {
+	return func(builder *Builder) error {
+		if len(key) == 0 {
+			return fmt.Errorf("annotation key cannot be empty")
+		}
+		if builder.Variable.Metadata.Annotations == nil {
+			builder.Variable.Metadata.Annotations = make(map[string]string)
+		}
+		builder.Variable.Metadata.Annotations[key] = value
+		return nil
+	}
+}
@@ -14,6 +14,7 @@
 package textvariable
 
 import (
+	sdk "github.com/perses/perses/go-sdk/common"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/perses/pkg/model/api/v1/variable"
 )
@@ -32,6 +33,14 @@ func Constant(isConstant bool) Option {
 	}
 }
 
+// Extra stores an organization specific metadata (e.g. the owner of the variable) in the extensions of the variable.
+// Perses ignores the extensions.
+func Extra(key string, value interface{}) Option {
+	return func(builder *Builder) error {
+		return sdk.SetExtension(&builder.TextVariableSpec.Extensions, key, value)
+	}
+}
+
 func Description(description string) Option {
 	return func(builder *Builder) error {
 		if builder.TextVariableSpec.Display == nil {
@@ -14,9 +14,15 @@
 package variable
 
 import (
+	sdk "github.com/perses/perses/go-sdk/common"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 )
 
+// PersistAnnotation is the annotation telling whether the value selected for the variable should be kept across the
+// sessions ("true") or reset at each visit ("false"). Perses doesn't use it: it is a hint for the tools handling the
+// sessions. Without it, the selection is meant to be kept.
+const PersistAnnotation = "persist"
+
 type Option func(variable *Builder) error
 
 type Builder struct {
@@ -36,10 +42,8 @@ func New(name string, options ...Option) (Builder, error) {
 		// TODO: text_variable.Text(""),
 	}
 
-	for _, opt := range append(defaults, options...) {
-		if err := opt(builder); err != nil {
-			return *builder, err
-		}
+	if err := sdk.ApplyOptions(builder, append(defaults, options...)); err != nil {
+		return *builder, err
 	}
 
 	return *builder, nil
@@ -51,6 +51,16 @@ type Authorization interface {
 	// In case the endpoint is anonymous, or the context is empty, it will return true.
 	// In case the user information is not found in the context, the implementation should return false.
 	HasPermission(ctx echo.Context, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope) bool
+	// HasResourcePermission checks if the user has the permission to perform the action on the resource of the project
+	// with the given scope. Unlike HasPermission, the permissions restricted to some resources including this one are
+	// considered as well.
+	// In case the endpoint is anonymous, or the context is empty, it will return true.
+	HasResourcePermission(ctx echo.Context, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope, requestName string) bool
+	// GetUserResources returns, by project, the names of the resources the user has access to through the permissions
+	// restricted to some resources, in the context of the action and the scope requested. The names of the resources
+	// accessible in every project are under the wildcard project.
+	// Be aware that this function cannot be called from an anonymous endpoint.
+	GetUserResources(ctx echo.Context, requestAction v1Role.Action, requestScope v1Role.Scope) (map[string][]string, error)
 	// GetPermissions returns the permissions of the user found in the context.
 	// Be aware that this function cannot be called from an anonymous endpoint.
 	// In case the user information is not found in the context, the implementation should return an error.
@@ -64,9 +74,9 @@ type Authorization interface {
 }
 
 func New(userDAO user.DAO, roleDAO role.DAO, roleBindingDAO rolebinding.DAO,
-	globalRoleDAO globalrole.DAO, globalRoleBindingDAO globalrolebinding.DAO, conf config.Config) (Authorization, error) {
+	globalRoleDAO globalrole.DAO, globalRoleBindingDAO globalrolebinding.DAO, conf config.Config, caseSensitive bool) (Authorization, error) {
 	if !conf.Security.EnableAuth {
 		return &disabledImpl{}, nil
 	}
-	return native.New(userDAO, roleDAO, roleBindingDAO, globalRoleDAO, globalRoleBindingDAO, conf)
+	return native.New(userDAO, roleDAO, roleBindingDAO, globalRoleDAO, globalRoleBindingDAO, conf, caseSensitive)
 }
@@ -51,6 +51,14 @@ func (r *disabledImpl) HasPermission(_ echo.Context, _ v1Role.Action, _ string,
 	return true
 }
 
+func (r *disabledImpl) HasResourcePermission(_ echo.Context, _ v1Role.Action, _ string, _ v1Role.Scope, _ string) bool {
+	return true
+}
+
+func (r *disabledImpl) GetUserResources(_ echo.Context, _ v1Role.Action, _ v1Role.Scope) (map[string][]string, error) {
+	return map[string][]string{}, nil
+}
+
 func (r *disabledImpl) GetPermissions(_ echo.Context) (map[string][]*v1Role.Permission, error) {
 	return nil, nil
 }
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"sync"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -39,13 +40,16 @@ import (
 )
 
 func New(userDAO user.DAO, roleDAO role.DAO, roleBindingDAO rolebinding.DAO,
-	globalRoleDAO globalrole.DAO, globalRoleBindingDAO globalrolebinding.DAO, conf config.Config) (*native, error) {
+	globalRoleDAO globalrole.DAO, globalRoleBindingDAO globalrolebinding.DAO, conf config.Config, caseSensitive bool) (*native, error) {
 	key, err := hex.DecodeString(string(conf.Security.EncryptionKey))
 	if err != nil {
 		return nil, err
 	}
+	for _, permission := range conf.Security.Authorization.GuestPermissions {
+		permission.Flatten(caseSensitive)
+	}
 	return &native{
-		cache:                &cache{},
+		cache:                &cache{caseSensitive: caseSensitive},
 		userDAO:              userDAO,
 		roleDAO:              roleDAO,
 		roleBindingDAO:       roleBindingDAO,
@@ -152,9 +156,14 @@ func (n *native) GetUserProjects(ctx echo.Context, requestAction v1Role.Action,
 		return []string{v1.WildcardProject}, nil
 	}
 
+	// The projects in which the user can only read some dashboards are listed as well, so he can reach them.
+	listRestricted := requestScope == v1Role.ProjectScope && requestAction == v1Role.ReadAction
 	var projects []string
 	for project, permList := range projectPermission {
-		if project != v1.WildcardProject && listHasPermission(permList, requestAction, requestScope) {
+		if project == v1.WildcardProject {
+			continue
+		}
+		if listHasPermission(permList, requestAction, requestScope) || (listRestricted && len(listRestrictedResources(permList, v1Role.ReadAction, v1Role.DashboardScope)) > 0) {
 			projects = append(projects, project)
 		}
 	}
@@ -162,6 +171,10 @@ func (n *native) GetUserProjects(ctx echo.Context, requestAction v1Role.Action,
 }
 
 func (n *native) HasPermission(ctx echo.Context, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope) bool {
+	return n.HasResourcePermission(ctx, requestAction, requestProject, requestScope, "")
+}
+
+func (n *native) HasResourcePermission(ctx echo.Context, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope, requestName string) bool {
 	// If the context is nil, it means the function is called internally without a request context.
 	// And in this case, we assume we want to bypass the authorization check.
 	if ctx == nil {
@@ -183,13 +196,38 @@ func (n *native) HasPermission(ctx echo.Context, requestAction v1Role.Action, re
 		return false // No username found, cannot check permissions
 	}
 	// Checking default permissions
-	if ok := listHasPermission(n.guestPermissions, requestAction, requestScope); ok {
+	if ok := listHasResourcePermission(n.guestPermissions, requestAction, requestScope, n.cache.normalize(requestName)); ok {
 		return true
 	}
 	// Checking cached permissions
 	n.mutex.RLock()
 	defer n.mutex.RUnlock()
-	return n.cache.hasPermission(username, requestAction, requestProject, requestScope)
+	if requestScope == v1Role.ProjectScope && requestAction == v1Role.ReadAction && n.cache.hasRestrictedResources(username, requestProject) {
+		// The user can read some dashboards of the project, so he needs to read the project as well to reach them.
+		return true
+	}
+	return n.cache.hasResourcePermission(username, requestAction, requestProject, requestScope, requestName)
+}
+
+func (n *native) GetUserResources(ctx echo.Context, requestAction v1Role.Action, requestScope v1Role.Scope) (map[string][]string, error) {
+	username, err := n.GetUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if username == "" {
+		// This method should not be called if the endpoint is anonymous or the username is not found.
+		logrus.Error("failed to get username from context to list the user resources")
+		return nil, apiInterface.InternalError
+	}
+	n.mutex.RLock()
+	resources := n.cache.resources(username, requestAction, requestScope)
+	n.mutex.RUnlock()
+	for _, name := range listRestrictedResources(n.guestPermissions, requestAction, requestScope) {
+		if !slices.Contains(resources[v1.WildcardProject], name) {
+			resources[v1.WildcardProject] = append(resources[v1.WildcardProject], name)
+		}
+	}
+	return resources, nil
 }
 
 func (n *native) GetPermissions(ctx echo.Context) (map[string][]*v1Role.Permission, error) {
@@ -258,6 +296,7 @@ func (n *native) loadAllPermissions() (usersPermissions, error) {
 				}
 				globalRolePermissions := globalRole.Spec.Permissions
 				for i := range globalRolePermissions {
+					globalRolePermissions[i].Flatten(n.cache.caseSensitive)
 					permissionBuild.addEntry(usr.Metadata.Name, v1.WildcardProject, &globalRolePermissions[i])
 				}
 			}
@@ -274,6 +313,7 @@ func (n *native) loadAllPermissions() (usersPermissions, error) {
 				}
 				rolePermissions := projectRole.Spec.Permissions
 				for i := range rolePermissions {
+					rolePermissions[i].Flatten(n.cache.caseSensitive)
 					permissionBuild.addEntry(usr.Metadata.Name, roleBinding.Metadata.Project, &rolePermissions[i])
 				}
 			}
@@ -211,6 +211,139 @@ func TestCacheHasPermission(t *testing.T) {
 	}
 }
 
+func restrictedMockCache() cache {
+	permissions := make(usersPermissions)
+	permissions.addEntry("user0", "project0", &role.Permission{
+		Actions:   []role.Action{role.ReadAction},
+		Scopes:    []role.Scope{role.DashboardScope},
+		Resources: []string{"cpu"},
+	})
+	permissions.addEntry("user0", v1.WildcardProject, &role.Permission{
+		Actions:   []role.Action{role.WildcardAction},
+		Scopes:    []role.Scope{role.DashboardScope},
+		Resources: []string{"overview"},
+	})
+	permissions.addEntry("user1", "project0", &role.Permission{
+		Actions: []role.Action{role.ReadAction},
+		Scopes:  []role.Scope{role.DashboardScope},
+	})
+	// The names of the resources are not case-sensitive, so they are lowercased like the names of the dashboards.
+	diskPermission := &role.Permission{
+		Actions:   []role.Action{role.ReadAction},
+		Scopes:    []role.Scope{role.DashboardScope},
+		Resources: []string{"Disk"},
+	}
+	diskPermission.Flatten(false)
+	permissions.addEntry("user2", "project0", diskPermission)
+	return cache{permissions: permissions}
+}
+
+func TestCacheHasResourcePermission(t *testing.T) {
+	restrictedCache := restrictedMockCache()
+
+	testSuites := []struct {
+		title          string
+		user           string
+		reqAction      role.Action
+		reqProject     string
+		reqScope       role.Scope
+		reqName        string
+		expectedResult bool
+	}{
+		{
+			title:          "user0 has 'read' perm on the dashboard 'cpu' of 'project0'",
+			user:           "user0",
+			reqAction:      role.ReadAction,
+			reqProject:     "project0",
+			reqScope:       role.DashboardScope,
+			reqName:        "cpu",
+			expectedResult: true,
+		},
+		{
+			title:          "user0 hasn't 'read' perm on the dashboard 'memory' of 'project0'",
+			user:           "user0",
+			reqAction:      role.ReadAction,
+			reqProject:     "project0",
+			reqScope:       role.DashboardScope,
+			reqName:        "memory",
+			expectedResult: false,
+		},
+		{
+			title:          "user0 hasn't 'read' perm on all the dashboards of 'project0'",
+			user:           "user0",
+			reqAction:      role.ReadAction,
+			reqProject:     "project0",
+			reqScope:       role.DashboardScope,
+			expectedResult: false,
+		},
+		{
+			title:          "user0 hasn't 'update' perm on the dashboard 'cpu' of 'project0'",
+			user:           "user0",
+			reqAction:      role.UpdateAction,
+			reqProject:     "project0",
+			reqScope:       role.DashboardScope,
+			reqName:        "cpu",
+			expectedResult: false,
+		},
+		{
+			title:          "user0 has 'update' perm on the dashboard 'overview' of any project",
+			user:           "user0",
+			reqAction:      role.UpdateAction,
+			reqProject:     "project1",
+			reqScope:       role.DashboardScope,
+			reqName:        "overview",
+			expectedResult: true,
+		},
+		{
+			title:          "user2 has 'read' perm on the dashboard 'disk' of 'project0'",
+			user:           "user2",
+			reqAction:      role.ReadAction,
+			reqProject:     "project0",
+			reqScope:       role.DashboardScope,
+			reqName:        "disk",
+			expectedResult: true,
+		},
+		{
+			title:          "user2 has 'read' perm on the dashboard 'DISK' of 'project0'",
+			user:           "user2",
+			reqAction:      role.ReadAction,
+			reqProject:     "project0",
+			reqScope:       role.DashboardScope,
+			reqName:        "DISK",
+			expectedResult: true,
+		},
+		{
+			title:          "user1 has 'read' perm on the dashboard 'memory' of 'project0'",
+			user:           "user1",
+			reqAction:      role.ReadAction,
+			reqProject:     "project0",
+			reqScope:       role.DashboardScope,
+			reqName:        "memory",
+			expectedResult: true,
+		},
+	}
+	for i := range testSuites {
+		test := testSuites[i]
+		t.Run(test.title, func(t *testing.T) {
+			assert.Equal(t, test.expectedResult, restrictedCache.hasResourcePermission(test.user, test.reqAction, test.reqProject, test.reqScope, test.reqName))
+		})
+	}
+}
+
+func TestCacheHasRestrictedResources(t *testing.T) {
+	restrictedCache := restrictedMockCache()
+	assert.True(t, restrictedCache.hasRestrictedResources("user0", "project0"))
+	assert.False(t, restrictedCache.hasRestrictedResources("user0", "project1"))
+	assert.False(t, restrictedCache.hasRestrictedResources("user1", "project0"))
+}
+
+func TestCacheResources(t *testing.T) {
+	restrictedCache := restrictedMockCache()
+	assert.Equal(t, map[string][]string{"project0": {"cpu"}, v1.WildcardProject: {"overview"}}, restrictedCache.resources("user0", role.ReadAction, role.DashboardScope))
+	assert.Equal(t, map[string][]string{v1.WildcardProject: {"overview"}}, restrictedCache.resources("user0", role.DeleteAction, role.DashboardScope))
+	assert.Equal(t, map[string][]string{}, restrictedCache.resources("user1", role.ReadAction, role.DashboardScope))
+}
+
 func BenchmarkCacheHasPermission(b *testing.B) {
 	benchSuites := []struct {
 		userCount          int
@@ -14,6 +14,9 @@
 package native
 
 import (
+	"slices"
+	"strings"
+
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	v1Role "github.com/perses/perses/pkg/model/api/v1/role"
 )
@@ -37,9 +40,27 @@ func (p usersPermissions) addEntry(user string, project string, permission *v1Ro
 
 type cache struct {
 	permissions usersPermissions
+	// caseSensitive tells whether the names of the resources are case-sensitive. When they are not, the names of the
+	// resources of the permissions are expected to be lowercased (see Permission.Flatten).
+	caseSensitive bool
+}
+
+// normalize lowercases the name of the resource when the names are not case-sensitive.
+func (c *cache) normalize(name string) string {
+	if c.caseSensitive {
+		return name
+	}
+	return strings.ToLower(name)
 }
 
 func (c *cache) hasPermission(user string, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope) bool {
+	return c.hasResourcePermission(user, requestAction, requestProject, requestScope, "")
+}
+
+// hasResourcePermission checks the permission on the resource of the project named requestName. When requestName is
+// empty, only the permissions applying to all the resources of the scope are considered.
+func (c *cache) hasResourcePermission(user string, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope, requestName string) bool {
+	requestName = c.normalize(requestName)
 	usrPermissions, ok := c.permissions[user]
 	if !ok {
 		return false
@@ -48,7 +69,7 @@ func (c *cache) hasPermission(user string, requestAction v1Role.Action, requestP
 	// Checking global perm first
 	if requestProject != v1.WildcardProject {
 		if globalPermissions, ok := usrPermissions[v1.WildcardProject]; ok {
-			if listHasPermission(globalPermissions, requestAction, requestScope) {
+			if listHasResourcePermission(globalPermissions, requestAction, requestScope, requestName) {
 				return true
 			}
 		}
@@ -58,17 +79,62 @@ func (c *cache) hasPermission(user string, requestAction v1Role.Action, requestP
 	if !ok {
 		return false
 	}
-	return listHasPermission(projectPermissions, requestAction, requestScope)
+	return listHasResourcePermission(projectPermissions, requestAction, requestScope, requestName)
 }
 
+// hasRestrictedResources tells whether the user can read some dashboards of the project through the permissions
+// restricted to some resources.
+func (c *cache) hasRestrictedResources(user string, project string) bool {
+	return len(listRestrictedResources(c.permissions[user][project], v1Role.ReadAction, v1Role.DashboardScope)) > 0
+}
+
+// resources returns, by project, the names of the resources the user has access to through the permissions restricted
+// to some resources.
+func (c *cache) resources(user string, requestAction v1Role.Action, requestScope v1Role.Scope) map[string][]string {
+	result := make(map[string][]string)
+	for project, permissions := range c.permissions[user] {
+		if names := listRestrictedResources(permissions, requestAction, requestScope); len(names) > 0 {
+			result[project] = names
+		}
+	}
+	return result
+}
+
+// listHasPermission checks the permissions applying to all the resources of the scope.
 func listHasPermission(permissions []*v1Role.Permission, requestAction v1Role.Action, requestScope v1Role.Scope) bool {
+	return listHasResourcePermission(permissions, requestAction, requestScope, "")
+}
+
+func listHasResourcePermission(permissions []*v1Role.Permission, requestAction v1Role.Action, requestScope v1Role.Scope, requestName string) bool {
+	for _, permission := range permissions {
+		if permission.AppliesTo(requestName) && permissionMatches(permission, requestAction, requestScope) {
+			return true
+		}
+	}
+	return false
+}
+
+// listRestrictedResources returns the names of the resources targeted by the permissions restricted to some resources.
+func listRestrictedResources(permissions []*v1Role.Permission, requestAction v1Role.Action, requestScope v1Role.Scope) []string {
+	var names []string
 	for _, permission := range permissions {
-		for _, action := range permission.Actions {
-			if action == requestAction || action == v1Role.WildcardAction {
-				for _, scope := range permission.Scopes {
-					if scope == requestScope || scope == v1Role.WildcardScope {
-						return true
-					}
+		if permission.IsRestricted() && permissionMatches(permission, requestAction, requestScope) {
+			for _, name := range permission.Resources {
+				if !slices.Contains(names, name) {
+					names = append(names, name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+func permissionMatches(permission *v1Role.Permission, requestAction v1Role.Action, requestScope v1Role.Scope) bool {
+	for _, action := range permission.Actions {
+		if action == requestAction || action == v1Role.WildcardAction {
+			for _, scope := range permission.Scopes {
+				if scope == requestScope || scope == v1Role.WildcardScope {
+					return true
 				}
 			}
 		}
@@ -26,6 +26,7 @@ import (
 	"github.com/perses/perses/internal/api/dashboard"
 	"github.com/perses/perses/internal/api/dependency"
 	"github.com/perses/perses/internal/api/discovery"
+	"github.com/perses/perses/internal/api/impl/v1/audit"
 	"github.com/perses/perses/internal/api/provisioning"
 	"github.com/perses/perses/internal/api/utils"
 	"github.com/perses/perses/pkg/model/api/config"
@@ -60,10 +61,20 @@ func New(conf config.Config, enablePprof bool, registry *prometheus.Registry, ba
 		runner.WithTimerTasks(time.Duration(conf.EphemeralDashboard.CleanupInterval), ephemeralDashboardsCleaner)
 	}
 
+	// delete the events of the audit log older than the retention
+	if conf.Audit.Enable && conf.Audit.Retention > 0 {
+		auditCleaner := audit.NewCleaner(persistenceManager.GetAudit(), time.Duration(conf.Audit.Retention))
+		runner.WithTimerTasks(time.Duration(conf.Audit.CleanupInterval), auditCleaner)
+	}
+
 	if len(conf.Provisioning.Folders) > 0 {
 		provisioningTask := provisioning.New(serviceManager, conf.Provisioning.Folders, persesDAO.IsCaseSensitive())
 		runner.WithTimerTasks(time.Duration(conf.Provisioning.Interval), provisioningTask)
 	}
+	if len(conf.Provisioning.Git) > 0 {
+		gitProvisioningTask := provisioning.NewGit(serviceManager, conf.Provisioning.Git, conf.Provisioning.GitFolder, persesDAO.IsCaseSensitive())
+		runner.WithTimerTasks(time.Duration(conf.Provisioning.Interval), gitProvisioningTask)
+	}
 	if len(conf.Datasource.Global.Discovery) > 0 {
 		datasourceDiscoveryTasks, sdErr := discovery.New(conf, serviceManager, persesDAO.IsCaseSensitive())
 		if sdErr != nil {
@@ -71,6 +82,7 @@ func New(conf config.Config, enablePprof bool, registry *prometheus.Registry, ba
 		}
 		runner.WithTaskHelpers(datasourceDiscoveryTasks...)
 	}
+	runner.WithTimerTasks(time.Duration(conf.Search.RefreshInterval), serviceManager.GetSearch())
 	if conf.Security.EnableAuth {
 		rbacTask := authorization.NewPermissionRefreshCronTask(serviceManager.GetAuthorization(), persesDAO)
 		runner.WithTimerTasks(time.Duration(conf.Security.Authorization.CheckLatestUpdateInterval), rbacTask)
@@ -22,7 +22,11 @@ import (
 	configendpoint "github.com/perses/perses/internal/api/impl/config"
 	migrateendpoint "github.com/perses/perses/internal/api/impl/migrate"
 	"github.com/perses/perses/internal/api/impl/proxy"
+	"github.com/perses/perses/internal/api/impl/v1/apply"
+	"github.com/perses/perses/internal/api/impl/v1/audit"
 	"github.com/perses/perses/internal/api/impl/v1/dashboard"
+	"github.com/perses/perses/internal/api/impl/v1/dashboardmove"
+	"github.com/perses/perses/internal/api/impl/v1/dashboardversion"
 	"github.com/perses/perses/internal/api/impl/v1/datasource"
 	"github.com/perses/perses/internal/api/impl/v1/ephemeraldashboard"
 	"github.com/perses/perses/internal/api/impl/v1/folder"
@@ -32,11 +36,16 @@ import (
 	"github.com/perses/perses/internal/api/impl/v1/globalsecret"
 	"github.com/perses/perses/internal/api/impl/v1/globalvariable"
 	"github.com/perses/perses/internal/api/impl/v1/health"
+	"github.com/perses/perses/internal/api/impl/v1/librarypanel"
 	"github.com/perses/perses/internal/api/impl/v1/plugin"
 	"github.com/perses/perses/internal/api/impl/v1/project"
+	"github.com/perses/perses/internal/api/impl/v1/render"
 	"github.com/perses/perses/internal/api/impl/v1/role"
 	"github.com/perses/perses/internal/api/impl/v1/rolebinding"
+	"github.com/perses/perses/internal/api/impl/v1/schema"
+	"github.com/perses/perses/internal/api/impl/v1/search"
 	"github.com/perses/perses/internal/api/impl/v1/secret"
+	"github.com/perses/perses/internal/api/impl/v1/snapshot"
 	"github.com/perses/perses/internal/api/impl/v1/user"
 	"github.com/perses/perses/internal/api/impl/v1/variable"
 	"github.com/perses/perses/internal/api/impl/v1/view"
@@ -60,28 +69,49 @@ func NewPersesAPI(serviceManager dependency.ServiceManager, persistenceManager d
 	readonly := cfg.Security.Readonly
 	caseSensitive := persistenceManager.GetPersesDAO().IsCaseSensitive()
 	apiV1Endpoints := []route.Endpoint{
-		dashboard.NewEndpoint(serviceManager.GetDashboard(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		datasource.NewEndpoint(cfg.Datasource, serviceManager.GetDatasource(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		ephemeraldashboard.NewEndpoint(serviceManager.GetEphemeralDashboard(), serviceManager.GetAuthorization(), readonly, caseSensitive, cfg.EphemeralDashboard.Enable),
-		folder.NewEndpoint(serviceManager.GetFolder(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		globaldatasource.NewEndpoint(cfg.Datasource, serviceManager.GetGlobalDatasource(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		globalrole.NewEndpoint(serviceManager.GetGlobalRole(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		globalrolebinding.NewEndpoint(serviceManager.GetGlobalRoleBinding(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		globalsecret.NewEndpoint(serviceManager.GetGlobalSecret(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		globalvariable.NewEndpoint(cfg.Variable, serviceManager.GetGlobalVariable(), serviceManager.GetAuthorization(), readonly, caseSensitive),
+		apply.NewEndpoint(cfg, serviceManager, persistenceManager, readonly, caseSensitive),
+		audit.NewEndpoint(serviceManager.GetAudit(), serviceManager.GetAuthorization(), caseSensitive),
+		dashboard.NewEndpoint(serviceManager.GetDashboard(), serviceManager.GetAuthorization(), serviceManager.GetAudit(), readonly, caseSensitive),
+		dashboardmove.NewEndpoint(serviceManager.GetDashboard(), serviceManager.GetAuthorization(), serviceManager.GetAudit(), readonly, caseSensitive),
+		dashboardversion.NewEndpoint(serviceManager.GetDashboard(), serviceManager.GetAuthorization(), serviceManager.GetAudit(), readonly, caseSensitive),
+		datasource.NewEndpoint(cfg.Datasource, serviceManager.GetDatasource(), serviceManager.GetAuthorization(), serviceManager.GetAudit(), readonly, caseSensitive),
+		ephemeraldashboard.NewEndpoint(serviceManager.GetEphemeralDashboard(), serviceManager.GetAuthorization(), serviceManager.GetAudit(), readonly, caseSensitive, cfg.EphemeralDashboard.Enable),
+		folder.NewEndpoint(serviceManager.GetFolder(), serviceManager.GetAuthorization(), serviceManager.GetAudit(), readonly, caseSensitive),
+		globaldatasource.NewEndpoint(cfg.Datasource, serviceManager.GetGlobalDatasource(), serviceManager.GetAuthorization(), serviceManager.GetAudit(), readonly, caseSensitive),
+		globalrole.NewEndpoint(serviceManager.GetGlobalRole(), serviceManager.GetAuthorization(), serviceManager.GetAudit(), readonly, caseSensitive),
+		globalrolebinding.NewEndpoint(serviceManager.GetGlobalRoleBinding(), serviceManager.GetAuthorization(), serviceManager.GetAudit(), readonly, caseSensitive),
+		globalsecret.NewEndpoint(serviceManager.GetGlobalSecret(), serviceManager.GetAuthorization(), serviceManager.GetAudit(), readonly, caseSensitive),
+		globalvariable.NewEndpoint(cfg.Variable, serviceManager.GetGlobalVariable(), serviceManager.GetAuthorization(), serviceManager.GetAudit(), readonly, caseSensitive),
 		health.NewEndpoint(serviceManager.GetHealth()),
+		librarypanel.NewEndpoint(serviceManager.GetLibraryPanel(), serviceManager.GetAuthorization(), serviceManager.GetAudit(), readonly, caseSensitive),
 		plugin.NewEndpoint(serviceManager.GetPlugin(), cfg.Plugin.EnableDev),
-		project.NewEndpoint(serviceManager.GetProject(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		role.NewEndpoint(serviceManager.GetRole(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		rolebinding.NewEndpoint(serviceManager.GetRoleBinding(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		secret.NewEndpoint(serviceManager.GetSecret(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		user.NewEndpoint(serviceManager.GetUser(), serviceManager.GetAuthorization(), cfg.Security.Authentication.DisableSignUp, readonly, caseSensitive),
-		variable.NewEndpoint(cfg.Variable, serviceManager.GetVariable(), serviceManager.GetAuthorization(), readonly, caseSensitive),
+		project.NewEndpoint(serviceManager.GetProject(), serviceManager.GetAuthorization(), serviceManager.GetAudit(), readonly, caseSensitive),
+		role.NewEndpoint(serviceManager.GetRole(), serviceManager.GetAuthorization(), serviceManager.GetAudit(), readonly, caseSensitive),
+		rolebinding.NewEndpoint(serviceManager.GetRoleBinding(), serviceManager.GetAuthorization(), serviceManager.GetAudit(), readonly, caseSensitive),
+		search.NewEndpoint(serviceManager.GetSearch(), serviceManager.GetAuthorization(), caseSensitive),
+		secret.NewEndpoint(serviceManager.GetSecret(), serviceManager.GetAuthorization(), serviceManager.GetAudit(), readonly, caseSensitive),
+		snapshot.NewEndpoint(serviceManager.GetSnapshot(), serviceManager.GetAuthorization(), serviceManager.GetAudit(), readonly, caseSensitive),
+		user.NewEndpoint(serviceManager.GetUser(), serviceManager.GetAuthorization(), serviceManager.GetAudit(), cfg.Security.Authentication.DisableSignUp, readonly, caseSensitive),
+		variable.NewEndpoint(cfg.Variable, serviceManager.GetVariable(), serviceManager.GetAuthorization(), serviceManager.GetAudit(), readonly, caseSensitive),
 		view.NewEndpoint(serviceManager.GetView(), serviceManager.GetAuthorization(), serviceManager.GetDashboard()),
 	}
 
+	renderEndpoint, err := render.NewEndpoint(cfg.Rendering, serviceManager.GetDashboard(), serviceManager.GetAuthorization(), caseSensitive)
+	if err != nil {
+		logrus.WithError(err).Fatal("error initializing the rendering endpoint")
+	}
+	apiV1Endpoints = append(apiV1Endpoints, renderEndpoint)
+
+	schemaEndpoint, err := schema.NewEndpoint()
+	if err != nil {
+		logrus.WithError(err).Fatal("error initializing the schema endpoint")
+	}
+	apiV1Endpoints = append(apiV1Endpoints, schemaEndpoint)
+
 	authEndpoint, err := authendpoint.New(
 		persistenceManager.GetUser(),
+		persistenceManager.GetRoleBinding(),
+		persistenceManager.GetGlobalRoleBinding(),
 		serviceManager.GetJWT(),
 		serviceManager.GetAuthorization(),
 		cfg.Security.Authentication.Providers,
@@ -17,6 +17,9 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	modelAPI "github.com/perses/perses/pkg/model/api"
@@ -24,14 +27,20 @@ import (
 	"github.com/tidwall/gjson"
 
 	"github.com/go-sql-driver/mysql"
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	databaseFile "github.com/perses/perses/internal/api/database/file"
 	databaseModel "github.com/perses/perses/internal/api/database/model"
+	databaseObjectStorage "github.com/perses/perses/internal/api/database/objectstorage"
 	databaseSQL "github.com/perses/perses/internal/api/database/sql"
 	"github.com/perses/perses/pkg/model/api/config"
 	promConfig "github.com/prometheus/common/config"
 	"github.com/sirupsen/logrus"
 )
 
+const objectStorageTimeout = 30 * time.Second
+
 type dao struct {
 	databaseModel.DAO
 	client databaseModel.DAO
@@ -88,6 +97,18 @@ func (d *dao) GetLatestUpdateTime(kind []modelV1.Kind) (*string, error) {
 	return d.client.GetLatestUpdateTime(kind)
 }
 
+// transactionalDAO is the dao of a database supporting the transactions.
+type transactionalDAO struct {
+	*dao
+	client databaseModel.TransactionalDAO
+}
+
+func (d *transactionalDAO) InTransaction(f func(dao databaseModel.DAO) error) error {
+	return d.client.InTransaction(func(client databaseModel.DAO) error {
+		return f(&dao{client: client})
+	})
+}
+
 func New(conf config.Database) (databaseModel.DAO, error) {
 	var client databaseModel.DAO
 	if conf.File != nil {
@@ -149,8 +170,115 @@ func New(conf config.Database) (databaseModel.DAO, error) {
 			SchemaName:    c.DBName,
 			CaseSensitive: c.CaseSensitive,
 		}
+	} else if conf.Postgres != nil {
+		db, err := openPostgres(conf.Postgres)
+		if err != nil {
+			return nil, err
+		}
+		client = &databaseSQL.DAO{
+			DB:            db,
+			SchemaName:    conf.Postgres.Schema,
+			CaseSensitive: conf.Postgres.CaseSensitive,
+			Flavor:        sqlbuilder.PostgreSQL,
+		}
+	} else if conf.ObjectStorage != nil {
+		objectStorageClient, err := newObjectStorageClient(conf.ObjectStorage)
+		if err != nil {
+			return nil, err
+		}
+		client = &databaseObjectStorage.DAO{
+			Client:        objectStorageClient,
+			Prefix:        conf.ObjectStorage.Prefix,
+			CacheTTL:      time.Duration(conf.ObjectStorage.CacheTTL),
+			CaseSensitive: conf.ObjectStorage.CaseSensitive,
+		}
 	} else {
 		return nil, fmt.Errorf("no dao defined")
 	}
+	if transactionalClient, ok := client.(databaseModel.TransactionalDAO); ok {
+		return &transactionalDAO{dao: &dao{client: client}, client: transactionalClient}, nil
+	}
 	return &dao{client: client}, nil
 }
+
+func openPostgres(c *config.Postgres) (*sql.DB, error) {
+	u := &url.URL{
+		Scheme: "postgres",
+		Host:   string(c.Addr),
+		// The database needs a '/' prefix
+		Path: "/" + c.DBName,
+	}
+	if len(c.User) > 0 {
+		if len(c.Password) > 0 {
+			u.User = url.UserPassword(string(c.User), string(c.Password))
+		} else {
+			u.User = url.User(string(c.User))
+		}
+	}
+	query := url.Values{}
+	if len(c.SSLMode) > 0 {
+		query.Set("sslmode", string(c.SSLMode))
+	}
+	if c.ConnectTimeout > 0 {
+		// connect_timeout is expressed in seconds
+		query.Set("connect_timeout", strconv.Itoa(int(time.Duration(c.ConnectTimeout).Seconds())))
+	}
+	u.RawQuery = query.Encode()
+
+	pgxConfig, err := pgx.ParseConfig(u.String())
+	if err != nil {
+		logrus.WithError(err).Error("Failed to parse the postgres configuration")
+		return nil, err
+	}
+	// (OPTIONAL) Configure TLS
+	if c.TLSConfig != nil {
+		tlsConfig, parseErr := promConfig.NewTLSConfig(c.TLSConfig)
+		if parseErr != nil {
+			logrus.WithError(parseErr).Error("Failed to parse TLS from configuration")
+			return nil, parseErr
+		}
+		if len(tlsConfig.ServerName) == 0 {
+			tlsConfig.ServerName = pgxConfig.Host
+		}
+		pgxConfig.TLSConfig = tlsConfig
+	}
+
+	db := stdlib.OpenDB(*pgxConfig)
+	// The pool of connections is managed by database/sql.
+	db.SetMaxOpenConns(c.MaxOpenConns)
+	if c.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(c.MaxIdleConns)
+	}
+	db.SetConnMaxLifetime(time.Duration(c.ConnMaxLifetime))
+	db.SetConnMaxIdleTime(time.Duration(c.ConnMaxIdleTime))
+	return db, nil
+}
+
+func newObjectStorageClient(c *config.ObjectStorage) (*databaseObjectStorage.Client, error) {
+	endpoint, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := &http.Client{Timeout: objectStorageTimeout}
+	// (OPTIONAL) Configure TLS
+	if c.TLSConfig != nil {
+		tlsConfig, parseErr := promConfig.NewTLSConfig(c.TLSConfig)
+		if parseErr != nil {
+			logrus.WithError(parseErr).Error("Failed to parse TLS from configuration")
+			return nil, parseErr
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		httpClient.Transport = transport
+	}
+	return &databaseObjectStorage.Client{
+		Endpoint:        endpoint,
+		Bucket:          c.Bucket,
+		Region:          c.Region,
+		AccessKeyID:     string(c.AccessKeyID),
+		SecretAccessKey: string(c.SecretAccessKey),
+		SessionToken:    string(c.SessionToken),
+		ForcePathStyle:  c.ForcePathStyle,
+		HTTPClient:      httpClient,
+	}, nil
+}
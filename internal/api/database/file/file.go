@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
 	modelAPI "github.com/perses/perses/pkg/model/api"
 	"github.com/perses/perses/pkg/model/api/config"
 	modelV1 "github.com/perses/perses/pkg/model/api/v1"
@@ -199,6 +200,7 @@ func (d *DAO) Query(query databaseModel.Query, slice interface{}) error {
 	if files, err = d.visit(folder, prefix); err != nil {
 		return err
 	}
+	files = d.filterFiles(query, files)
 	if len(files) <= 0 {
 		// in case the result is empty, let's initialize the slice just to avoid returning a nil slice
 		sliceElem = reflect.MakeSlice(typeParameter, 0, 0)
@@ -258,7 +260,7 @@ func (d *DAO) DeleteByQuery(query databaseModel.Query) error {
 	if !isExist {
 		return nil
 	}
-	if len(prefix) == 0 {
+	if _, isAuditQuery := query.(*audit.Query); len(prefix) == 0 && !isAuditQuery {
 		return os.RemoveAll(folder)
 	}
 	// in case there is a prefix file name we need to delete only the files that is matching the prefix and not the folder entirely
@@ -266,6 +268,7 @@ func (d *DAO) DeleteByQuery(query databaseModel.Query) error {
 	if files, err = d.visit(folder, prefix); err != nil {
 		return err
 	}
+	files = d.filterFiles(query, files)
 	if len(files) <= 0 {
 		return nil
 	}
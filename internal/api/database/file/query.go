@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
 	"github.com/perses/perses/internal/api/interface/v1/ephemeraldashboard"
@@ -29,10 +30,12 @@ import (
 	"github.com/perses/perses/internal/api/interface/v1/globalrolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/globalsecret"
 	"github.com/perses/perses/internal/api/interface/v1/globalvariable"
+	"github.com/perses/perses/internal/api/interface/v1/librarypanel"
 	"github.com/perses/perses/internal/api/interface/v1/project"
 	"github.com/perses/perses/internal/api/interface/v1/role"
 	"github.com/perses/perses/internal/api/interface/v1/rolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/secret"
+	"github.com/perses/perses/internal/api/interface/v1/snapshot"
 	"github.com/perses/perses/internal/api/interface/v1/user"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
@@ -63,11 +66,32 @@ func (d *DAO) generateResourceQuery(kind v1.Kind) string {
 	return filepath.Join(d.Folder, v1.PluralKindMap[kind])
 }
 
+// filterFiles keeps the files matching the part of the query that the folder and the prefix don't cover: the events
+// of the audit log are selected by their ID.
+func (d *DAO) filterFiles(query databaseModel.Query, files []string) []string {
+	q, isAuditQuery := query.(*audit.Query)
+	if !isAuditQuery {
+		return files
+	}
+	result := make([]string, 0, len(files))
+	for _, file := range files {
+		if q.MatchID(strings.TrimSuffix(filepath.Base(file), fmt.Sprintf(".%s", d.Extension))) {
+			result = append(result, file)
+		}
+	}
+	return result
+}
+
 func (d *DAO) buildQuery(query databaseModel.Query) (pathFolder string, prefix string, isExist bool, err error) {
 	switch qt := query.(type) {
+	case *audit.Query:
+		pathFolder = d.generateResourceQuery(v1.KindAuditEvent)
 	case *dashboard.Query:
 		pathFolder = d.generateProjectResourceQuery(v1.KindDashboard, qt.Project)
 		prefix = qt.NamePrefix
+	case *dashboard.HistoryQuery:
+		pathFolder = d.generateProjectResourceQuery(v1.KindDashboardHistory, qt.Project)
+		prefix = qt.NamePrefix
 	case *datasource.Query:
 		pathFolder = d.generateProjectResourceQuery(v1.KindDatasource, qt.Project)
 		prefix = qt.NamePrefix
@@ -92,6 +116,9 @@ func (d *DAO) buildQuery(query databaseModel.Query) (pathFolder string, prefix s
 	case *globalvariable.Query:
 		pathFolder = d.generateResourceQuery(v1.KindGlobalVariable)
 		prefix = qt.NamePrefix
+	case *librarypanel.Query:
+		pathFolder = d.generateProjectResourceQuery(v1.KindLibraryPanel, qt.Project)
+		prefix = qt.NamePrefix
 	case *project.Query:
 		pathFolder = d.generateResourceQuery(v1.KindProject)
 		prefix = qt.NamePrefix
@@ -104,6 +131,9 @@ func (d *DAO) buildQuery(query databaseModel.Query) (pathFolder string, prefix s
 	case *secret.Query:
 		pathFolder = d.generateProjectResourceQuery(v1.KindSecret, qt.Project)
 		prefix = qt.NamePrefix
+	case *snapshot.Query:
+		pathFolder = d.generateProjectResourceQuery(v1.KindSnapshot, qt.Project)
+		prefix = qt.NamePrefix
 	case *user.Query:
 		pathFolder = d.generateResourceQuery(v1.KindUser)
 		prefix = qt.NamePrefix
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
 	"github.com/perses/perses/internal/api/interface/v1/folder"
@@ -50,6 +51,21 @@ func TestBuildQuery(t *testing.T) {
 			expectedPath:       "dashboards",
 			expectedNamePrefix: "meta",
 		},
+		{
+			title: "auditQuery",
+			query: &audit.Query{
+				Project: "perses",
+				User:    "admin",
+			},
+			expectedPath: "auditevents",
+		},
+		{
+			title: "dashboardHistoryQuery",
+			query: &dashboard.HistoryQuery{
+				Project: "perses",
+			},
+			expectedPath: filepath.Join("dashboardhistories", "perses"),
+		},
 		{
 			title: "datasourceQuery",
 			query: &datasource.Query{
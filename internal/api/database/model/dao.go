@@ -46,3 +46,11 @@ type DAO interface {
 	HealthCheck() bool
 	GetLatestUpdateTime(kind []modelV1.Kind) (*string, error)
 }
+
+// TransactionalDAO is implemented by the databases able to run several operations atomically.
+type TransactionalDAO interface {
+	DAO
+	// InTransaction calls f with a DAO running all its operations in the same transaction. The transaction is
+	// committed if f succeeds and rolled back otherwise.
+	InTransaction(f func(dao DAO) error) error
+}
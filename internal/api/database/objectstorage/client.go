@@ -0,0 +1,362 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databaseobjectstorage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	amzDateFormat      = "20060102T150405Z"
+	amzShortDateFormat = "20060102"
+	signingAlgorithm   = "AWS4-HMAC-SHA256"
+	serviceName        = "s3"
+	// maxErrorBodySize is the maximum number of bytes of the body of an error response kept in the error message.
+	maxErrorBodySize = 512
+)
+
+var (
+	errNotFound           = errors.New("object not found")
+	errPreconditionFailed = errors.New("precondition failed")
+)
+
+// Client is a minimal client of the S3 API. This API is implemented by AWS S3, but also by most of the object storages
+// like MinIO, Ceph, or Google Cloud Storage through its XML API.
+// The requests are signed with the AWS Signature Version 4, unless no access key is provided.
+type Client struct {
+	// Endpoint is the URL of the API, e.g. https://s3.eu-west-1.amazonaws.com
+	Endpoint *url.URL
+	Bucket   string
+	Region   string
+	// AccessKeyID and SecretAccessKey are the credentials used to sign the requests.
+	AccessKeyID     string
+	SecretAccessKey string
+	// SessionToken is the token of temporary credentials. It is optional.
+	SessionToken string
+	// ForcePathStyle uses the path-style URLs (endpoint/bucket/key) instead of the virtual-hosted-style URLs
+	// (bucket.endpoint/key). Most of the self-hosted object storages require it.
+	ForcePathStyle bool
+	HTTPClient     *http.Client
+	// now is used to sign the requests. It can be replaced in tests.
+	now func() time.Time
+}
+
+// Get returns the content of the object and its ETag. When ifNoneMatch is set and the object still has this ETag, no
+// content is returned and notModified is true.
+func (c *Client) Get(key string, ifNoneMatch string) (data []byte, etag string, notModified bool, err error) {
+	headers := map[string]string{}
+	if len(ifNoneMatch) > 0 {
+		headers["If-None-Match"] = ifNoneMatch
+	}
+	resp, err := c.do(http.MethodGet, c.objectPath(key), nil, nil, headers)
+	if err != nil {
+		return nil, "", false, err
+	}
+	defer resp.Body.Close() //nolint: errcheck
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, ifNoneMatch, true, nil
+	}
+	if checkErr := checkResponse(resp); checkErr != nil {
+		return nil, "", false, checkErr
+	}
+	data, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", false, err
+	}
+	return data, resp.Header.Get("ETag"), false, nil
+}
+
+// Head returns the ETag of the object, or errNotFound if it doesn't exist.
+func (c *Client) Head(key string) (string, error) {
+	resp, err := c.do(http.MethodHead, c.objectPath(key), nil, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close() //nolint: errcheck
+	if checkErr := checkResponse(resp); checkErr != nil {
+		return "", checkErr
+	}
+	return resp.Header.Get("ETag"), nil
+}
+
+// Put writes the object and returns its new ETag.
+// When ifMatch is set, the object is written only if its current ETag is ifMatch. When ifNoneMatch is true, the object
+// is written only if it doesn't exist yet. Otherwise, errPreconditionFailed is returned.
+func (c *Client) Put(key string, data []byte, ifMatch string, ifNoneMatch bool) (string, error) {
+	headers := map[string]string{"Content-Type": "application/json"}
+	if len(ifMatch) > 0 {
+		headers["If-Match"] = ifMatch
+	}
+	if ifNoneMatch {
+		headers["If-None-Match"] = "*"
+	}
+	resp, err := c.do(http.MethodPut, c.objectPath(key), nil, data, headers)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close() //nolint: errcheck
+	if checkErr := checkResponse(resp); checkErr != nil {
+		return "", checkErr
+	}
+	return resp.Header.Get("ETag"), nil
+}
+
+// Delete removes the object. Removing an object that doesn't exist is not an error.
+func (c *Client) Delete(key string) error {
+	resp, err := c.do(http.MethodDelete, c.objectPath(key), nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint: errcheck
+	return checkResponse(resp)
+}
+
+// Object describes an object of the bucket.
+type Object struct {
+	Key          string    `xml:"Key"`
+	LastModified time.Time `xml:"LastModified"`
+}
+
+type listBucketResult struct {
+	Contents              []Object `xml:"Contents"`
+	IsTruncated           bool     `xml:"IsTruncated"`
+	NextContinuationToken string   `xml:"NextContinuationToken"`
+}
+
+// List returns the keys of all the objects starting with the prefix.
+func (c *Client) List(prefix string) ([]string, error) {
+	objects, err := c.ListObjects(prefix)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(objects))
+	for _, object := range objects {
+		keys = append(keys, object.Key)
+	}
+	return keys, nil
+}
+
+// ListObjects returns all the objects starting with the prefix.
+func (c *Client) ListObjects(prefix string) ([]Object, error) {
+	var objects []Object
+	continuationToken := ""
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", prefix)
+		if len(continuationToken) > 0 {
+			query.Set("continuation-token", continuationToken)
+		}
+		result, err := c.list(query)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, result.Contents...)
+		if !result.IsTruncated || len(result.NextContinuationToken) == 0 {
+			return objects, nil
+		}
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+func (c *Client) list(query url.Values) (*listBucketResult, error) {
+	resp, err := c.do(http.MethodGet, c.bucketPath(), query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint: errcheck
+	if checkErr := checkResponse(resp); checkErr != nil {
+		return nil, checkErr
+	}
+	result := &listBucketResult{}
+	if decodeErr := xml.NewDecoder(resp.Body).Decode(result); decodeErr != nil {
+		return nil, fmt.Errorf("unable to decode the list of objects: %w", decodeErr)
+	}
+	return result, nil
+}
+
+// HeadBucket checks that the bucket exists and that the credentials give access to it.
+func (c *Client) HeadBucket() error {
+	resp, err := c.do(http.MethodHead, c.bucketPath(), nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint: errcheck
+	return checkResponse(resp)
+}
+
+func (c *Client) bucketPath() string {
+	if c.ForcePathStyle {
+		return "/" + c.Bucket
+	}
+	return "/"
+}
+
+func (c *Client) objectPath(key string) string {
+	if c.ForcePathStyle {
+		return "/" + c.Bucket + "/" + key
+	}
+	return "/" + key
+}
+
+func (c *Client) host() string {
+	if c.ForcePathStyle {
+		return c.Endpoint.Host
+	}
+	return c.Bucket + "." + c.Endpoint.Host
+}
+
+func (c *Client) do(method string, path string, query url.Values, body []byte, headers map[string]string) (*http.Response, error) {
+	rawURL := fmt.Sprintf("%s://%s%s", c.Endpoint.Scheme, c.host(), uriEncode(path, false))
+	if canonicalQuery := canonicalQueryString(query); len(canonicalQuery) > 0 {
+		rawURL += "?" + canonicalQuery
+	}
+	req, err := http.NewRequest(method, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	if len(c.AccessKeyID) > 0 {
+		c.sign(req, path, query, body)
+	}
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return httpClient.Do(req)
+}
+
+// sign adds the headers of the AWS Signature Version 4 to the request.
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func (c *Client) sign(req *http.Request, path string, query url.Values, body []byte) {
+	now := time.Now
+	if c.now != nil {
+		now = c.now
+	}
+	t := now().UTC()
+	amzDate := t.Format(amzDateFormat)
+	payloadHash := sha256Hex(body)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if len(c.SessionToken) > 0 {
+		req.Header.Set("X-Amz-Security-Token", c.SessionToken)
+	}
+
+	// Every header set so far is signed, in addition to the host.
+	headerValues := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		headerValues[strings.ToLower(name)] = strings.TrimSpace(req.Header.Get(name))
+	}
+	headerNames := make([]string, 0, len(headerValues))
+	for name := range headerValues {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+	var canonicalHeaders strings.Builder
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name + ":" + headerValues[name] + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(path, false),
+		canonicalQueryString(query),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := strings.Join([]string{t.Format(amzShortDateFormat), c.Region, serviceName, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{signingAlgorithm, amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+c.SecretAccessKey), t.Format(amzShortDateFormat))
+	signingKey = hmacSHA256(signingKey, c.Region)
+	signingKey = hmacSHA256(signingKey, serviceName)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, c.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func checkResponse(resp *http.Response) error {
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusNotFound:
+		return errNotFound
+	// 409 is returned by S3 when a conditional write conflicts with another one in progress.
+	case resp.StatusCode == http.StatusPreconditionFailed || resp.StatusCode == http.StatusConflict:
+		return errPreconditionFailed
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	return fmt.Errorf("%s %s: unexpected status code %d: %s", resp.Request.Method, resp.Request.URL.Path, resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// canonicalQueryString returns the query parameters sorted by name and encoded as required by the signature.
+func canonicalQueryString(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parameters := make([]string, 0, len(names))
+	for _, name := range names {
+		for _, value := range query[name] {
+			parameters = append(parameters, uriEncode(name, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(parameters, "&")
+}
+
+// uriEncode encodes every byte except the unreserved characters of the RFC 3986. The slashes are kept unless
+// encodeSlash is true.
+func uriEncode(s string, encodeSlash bool) string {
+	var result strings.Builder
+	for i := 0; i < len(s); i++ {
+		b := s[i]
+		if (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') ||
+			b == '-' || b == '_' || b == '.' || b == '~' || (b == '/' && !encodeSlash) {
+			result.WriteByte(b)
+			continue
+		}
+		fmt.Fprintf(&result, "%%%02X", b)
+	}
+	return result.String()
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,431 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databaseobjectstorage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	objectExtension = ".json"
+	// deletionMarker is the name of the object rewritten in the folder of a kind each time a resource of this kind is
+	// deleted, so the deletions change the latest update time of the kind.
+	deletionMarker = ".deleted"
+	// latestUpdateTimeFormat is the format of the time returned by GetLatestUpdateTime, the one of the SQL databases.
+	latestUpdateTimeFormat = "2006-01-02 15:04:05"
+)
+
+// maxCachedObjects is the maximum number of objects kept in the local cache.
+var maxCachedObjects = 10000
+
+func generateID(kind modelV1.Kind, metadata modelAPI.Metadata) (string, error) {
+	switch m := metadata.(type) {
+	case *modelV1.ProjectMetadata:
+		return path.Join(modelV1.PluralKindMap[kind], m.Project, m.Name), nil
+	case *modelV1.Metadata:
+		return path.Join(modelV1.PluralKindMap[kind], m.Name), nil
+	}
+	return "", fmt.Errorf("metadata %T not managed", metadata)
+}
+
+// metadataVersion returns the version of the metadata, 0 when the metadata has no version.
+func metadataVersion(metadata modelAPI.Metadata) uint64 {
+	switch m := metadata.(type) {
+	case *modelV1.ProjectMetadata:
+		return m.Version
+	case *modelV1.Metadata:
+		return m.Version
+	}
+	return 0
+}
+
+type cachedObject struct {
+	data []byte
+	etag string
+	// version is the version of the metadata of the object.
+	version   uint64
+	fetchedAt time.Time
+}
+
+// DAO stores every resource as a JSON object in a bucket exposing the S3 API, so the Perses servers can be stateless.
+// Azure Blob Storage doesn't expose this API and is not supported. Google Cloud Storage is supported through its XML API.
+//
+// An update of a resource is based on the version read before (see modelV1.Metadata.Update), so it is rejected with a
+// conflict when the object doesn't have this version anymore, e.g. because it has been modified by another server
+// since it has been read (optimistic concurrency).
+type DAO struct {
+	databaseModel.DAO
+	Client *Client
+	// Prefix is prepended to the key of every object, so several Perses can share the same bucket.
+	Prefix string
+	// CacheTTL is the duration during which an object is served from the local cache without asking the storage if it
+	// changed. Once expired, the object is read again only if its ETag changed. The cache is disabled when it is 0.
+	// The cache contains at most maxCachedObjects objects.
+	CacheTTL      time.Duration
+	CaseSensitive bool
+	mutex         sync.Mutex
+	objects       map[string]*cachedObject
+}
+
+func (d *DAO) Init() error {
+	if err := d.Client.HeadBucket(); err != nil {
+		return fmt.Errorf("unable to access the bucket %q: %w", d.Client.Bucket, err)
+	}
+	return nil
+}
+
+func (d *DAO) IsCaseSensitive() bool {
+	return d.CaseSensitive
+}
+
+func (d *DAO) Close() error {
+	return nil
+}
+
+func (d *DAO) Create(entity modelAPI.Entity) error {
+	// Flatten the metadata in case the config is activated.
+	// See https://github.com/perses/perses/issues/1721 for more details.
+	entity.GetMetadata().Flatten(d.CaseSensitive)
+	id, generateIDErr := generateID(modelV1.Kind(entity.GetKind()), entity.GetMetadata())
+	if generateIDErr != nil {
+		return generateIDErr
+	}
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return err
+	}
+	key := d.buildKey(id)
+	// The object is written only if it doesn't exist, so two servers cannot create the same resource.
+	etag, putErr := d.Client.Put(key, data, "", true)
+	if putErr != nil {
+		if errors.Is(putErr, errPreconditionFailed) {
+			return &databaseModel.Error{Key: id, Code: databaseModel.ErrorCodeConflict}
+		}
+		return putErr
+	}
+	d.remember(key, data, etag)
+	return nil
+}
+
+func (d *DAO) Upsert(entity modelAPI.Entity) error {
+	entity.GetMetadata().Flatten(d.CaseSensitive)
+	id, generateIDErr := generateID(modelV1.Kind(entity.GetKind()), entity.GetMetadata())
+	if generateIDErr != nil {
+		return generateIDErr
+	}
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return err
+	}
+	key := d.buildKey(id)
+	// The object is overwritten only if it still has the version the entity has been updated from.
+	ifMatch, err := d.previousETag(key, metadataVersion(entity.GetMetadata()))
+	var etag string
+	if err == nil {
+		etag, err = d.Client.Put(key, data, ifMatch, false)
+	}
+	if err != nil {
+		if errors.Is(err, errPreconditionFailed) {
+			d.forget(key)
+			return &databaseModel.Error{Key: id, Code: databaseModel.ErrorCodeConflict}
+		}
+		return err
+	}
+	d.remember(key, data, etag)
+	return nil
+}
+
+func (d *DAO) Get(kind modelV1.Kind, metadata modelAPI.Metadata, entity modelAPI.Entity) error {
+	metadata.Flatten(d.CaseSensitive)
+	id, generateIDErr := generateID(kind, metadata)
+	if generateIDErr != nil {
+		return generateIDErr
+	}
+	data, err := d.read(d.buildKey(id))
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			return &databaseModel.Error{Key: id, Code: databaseModel.ErrorCodeNotFound}
+		}
+		return err
+	}
+	return json.Unmarshal(data, entity)
+}
+
+// RawMetadataQuery returns the objects matching the query without their spec.
+func (d *DAO) RawMetadataQuery(query databaseModel.Query, kind modelV1.Kind) ([]json.RawMessage, error) {
+	raws, err := d.RawQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]json.RawMessage, 0, len(raws))
+	for _, raw := range raws {
+		metadata := gjson.GetBytes(raw, "metadata").Raw
+		result = append(result, []byte(fmt.Sprintf(`{"kind":"%s","metadata":%s,"spec":{}}`, kind, metadata)))
+	}
+	return result, nil
+}
+
+func (d *DAO) RawQuery(query databaseModel.Query) ([]json.RawMessage, error) {
+	keys, err := d.listQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]json.RawMessage, 0, len(keys))
+	for _, key := range keys {
+		data, readErr := d.read(key)
+		if readErr != nil {
+			if errors.Is(readErr, errNotFound) {
+				// The object has been deleted since the list has been made.
+				continue
+			}
+			return nil, readErr
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (d *DAO) Query(query databaseModel.Query, slice interface{}) error {
+	typeParameter := reflect.TypeOf(slice)
+	// to avoid any miss usage when using this method, slice should be a pointer to a slice.
+	if typeParameter.Kind() != reflect.Ptr {
+		return fmt.Errorf("slice in parameter is not a pointer to a slice but a %q", typeParameter.Kind())
+	}
+	if typeParameter.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("slice in parameter is not actually a slice but a %q", typeParameter.Elem().Kind())
+	}
+	raws, err := d.RawQuery(query)
+	if err != nil {
+		return err
+	}
+	// Decoding a JSON array fills the slice, and initializes it when the result is empty.
+	data, err := json.Marshal(raws)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, slice)
+}
+
+func (d *DAO) Delete(kind modelV1.Kind, metadata modelAPI.Metadata) error {
+	id, generateIDErr := generateID(kind, metadata)
+	if generateIDErr != nil {
+		return generateIDErr
+	}
+	key := d.buildKey(id)
+	// Deleting an object that doesn't exist is not an error for the storage, so its existence is checked first.
+	if _, err := d.Client.Head(key); err != nil {
+		if errors.Is(err, errNotFound) {
+			return &databaseModel.Error{Key: id, Code: databaseModel.ErrorCodeNotFound}
+		}
+		return err
+	}
+	d.forget(key)
+	if err := d.Client.Delete(key); err != nil {
+		return err
+	}
+	return d.putDeletionMarker(modelV1.PluralKindMap[kind])
+}
+
+func (d *DAO) DeleteByQuery(query databaseModel.Query) error {
+	keys, err := d.listQuery(query)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		d.forget(key)
+		if deleteErr := d.Client.Delete(key); deleteErr != nil {
+			return deleteErr
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	// The folder of the query starts with the folder of the kind.
+	folder, _, _ := d.buildQuery(query)
+	return d.putDeletionMarker(strings.Split(folder, "/")[0])
+}
+
+func (d *DAO) HealthCheck() bool {
+	return d.Client.HeadBucket() == nil
+}
+
+// GetLatestUpdateTime returns the latest modification time of the objects of the kinds, according to the listing of
+// the bucket. The deletions are taken into account thanks to the deletion marker of each kind.
+func (d *DAO) GetLatestUpdateTime(kinds []modelV1.Kind) (*string, error) {
+	var latest time.Time
+	for _, kind := range kinds {
+		objects, err := d.Client.ListObjects(path.Join(d.Prefix, modelV1.PluralKindMap[kind]) + "/")
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range objects {
+			if object.LastModified.After(latest) {
+				latest = object.LastModified
+			}
+		}
+	}
+	if latest.IsZero() {
+		return nil, nil
+	}
+	result := latest.UTC().Format(latestUpdateTimeFormat)
+	return &result, nil
+}
+
+func (d *DAO) buildKey(id string) string {
+	return path.Join(d.Prefix, id) + objectExtension
+}
+
+// putDeletionMarker rewrites the deletion marker of the folder of a kind. Its extension is not the one of the
+// resources, so it is never returned by the queries.
+func (d *DAO) putDeletionMarker(folder string) error {
+	_, err := d.Client.Put(path.Join(d.Prefix, folder, deletionMarker), []byte("{}"), "", false)
+	return err
+}
+
+// listQuery returns the keys of the objects matching the query.
+func (d *DAO) listQuery(query databaseModel.Query) ([]string, error) {
+	folder, prefix, err := d.buildQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build the query: %s", err)
+	}
+	keys, err := d.Client.List(path.Join(d.Prefix, folder) + "/")
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if !strings.HasSuffix(key, objectExtension) {
+			continue
+		}
+		name := path.Base(key)
+		if len(prefix) > 0 && !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		// The events of the audit log are selected by their ID.
+		if q, isAuditQuery := query.(*audit.Query); isAuditQuery && !q.MatchID(strings.TrimSuffix(name, objectExtension)) {
+			continue
+		}
+		result = append(result, key)
+	}
+	return result, nil
+}
+
+// read returns the content of the object, from the local cache when it is still fresh.
+func (d *DAO) read(key string) ([]byte, error) {
+	d.mutex.Lock()
+	cached := d.objects[key]
+	d.mutex.Unlock()
+	ifNoneMatch := ""
+	if cached != nil {
+		if time.Since(cached.fetchedAt) < d.CacheTTL {
+			return cached.data, nil
+		}
+		ifNoneMatch = cached.etag
+	}
+	data, etag, notModified, err := d.Client.Get(key, ifNoneMatch)
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			d.forget(key)
+		}
+		return nil, err
+	}
+	if notModified {
+		data = cached.data
+	}
+	d.remember(key, data, etag)
+	return data, nil
+}
+
+// previousETag returns the ETag of the object when it has the version preceding the given version, i.e. the version
+// the entity has been updated from. errPreconditionFailed is returned when the object has another version.
+// An entity with the version 0 is not based on a previous version, so the object is overwritten whatever its version.
+func (d *DAO) previousETag(key string, version uint64) (string, error) {
+	if version == 0 {
+		return "", nil
+	}
+	d.mutex.Lock()
+	cached := d.objects[key]
+	d.mutex.Unlock()
+	if cached != nil && cached.version == version-1 {
+		return cached.etag, nil
+	}
+	data, etag, _, err := d.Client.Get(key, "")
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	if gjson.GetBytes(data, "metadata.version").Uint() != version-1 {
+		return "", errPreconditionFailed
+	}
+	return etag, nil
+}
+
+// remember keeps the object in the local cache when it is enabled. When the cache is full, the expired objects are
+// removed, or an arbitrary one if none is expired.
+func (d *DAO) remember(key string, data []byte, etag string) {
+	if d.CacheTTL <= 0 {
+		return
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.objects == nil {
+		d.objects = make(map[string]*cachedObject)
+	}
+	if _, ok := d.objects[key]; !ok && len(d.objects) >= maxCachedObjects {
+		d.evict()
+	}
+	d.objects[key] = &cachedObject{
+		data:      data,
+		etag:      etag,
+		version:   gjson.GetBytes(data, "metadata.version").Uint(),
+		fetchedAt: time.Now(),
+	}
+}
+
+// evict removes the expired objects of the cache, or an arbitrary one when none is expired. The mutex must be held.
+func (d *DAO) evict() {
+	for key, object := range d.objects {
+		if time.Since(object.fetchedAt) >= d.CacheTTL {
+			delete(d.objects, key)
+		}
+	}
+	if len(d.objects) < maxCachedObjects {
+		return
+	}
+	for key := range d.objects {
+		delete(d.objects, key)
+		return
+	}
+}
+
+func (d *DAO) forget(key string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	delete(d.objects, key)
+}
@@ -0,0 +1,321 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databaseobjectstorage
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/role"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+// fakeStorage is an in-memory implementation of the part of the S3 API used by the DAO, with path-style URLs.
+type fakeStorage struct {
+	mutex   sync.Mutex
+	objects map[string][]byte
+	etags   map[string]string
+	// modified contains the time of the last modification of the objects. The clock moves forward of one minute at each
+	// modification.
+	modified map[string]time.Time
+	clock    time.Time
+	version  int
+	gets     int
+}
+
+func (s *fakeStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/bucket"), "/")
+	if len(key) == 0 {
+		if r.Method == http.MethodHead {
+			return
+		}
+		s.list(w, r.URL.Query().Get("prefix"))
+		return
+	}
+	etag, exists := s.etags[key]
+	switch r.Method {
+	case http.MethodGet:
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		s.gets++
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write(s.objects[key])
+	case http.MethodHead:
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case http.MethodPut:
+		if (r.Header.Get("If-None-Match") == "*" && exists) ||
+			(len(r.Header.Get("If-Match")) > 0 && r.Header.Get("If-Match") != etag) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		s.version++
+		s.clock = s.clock.Add(time.Minute)
+		s.objects[key] = data
+		s.etags[key] = fmt.Sprintf(`"%d"`, s.version)
+		s.modified[key] = s.clock
+		w.Header().Set("ETag", s.etags[key])
+	case http.MethodDelete:
+		delete(s.objects, key)
+		delete(s.etags, key)
+		delete(s.modified, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *fakeStorage) list(w http.ResponseWriter, prefix string) {
+	result := listBucketResult{}
+	var keys []string
+	for key := range s.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		result.Contents = append(result.Contents, Object{Key: key, LastModified: s.modified[key]})
+	}
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+// update simulates a modification of an object by another server.
+func (s *fakeStorage) update(key string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.version++
+	s.clock = s.clock.Add(time.Minute)
+	s.etags[key] = fmt.Sprintf(`"%d"`, s.version)
+	s.modified[key] = s.clock
+}
+
+func newDAO(t *testing.T, cacheTTL time.Duration) (*DAO, *fakeStorage) {
+	storage := &fakeStorage{
+		objects:  map[string][]byte{},
+		etags:    map[string]string{},
+		modified: map[string]time.Time{},
+		clock:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	server := httptest.NewServer(storage)
+	t.Cleanup(server.Close)
+	endpoint, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	d := &DAO{
+		Client: &Client{
+			Endpoint:        endpoint,
+			Bucket:          "bucket",
+			Region:          "us-east-1",
+			AccessKeyID:     "access",
+			SecretAccessKey: "secret",
+			ForcePathStyle:  true,
+		},
+		Prefix:        "perses",
+		CacheTTL:      cacheTTL,
+		CaseSensitive: true,
+	}
+	require.NoError(t, d.Init())
+	return d, storage
+}
+
+func newDashboard(project string, name string) *modelV1.Dashboard {
+	return &modelV1.Dashboard{
+		Kind: modelV1.KindDashboard,
+		Metadata: modelV1.ProjectMetadata{
+			Metadata:               modelV1.Metadata{Name: name},
+			ProjectMetadataWrapper: modelV1.ProjectMetadataWrapper{Project: project},
+		},
+		Spec: modelV1.DashboardSpec{Duration: common.Duration(time.Hour)},
+	}
+}
+
+func TestDAO_CreateGetDelete(t *testing.T) {
+	d, storage := newDAO(t, 0)
+	dash := newDashboard("perses", "demo")
+	require.NoError(t, d.Create(dash))
+	assert.Contains(t, storage.objects, "perses/dashboards/perses/demo.json")
+	assert.True(t, databaseModel.IsKeyConflict(d.Create(newDashboard("perses", "demo"))))
+
+	result := &modelV1.Dashboard{}
+	require.NoError(t, d.Get(modelV1.KindDashboard, &dash.Metadata, result))
+	assert.Equal(t, "demo", result.Metadata.Name)
+
+	require.NoError(t, d.Delete(modelV1.KindDashboard, &dash.Metadata))
+	assert.True(t, databaseModel.IsKeyNotFound(d.Get(modelV1.KindDashboard, &dash.Metadata, result)))
+	assert.True(t, databaseModel.IsKeyNotFound(d.Delete(modelV1.KindDashboard, &dash.Metadata)))
+}
+
+// update returns a copy of the dashboard updated from the version stored, like the service does.
+func update(t *testing.T, d *DAO, dash *modelV1.Dashboard) *modelV1.Dashboard {
+	previous := &modelV1.Dashboard{}
+	require.NoError(t, d.Get(modelV1.KindDashboard, &dash.Metadata, previous))
+	result := newDashboard(dash.Metadata.Project, dash.Metadata.Name)
+	result.Metadata.Update(previous.Metadata)
+	return result
+}
+
+func TestDAO_UpsertConflict(t *testing.T) {
+	for _, cacheTTL := range []time.Duration{0, time.Hour} {
+		t.Run(cacheTTL.String(), func(t *testing.T) {
+			d, _ := newDAO(t, cacheTTL)
+			dash := newDashboard("perses", "demo")
+			require.NoError(t, d.Create(dash))
+			require.NoError(t, d.Upsert(update(t, d, dash)))
+
+			// Two requests read the same version, the second update is rejected as it is based on an outdated version.
+			first := update(t, d, dash)
+			second := update(t, d, dash)
+			require.NoError(t, d.Upsert(first))
+			assert.True(t, databaseModel.IsKeyConflict(d.Upsert(second)))
+
+			// Another server modifies the dashboard, so the update based on the previous version is rejected.
+			other := &DAO{Client: d.Client, Prefix: d.Prefix, CacheTTL: cacheTTL, CaseSensitive: true}
+			third := update(t, d, dash)
+			require.NoError(t, other.Upsert(update(t, other, dash)))
+			assert.True(t, databaseModel.IsKeyConflict(d.Upsert(third)))
+
+			// Once the dashboard has been read again, it can be updated.
+			assert.NoError(t, d.Upsert(update(t, d, dash)))
+
+			// An entity which is not based on a previous version overwrites the object.
+			assert.NoError(t, d.Upsert(newDashboard("perses", "demo")))
+		})
+	}
+}
+
+func TestDAO_CacheSize(t *testing.T) {
+	previousMax := maxCachedObjects
+	maxCachedObjects = 2
+	t.Cleanup(func() { maxCachedObjects = previousMax })
+
+	d, _ := newDAO(t, 0)
+	require.NoError(t, d.Create(newDashboard("perses", "demo")))
+	assert.Empty(t, d.objects)
+
+	d, _ = newDAO(t, time.Hour)
+	for _, name := range []string{"a", "b", "c"} {
+		require.NoError(t, d.Create(newDashboard("perses", name)))
+	}
+	assert.Len(t, d.objects, 2)
+}
+
+func TestDAO_Query(t *testing.T) {
+	d, _ := newDAO(t, 0)
+	for _, dash := range []*modelV1.Dashboard{
+		newDashboard("perses", "demo"),
+		newDashboard("perses", "node"),
+		newDashboard("other", "demo"),
+	} {
+		require.NoError(t, d.Create(dash))
+	}
+
+	var result []*modelV1.Dashboard
+	require.NoError(t, d.Query(&dashboard.Query{Project: "perses"}, &result))
+	assert.Len(t, result, 2)
+
+	result = nil
+	require.NoError(t, d.Query(&dashboard.Query{NamePrefix: "de"}, &result))
+	assert.Len(t, result, 2)
+
+	result = nil
+	require.NoError(t, d.Query(&dashboard.Query{Project: "unknown"}, &result))
+	assert.NotNil(t, result)
+	assert.Empty(t, result)
+
+	require.NoError(t, d.DeleteByQuery(&dashboard.Query{Project: "perses"}))
+	raws, err := d.RawQuery(&dashboard.Query{})
+	require.NoError(t, err)
+	assert.Len(t, raws, 1)
+}
+
+func TestDAO_Cache(t *testing.T) {
+	d, storage := newDAO(t, time.Hour)
+	dash := newDashboard("perses", "demo")
+	require.NoError(t, d.Create(dash))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, d.Get(modelV1.KindDashboard, &dash.Metadata, &modelV1.Dashboard{}))
+	}
+	assert.Equal(t, 0, storage.gets)
+
+	// once expired, the object is read again only if it changed
+	d.CacheTTL = time.Nanosecond
+	require.NoError(t, d.Get(modelV1.KindDashboard, &dash.Metadata, &modelV1.Dashboard{}))
+	assert.Equal(t, 0, storage.gets)
+	storage.update("perses/dashboards/perses/demo.json")
+	require.NoError(t, d.Get(modelV1.KindDashboard, &dash.Metadata, &modelV1.Dashboard{}))
+	assert.Equal(t, 1, storage.gets)
+}
+
+func TestDAO_RawMetadataQuery(t *testing.T) {
+	d, _ := newDAO(t, 0)
+	require.NoError(t, d.Create(newDashboard("perses", "demo")))
+	raws, err := d.RawMetadataQuery(&dashboard.Query{Project: "perses"}, modelV1.KindDashboard)
+	require.NoError(t, err)
+	require.Len(t, raws, 1)
+	assert.Equal(t, "Dashboard", gjson.GetBytes(raws[0], "kind").String())
+	assert.Equal(t, "demo", gjson.GetBytes(raws[0], "metadata.name").String())
+	assert.Equal(t, "perses", gjson.GetBytes(raws[0], "metadata.project").String())
+	assert.JSONEq(t, "{}", gjson.GetBytes(raws[0], "spec").Raw)
+}
+
+func TestDAO_GetLatestUpdateTime(t *testing.T) {
+	d, _ := newDAO(t, 0)
+	kinds := []modelV1.Kind{modelV1.KindRole, modelV1.KindGlobalRole}
+	latest, err := d.GetLatestUpdateTime(kinds)
+	require.NoError(t, err)
+	assert.Nil(t, latest)
+
+	require.NoError(t, d.Create(newDashboard("perses", "demo")))
+	roleEntity := &modelV1.Role{Kind: modelV1.KindRole, Metadata: *modelV1.NewProjectMetadata("perses", "editor")}
+	require.NoError(t, d.Create(roleEntity))
+	latest, err = d.GetLatestUpdateTime(kinds)
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.Equal(t, "2025-01-01 00:02:00", *latest)
+
+	// The deletion of a role is an update of the roles.
+	require.NoError(t, d.Delete(modelV1.KindRole, &roleEntity.Metadata))
+	latest, err = d.GetLatestUpdateTime(kinds)
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.Equal(t, "2025-01-01 00:03:00", *latest)
+
+	// The deletion marker is not a resource.
+	var roles []*modelV1.Role
+	require.NoError(t, d.Query(&role.Query{}, &roles))
+	assert.Empty(t, roles)
+}
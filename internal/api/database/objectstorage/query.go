@@ -0,0 +1,123 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databaseobjectstorage
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/datasource"
+	"github.com/perses/perses/internal/api/interface/v1/ephemeraldashboard"
+	"github.com/perses/perses/internal/api/interface/v1/folder"
+	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
+	"github.com/perses/perses/internal/api/interface/v1/globalrole"
+	"github.com/perses/perses/internal/api/interface/v1/globalrolebinding"
+	"github.com/perses/perses/internal/api/interface/v1/globalsecret"
+	"github.com/perses/perses/internal/api/interface/v1/globalvariable"
+	"github.com/perses/perses/internal/api/interface/v1/librarypanel"
+	"github.com/perses/perses/internal/api/interface/v1/project"
+	"github.com/perses/perses/internal/api/interface/v1/role"
+	"github.com/perses/perses/internal/api/interface/v1/rolebinding"
+	"github.com/perses/perses/internal/api/interface/v1/secret"
+	"github.com/perses/perses/internal/api/interface/v1/snapshot"
+	"github.com/perses/perses/internal/api/interface/v1/user"
+	"github.com/perses/perses/internal/api/interface/v1/variable"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+func generateProjectResourceQuery(kind v1.Kind, project string) string {
+	if len(project) == 0 {
+		// It's used when we query a list of object. It can happen that the project is empty.
+		return v1.PluralKindMap[kind]
+	}
+	return path.Join(v1.PluralKindMap[kind], project)
+}
+
+func generateResourceQuery(kind v1.Kind) string {
+	return v1.PluralKindMap[kind]
+}
+
+// buildQuery returns the folder in which the objects matching the query are stored (without the prefix of the DAO),
+// and the prefix of their name.
+func (d *DAO) buildQuery(query databaseModel.Query) (keyFolder string, prefix string, err error) {
+	switch qt := query.(type) {
+	case *audit.Query:
+		keyFolder = generateResourceQuery(v1.KindAuditEvent)
+	case *dashboard.Query:
+		keyFolder = generateProjectResourceQuery(v1.KindDashboard, qt.Project)
+		prefix = qt.NamePrefix
+	case *dashboard.HistoryQuery:
+		keyFolder = generateProjectResourceQuery(v1.KindDashboardHistory, qt.Project)
+		prefix = qt.NamePrefix
+	case *datasource.Query:
+		keyFolder = generateProjectResourceQuery(v1.KindDatasource, qt.Project)
+		prefix = qt.NamePrefix
+	case *ephemeraldashboard.Query:
+		keyFolder = generateProjectResourceQuery(v1.KindEphemeralDashboard, qt.Project)
+		prefix = qt.NamePrefix
+	case *folder.Query:
+		keyFolder = generateProjectResourceQuery(v1.KindFolder, qt.Project)
+		prefix = qt.NamePrefix
+	case *globaldatasource.Query:
+		keyFolder = generateResourceQuery(v1.KindGlobalDatasource)
+		prefix = qt.NamePrefix
+	case *globalrole.Query:
+		keyFolder = generateResourceQuery(v1.KindGlobalRole)
+		prefix = qt.NamePrefix
+	case *globalrolebinding.Query:
+		keyFolder = generateResourceQuery(v1.KindGlobalRoleBinding)
+		prefix = qt.NamePrefix
+	case *globalsecret.Query:
+		keyFolder = generateResourceQuery(v1.KindGlobalSecret)
+		prefix = qt.NamePrefix
+	case *globalvariable.Query:
+		keyFolder = generateResourceQuery(v1.KindGlobalVariable)
+		prefix = qt.NamePrefix
+	case *librarypanel.Query:
+		keyFolder = generateProjectResourceQuery(v1.KindLibraryPanel, qt.Project)
+		prefix = qt.NamePrefix
+	case *project.Query:
+		keyFolder = generateResourceQuery(v1.KindProject)
+		prefix = qt.NamePrefix
+	case *role.Query:
+		keyFolder = generateProjectResourceQuery(v1.KindRole, qt.Project)
+		prefix = qt.NamePrefix
+	case *rolebinding.Query:
+		keyFolder = generateProjectResourceQuery(v1.KindRoleBinding, qt.Project)
+		prefix = qt.NamePrefix
+	case *secret.Query:
+		keyFolder = generateProjectResourceQuery(v1.KindSecret, qt.Project)
+		prefix = qt.NamePrefix
+	case *snapshot.Query:
+		keyFolder = generateProjectResourceQuery(v1.KindSnapshot, qt.Project)
+		prefix = qt.NamePrefix
+	case *user.Query:
+		keyFolder = generateResourceQuery(v1.KindUser)
+		prefix = qt.NamePrefix
+	case *variable.Query:
+		keyFolder = generateProjectResourceQuery(v1.KindVariable, qt.Project)
+		prefix = qt.NamePrefix
+	default:
+		return "", "", fmt.Errorf("this type of query '%T' is not managed", qt)
+	}
+	if !d.CaseSensitive {
+		keyFolder = strings.ToLower(keyFolder)
+		prefix = strings.ToLower(prefix)
+	}
+	return keyFolder, prefix, nil
+}
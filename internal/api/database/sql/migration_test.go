@@ -0,0 +1,47 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasesql
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresMigrationsVersions(t *testing.T) {
+	for i, m := range postgresMigrations {
+		assert.Equal(t, i+1, m.version)
+	}
+}
+
+func TestPostgresMigrationsCreateEveryTable(t *testing.T) {
+	var statements []string
+	for _, m := range postgresMigrations {
+		statements = append(statements, m.statements...)
+	}
+	created := func(prefix string) bool {
+		for _, statement := range statements {
+			if strings.HasPrefix(statement, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, table := range append(resourceTables, projectResourceTables...) {
+		assert.Truef(t, created(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %%[1]s.%q `, table)), "no migration creates the table %q", table)
+		assert.Truef(t, created(fmt.Sprintf(`CREATE TRIGGER "perses_touch_%s" `, table)), "no migration tracks the updates of the table %q", table)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasesql
+
+import (
+	"fmt"
+
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	tableSchemaMigration = "schema_migration"
+	tableTableUpdate     = "table_update"
+	// migrationLockID is the key of the advisory lock taken while migrating the schema, so several Perses instances
+	// starting at the same time don't apply the same migrations concurrently.
+	migrationLockID = 7_023_154_391
+)
+
+// migration is a change of the schema of the PostgreSQL database. The migrations are applied in order, only once. An
+// applied migration must never be modified: a new one must be appended instead. In particular, a new table must be
+// created by a new migration and not added to an existing one.
+//
+// The statements are frozen: they don't depend on the current list of tables. They are format strings where %[1]s is
+// replaced by the quoted name of the schema.
+type migration struct {
+	version     int
+	description string
+	statements  []string
+}
+
+var postgresMigrations = []migration{
+	{
+		version:     1,
+		description: "create the resource tables",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS %[1]s."auditevent" (id VARCHAR(128) NOT NULL PRIMARY KEY, name VARCHAR(128) NOT NULL, doc JSONB NOT NULL)`,
+			`CREATE TABLE IF NOT EXISTS %[1]s."globaldatasource" (id VARCHAR(128) NOT NULL PRIMARY KEY, name VARCHAR(128) NOT NULL, doc JSONB NOT NULL)`,
+			`CREATE TABLE IF NOT EXISTS %[1]s."globalrole" (id VARCHAR(128) NOT NULL PRIMARY KEY, name VARCHAR(128) NOT NULL, doc JSONB NOT NULL)`,
+			`CREATE TABLE IF NOT EXISTS %[1]s."globalrolebinding" (id VARCHAR(128) NOT NULL PRIMARY KEY, name VARCHAR(128) NOT NULL, doc JSONB NOT NULL)`,
+			`CREATE TABLE IF NOT EXISTS %[1]s."globalsecret" (id VARCHAR(128) NOT NULL PRIMARY KEY, name VARCHAR(128) NOT NULL, doc JSONB NOT NULL)`,
+			`CREATE TABLE IF NOT EXISTS %[1]s."globalvariable" (id VARCHAR(128) NOT NULL PRIMARY KEY, name VARCHAR(128) NOT NULL, doc JSONB NOT NULL)`,
+			`CREATE TABLE IF NOT EXISTS %[1]s."project" (id VARCHAR(128) NOT NULL PRIMARY KEY, name VARCHAR(128) NOT NULL, doc JSONB NOT NULL)`,
+			`CREATE TABLE IF NOT EXISTS %[1]s."user" (id VARCHAR(128) NOT NULL PRIMARY KEY, name VARCHAR(128) NOT NULL, doc JSONB NOT NULL)`,
+			`CREATE TABLE IF NOT EXISTS %[1]s."dashboard" (id VARCHAR(256) NOT NULL PRIMARY KEY, name VARCHAR(128) NOT NULL, project VARCHAR(128) NOT NULL, doc JSONB NOT NULL)`,
+			`CREATE TABLE IF NOT EXISTS %[1]s."dashboardhistory" (id VARCHAR(256) NOT NULL PRIMARY KEY, name VARCHAR(128) NOT NULL, project VARCHAR(128) NOT NULL, doc JSONB NOT NULL)`,
+			`CREATE TABLE IF NOT EXISTS %[1]s."datasource" (id VARCHAR(256) NOT NULL PRIMARY KEY, name VARCHAR(128) NOT NULL, project VARCHAR(128) NOT NULL, doc JSONB NOT NULL)`,
+			`CREATE TABLE IF NOT EXISTS %[1]s."ephemeraldashboard" (id VARCHAR(256) NOT NULL PRIMARY KEY, name VARCHAR(128) NOT NULL, project VARCHAR(128) NOT NULL, doc JSONB NOT NULL)`,
+			`CREATE TABLE IF NOT EXISTS %[1]s."folder" (id VARCHAR(256) NOT NULL PRIMARY KEY, name VARCHAR(128) NOT NULL, project VARCHAR(128) NOT NULL, doc JSONB NOT NULL)`,
+			`CREATE TABLE IF NOT EXISTS %[1]s."librarypanel" (id VARCHAR(256) NOT NULL PRIMARY KEY, name VARCHAR(128) NOT NULL, project VARCHAR(128) NOT NULL, doc JSONB NOT NULL)`,
+			`CREATE TABLE IF NOT EXISTS %[1]s."role" (id VARCHAR(256) NOT NULL PRIMARY KEY, name VARCHAR(128) NOT NULL, project VARCHAR(128) NOT NULL, doc JSONB NOT NULL)`,
+			`CREATE TABLE IF NOT EXISTS %[1]s."rolebinding" (id VARCHAR(256) NOT NULL PRIMARY KEY, name VARCHAR(128) NOT NULL, project VARCHAR(128) NOT NULL, doc JSONB NOT NULL)`,
+			`CREATE TABLE IF NOT EXISTS %[1]s."secret" (id VARCHAR(256) NOT NULL PRIMARY KEY, name VARCHAR(128) NOT NULL, project VARCHAR(128) NOT NULL, doc JSONB NOT NULL)`,
+			`CREATE TABLE IF NOT EXISTS %[1]s."snapshot" (id VARCHAR(256) NOT NULL PRIMARY KEY, name VARCHAR(128) NOT NULL, project VARCHAR(128) NOT NULL, doc JSONB NOT NULL)`,
+			`CREATE TABLE IF NOT EXISTS %[1]s."variable" (id VARCHAR(256) NOT NULL PRIMARY KEY, name VARCHAR(128) NOT NULL, project VARCHAR(128) NOT NULL, doc JSONB NOT NULL)`,
+		},
+	},
+	{
+		version:     2,
+		description: "track the last update time of the tables",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS %[1]s."table_update" (table_name VARCHAR(128) NOT NULL PRIMARY KEY, updated_at TIMESTAMPTZ NOT NULL)`,
+			`CREATE OR REPLACE FUNCTION %[1]s.perses_touch_table() RETURNS trigger AS $$
+BEGIN
+	INSERT INTO %[1]s."table_update" (table_name, updated_at) VALUES (TG_TABLE_NAME, now())
+	ON CONFLICT (table_name) DO UPDATE SET updated_at = EXCLUDED.updated_at;
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql`,
+			`CREATE TRIGGER "perses_touch_auditevent" AFTER INSERT OR UPDATE OR DELETE ON %[1]s."auditevent" FOR EACH STATEMENT EXECUTE FUNCTION %[1]s.perses_touch_table()`,
+			`CREATE TRIGGER "perses_touch_globaldatasource" AFTER INSERT OR UPDATE OR DELETE ON %[1]s."globaldatasource" FOR EACH STATEMENT EXECUTE FUNCTION %[1]s.perses_touch_table()`,
+			`CREATE TRIGGER "perses_touch_globalrole" AFTER INSERT OR UPDATE OR DELETE ON %[1]s."globalrole" FOR EACH STATEMENT EXECUTE FUNCTION %[1]s.perses_touch_table()`,
+			`CREATE TRIGGER "perses_touch_globalrolebinding" AFTER INSERT OR UPDATE OR DELETE ON %[1]s."globalrolebinding" FOR EACH STATEMENT EXECUTE FUNCTION %[1]s.perses_touch_table()`,
+			`CREATE TRIGGER "perses_touch_globalsecret" AFTER INSERT OR UPDATE OR DELETE ON %[1]s."globalsecret" FOR EACH STATEMENT EXECUTE FUNCTION %[1]s.perses_touch_table()`,
+			`CREATE TRIGGER "perses_touch_globalvariable" AFTER INSERT OR UPDATE OR DELETE ON %[1]s."globalvariable" FOR EACH STATEMENT EXECUTE FUNCTION %[1]s.perses_touch_table()`,
+			`CREATE TRIGGER "perses_touch_project" AFTER INSERT OR UPDATE OR DELETE ON %[1]s."project" FOR EACH STATEMENT EXECUTE FUNCTION %[1]s.perses_touch_table()`,
+			`CREATE TRIGGER "perses_touch_user" AFTER INSERT OR UPDATE OR DELETE ON %[1]s."user" FOR EACH STATEMENT EXECUTE FUNCTION %[1]s.perses_touch_table()`,
+			`CREATE TRIGGER "perses_touch_dashboard" AFTER INSERT OR UPDATE OR DELETE ON %[1]s."dashboard" FOR EACH STATEMENT EXECUTE FUNCTION %[1]s.perses_touch_table()`,
+			`CREATE TRIGGER "perses_touch_dashboardhistory" AFTER INSERT OR UPDATE OR DELETE ON %[1]s."dashboardhistory" FOR EACH STATEMENT EXECUTE FUNCTION %[1]s.perses_touch_table()`,
+			`CREATE TRIGGER "perses_touch_datasource" AFTER INSERT OR UPDATE OR DELETE ON %[1]s."datasource" FOR EACH STATEMENT EXECUTE FUNCTION %[1]s.perses_touch_table()`,
+			`CREATE TRIGGER "perses_touch_ephemeraldashboard" AFTER INSERT OR UPDATE OR DELETE ON %[1]s."ephemeraldashboard" FOR EACH STATEMENT EXECUTE FUNCTION %[1]s.perses_touch_table()`,
+			`CREATE TRIGGER "perses_touch_folder" AFTER INSERT OR UPDATE OR DELETE ON %[1]s."folder" FOR EACH STATEMENT EXECUTE FUNCTION %[1]s.perses_touch_table()`,
+			`CREATE TRIGGER "perses_touch_librarypanel" AFTER INSERT OR UPDATE OR DELETE ON %[1]s."librarypanel" FOR EACH STATEMENT EXECUTE FUNCTION %[1]s.perses_touch_table()`,
+			`CREATE TRIGGER "perses_touch_role" AFTER INSERT OR UPDATE OR DELETE ON %[1]s."role" FOR EACH STATEMENT EXECUTE FUNCTION %[1]s.perses_touch_table()`,
+			`CREATE TRIGGER "perses_touch_rolebinding" AFTER INSERT OR UPDATE OR DELETE ON %[1]s."rolebinding" FOR EACH STATEMENT EXECUTE FUNCTION %[1]s.perses_touch_table()`,
+			`CREATE TRIGGER "perses_touch_secret" AFTER INSERT OR UPDATE OR DELETE ON %[1]s."secret" FOR EACH STATEMENT EXECUTE FUNCTION %[1]s.perses_touch_table()`,
+			`CREATE TRIGGER "perses_touch_snapshot" AFTER INSERT OR UPDATE OR DELETE ON %[1]s."snapshot" FOR EACH STATEMENT EXECUTE FUNCTION %[1]s.perses_touch_table()`,
+			`CREATE TRIGGER "perses_touch_variable" AFTER INSERT OR UPDATE OR DELETE ON %[1]s."variable" FOR EACH STATEMENT EXECUTE FUNCTION %[1]s.perses_touch_table()`,
+		},
+	},
+}
+
+// migrate creates the schema if needed and applies the migrations that are not applied yet, in a single transaction.
+func (d *DAO) migrate() error {
+	return d.inTransaction(func(tx queryer) error {
+		if _, err := tx.Exec("SELECT pg_advisory_xact_lock($1)", migrationLockID); err != nil {
+			return err
+		}
+		schema := d.flavor().Quote(d.SchemaName)
+		if _, err := tx.Exec(fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", schema)); err != nil {
+			return err
+		}
+		migrationTable := d.generateCompleteTableName(tableSchemaMigration)
+		if _, err := tx.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (version INTEGER NOT NULL PRIMARY KEY, applied_at TIMESTAMPTZ NOT NULL DEFAULT now())", migrationTable)); err != nil {
+			return err
+		}
+		var currentVersion int
+		if err := tx.QueryRow(fmt.Sprintf("SELECT COALESCE(MAX(version), 0) FROM %s", migrationTable)).Scan(&currentVersion); err != nil {
+			return err
+		}
+		for _, m := range postgresMigrations {
+			if m.version <= currentVersion {
+				continue
+			}
+			logrus.Infof("applying the database migration %d: %s", m.version, m.description)
+			for _, statement := range m.statements {
+				if _, err := tx.Exec(fmt.Sprintf(statement, schema)); err != nil {
+					return fmt.Errorf("migration %d failed: %w", m.version, err)
+				}
+			}
+			if _, err := tx.Exec(fmt.Sprintf("INSERT INTO %s (version) VALUES ($1)", migrationTable), m.version); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// getPostgresLatestUpdateTime returns the last time one of the tables has been modified, as tracked by the triggers
+// created by the migrations. PostgreSQL doesn't record it like the information_schema of MySQL does.
+func (d *DAO) getPostgresLatestUpdateTime(kinds []modelV1.Kind) (*string, error) {
+	tableNames := make([]interface{}, 0, len(kinds))
+	for _, kind := range kinds {
+		tableName, err := getTableName(kind)
+		if err != nil {
+			return nil, err
+		}
+		tableNames = append(tableNames, tableName)
+	}
+	sb := d.flavor().NewSelectBuilder()
+	sb.Select("to_char(MAX(updated_at) AT TIME ZONE 'UTC', 'YYYY-MM-DD HH24:MI:SS')")
+	sb.From(d.generateCompleteTableName(tableTableUpdate))
+	sb.Where(sb.In("table_name", tableNames...))
+	query, args := sb.Build()
+
+	var timestamp *string
+	if err := d.DB.QueryRow(query, args...).Scan(&timestamp); err != nil {
+		return nil, err
+	}
+	return timestamp, nil
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/huandu/go-sqlbuilder"
 	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
 	"github.com/perses/perses/internal/api/interface/v1/ephemeraldashboard"
@@ -29,26 +30,28 @@ import (
 	"github.com/perses/perses/internal/api/interface/v1/globalrolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/globalsecret"
 	"github.com/perses/perses/internal/api/interface/v1/globalvariable"
+	"github.com/perses/perses/internal/api/interface/v1/librarypanel"
 	"github.com/perses/perses/internal/api/interface/v1/project"
 	"github.com/perses/perses/internal/api/interface/v1/role"
 	"github.com/perses/perses/internal/api/interface/v1/rolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/secret"
+	"github.com/perses/perses/internal/api/interface/v1/snapshot"
 	"github.com/perses/perses/internal/api/interface/v1/user"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
 	modelAPI "github.com/perses/perses/pkg/model/api"
 	modelV1 "github.com/perses/perses/pkg/model/api/v1"
 )
 
-func generateProjectResourceInsertQuery(tableName string, id string, rowJSONDoc []byte, metadata *modelV1.ProjectMetadata) (string, []interface{}) {
-	return sqlbuilder.NewInsertBuilder().
+func (d *DAO) generateProjectResourceInsertQuery(tableName string, id string, rowJSONDoc []byte, metadata *modelV1.ProjectMetadata) (string, []interface{}) {
+	return d.flavor().NewInsertBuilder().
 		InsertInto(tableName).
 		Cols(colID, colName, colProject, colDoc).
 		Values(id, metadata.Name, metadata.Project, rowJSONDoc).
 		Build()
 }
 
-func generateResourceInsertQuery(tableName string, id string, rowJSONDoc []byte, metadata *modelV1.Metadata) (string, []interface{}) {
-	return sqlbuilder.NewInsertBuilder().
+func (d *DAO) generateResourceInsertQuery(tableName string, id string, rowJSONDoc []byte, metadata *modelV1.Metadata) (string, []interface{}) {
+	return d.flavor().NewInsertBuilder().
 		InsertInto(tableName).
 		Cols(colID, colName, colDoc).
 		Values(id, metadata.Name, rowJSONDoc).
@@ -68,9 +71,9 @@ func (d *DAO) generateInsertQuery(entity modelAPI.Entity) (string, []interface{}
 	var args []interface{}
 	switch m := entity.GetMetadata().(type) {
 	case *modelV1.ProjectMetadata:
-		sql, args = generateProjectResourceInsertQuery(tableName, id, rowJSONDoc, m)
+		sql, args = d.generateProjectResourceInsertQuery(tableName, id, rowJSONDoc, m)
 	case *modelV1.Metadata:
-		sql, args = generateResourceInsertQuery(tableName, id, rowJSONDoc, m)
+		sql, args = d.generateResourceInsertQuery(tableName, id, rowJSONDoc, m)
 	}
 	return sql, args, nil
 }
@@ -84,7 +87,7 @@ func (d *DAO) generateUpdateQuery(entity modelAPI.Entity) (string, []interface{}
 	if unmarshalErr != nil {
 		return "", nil, unmarshalErr
 	}
-	builder := sqlbuilder.NewUpdateBuilder().Update(tableName)
+	builder := d.flavor().NewUpdateBuilder().Update(tableName)
 	builder.Where(builder.Equal(colID, id))
 	builder.Set(builder.Assign(colDoc, rowJSONDoc))
 	sql, args := builder.Build()
@@ -98,7 +101,7 @@ func (d *DAO) generateSelectQuery(tableName string, project string, name string)
 		p = strings.ToLower(p)
 		n = strings.ToLower(n)
 	}
-	queryBuilder := sqlbuilder.NewSelectBuilder().
+	queryBuilder := d.flavor().NewSelectBuilder().
 		Select(colDoc).
 		From(tableName)
 	if len(n) > 0 {
@@ -110,12 +113,66 @@ func (d *DAO) generateSelectQuery(tableName string, project string, name string)
 	return queryBuilder.Build()
 }
 
+// generateAuditSelectQuery returns the events of the audit log matching the query, from the most recent to the oldest.
+// The name of an event is its ID, which starts with its timestamp.
+func (d *DAO) generateAuditSelectQuery(q *audit.Query) (string, []interface{}) {
+	queryBuilder := d.flavor().NewSelectBuilder().
+		Select(colDoc).
+		From(d.generateCompleteTableName(tableAuditEvent))
+	queryBuilder.Where(d.auditConditions(&queryBuilder.Cond, q)...)
+	queryBuilder.OrderBy(colName).Desc()
+	if q.Limit > 0 {
+		queryBuilder.Limit(q.Limit)
+	}
+	return queryBuilder.Build()
+}
+
+func (d *DAO) generateAuditDeleteQuery(q *audit.Query) (string, []interface{}) {
+	queryBuilder := d.flavor().NewDeleteBuilder().
+		DeleteFrom(d.generateCompleteTableName(tableAuditEvent))
+	queryBuilder.Where(d.auditConditions(&queryBuilder.Cond, q)...)
+	return queryBuilder.Build()
+}
+
+func (d *DAO) auditConditions(cond *sqlbuilder.Cond, q *audit.Query) []string {
+	var conditions []string
+	from, to := q.IDRange()
+	if len(from) > 0 {
+		conditions = append(conditions, cond.GreaterEqualThan(colName, from))
+	}
+	if len(to) > 0 {
+		conditions = append(conditions, cond.LessThan(colName, to))
+	}
+	if len(q.Project) > 0 {
+		conditions = append(conditions, cond.Equal(d.jsonSpecField("project"), q.Project))
+	}
+	if len(q.User) > 0 {
+		conditions = append(conditions, cond.Equal(d.jsonSpecField("user"), q.User))
+	}
+	if len(q.Kind) > 0 {
+		conditions = append(conditions, cond.Equal(fmt.Sprintf("LOWER(%s)", d.jsonSpecField("kind")), strings.ToLower(q.Kind)))
+	}
+	return conditions
+}
+
+// jsonSpecField returns the expression extracting a text field of the spec of the JSON documents.
+func (d *DAO) jsonSpecField(field string) string {
+	if d.isPostgres() {
+		return fmt.Sprintf("%s->'spec'->>'%s'", colDoc, field)
+	}
+	return fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(%s, '$.spec.%s'))", colDoc, field)
+}
+
 func (d *DAO) buildQuery(query databaseModel.Query) (string, []interface{}, error) {
 	var sqlQuery string
 	var args []interface{}
 	switch qt := query.(type) {
+	case *audit.Query:
+		sqlQuery, args = d.generateAuditSelectQuery(qt)
 	case *dashboard.Query:
 		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableDashboard), qt.Project, qt.NamePrefix)
+	case *dashboard.HistoryQuery:
+		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableDashboardHistory), qt.Project, qt.NamePrefix)
 	case *datasource.Query:
 		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableDatasource), qt.Project, qt.NamePrefix)
 	case *ephemeraldashboard.Query:
@@ -132,6 +189,8 @@ func (d *DAO) buildQuery(query databaseModel.Query) (string, []interface{}, erro
 		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableGlobalSecret), "", qt.NamePrefix)
 	case *globalvariable.Query:
 		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableGlobalVariable), "", qt.NamePrefix)
+	case *librarypanel.Query:
+		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableLibraryPanel), qt.Project, qt.NamePrefix)
 	case *project.Query:
 		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableProject), "", qt.NamePrefix)
 	case *role.Query:
@@ -140,6 +199,8 @@ func (d *DAO) buildQuery(query databaseModel.Query) (string, []interface{}, erro
 		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableRoleBinding), qt.Project, qt.NamePrefix)
 	case *secret.Query:
 		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableSecret), qt.Project, qt.NamePrefix)
+	case *snapshot.Query:
+		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableSnapshot), qt.Project, qt.NamePrefix)
 	case *user.Query:
 		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableUser), "", qt.NamePrefix)
 	case *variable.Query:
@@ -158,7 +219,7 @@ func (d *DAO) generateDeleteQuery(tableName string, project string, name string)
 		n = strings.ToLower(n)
 	}
 
-	queryBuilder := sqlbuilder.NewDeleteBuilder().
+	queryBuilder := d.flavor().NewDeleteBuilder().
 		DeleteFrom(tableName)
 	if len(n) > 0 {
 		queryBuilder.Where(queryBuilder.Like(colName, fmt.Sprintf("%s%%", n)))
@@ -173,8 +234,12 @@ func (d *DAO) buildDeleteQuery(query databaseModel.Query) (string, []interface{}
 	var sqlQuery string
 	var args []interface{}
 	switch qt := query.(type) {
+	case *audit.Query:
+		sqlQuery, args = d.generateAuditDeleteQuery(qt)
 	case *dashboard.Query:
 		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableDashboard), qt.Project, qt.NamePrefix)
+	case *dashboard.HistoryQuery:
+		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableDashboardHistory), qt.Project, qt.NamePrefix)
 	case *datasource.Query:
 		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableDatasource), qt.Project, qt.NamePrefix)
 	case *ephemeraldashboard.Query:
@@ -191,6 +256,8 @@ func (d *DAO) buildDeleteQuery(query databaseModel.Query) (string, []interface{}
 		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableGlobalSecret), "", qt.NamePrefix)
 	case *globalvariable.Query:
 		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableGlobalVariable), "", qt.NamePrefix)
+	case *librarypanel.Query:
+		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableLibraryPanel), qt.Project, qt.NamePrefix)
 	case *project.Query:
 		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableProject), "", qt.NamePrefix)
 	case *role.Query:
@@ -199,6 +266,8 @@ func (d *DAO) buildDeleteQuery(query databaseModel.Query) (string, []interface{}
 		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableRoleBinding), qt.Project, qt.NamePrefix)
 	case *secret.Query:
 		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableSecret), qt.Project, qt.NamePrefix)
+	case *snapshot.Query:
+		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableSnapshot), qt.Project, qt.NamePrefix)
 	case *user.Query:
 		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableUser), "", qt.NamePrefix)
 	case *variable.Query:
@@ -14,8 +14,12 @@
 package databasesql
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -65,3 +69,30 @@ func TestGenerateProjectResourceSelectQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestGeneratePostgresSelectQuery(t *testing.T) {
+	d := &DAO{SchemaName: "public", Flavor: sqlbuilder.PostgreSQL}
+	sqlQuery, args := d.generateSelectQuery(d.generateCompleteTableName(tableUser), "", "Foo")
+	assert.Equal(t, `SELECT doc FROM "public"."user" WHERE name LIKE $1`, sqlQuery)
+	assert.Equal(t, []interface{}{"foo%"}, args)
+}
+
+func TestGenerateAuditQuery(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	q := &audit.Query{Project: "perses", User: "admin", Kind: "Dashboard", Start: start, Limit: 10}
+	from := fmt.Sprintf("%019d", start.UnixNano())
+
+	d := &DAO{SchemaName: "perses"}
+	sqlQuery, args := d.generateAuditSelectQuery(q)
+	assert.Equal(t, "SELECT doc FROM perses.auditevent WHERE name >= ? AND JSON_UNQUOTE(JSON_EXTRACT(doc, '$.spec.project')) = ? AND JSON_UNQUOTE(JSON_EXTRACT(doc, '$.spec.user')) = ? AND LOWER(JSON_UNQUOTE(JSON_EXTRACT(doc, '$.spec.kind'))) = ? ORDER BY name DESC LIMIT ?", sqlQuery)
+	assert.Equal(t, []interface{}{from, "perses", "admin", "dashboard", 10}, args)
+
+	d = &DAO{SchemaName: "public", Flavor: sqlbuilder.PostgreSQL}
+	sqlQuery, args = d.generateAuditSelectQuery(&audit.Query{Kind: "Dashboard", Cursor: "1"})
+	assert.Equal(t, `SELECT doc FROM "public"."auditevent" WHERE name < $1 AND LOWER(doc->'spec'->>'kind') = $2 ORDER BY name DESC`, sqlQuery)
+	assert.Equal(t, []interface{}{"1", "dashboard"}, args)
+
+	sqlQuery, args = d.generateAuditDeleteQuery(&audit.Query{End: start.Add(-time.Nanosecond)})
+	assert.Equal(t, `DELETE FROM "public"."auditevent" WHERE name < $1`, sqlQuery)
+	assert.Equal(t, []interface{}{from}, args)
+}
@@ -27,7 +27,9 @@ import (
 )
 
 const (
+	tableAuditEvent         = "auditevent"
 	tableDashboard          = "dashboard"
+	tableDashboardHistory   = "dashboardhistory"
 	tableDatasource         = "datasource"
 	tableEphemeralDashboard = "ephemeraldashboard"
 	tableFolder             = "folder"
@@ -36,10 +38,12 @@ const (
 	tableGlobalRoleBinding  = "globalrolebinding"
 	tableGlobalSecret       = "globalsecret"
 	tableGlobalVariable     = "globalvariable"
+	tableLibraryPanel       = "librarypanel"
 	tableProject            = "project"
 	tableRole               = "role"
 	tableRoleBinding        = "rolebinding"
 	tableSecret             = "secret"
+	tableSnapshot           = "snapshot"
 	tableUser               = "user"
 	tableVariable           = "variable"
 
@@ -49,10 +53,40 @@ const (
 	colProject = "project"
 )
 
+var (
+	resourceTables = []string{
+		tableAuditEvent,
+		tableGlobalDatasource,
+		tableGlobalRole,
+		tableGlobalRoleBinding,
+		tableGlobalSecret,
+		tableGlobalVariable,
+		tableProject,
+		tableUser,
+	}
+	projectResourceTables = []string{
+		tableDashboard,
+		tableDashboardHistory,
+		tableDatasource,
+		tableEphemeralDashboard,
+		tableFolder,
+		tableLibraryPanel,
+		tableRole,
+		tableRoleBinding,
+		tableSecret,
+		tableSnapshot,
+		tableVariable,
+	}
+)
+
 func getTableName(kind modelV1.Kind) (string, error) {
 	switch kind {
+	case modelV1.KindAuditEvent:
+		return tableAuditEvent, nil
 	case modelV1.KindDashboard:
 		return tableDashboard, nil
+	case modelV1.KindDashboardHistory:
+		return tableDashboardHistory, nil
 	case modelV1.KindDatasource:
 		return tableDatasource, nil
 	case modelV1.KindEphemeralDashboard:
@@ -69,6 +103,8 @@ func getTableName(kind modelV1.Kind) (string, error) {
 		return tableGlobalSecret, nil
 	case modelV1.KindGlobalVariable:
 		return tableGlobalVariable, nil
+	case modelV1.KindLibraryPanel:
+		return tableLibraryPanel, nil
 	case modelV1.KindProject:
 		return tableProject, nil
 	case modelV1.KindRole:
@@ -77,6 +113,8 @@ func getTableName(kind modelV1.Kind) (string, error) {
 		return tableRoleBinding, nil
 	case modelV1.KindSecret:
 		return tableSecret, nil
+	case modelV1.KindSnapshot:
+		return tableSnapshot, nil
 	case modelV1.KindUser:
 		return tableUser, nil
 	case modelV1.KindVariable:
@@ -96,34 +134,30 @@ func generateID(metadata modelAPI.Metadata) (string, error) {
 	return "", fmt.Errorf("metadata %T not managed", metadata)
 }
 
+// queryer is implemented by both *sql.DB and *sql.Tx.
+type queryer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
 type DAO struct {
 	databaseModel.DAO
-	DB            *sql.DB
+	DB *sql.DB
+	// SchemaName is the name of the database for MySQL, and the name of the schema for PostgreSQL.
 	SchemaName    string
 	CaseSensitive bool
+	// Flavor is the SQL dialect spoken by the database. MySQL is used when it is not set.
+	Flavor sqlbuilder.Flavor
+	// tx is the transaction in which every query is run, when the DAO has been created by InTransaction.
+	tx *sql.Tx
 }
 
 func (d *DAO) Init() error {
-	tables := []string{
-		d.createResourceTable(tableGlobalDatasource),
-		d.createResourceTable(tableGlobalRole),
-		d.createResourceTable(tableGlobalRoleBinding),
-		d.createResourceTable(tableGlobalSecret),
-		d.createResourceTable(tableGlobalVariable),
-		d.createResourceTable(tableProject),
-		d.createResourceTable(tableUser),
-
-		d.createProjectResourceTable(tableDashboard),
-		d.createProjectResourceTable(tableDatasource),
-		d.createProjectResourceTable(tableEphemeralDashboard),
-		d.createProjectResourceTable(tableFolder),
-		d.createProjectResourceTable(tableRole),
-		d.createProjectResourceTable(tableRoleBinding),
-		d.createProjectResourceTable(tableSecret),
-		d.createProjectResourceTable(tableVariable),
-	}
-
-	for _, table := range tables {
+	if d.isPostgres() {
+		return d.migrate()
+	}
+	for _, table := range d.tables() {
 		if err := d.createTable(table); err != nil {
 			return err
 		}
@@ -131,27 +165,57 @@ func (d *DAO) Init() error {
 	return nil
 }
 
+func (d *DAO) flavor() sqlbuilder.Flavor {
+	if d.Flavor == sqlbuilder.PostgreSQL {
+		return d.Flavor
+	}
+	return sqlbuilder.MySQL
+}
+
+func (d *DAO) isPostgres() bool {
+	return d.flavor() == sqlbuilder.PostgreSQL
+}
+
+// tables returns the queries creating the tables of every kind stored in the database.
+func (d *DAO) tables() []string {
+	tables := make([]string, 0, len(resourceTables)+len(projectResourceTables))
+	for _, table := range resourceTables {
+		tables = append(tables, d.createResourceTable(table))
+	}
+	for _, table := range projectResourceTables {
+		tables = append(tables, d.createProjectResourceTable(table))
+	}
+	return tables
+}
+
 func (d *DAO) IsCaseSensitive() bool {
 	return d.CaseSensitive
 }
 
 func (d *DAO) createResourceTable(tableName string) string {
-	return sqlbuilder.CreateTable(d.generateCompleteTableName(tableName)).IfNotExists().
+	return d.flavor().NewCreateTableBuilder().CreateTable(d.generateCompleteTableName(tableName)).IfNotExists().
 		Define(colID, "VARCHAR(128)", "NOT NULL", "PRIMARY KEY").
 		Define(colName, "VARCHAR(128)", "NOT NULL").
-		Define(colDoc, "JSON", "NOT NULL").
+		Define(colDoc, d.jsonType(), "NOT NULL").
 		String()
 }
 
 func (d *DAO) createProjectResourceTable(tableName string) string {
-	return sqlbuilder.CreateTable(d.generateCompleteTableName(tableName)).IfNotExists().
+	return d.flavor().NewCreateTableBuilder().CreateTable(d.generateCompleteTableName(tableName)).IfNotExists().
 		Define(colID, "VARCHAR(256)", "NOT NULL", "PRIMARY KEY").
 		Define(colName, "VARCHAR(128)", "NOT NULL").
 		Define(colProject, "VARCHAR(128)", "NOT NULL").
-		Define(colDoc, "JSON", "NOT NULL").
+		Define(colDoc, d.jsonType(), "NOT NULL").
 		String()
 }
 
+func (d *DAO) jsonType() string {
+	if d.isPostgres() {
+		return "JSONB"
+	}
+	return "JSON"
+}
+
 func (d *DAO) createTable(query string) error {
 	r, e := d.DB.Query(query)
 	if e != nil {
@@ -162,6 +226,9 @@ func (d *DAO) createTable(query string) error {
 
 // GetLatestUpdateTime queries the database to retrieve the latest update time for the specified table names.
 func (d *DAO) GetLatestUpdateTime(kinds []modelV1.Kind) (*string, error) {
+	if d.isPostgres() {
+		return d.getPostgresLatestUpdateTime(kinds)
+	}
 	sb := sqlbuilder.Select("UPDATE_TIME")
 	sb.From("information_schema.tables")
 	var whereConditions []string
@@ -202,53 +269,50 @@ func (d *DAO) Create(entity modelAPI.Entity) error {
 	// Also, it will avoid an issue with the permission when activated.
 	// See https://github.com/perses/perses/issues/1721 for more details.
 	entity.GetMetadata().Flatten(d.CaseSensitive)
-	id, isExist, err := d.exists(modelV1.Kind(entity.GetKind()), entity.GetMetadata())
-	if err != nil {
-		return err
-	}
-	if isExist {
-		return &databaseModel.Error{Key: id, Code: databaseModel.ErrorCodeConflict}
-	}
-
-	sqlQuery, args, queryErr := d.generateInsertQuery(entity)
-	if queryErr != nil {
-		return queryErr
-	}
+	return d.inTransaction(func(tx queryer) error {
+		id, isExist, err := d.exists(tx, modelV1.Kind(entity.GetKind()), entity.GetMetadata())
+		if err != nil {
+			return err
+		}
+		if isExist {
+			return &databaseModel.Error{Key: id, Code: databaseModel.ErrorCodeConflict}
+		}
 
-	createQuery, createErr := d.DB.Query(sqlQuery, args...)
-	if createErr != nil {
+		sqlQuery, args, queryErr := d.generateInsertQuery(entity)
+		if queryErr != nil {
+			return queryErr
+		}
+		_, createErr := tx.Exec(sqlQuery, args...)
 		return createErr
-	}
-	return createQuery.Close()
+	})
 }
 
 func (d *DAO) Upsert(entity modelAPI.Entity) error {
 	entity.GetMetadata().Flatten(d.CaseSensitive)
-	_, isExist, err := d.exists(modelV1.Kind(entity.GetKind()), entity.GetMetadata())
-	if err != nil {
-		return err
-	}
-	var sqlQuery string
-	var args []interface{}
-	var queryGeneratorErr error
-	if !isExist {
-		sqlQuery, args, queryGeneratorErr = d.generateInsertQuery(entity)
-	} else {
-		sqlQuery, args, queryGeneratorErr = d.generateUpdateQuery(entity)
-	}
-	if queryGeneratorErr != nil {
-		return queryGeneratorErr
-	}
-	upsertQuery, upsertErr := d.DB.Query(sqlQuery, args...)
-	if upsertErr != nil {
+	return d.inTransaction(func(tx queryer) error {
+		_, isExist, err := d.exists(tx, modelV1.Kind(entity.GetKind()), entity.GetMetadata())
+		if err != nil {
+			return err
+		}
+		var sqlQuery string
+		var args []interface{}
+		var queryGeneratorErr error
+		if !isExist {
+			sqlQuery, args, queryGeneratorErr = d.generateInsertQuery(entity)
+		} else {
+			sqlQuery, args, queryGeneratorErr = d.generateUpdateQuery(entity)
+		}
+		if queryGeneratorErr != nil {
+			return queryGeneratorErr
+		}
+		_, upsertErr := tx.Exec(sqlQuery, args...)
 		return upsertErr
-	}
-	return upsertQuery.Close()
+	})
 }
 
 func (d *DAO) Get(kind modelV1.Kind, metadata modelAPI.Metadata, entity modelAPI.Entity) error {
 	metadata.Flatten(d.CaseSensitive)
-	id, query, queryErr := d.get(kind, metadata)
+	id, query, queryErr := d.get(d.conn(), kind, metadata)
 	if queryErr != nil {
 		return queryErr
 	}
@@ -268,7 +332,7 @@ func (d *DAO) RawQuery(query databaseModel.Query) ([]json.RawMessage, error) {
 	if buildQueryErr != nil {
 		return nil, fmt.Errorf("unable to build the query: %s", buildQueryErr)
 	}
-	rows, runQueryErr := d.DB.Query(q, args...)
+	rows, runQueryErr := d.conn().Query(q, args...)
 	if runQueryErr != nil {
 		return nil, runQueryErr
 	}
@@ -314,7 +378,7 @@ func (d *DAO) Query(query databaseModel.Query, slice interface{}) error {
 	if buildQueryErr != nil {
 		return fmt.Errorf("unable to build the query: %s", buildQueryErr)
 	}
-	rows, runQueryErr := d.DB.Query(q, args...)
+	rows, runQueryErr := d.conn().Query(q, args...)
 	if runQueryErr != nil {
 		return runQueryErr
 	}
@@ -355,28 +419,27 @@ func (d *DAO) Query(query databaseModel.Query, slice interface{}) error {
 }
 
 func (d *DAO) Delete(kind modelV1.Kind, metadata modelAPI.Metadata) error {
-	id, isExist, err := d.exists(kind, metadata)
-	if err != nil {
-		return err
-	}
-	if !isExist {
-		return &databaseModel.Error{Key: id, Code: databaseModel.ErrorCodeNotFound}
-	}
+	return d.inTransaction(func(tx queryer) error {
+		id, isExist, err := d.exists(tx, kind, metadata)
+		if err != nil {
+			return err
+		}
+		if !isExist {
+			return &databaseModel.Error{Key: id, Code: databaseModel.ErrorCodeNotFound}
+		}
 
-	id, tableName, idErr := d.getIDAndTableName(kind, metadata)
-	if idErr != nil {
-		return idErr
-	}
+		id, tableName, idErr := d.getIDAndTableName(kind, metadata)
+		if idErr != nil {
+			return idErr
+		}
 
-	deleteBuilder := sqlbuilder.NewDeleteBuilder().DeleteFrom(tableName)
-	deleteBuilder.Where(deleteBuilder.Equal(colID, id))
-	sqlQuery, args := deleteBuilder.Build()
+		deleteBuilder := d.flavor().NewDeleteBuilder().DeleteFrom(tableName)
+		deleteBuilder.Where(deleteBuilder.Equal(colID, id))
+		sqlQuery, args := deleteBuilder.Build()
 
-	deleteQuery, err := d.DB.Query(sqlQuery, args...)
-	if err != nil {
-		return err
-	}
-	return deleteQuery.Close()
+		_, deleteErr := tx.Exec(sqlQuery, args...)
+		return deleteErr
+	})
 }
 
 func (d *DAO) DeleteByQuery(query databaseModel.Query) error {
@@ -384,7 +447,7 @@ func (d *DAO) DeleteByQuery(query databaseModel.Query) error {
 	if buildQueryErr != nil {
 		return fmt.Errorf("unable to build the query: %s", buildQueryErr)
 	}
-	rows, runQueryErr := d.DB.Query(q, args...)
+	rows, runQueryErr := d.conn().Query(q, args...)
 	if runQueryErr != nil {
 		return runQueryErr
 	}
@@ -412,12 +475,66 @@ func (d *DAO) getIDAndTableName(kind modelV1.Kind, metadata modelAPI.Metadata) (
 }
 
 // generateCompleteTableName concat the tableName and the DBName. This should be used everytime a FROM condition is used.
+// With PostgreSQL, both are quoted as some table names like "user" are reserved keywords.
 func (d *DAO) generateCompleteTableName(tableName string) string {
+	if d.isPostgres() {
+		return fmt.Sprintf("%s.%s", d.flavor().Quote(d.SchemaName), d.flavor().Quote(tableName))
+	}
 	return fmt.Sprintf("%s.%s", d.SchemaName, tableName)
 }
 
-func (d *DAO) exists(kind modelV1.Kind, metadata modelAPI.Metadata) (string, bool, error) {
-	id, query, queryErr := d.get(kind, metadata)
+// InTransaction calls f with a DAO running all its queries in the same transaction, which is committed if f succeeds
+// and rolled back otherwise.
+func (d *DAO) InTransaction(f func(dao databaseModel.DAO) error) error {
+	return d.transaction(func(txDAO *DAO) error {
+		return f(txDAO)
+	})
+}
+
+// inTransaction runs f in a transaction that is committed if f succeeds and rolled back otherwise, so the check of the
+// existence of a resource and its modification are atomic.
+func (d *DAO) inTransaction(f func(tx queryer) error) error {
+	return d.transaction(func(txDAO *DAO) error {
+		return f(txDAO.tx)
+	})
+}
+
+// transaction calls f with a copy of the DAO bound to a new transaction. When the DAO is already bound to a
+// transaction, f joins it, and it is committed or rolled back by the caller who started it.
+func (d *DAO) transaction(f func(txDAO *DAO) error) error {
+	if d.tx != nil {
+		return f(d)
+	}
+	tx, err := d.DB.Begin()
+	if err != nil {
+		return err
+	}
+	txDAO := &DAO{
+		DB:            d.DB,
+		SchemaName:    d.SchemaName,
+		CaseSensitive: d.CaseSensitive,
+		Flavor:        d.Flavor,
+		tx:            tx,
+	}
+	if fErr := f(txDAO); fErr != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logrus.WithError(rollbackErr).Error("unable to rollback the transaction")
+		}
+		return fErr
+	}
+	return tx.Commit()
+}
+
+// conn returns the transaction of the DAO if any, the database otherwise.
+func (d *DAO) conn() queryer {
+	if d.tx != nil {
+		return d.tx
+	}
+	return d.DB
+}
+
+func (d *DAO) exists(q queryer, kind modelV1.Kind, metadata modelAPI.Metadata) (string, bool, error) {
+	id, query, queryErr := d.get(q, kind, metadata)
 	if queryErr != nil {
 		return "", false, queryErr
 	}
@@ -425,18 +542,18 @@ func (d *DAO) exists(kind modelV1.Kind, metadata modelAPI.Metadata) (string, boo
 	return id, query.Next(), nil
 }
 
-func (d *DAO) get(kind modelV1.Kind, metadata modelAPI.Metadata) (string, *sql.Rows, error) {
+func (d *DAO) get(q queryer, kind modelV1.Kind, metadata modelAPI.Metadata) (string, *sql.Rows, error) {
 	id, tableName, idErr := d.getIDAndTableName(kind, metadata)
 	if idErr != nil {
 		return "", nil, idErr
 	}
 
-	queryBuilder := sqlbuilder.NewSelectBuilder().
+	queryBuilder := d.flavor().NewSelectBuilder().
 		Select(colDoc).
 		From(tableName)
 	queryBuilder.Where(queryBuilder.Equal(colID, id))
 	sqlQuery, args := queryBuilder.Build()
 
-	rows, err := d.DB.Query(sqlQuery, args...)
+	rows, err := q.Query(sqlQuery, args...)
 	return id, rows, err
 }
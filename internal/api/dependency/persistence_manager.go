@@ -16,6 +16,7 @@ package dependency
 import (
 	"github.com/perses/perses/internal/api/database"
 	databaseModel "github.com/perses/perses/internal/api/database/model"
+	auditImpl "github.com/perses/perses/internal/api/impl/v1/audit"
 	dashboardImpl "github.com/perses/perses/internal/api/impl/v1/dashboard"
 	datasourceImpl "github.com/perses/perses/internal/api/impl/v1/datasource"
 	ephemeralDashboardImpl "github.com/perses/perses/internal/api/impl/v1/ephemeraldashboard"
@@ -26,12 +27,15 @@ import (
 	globalSecretImpl "github.com/perses/perses/internal/api/impl/v1/globalsecret"
 	globalVariableImpl "github.com/perses/perses/internal/api/impl/v1/globalvariable"
 	healthImpl "github.com/perses/perses/internal/api/impl/v1/health"
+	libraryPanelImpl "github.com/perses/perses/internal/api/impl/v1/librarypanel"
 	projectImpl "github.com/perses/perses/internal/api/impl/v1/project"
 	roleImpl "github.com/perses/perses/internal/api/impl/v1/role"
 	roleBindingImpl "github.com/perses/perses/internal/api/impl/v1/rolebinding"
 	secretImpl "github.com/perses/perses/internal/api/impl/v1/secret"
+	snapshotImpl "github.com/perses/perses/internal/api/impl/v1/snapshot"
 	userImpl "github.com/perses/perses/internal/api/impl/v1/user"
 	variableImpl "github.com/perses/perses/internal/api/impl/v1/variable"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
 	"github.com/perses/perses/internal/api/interface/v1/ephemeraldashboard"
@@ -42,16 +46,19 @@ import (
 	"github.com/perses/perses/internal/api/interface/v1/globalsecret"
 	"github.com/perses/perses/internal/api/interface/v1/globalvariable"
 	"github.com/perses/perses/internal/api/interface/v1/health"
+	"github.com/perses/perses/internal/api/interface/v1/librarypanel"
 	"github.com/perses/perses/internal/api/interface/v1/project"
 	"github.com/perses/perses/internal/api/interface/v1/role"
 	"github.com/perses/perses/internal/api/interface/v1/rolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/secret"
+	"github.com/perses/perses/internal/api/interface/v1/snapshot"
 	"github.com/perses/perses/internal/api/interface/v1/user"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
 	"github.com/perses/perses/pkg/model/api/config"
 )
 
 type PersistenceManager interface {
+	GetAudit() audit.DAO
 	GetDashboard() dashboard.DAO
 	GetDatasource() datasource.DAO
 	GetEphemeralDashboard() ephemeraldashboard.DAO
@@ -62,17 +69,20 @@ type PersistenceManager interface {
 	GetGlobalSecret() globalsecret.DAO
 	GetGlobalVariable() globalvariable.DAO
 	GetHealth() health.DAO
+	GetLibraryPanel() librarypanel.DAO
 	GetPersesDAO() databaseModel.DAO
 	GetProject() project.DAO
 	GetRole() role.DAO
 	GetRoleBinding() rolebinding.DAO
 	GetSecret() secret.DAO
+	GetSnapshot() snapshot.DAO
 	GetUser() user.DAO
 	GetVariable() variable.DAO
 }
 
 type persistence struct {
 	PersistenceManager
+	audit              audit.DAO
 	dashboard          dashboard.DAO
 	datasource         datasource.DAO
 	ephemeralDashboard ephemeraldashboard.DAO
@@ -83,11 +93,13 @@ type persistence struct {
 	globalSecret       globalsecret.DAO
 	globalVariable     globalvariable.DAO
 	health             health.DAO
+	libraryPanel       librarypanel.DAO
 	perses             databaseModel.DAO
 	project            project.DAO
 	role               role.DAO
 	roleBinding        rolebinding.DAO
 	secret             secret.DAO
+	snapshot           snapshot.DAO
 	user               user.DAO
 	variable           variable.DAO
 }
@@ -97,6 +109,7 @@ func NewPersistenceManager(conf config.Database) (PersistenceManager, error) {
 	if err != nil {
 		return nil, err
 	}
+	auditDAO := auditImpl.NewDAO(persesDAO)
 	dashboardDAO := dashboardImpl.NewDAO(persesDAO)
 	datasourceDAO := datasourceImpl.NewDAO(persesDAO)
 	ephemeralDashboardDAO := ephemeralDashboardImpl.NewDAO(persesDAO)
@@ -107,13 +120,16 @@ func NewPersistenceManager(conf config.Database) (PersistenceManager, error) {
 	globalSecretDAO := globalSecretImpl.NewDAO(persesDAO)
 	globalVariableDAO := globalVariableImpl.NewDAO(persesDAO)
 	healthDAO := healthImpl.NewDAO(persesDAO)
+	libraryPanelDAO := libraryPanelImpl.NewDAO(persesDAO)
 	projectDAO := projectImpl.NewDAO(persesDAO)
 	roleDAO := roleImpl.NewDAO(persesDAO)
 	roleBindingDAO := roleBindingImpl.NewDAO(persesDAO)
 	secretDAO := secretImpl.NewDAO(persesDAO)
+	snapshotDAO := snapshotImpl.NewDAO(persesDAO)
 	userDAO := userImpl.NewDAO(persesDAO)
 	variableDAO := variableImpl.NewDAO(persesDAO)
 	return &persistence{
+		audit:              auditDAO,
 		dashboard:          dashboardDAO,
 		datasource:         datasourceDAO,
 		ephemeralDashboard: ephemeralDashboardDAO,
@@ -124,16 +140,22 @@ func NewPersistenceManager(conf config.Database) (PersistenceManager, error) {
 		globalSecret:       globalSecretDAO,
 		globalVariable:     globalVariableDAO,
 		health:             healthDAO,
+		libraryPanel:       libraryPanelDAO,
 		perses:             persesDAO,
 		project:            projectDAO,
 		role:               roleDAO,
 		roleBinding:        roleBindingDAO,
 		secret:             secretDAO,
+		snapshot:           snapshotDAO,
 		user:               userDAO,
 		variable:           variableDAO,
 	}, nil
 }
 
+func (p *persistence) GetAudit() audit.DAO {
+	return p.audit
+}
+
 func (p *persistence) GetDashboard() dashboard.DAO {
 	return p.dashboard
 }
@@ -174,6 +196,10 @@ func (p *persistence) GetHealth() health.DAO {
 	return p.health
 }
 
+func (p *persistence) GetLibraryPanel() librarypanel.DAO {
+	return p.libraryPanel
+}
+
 func (p *persistence) GetPersesDAO() databaseModel.DAO {
 	return p.perses
 }
@@ -194,6 +220,10 @@ func (p *persistence) GetSecret() secret.DAO {
 	return p.secret
 }
 
+func (p *persistence) GetSnapshot() snapshot.DAO {
+	return p.snapshot
+}
+
 func (p *persistence) GetUser() user.DAO {
 	return p.user
 }
@@ -18,6 +18,7 @@ package dependency
 import (
 	"github.com/perses/perses/internal/api/authorization"
 	"github.com/perses/perses/internal/api/crypto"
+	auditImpl "github.com/perses/perses/internal/api/impl/v1/audit"
 	dashboardImpl "github.com/perses/perses/internal/api/impl/v1/dashboard"
 	datasourceImpl "github.com/perses/perses/internal/api/impl/v1/datasource"
 	ephemeralDashboardImpl "github.com/perses/perses/internal/api/impl/v1/ephemeraldashboard"
@@ -28,13 +29,16 @@ import (
 	globalSecretImpl "github.com/perses/perses/internal/api/impl/v1/globalsecret"
 	globalVariableImpl "github.com/perses/perses/internal/api/impl/v1/globalvariable"
 	healthImpl "github.com/perses/perses/internal/api/impl/v1/health"
+	libraryPanelImpl "github.com/perses/perses/internal/api/impl/v1/librarypanel"
 	projectImpl "github.com/perses/perses/internal/api/impl/v1/project"
 	roleImpl "github.com/perses/perses/internal/api/impl/v1/role"
 	roleBindingImpl "github.com/perses/perses/internal/api/impl/v1/rolebinding"
 	secretImpl "github.com/perses/perses/internal/api/impl/v1/secret"
+	snapshotImpl "github.com/perses/perses/internal/api/impl/v1/snapshot"
 	userImpl "github.com/perses/perses/internal/api/impl/v1/user"
 	variableImpl "github.com/perses/perses/internal/api/impl/v1/variable"
 	viewImpl "github.com/perses/perses/internal/api/impl/v1/view"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
 	"github.com/perses/perses/internal/api/interface/v1/ephemeraldashboard"
@@ -45,20 +49,25 @@ import (
 	"github.com/perses/perses/internal/api/interface/v1/globalsecret"
 	"github.com/perses/perses/internal/api/interface/v1/globalvariable"
 	"github.com/perses/perses/internal/api/interface/v1/health"
+	"github.com/perses/perses/internal/api/interface/v1/librarypanel"
 	"github.com/perses/perses/internal/api/interface/v1/project"
 	"github.com/perses/perses/internal/api/interface/v1/role"
 	"github.com/perses/perses/internal/api/interface/v1/rolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/secret"
+	"github.com/perses/perses/internal/api/interface/v1/snapshot"
 	"github.com/perses/perses/internal/api/interface/v1/user"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
 	"github.com/perses/perses/internal/api/interface/v1/view"
 	"github.com/perses/perses/internal/api/plugin"
 	"github.com/perses/perses/internal/api/plugin/migrate"
 	"github.com/perses/perses/internal/api/plugin/schema"
+	"github.com/perses/perses/internal/api/search"
+	"github.com/perses/perses/internal/api/webhook"
 	"github.com/perses/perses/pkg/model/api/config"
 )
 
 type ServiceManager interface {
+	GetAudit() audit.Service
 	GetAuthorization() authorization.Authorization
 	GetCrypto() crypto.Crypto
 	GetDashboard() dashboard.Service
@@ -72,20 +81,25 @@ type ServiceManager interface {
 	GetGlobalVariable() globalvariable.Service
 	GetHealth() health.Service
 	GetJWT() crypto.JWT
+	GetLibraryPanel() librarypanel.Service
 	GetMigration() migrate.Migration
 	GetPlugin() plugin.Plugin
 	GetProject() project.Service
 	GetSchema() schema.Schema
+	GetSearch() search.Search
 	GetRole() role.Service
 	GetRoleBinding() rolebinding.Service
 	GetSecret() secret.Service
+	GetSnapshot() snapshot.Service
 	GetUser() user.Service
 	GetVariable() variable.Service
 	GetView() view.Service
+	GetWebhook() webhook.Webhook
 }
 
 type service struct {
 	ServiceManager
+	audit              audit.Service
 	authorization      authorization.Authorization
 	crypto             crypto.Crypto
 	dashboard          dashboard.Service
@@ -99,16 +113,20 @@ type service struct {
 	globalVariable     globalvariable.Service
 	health             health.Service
 	jwt                crypto.JWT
+	libraryPanel       librarypanel.Service
 	migrate            migrate.Migration
 	plugin             plugin.Plugin
 	project            project.Service
 	schema             schema.Schema
+	search             search.Search
 	role               role.Service
 	roleBinding        rolebinding.Service
 	secret             secret.Service
+	snapshot           snapshot.Service
 	user               user.Service
 	variable           variable.Service
 	view               view.Service
+	webhook            webhook.Webhook
 }
 
 func NewServiceManager(dao PersistenceManager, conf config.Config) (ServiceManager, error) {
@@ -116,32 +134,41 @@ func NewServiceManager(dao PersistenceManager, conf config.Config) (ServiceManag
 	if err != nil {
 		return nil, err
 	}
-	authzService, err := authorization.New(dao.GetUser(), dao.GetRole(), dao.GetRoleBinding(), dao.GetGlobalRole(), dao.GetGlobalRoleBinding(), conf)
+	authzService, err := authorization.New(dao.GetUser(), dao.GetRole(), dao.GetRoleBinding(), dao.GetGlobalRole(), dao.GetGlobalRoleBinding(), conf, dao.GetPersesDAO().IsCaseSensitive())
 	if err != nil {
 		return nil, err
 	}
+	webhookService, err := webhook.New(conf.Webhooks, authzService)
+	if err != nil {
+		return nil, err
+	}
+	auditService := auditImpl.NewService(conf.Audit, dao.GetAudit(), authzService)
 	pluginService := plugin.New(conf.Plugin)
 	schemaService := pluginService.Schema()
 	migrateService := pluginService.Migration()
-	dashboardService := dashboardImpl.NewService(conf, dao.GetDashboard(), dao.GetGlobalVariable(), dao.GetVariable(), schemaService)
-	datasourceService := datasourceImpl.NewService(dao.GetDatasource(), schemaService)
+	dashboardService := dashboardImpl.NewService(conf, dao.GetDashboard(), dao.GetGlobalVariable(), dao.GetVariable(), dao.GetLibraryPanel(), dao.GetDatasource(), dao.GetGlobalDatasource(), schemaService, webhookService)
+	datasourceService := datasourceImpl.NewService(dao.GetDatasource(), schemaService, webhookService)
 	ephemeralDashboardService := ephemeralDashboardImpl.NewService(dao.GetEphemeralDashboard(), dao.GetGlobalVariable(), dao.GetVariable(), schemaService)
 	folderService := folderImpl.NewService(dao.GetFolder())
 	variableService := variableImpl.NewService(dao.GetVariable(), schemaService)
-	globalDatasourceService := globalDatasourceImpl.NewService(dao.GetGlobalDatasource(), schemaService)
+	globalDatasourceService := globalDatasourceImpl.NewService(dao.GetGlobalDatasource(), schemaService, webhookService)
 	globalRole := globalRoleImpl.NewService(dao.GetGlobalRole(), authzService, schemaService)
 	globalRoleBinding := globalRoleBindingImpl.NewService(dao.GetGlobalRoleBinding(), dao.GetGlobalRole(), dao.GetUser(), authzService, schemaService)
 	globalSecret := globalSecretImpl.NewService(dao.GetGlobalSecret(), cryptoService)
 	globalVariableService := globalVariableImpl.NewService(dao.GetGlobalVariable(), schemaService)
 	healthService := healthImpl.NewService(dao.GetHealth())
-	projectService := projectImpl.NewService(dao.GetProject(), dao.GetFolder(), dao.GetDatasource(), dao.GetDashboard(), dao.GetRole(), dao.GetRoleBinding(), dao.GetSecret(), dao.GetVariable(), authzService)
+	libraryPanelService := libraryPanelImpl.NewService(dao.GetLibraryPanel(), schemaService)
+	projectService := projectImpl.NewService(dao.GetProject(), dao.GetFolder(), dao.GetDatasource(), dao.GetDashboard(), dao.GetLibraryPanel(), dao.GetRole(), dao.GetRoleBinding(), dao.GetSecret(), dao.GetSnapshot(), dao.GetVariable(), authzService)
 	roleService := roleImpl.NewService(dao.GetRole(), authzService, schemaService)
 	roleBindingService := roleBindingImpl.NewService(dao.GetRoleBinding(), dao.GetRole(), dao.GetUser(), authzService, schemaService)
 	secretService := secretImpl.NewService(dao.GetSecret(), cryptoService)
+	snapshotService := snapshotImpl.NewService(dao.GetSnapshot(), dashboardService)
+	searchService := search.New(dao.GetDashboard())
 	userService := userImpl.NewService(dao.GetUser(), authzService)
 	viewService := viewImpl.NewMetricsViewService()
 
 	svc := &service{
+		audit:              auditService,
 		authorization:      authzService,
 		crypto:             cryptoService,
 		dashboard:          dashboardService,
@@ -155,20 +182,28 @@ func NewServiceManager(dao PersistenceManager, conf config.Config) (ServiceManag
 		globalVariable:     globalVariableService,
 		health:             healthService,
 		jwt:                jwtService,
+		libraryPanel:       libraryPanelService,
 		migrate:            migrateService,
 		plugin:             pluginService,
 		project:            projectService,
 		role:               roleService,
 		roleBinding:        roleBindingService,
 		schema:             schemaService,
+		search:             searchService,
 		secret:             secretService,
+		snapshot:           snapshotService,
 		user:               userService,
 		variable:           variableService,
 		view:               viewService,
+		webhook:            webhookService,
 	}
 	return svc, nil
 }
 
+func (s *service) GetAudit() audit.Service {
+	return s.audit
+}
+
 func (s *service) GetAuthorization() authorization.Authorization {
 	return s.authorization
 }
@@ -221,6 +256,10 @@ func (s *service) GetJWT() crypto.JWT {
 	return s.jwt
 }
 
+func (s *service) GetLibraryPanel() librarypanel.Service {
+	return s.libraryPanel
+}
+
 func (s *service) GetMigration() migrate.Migration {
 	return s.migrate
 }
@@ -237,6 +276,10 @@ func (s *service) GetSchema() schema.Schema {
 	return s.schema
 }
 
+func (s *service) GetSearch() search.Search {
+	return s.search
+}
+
 func (s *service) GetRole() role.Service {
 	return s.role
 }
@@ -249,6 +292,10 @@ func (s *service) GetSecret() secret.Service {
 	return s.secret
 }
 
+func (s *service) GetSnapshot() snapshot.Service {
+	return s.snapshot
+}
+
 func (s *service) GetUser() user.Service {
 	return s.user
 }
@@ -260,3 +307,7 @@ func (s *service) GetVariable() variable.Service {
 func (s *service) GetView() view.Service {
 	return s.view
 }
+
+func (s *service) GetWebhook() webhook.Webhook {
+	return s.webhook
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/dependency"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/utils"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+	"github.com/perses/perses/pkg/model/api/v1/variable"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyResources(t *testing.T) {
+	e2eframework.WithServer(t, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []modelAPI.Entity {
+		project := e2eframework.NewProject("perses")
+		e2eframework.CreateAndWaitUntilEntityExists(t, manager, project)
+		existingDatasource := e2eframework.NewDatasource(t, "perses", "prometheus")
+		e2eframework.CreateAndWaitUntilEntityExists(t, manager, existingDatasource)
+		dashboard := e2eframework.NewDashboard(t, "perses", "overview")
+		variable := e2eframework.NewVariable("perses", "job")
+
+		expect.POST(fmt.Sprintf("%s/apply", utils.APIV1Prefix)).
+			WithJSON([]modelAPI.Entity{dashboard, existingDatasource, variable}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			IsEqual([]modelAPI.ApplyResult{
+				{Kind: "Datasource", Project: "perses", Name: "prometheus", Action: modelAPI.ApplyActionUpdated},
+				{Kind: "Variable", Project: "perses", Name: "job", Action: modelAPI.ApplyActionCreated},
+				{Kind: "Dashboard", Project: "perses", Name: "overview", Action: modelAPI.ApplyActionCreated},
+			})
+
+		_, err := manager.GetDashboard().Get("perses", "overview")
+		assert.NoError(t, err)
+		_, err = manager.GetVariable().Get("perses", "job")
+		assert.NoError(t, err)
+		return []modelAPI.Entity{dashboard, variable, existingDatasource, project}
+	})
+}
+
+func TestApplyRollback(t *testing.T) {
+	e2eframework.WithServer(t, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []modelAPI.Entity {
+		project := e2eframework.NewProject("perses")
+		e2eframework.CreateAndWaitUntilEntityExists(t, manager, project)
+		jobVariable := e2eframework.NewVariable("perses", "job")
+		// The dashboard is applied after the variable, and is rejected by the validation as its variable uses the
+		// prefix of the builtin variables.
+		invalidDashboard := e2eframework.NewDashboard(t, "perses", "overview")
+		invalidDashboard.Spec.Variables = append(invalidDashboard.Spec.Variables, dashboard.Variable{
+			Kind: variable.KindText,
+			Spec: &dashboard.TextVariableSpec{TextSpec: variable.TextSpec{Value: "perses"}, Name: "__invalid"},
+		})
+
+		expect.POST(fmt.Sprintf("%s/apply", utils.APIV1Prefix)).
+			WithJSON([]modelAPI.Entity{invalidDashboard, jobVariable}).
+			Expect().
+			Status(http.StatusBadRequest)
+
+		_, err := manager.GetVariable().Get("perses", "job")
+		assert.True(t, databaseModel.IsKeyNotFound(err))
+		_, err = manager.GetDashboard().Get("perses", "overview")
+		assert.True(t, databaseModel.IsKeyNotFound(err))
+		return []modelAPI.Entity{project}
+	})
+}
+
+func TestApplyUnsupportedKind(t *testing.T) {
+	e2eframework.WithServer(t, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []modelAPI.Entity {
+		expect.POST(fmt.Sprintf("%s/apply", utils.APIV1Prefix)).
+			WithJSON([]modelAPI.Entity{e2eframework.NewRole("perses", "admin")}).
+			Expect().
+			Status(http.StatusBadRequest)
+		return []modelAPI.Entity{}
+	})
+}
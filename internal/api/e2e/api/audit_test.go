@@ -0,0 +1,113 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/perses/perses/internal/api/dependency"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/utils"
+	"github.com/perses/perses/pkg/model/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/variable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLog(t *testing.T) {
+	conf := e2eframework.DefaultConfig()
+	conf.Audit.Enable = true
+	e2eframework.WithServerConfig(t, conf, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		defer func() {
+			require.NoError(t, manager.GetAudit().DeleteBefore(time.Now().Add(time.Minute)))
+		}()
+		start := time.Now().UTC()
+		project := e2eframework.NewProject("audit")
+		expect.POST(fmt.Sprintf("%s/%s", utils.APIV1Prefix, utils.PathProject)).
+			WithJSON(project).
+			Expect().
+			Status(http.StatusOK)
+
+		entity := e2eframework.NewVariable(project.Metadata.Name, "var")
+		variablePath := fmt.Sprintf("%s/%s/%s/%s", utils.APIV1Prefix, utils.PathProject, project.Metadata.Name, utils.PathVariable)
+		expect.POST(variablePath).
+			WithJSON(entity).
+			Expect().
+			Status(http.StatusOK)
+		entity.Spec.Spec.(*variable.ListSpec).Display = &variable.Display{Name: "updated"}
+		expect.PUT(fmt.Sprintf("%s/%s", variablePath, entity.Metadata.Name)).
+			WithJSON(entity).
+			Expect().
+			Status(http.StatusOK)
+		expect.DELETE(fmt.Sprintf("%s/%s", variablePath, entity.Metadata.Name)).
+			Expect().
+			Status(http.StatusNoContent)
+
+		var events []*api.AuditEvent
+		raw := expect.GET(fmt.Sprintf("%s/%s", utils.APIV1Prefix, utils.PathAudit)).
+			WithQuery("project", project.Metadata.Name).
+			WithQuery("kind", "variable").
+			WithQuery("start", start.Format(time.RFC3339Nano)).
+			Expect().
+			Status(http.StatusOK).
+			Body().
+			Raw()
+		require.NoError(t, json.Unmarshal([]byte(raw), &events))
+		require.Len(t, events, 3)
+
+		deleted, updated, created := events[0], events[1], events[2]
+		assert.Equal(t, api.AuditActionCreated, created.Action)
+		assert.Equal(t, string(modelV1.KindVariable), created.Kind)
+		assert.Equal(t, "var", created.Name)
+		assert.Empty(t, created.OldSpecHash)
+		assert.NotEmpty(t, created.NewSpecHash)
+
+		assert.Equal(t, api.AuditActionUpdated, updated.Action)
+		assert.Equal(t, created.NewSpecHash, updated.OldSpecHash)
+		assert.NotEqual(t, updated.OldSpecHash, updated.NewSpecHash)
+
+		assert.Equal(t, api.AuditActionDeleted, deleted.Action)
+		assert.Equal(t, updated.NewSpecHash, deleted.OldSpecHash)
+		assert.Empty(t, deleted.NewSpecHash)
+
+		var page []*api.AuditEvent
+		raw = expect.GET(fmt.Sprintf("%s/%s", utils.APIV1Prefix, utils.PathAudit)).
+			WithQuery("project", project.Metadata.Name).
+			WithQuery("kind", "variable").
+			WithQuery("limit", 2).
+			WithQuery("cursor", deleted.ID).
+			Expect().
+			Status(http.StatusOK).
+			Body().
+			Raw()
+		require.NoError(t, json.Unmarshal([]byte(raw), &page))
+		assert.Equal(t, []*api.AuditEvent{updated, created}, page)
+
+		expect.GET(fmt.Sprintf("%s/%s", utils.APIV1Prefix, utils.PathAudit)).
+			WithQuery("start", "2025-01-02T00:00:00Z").
+			WithQuery("end", "2025-01-01T00:00:00Z").
+			Expect().
+			Status(http.StatusBadRequest)
+		return []api.Entity{project}
+	})
+}
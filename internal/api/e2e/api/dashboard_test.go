@@ -126,6 +126,74 @@ func TestListDashboardWithOnlyMetadata(t *testing.T) {
 	})
 }
 
+func TestMoveDashboardToAnotherProject(t *testing.T) {
+	e2eframework.WithServer(t, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		persesProject := e2eframework.NewProject("perses")
+		productionProject := e2eframework.NewProject("production")
+		demoDatasource := e2eframework.NewDatasource(t, "perses", "PrometheusDemo")
+		demoDashboard := e2eframework.NewDashboard(t, "perses", "Demo")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, persesProject, productionProject, demoDatasource, demoDashboard)
+
+		response := expect.POST(fmt.Sprintf("%s/%s/%s/%s/%s/move", utils.APIV1Prefix, utils.PathProject, "perses", utils.PathDashboard, "Demo")).
+			WithJSON(modelV1.DashboardMoveRequest{Project: "production"}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+		response.Value("rewritten").Array().Length().IsEqual(1)
+		response.Value("rewritten").Array().Value(0).Object().Value("name").IsEqual("PrometheusDemo")
+		response.NotContainsKey("broken")
+
+		moved, err := manager.GetDashboard().Get("production", "Demo")
+		assert.NoError(t, err)
+		assert.Contains(t, moved.Spec.Datasources, "PrometheusDemo")
+		assert.False(t, moved.Spec.Datasources["PrometheusDemo"].Default)
+		_, err = manager.GetDashboard().Get("perses", "Demo")
+		assert.Error(t, err)
+
+		return []api.Entity{persesProject, productionProject, demoDatasource, moved}
+	})
+}
+
+func TestMoveDashboardWithBrokenReferences(t *testing.T) {
+	e2eframework.WithServer(t, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		persesProject := e2eframework.NewProject("perses")
+		productionProject := e2eframework.NewProject("production")
+		demoDashboard := e2eframework.NewDashboard(t, "perses", "Demo")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, persesProject, productionProject, demoDashboard)
+		path := fmt.Sprintf("%s/%s/%s/%s/%s/move", utils.APIV1Prefix, utils.PathProject, "perses", utils.PathDashboard, "Demo")
+
+		// The datasource PrometheusDemo exists in none of the projects.
+		expect.POST(path).
+			WithJSON(modelV1.DashboardMoveRequest{Project: "production", Copy: true}).
+			Expect().
+			Status(http.StatusBadRequest)
+
+		expect.POST(path).
+			WithJSON(modelV1.DashboardMoveRequest{Project: "production", Copy: true, DryRun: true}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object().
+			Value("broken").
+			Array().
+			NotEmpty()
+		_, err := manager.GetDashboard().Get("production", "Demo")
+		assert.Error(t, err)
+
+		expect.POST(path).
+			WithJSON(modelV1.DashboardMoveRequest{Project: "production", Copy: true, Force: true}).
+			Expect().
+			Status(http.StatusOK)
+		copied, err := manager.GetDashboard().Get("production", "Demo")
+		assert.NoError(t, err)
+		_, err = manager.GetDashboard().Get("perses", "Demo")
+		assert.NoError(t, err)
+
+		return []api.Entity{persesProject, productionProject, demoDashboard, copied}
+	})
+}
+
 func extractDashboardFromHTTPBody(body interface{}) *modelV1.Dashboard {
 	b := testUtils.JSONMarshalStrict(body)
 	dashboard := &modelV1.Dashboard{}
@@ -46,6 +46,7 @@ import (
 )
 
 var useSQL = os.Getenv("PERSES_TEST_USE_SQL")
+var usePostgres = os.Getenv("PERSES_TEST_USE_POSTGRES")
 
 func DefaultConfig() apiConfig.Config {
 	projectPath := test.GetRepositoryPath()
@@ -121,6 +122,18 @@ func CreateServer(t *testing.T, conf apiConfig.Config) (*httptest.Server, *httpe
 				CaseSensitive:        true,
 			},
 		}
+	} else if usePostgres == "true" {
+		conf.Database = apiConfig.Database{
+			Postgres: &apiConfig.Postgres{
+				User:          "user",
+				Password:      "password",
+				Addr:          "localhost:5432",
+				DBName:        "perses",
+				Schema:        "public",
+				SSLMode:       "disable",
+				CaseSensitive: true,
+			},
+		}
 	} else {
 		conf.Database = apiConfig.Database{
 			File: defaultFileConfig(),
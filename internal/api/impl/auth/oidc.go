@@ -15,6 +15,7 @@ package auth
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -25,6 +26,8 @@ import (
 	"github.com/perses/perses/internal/api/authorization"
 	"github.com/perses/perses/internal/api/crypto"
 	apiinterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/globalrolebinding"
+	"github.com/perses/perses/internal/api/interface/v1/rolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/user"
 	"github.com/perses/perses/internal/api/route"
 	"github.com/perses/perses/internal/api/utils"
@@ -45,6 +48,29 @@ type oidcUserInfo struct {
 	Subject string `json:"sub,omitempty"`
 	// issuer is not supposed to be taken from json, but instead it must be set right before the db sync.
 	issuer string
+	// claims are all the claims of the user info, used by the role mappings.
+	claims map[string]interface{}
+}
+
+func (u *oidcUserInfo) UnmarshalJSON(data []byte) error {
+	type plain oidcUserInfo
+	if err := json.Unmarshal(data, (*plain)(u)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &u.claims)
+}
+
+// mergeClaims adds the claims of the ID token that are missing in the user info. Some providers only put the groups
+// of the user in the ID token.
+func (u *oidcUserInfo) mergeClaims(claims map[string]interface{}) {
+	if u.claims == nil {
+		u.claims = make(map[string]interface{}, len(claims))
+	}
+	for key, value := range claims {
+		if _, ok := u.claims[key]; !ok {
+			u.claims[key] = value
+		}
+	}
 }
 
 // GetSubject implements [rp.SubjectGetter]
@@ -151,13 +177,18 @@ type oIDCEndpoint struct {
 	urlParams              map[string]string
 	issuer                 string
 	svc                    service
+	roleMapper             *roleMapper
 }
 
-func newOIDCEndpoint(provider config.OIDCProvider, jwt crypto.JWT, dao user.DAO, authz authorization.Authorization) (route.Endpoint, error) {
+func newOIDCEndpoint(provider config.OIDCProvider, jwt crypto.JWT, dao user.DAO, roleBindingDAO rolebinding.DAO, globalRoleBindingDAO globalrolebinding.DAO, authz authorization.Authorization) (route.Endpoint, error) {
 	relyingParty, err := newRelyingParty(provider, nil)
 	if err != nil {
 		return nil, err
 	}
+	mapper, err := newRoleMapper(provider.SlugID, provider.RoleMappings, roleBindingDAO, globalRoleBindingDAO, authz)
+	if err != nil {
+		return nil, err
+	}
 	deviceCodeRelyingParty := relyingParty
 	if provider.DeviceCode != nil {
 		deviceCodeRelyingParty, err = newRelyingParty(provider, provider.DeviceCode)
@@ -183,6 +214,7 @@ func newOIDCEndpoint(provider config.OIDCProvider, jwt crypto.JWT, dao user.DAO,
 		urlParams:              provider.URLParams,
 		issuer:                 provider.Issuer.String(),
 		svc:                    service{dao: dao, authz: authz},
+		roleMapper:             mapper,
 	}, nil
 }
 
@@ -227,8 +259,11 @@ func (e *oIDCEndpoint) auth(ctx echo.Context) error {
 //   - save the user in database if it's a new user, or update it with the collected information
 //   - ultimately, generate a Perses user session with an access and refresh token
 func (e *oIDCEndpoint) codeExchange(ctx echo.Context) error {
-	marshalUserinfo := func(w http.ResponseWriter, r *http.Request, _ *oidc.Tokens[*oidc.IDTokenClaims], state string, _ rp.RelyingParty, info *oidcUserInfo) {
+	marshalUserinfo := func(w http.ResponseWriter, r *http.Request, tokens *oidc.Tokens[*oidc.IDTokenClaims], state string, _ rp.RelyingParty, info *oidcUserInfo) {
 		redirectURI := state
+		if tokens.IDTokenClaims != nil {
+			info.mergeClaims(tokens.IDTokenClaims.Claims)
+		}
 
 		setCookie := func(cookie *http.Cookie) {
 			http.SetCookie(w, cookie)
@@ -307,6 +342,7 @@ func (e *oIDCEndpoint) token(ctx echo.Context) error {
 			e.logWithError(err).Error("Failed to request user info")
 			return err
 		}
+		uInfo.mergeClaims(idClaims.Claims)
 	case api.GrantTypeClientCredentials:
 		// Extract client_id and client_secret from Authorization header
 		clientID, clientSecret, ok := ctx.Request().BasicAuth()
@@ -344,8 +380,14 @@ func (e *oIDCEndpoint) performUserSync(userInfo *oidcUserInfo, setCookie func(co
 		return nil, &oidc.Error{ErrorType: oidc.InvalidRequest, Description: err.Error()}
 	}
 
-	// Generate and save access and refresh tokens
+	// Grant or revoke the roles of the role mappings according to the current claims of the user
 	username := usr.GetMetadata().GetName()
+	if mappingErr := e.roleMapper.sync(username, userInfo.claims); mappingErr != nil {
+		e.logWithError(mappingErr).Error("Failed to sync the role bindings of the user.")
+		return nil, mappingErr
+	}
+
+	// Generate and save access and refresh tokens
 	accessToken, err := e.tokenManagement.accessToken(username, setCookie)
 	if err != nil {
 		e.logWithError(err).Error("Failed to generate and save access token.")
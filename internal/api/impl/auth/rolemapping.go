@@ -0,0 +1,249 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/perses/perses/internal/api/authorization"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/interface/v1/globalrolebinding"
+	"github.com/perses/perses/internal/api/interface/v1/rolebinding"
+	"github.com/perses/perses/pkg/model/api/config"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// roleMappingPrefix prefixes the names of the role bindings managed by the role mappings, e.g. oidc-azure-editor.
+const roleMappingPrefix = "oidc"
+
+// roleMappingMaxAttempts is the number of times a role binding is synchronized when it has been created concurrently,
+// e.g. by another instance of Perses.
+const roleMappingMaxAttempts = 3
+
+// roleMappingTarget is a role granted by the role mappings. The project is empty for a GlobalRole.
+type roleMappingTarget struct {
+	project string
+	role    string
+}
+
+type roleMappingRule struct {
+	target roleMappingTarget
+	claim  string
+	match  *regexp.Regexp
+}
+
+// matches returns true if one of the values of the claim fully matches the regular expression of the rule.
+func (r roleMappingRule) matches(claims map[string]interface{}) bool {
+	return slices.ContainsFunc(claimValues(claims, r.claim), r.match.MatchString)
+}
+
+// roleMapper maintains the role bindings granting the roles of the role mappings of an OIDC provider. Each role has
+// its own role binding, named after the provider and the role, and the users are added or removed from it each time
+// they log in according to their claims. The role bindings created manually are never modified.
+// The synchronizations are serialized, so the users logging in at the same time don't override each other's subjects.
+type roleMapper struct {
+	mutex                sync.Mutex
+	slugID               string
+	rules                []roleMappingRule
+	targets              []roleMappingTarget
+	roleBindingDAO       rolebinding.DAO
+	globalRoleBindingDAO globalrolebinding.DAO
+	authz                authorization.Authorization
+}
+
+func newRoleMapper(slugID string, mappings []config.RoleMapping, roleBindingDAO rolebinding.DAO, globalRoleBindingDAO globalrolebinding.DAO, authz authorization.Authorization) (*roleMapper, error) {
+	mapper := &roleMapper{
+		slugID:               slugID,
+		roleBindingDAO:       roleBindingDAO,
+		globalRoleBindingDAO: globalRoleBindingDAO,
+		authz:                authz,
+	}
+	for _, mapping := range mappings {
+		// Like the relabeling of Prometheus, the regular expression is anchored.
+		match, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", mapping.Match))
+		if err != nil {
+			return nil, fmt.Errorf("invalid role mapping of the provider %q: %w", slugID, err)
+		}
+		target := roleMappingTarget{project: mapping.Project, role: mapping.Role}
+		mapper.rules = append(mapper.rules, roleMappingRule{target: target, claim: mapping.Claim, match: match})
+		if !slices.Contains(mapper.targets, target) {
+			mapper.targets = append(mapper.targets, target)
+		}
+	}
+	return mapper, nil
+}
+
+// sync grants to the user the roles of the rules matching the claims and revokes the other ones.
+func (m *roleMapper) sync(username string, claims map[string]interface{}) error {
+	if len(m.rules) == 0 {
+		return nil
+	}
+	granted := make(map[roleMappingTarget]bool, len(m.targets))
+	for _, rule := range m.rules {
+		if rule.matches(claims) {
+			granted[rule.target] = true
+		}
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	changed := false
+	for _, target := range m.targets {
+		targetChanged, err := m.syncTarget(target, username, granted[target])
+		if err != nil {
+			return err
+		}
+		changed = changed || targetChanged
+	}
+	if !changed {
+		return nil
+	}
+	// Refreshing RBAC cache as the role bindings of the user have been updated.
+	return m.authz.RefreshPermissions()
+}
+
+// syncTarget synchronizes the role binding of the target. When the role binding has been created in the meantime, it
+// is read again and the user is added to the existing subjects.
+func (m *roleMapper) syncTarget(target roleMappingTarget, username string, granted bool) (bool, error) {
+	var changed bool
+	var err error
+	for attempt := 0; attempt < roleMappingMaxAttempts; attempt++ {
+		if len(target.project) == 0 {
+			changed, err = m.syncGlobalRoleBinding(target, username, granted)
+		} else {
+			changed, err = m.syncRoleBinding(target, username, granted)
+		}
+		if !databaseModel.IsKeyConflict(err) {
+			return changed, err
+		}
+	}
+	return changed, err
+}
+
+func (m *roleMapper) syncRoleBinding(target roleMappingTarget, username string, granted bool) (bool, error) {
+	name := m.roleBindingName(target.role)
+	entity, err := m.roleBindingDAO.Get(target.project, name)
+	if err != nil {
+		if !databaseModel.IsKeyNotFound(err) || !granted {
+			return false, ignoreKeyNotFound(err)
+		}
+		entity = &v1.RoleBinding{
+			Kind:     v1.KindRoleBinding,
+			Metadata: *v1.NewProjectMetadata(target.project, name),
+			Spec:     v1.RoleBindingSpec{Role: target.role},
+		}
+		updateSubjects(&entity.Spec, username, true)
+		entity.Metadata.CreateNow()
+		return true, m.roleBindingDAO.Create(entity)
+	}
+	if !updateSubjects(&entity.Spec, username, granted) {
+		return false, nil
+	}
+	// A role binding requires at least one subject.
+	if len(entity.Spec.Subjects) == 0 {
+		return true, m.roleBindingDAO.Delete(target.project, name)
+	}
+	entity.Metadata.Update(entity.Metadata)
+	return true, m.roleBindingDAO.Update(entity)
+}
+
+func (m *roleMapper) syncGlobalRoleBinding(target roleMappingTarget, username string, granted bool) (bool, error) {
+	name := m.roleBindingName(target.role)
+	entity, err := m.globalRoleBindingDAO.Get(name)
+	if err != nil {
+		if !databaseModel.IsKeyNotFound(err) || !granted {
+			return false, ignoreKeyNotFound(err)
+		}
+		entity = &v1.GlobalRoleBinding{
+			Kind:     v1.KindGlobalRoleBinding,
+			Metadata: v1.Metadata{Name: name},
+			Spec:     v1.RoleBindingSpec{Role: target.role},
+		}
+		updateSubjects(&entity.Spec, username, true)
+		entity.Metadata.CreateNow()
+		return true, m.globalRoleBindingDAO.Create(entity)
+	}
+	if !updateSubjects(&entity.Spec, username, granted) {
+		return false, nil
+	}
+	if len(entity.Spec.Subjects) == 0 {
+		return true, m.globalRoleBindingDAO.Delete(name)
+	}
+	entity.Metadata.Update(entity.Metadata)
+	return true, m.globalRoleBindingDAO.Update(entity)
+}
+
+// roleBindingName returns the name of the role binding managed by the role mappings of the provider for the role.
+func (m *roleMapper) roleBindingName(role string) string {
+	return fmt.Sprintf("%s-%s-%s", roleMappingPrefix, m.slugID, role)
+}
+
+// updateSubjects adds the user to the subjects when granted is true, and removes it otherwise. It returns true if the
+// subjects have changed.
+func updateSubjects(spec *v1.RoleBindingSpec, username string, granted bool) bool {
+	if spec.Has(v1.KindUser, username) == granted {
+		return false
+	}
+	if granted {
+		spec.Subjects = append(spec.Subjects, v1.Subject{Kind: v1.KindUser, Name: username})
+		return true
+	}
+	spec.Subjects = slices.DeleteFunc(spec.Subjects, func(subject v1.Subject) bool {
+		return subject.Kind == v1.KindUser && subject.Name == username
+	})
+	return true
+}
+
+func ignoreKeyNotFound(err error) error {
+	if databaseModel.IsKeyNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// claimValues returns the values of the claim as strings. A claim can be a single value or a list of values, and a
+// nested claim is designated with dots, e.g. "realm_access.roles".
+func claimValues(claims map[string]interface{}, claim string) []string {
+	value, ok := claims[claim]
+	if !ok {
+		var current interface{} = claims
+		for _, key := range strings.Split(claim, ".") {
+			object, isObject := current.(map[string]interface{})
+			if !isObject {
+				return nil
+			}
+			current = object[key]
+		}
+		value = current
+	}
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{v}
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, element := range v {
+			if element != nil {
+				result = append(result, fmt.Sprint(element))
+			}
+		}
+		return result
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/perses/perses/internal/api/authorization"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/interface/v1/globalrolebinding"
+	"github.com/perses/perses/internal/api/interface/v1/rolebinding"
+	"github.com/perses/perses/pkg/model/api/config"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRoleBindingDAO stores copies of the role bindings like a database, so the concurrent updates can be lost.
+type fakeRoleBindingDAO struct {
+	rolebinding.DAO
+	mutex    sync.Mutex
+	entities map[string]*v1.RoleBinding
+	// beforeCreate is called before the creation of a role binding, to simulate a concurrent creation.
+	beforeCreate func()
+	// latency delays the response of the reads, to let the concurrent requests interleave.
+	latency time.Duration
+}
+
+func (d *fakeRoleBindingDAO) Create(entity *v1.RoleBinding) error {
+	if d.beforeCreate != nil {
+		d.beforeCreate()
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	key := entity.Metadata.Project + "/" + entity.Metadata.Name
+	if _, ok := d.entities[key]; ok {
+		return &databaseModel.Error{Key: key, Code: databaseModel.ErrorCodeConflict}
+	}
+	d.entities[key] = copyRoleBinding(entity)
+	return nil
+}
+
+func (d *fakeRoleBindingDAO) Update(entity *v1.RoleBinding) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.entities[entity.Metadata.Project+"/"+entity.Metadata.Name] = copyRoleBinding(entity)
+	return nil
+}
+
+func (d *fakeRoleBindingDAO) Delete(project string, name string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	delete(d.entities, project+"/"+name)
+	return nil
+}
+
+func (d *fakeRoleBindingDAO) Get(project string, name string) (*v1.RoleBinding, error) {
+	d.mutex.Lock()
+	entity, ok := d.entities[project+"/"+name]
+	if ok {
+		entity = copyRoleBinding(entity)
+	}
+	d.mutex.Unlock()
+	time.Sleep(d.latency)
+	if !ok {
+		return nil, &databaseModel.Error{Key: name, Code: databaseModel.ErrorCodeNotFound}
+	}
+	return entity, nil
+}
+
+func copyRoleBinding(entity *v1.RoleBinding) *v1.RoleBinding {
+	result := *entity
+	result.Spec.Subjects = slices.Clone(entity.Spec.Subjects)
+	return &result
+}
+
+type fakeGlobalRoleBindingDAO struct {
+	globalrolebinding.DAO
+	mutex    sync.Mutex
+	entities map[string]*v1.GlobalRoleBinding
+	latency  time.Duration
+}
+
+func (d *fakeGlobalRoleBindingDAO) Create(entity *v1.GlobalRoleBinding) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if _, ok := d.entities[entity.Metadata.Name]; ok {
+		return &databaseModel.Error{Key: entity.Metadata.Name, Code: databaseModel.ErrorCodeConflict}
+	}
+	d.entities[entity.Metadata.Name] = copyGlobalRoleBinding(entity)
+	return nil
+}
+
+func (d *fakeGlobalRoleBindingDAO) Update(entity *v1.GlobalRoleBinding) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.entities[entity.Metadata.Name] = copyGlobalRoleBinding(entity)
+	return nil
+}
+
+func (d *fakeGlobalRoleBindingDAO) Delete(name string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	delete(d.entities, name)
+	return nil
+}
+
+func (d *fakeGlobalRoleBindingDAO) Get(name string) (*v1.GlobalRoleBinding, error) {
+	d.mutex.Lock()
+	entity, ok := d.entities[name]
+	if ok {
+		entity = copyGlobalRoleBinding(entity)
+	}
+	d.mutex.Unlock()
+	time.Sleep(d.latency)
+	if !ok {
+		return nil, &databaseModel.Error{Key: name, Code: databaseModel.ErrorCodeNotFound}
+	}
+	return entity, nil
+}
+
+func copyGlobalRoleBinding(entity *v1.GlobalRoleBinding) *v1.GlobalRoleBinding {
+	result := *entity
+	result.Spec.Subjects = slices.Clone(entity.Spec.Subjects)
+	return &result
+}
+
+type fakeAuthorization struct {
+	authorization.Authorization
+	refreshed atomic.Int32
+}
+
+func (a *fakeAuthorization) RefreshPermissions() error {
+	a.refreshed.Add(1)
+	return nil
+}
+
+func TestRoleMapper(t *testing.T) {
+	roleBindingDAO := &fakeRoleBindingDAO{entities: map[string]*v1.RoleBinding{}}
+	globalRoleBindingDAO := &fakeGlobalRoleBindingDAO{entities: map[string]*v1.GlobalRoleBinding{}}
+	authz := &fakeAuthorization{}
+	mapper, err := newRoleMapper("azure", []config.RoleMapping{
+		{Claim: "groups", Match: "team-.*", Role: "editor", Project: "perses"},
+		{Claim: "realm_access.roles", Match: "admin", Role: "admin"},
+	}, roleBindingDAO, globalRoleBindingDAO, authz)
+	require.NoError(t, err)
+
+	require.NoError(t, mapper.sync("alice", map[string]interface{}{
+		"groups":       []interface{}{"team-perses", "everyone"},
+		"realm_access": map[string]interface{}{"roles": []interface{}{"admin"}},
+	}))
+	require.NoError(t, mapper.sync("bob", map[string]interface{}{"groups": "team-perses"}))
+	editors := roleBindingDAO.entities["perses/oidc-azure-editor"]
+	require.NotNil(t, editors)
+	assert.Equal(t, "editor", editors.Spec.Role)
+	assert.Equal(t, []v1.Subject{{Kind: v1.KindUser, Name: "alice"}, {Kind: v1.KindUser, Name: "bob"}}, editors.Spec.Subjects)
+	admins := globalRoleBindingDAO.entities["oidc-azure-admin"]
+	require.NotNil(t, admins)
+	assert.Equal(t, []v1.Subject{{Kind: v1.KindUser, Name: "alice"}}, admins.Spec.Subjects)
+	assert.Equal(t, 2, int(authz.refreshed.Load()))
+
+	// Nothing changes when the claims are the same.
+	require.NoError(t, mapper.sync("bob", map[string]interface{}{"groups": "team-perses"}))
+	assert.Equal(t, 2, int(authz.refreshed.Load()))
+
+	// The roles are revoked when the claims don't match anymore, and the empty role bindings are deleted.
+	require.NoError(t, mapper.sync("alice", map[string]interface{}{"groups": []interface{}{"everyone"}}))
+	assert.Equal(t, []v1.Subject{{Kind: v1.KindUser, Name: "bob"}}, roleBindingDAO.entities["perses/oidc-azure-editor"].Spec.Subjects)
+	assert.NotContains(t, globalRoleBindingDAO.entities, "oidc-azure-admin")
+	assert.Equal(t, 3, int(authz.refreshed.Load()))
+
+	// The regular expression must match the whole value.
+	require.NoError(t, mapper.sync("carol", map[string]interface{}{"groups": "not-team-perses"}))
+	assert.False(t, roleBindingDAO.entities["perses/oidc-azure-editor"].Spec.Has(v1.KindUser, "carol"))
+}
+
+func TestRoleMapperConcurrentLogins(t *testing.T) {
+	roleBindingDAO := &fakeRoleBindingDAO{entities: map[string]*v1.RoleBinding{}, latency: time.Millisecond}
+	globalRoleBindingDAO := &fakeGlobalRoleBindingDAO{entities: map[string]*v1.GlobalRoleBinding{}, latency: time.Millisecond}
+	mapper, err := newRoleMapper("azure", []config.RoleMapping{
+		{Claim: "groups", Match: "team-.*", Role: "editor", Project: "perses"},
+		{Claim: "groups", Match: "team-.*", Role: "viewer"},
+	}, roleBindingDAO, globalRoleBindingDAO, &fakeAuthorization{})
+	require.NoError(t, err)
+
+	var expected []v1.Subject
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		username := fmt.Sprintf("user%d", i)
+		expected = append(expected, v1.Subject{Kind: v1.KindUser, Name: username})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, mapper.sync(username, map[string]interface{}{"groups": "team-perses"}))
+		}()
+	}
+	wg.Wait()
+	assert.ElementsMatch(t, expected, roleBindingDAO.entities["perses/oidc-azure-editor"].Spec.Subjects)
+	assert.ElementsMatch(t, expected, globalRoleBindingDAO.entities["oidc-azure-viewer"].Spec.Subjects)
+}
+
+func TestRoleMapperCreationConflict(t *testing.T) {
+	roleBindingDAO := &fakeRoleBindingDAO{entities: map[string]*v1.RoleBinding{}}
+	globalRoleBindingDAO := &fakeGlobalRoleBindingDAO{entities: map[string]*v1.GlobalRoleBinding{}}
+	authz := &fakeAuthorization{}
+	mapper, err := newRoleMapper("azure", []config.RoleMapping{
+		{Claim: "groups", Match: "team-.*", Role: "editor", Project: "perses"},
+	}, roleBindingDAO, globalRoleBindingDAO, authz)
+	require.NoError(t, err)
+
+	// Another instance creates the role binding between the read and the creation.
+	roleBindingDAO.beforeCreate = func() {
+		roleBindingDAO.beforeCreate = nil
+		require.NoError(t, roleBindingDAO.Create(&v1.RoleBinding{
+			Kind:     v1.KindRoleBinding,
+			Metadata: *v1.NewProjectMetadata("perses", "oidc-azure-editor"),
+			Spec:     v1.RoleBindingSpec{Role: "editor", Subjects: []v1.Subject{{Kind: v1.KindUser, Name: "bob"}}},
+		}))
+	}
+	require.NoError(t, mapper.sync("alice", map[string]interface{}{"groups": "team-perses"}))
+	assert.Equal(t, []v1.Subject{{Kind: v1.KindUser, Name: "bob"}, {Kind: v1.KindUser, Name: "alice"}}, roleBindingDAO.entities["perses/oidc-azure-editor"].Spec.Subjects)
+	assert.Equal(t, 1, int(authz.refreshed.Load()))
+}
+
+func TestClaimValues(t *testing.T) {
+	claims := map[string]interface{}{
+		"groups":       []interface{}{"a", "b"},
+		"department":   "sre",
+		"level":        float64(3),
+		"realm_access": map[string]interface{}{"roles": []interface{}{"admin"}},
+		"dotted.claim": "value",
+	}
+	assert.Equal(t, []string{"a", "b"}, claimValues(claims, "groups"))
+	assert.Equal(t, []string{"sre"}, claimValues(claims, "department"))
+	assert.Equal(t, []string{"3"}, claimValues(claims, "level"))
+	assert.Equal(t, []string{"admin"}, claimValues(claims, "realm_access.roles"))
+	assert.Equal(t, []string{"value"}, claimValues(claims, "dotted.claim"))
+	assert.Nil(t, claimValues(claims, "missing"))
+	assert.Nil(t, claimValues(claims, "department.missing"))
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache contains the caches of the responses of the queries sent to the datasources through the proxy, so the
+// datasources are not queried again when the same dashboard is displayed by many viewers.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const keyPrefix = "perses:proxy:"
+
+// timeParameters are the query parameters of the Prometheus-like APIs holding a timestamp. They are rounded to a time
+// bucket, so the same query sent at slightly different times by different viewers has the same key.
+var timeParameters = []string{"start", "end", "time"}
+
+// cachedHeaders are the headers of the responses kept in the cache.
+var cachedHeaders = []string{"Content-Type", "Content-Encoding"}
+
+// IdentityHeaders are the headers of the requests identifying the user or the tenant. When they are forwarded to the
+// datasource, the responses fetched with different values must not be shared, so their values are part of the key.
+var IdentityHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", "X-Scope-OrgID"}
+
+// Response is a response of a datasource kept in the cache.
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// NewResponse returns the response to keep in the cache, with only the headers needed to serve it again.
+func NewResponse(statusCode int, header http.Header, body []byte) *Response {
+	response := &Response{StatusCode: statusCode, Header: http.Header{}, Body: body}
+	for _, name := range cachedHeaders {
+		if value := header.Get(name); len(value) > 0 {
+			response.Header.Set(name, value)
+		}
+	}
+	return response
+}
+
+// Cache stores the responses of the datasources. A cache is best effort: an implementation failing to read or to
+// write a response logs the error and behaves as if the response was not in the cache.
+type Cache interface {
+	Get(ctx context.Context, key string) (*Response, bool)
+	Set(ctx context.Context, key string, response *Response, ttl time.Duration)
+}
+
+// Key returns the key of the response of the request sent to the datasource identified by scope, or false when the
+// request cannot be cached. Only the GET requests and the POST requests sending a form (like the queries of
+// Prometheus) can be cached. The timestamps of the query are rounded down to the bucket, and the values of the
+// varyHeaders are part of the key.
+// When the request is a POST, its body is read and replaced, so it can still be forwarded.
+func Key(scope string, req *http.Request, path string, bucket time.Duration, varyHeaders []string) (string, bool) {
+	cacheControl := strings.ToLower(req.Header.Get("Cache-Control"))
+	if strings.Contains(cacheControl, "no-cache") || strings.Contains(cacheControl, "no-store") {
+		return "", false
+	}
+	parameters := url.Values{}
+	for name, values := range req.URL.Query() {
+		parameters[name] = append(parameters[name], values...)
+	}
+	switch req.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		if !strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+			return "", false
+		}
+		if req.Body != nil {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return "", false
+			}
+			req.Body = io.NopCloser(strings.NewReader(string(body)))
+			form, err := url.ParseQuery(string(body))
+			if err != nil {
+				return "", false
+			}
+			for name, values := range form {
+				parameters[name] = append(parameters[name], values...)
+			}
+		}
+	default:
+		return "", false
+	}
+	for _, name := range timeParameters {
+		for i, value := range parameters[name] {
+			parameters[name][i] = roundTimestamp(value, bucket)
+		}
+	}
+	hash := sha256.New()
+	for _, part := range []string{scope, path, parameters.Encode(), req.Header.Get("Accept-Encoding")} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{'\n'})
+	}
+	for _, name := range varyHeaders {
+		hash.Write([]byte(http.CanonicalHeaderKey(name) + ":" + strings.Join(req.Header.Values(name), ",")))
+		hash.Write([]byte{'\n'})
+	}
+	return keyPrefix + hex.EncodeToString(hash.Sum(nil)), true
+}
+
+// roundTimestamp rounds down a timestamp expressed as a number of seconds or in RFC3339 to the bucket. A value that
+// is not a timestamp is returned as is.
+func roundTimestamp(value string, bucket time.Duration) string {
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		t, parseErr := time.Parse(time.RFC3339Nano, value)
+		if parseErr != nil {
+			return value
+		}
+		seconds = float64(t.UnixNano()) / float64(time.Second)
+	}
+	bucketSeconds := math.Max(bucket.Seconds(), 1)
+	return strconv.FormatInt(int64(math.Floor(seconds/bucketSeconds)*bucketSeconds), 10)
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKey(t *testing.T) {
+	get := func(target string) *http.Request {
+		return httptest.NewRequest(http.MethodGet, target, nil)
+	}
+	post := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/query_range", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req
+	}
+	key := func(scope string, req *http.Request) string {
+		k, ok := Key(scope, req, "/api/v1/query_range", time.Minute, IdentityHeaders)
+		require.True(t, ok)
+		return k
+	}
+
+	reference := key("global/prometheus", get("/?query=up&start=1700000000&end=1700003600&step=15"))
+	// the timestamps in the same bucket of one minute give the same key
+	assert.Equal(t, reference, key("global/prometheus", get("/?step=15&query=up&start=1700000010.5&end=1700003630")))
+	assert.Equal(t, reference, key("global/prometheus", post("query=up&start=1700000000&end=1700003600&step=15")))
+	assert.NotEqual(t, reference, key("global/prometheus", get("/?query=up&start=1700000060&end=1700003600&step=15")))
+	assert.NotEqual(t, reference, key("project/perses/prometheus", get("/?query=up&start=1700000000&end=1700003600&step=15")))
+
+	// the body of a POST request is still readable after the key is computed
+	req := post("query=up")
+	key("global/prometheus", req)
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "query=up", string(body))
+
+	noCache := get("/?query=up")
+	noCache.Header.Set("Cache-Control", "no-cache")
+	_, ok := Key("global/prometheus", noCache, "/api/v1/query", time.Minute, nil)
+	assert.False(t, ok)
+	_, ok = Key("global/prometheus", httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}")), "/api/v1/query", time.Minute, nil)
+	assert.False(t, ok)
+}
+
+func TestKeyIdentityHeaders(t *testing.T) {
+	withHeader := func(name string, value string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/?query=up", nil)
+		if len(value) > 0 {
+			req.Header.Set(name, value)
+		}
+		return req
+	}
+	key := func(req *http.Request, varyHeaders []string) string {
+		k, ok := Key("global/prometheus", req, "/api/v1/query", time.Minute, varyHeaders)
+		require.True(t, ok)
+		return k
+	}
+
+	// the responses fetched by two users with their own credentials are not shared
+	alice := key(withHeader("Authorization", "Bearer alice"), IdentityHeaders)
+	bob := key(withHeader("Authorization", "Bearer bob"), IdentityHeaders)
+	assert.NotEqual(t, alice, bob)
+	assert.Equal(t, alice, key(withHeader("Authorization", "Bearer alice"), IdentityHeaders))
+	assert.NotEqual(t, alice, key(withHeader("Authorization", ""), IdentityHeaders))
+	assert.NotEqual(t, key(withHeader("X-Scope-OrgID", "tenant-a"), IdentityHeaders), key(withHeader("X-Scope-OrgID", "tenant-b"), IdentityHeaders))
+	// a header that is not forwarded to the datasource doesn't change the key
+	assert.Equal(t, key(withHeader("Authorization", "Bearer alice"), nil), key(withHeader("Authorization", "Bearer bob"), nil))
+}
+
+func TestRoundTimestamp(t *testing.T) {
+	assert.Equal(t, "1699999980", roundTimestamp("1700000000", time.Minute))
+	assert.Equal(t, "1699999980", roundTimestamp("2023-11-14T22:13:20Z", time.Minute))
+	assert.Equal(t, "now", roundTimestamp("now", time.Minute))
+}
+
+func TestMemory(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(2)
+	m.Set(ctx, "a", &Response{StatusCode: http.StatusOK, Body: []byte("a")}, time.Hour)
+	m.Set(ctx, "b", &Response{StatusCode: http.StatusOK, Body: []byte("b")}, time.Hour)
+	// reading a makes b the least recently used entry, evicted by the addition of c
+	_, ok := m.Get(ctx, "a")
+	assert.True(t, ok)
+	m.Set(ctx, "c", &Response{StatusCode: http.StatusOK, Body: []byte("c")}, time.Hour)
+	_, ok = m.Get(ctx, "b")
+	assert.False(t, ok)
+	response, ok := m.Get(ctx, "c")
+	require.True(t, ok)
+	assert.Equal(t, "c", string(response.Body))
+
+	m.Set(ctx, "d", &Response{StatusCode: http.StatusOK}, time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	_, ok = m.Get(ctx, "d")
+	assert.False(t, ok)
+}
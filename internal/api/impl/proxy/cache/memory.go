@@ -0,0 +1,82 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	key       string
+	response  *Response
+	expiresAt time.Time
+}
+
+// memory is a LRU cache kept in the memory of the server.
+type memory struct {
+	mutex      sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	// order holds the entries from the most to the least recently used.
+	order *list.List
+}
+
+// NewMemory returns a cache holding at most maxEntries responses in memory. The least recently used responses are
+// evicted first.
+func NewMemory(maxEntries int) Cache {
+	return &memory{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+func (m *memory) Get(_ context.Context, key string) (*Response, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*memoryEntry)
+	if time.Now().After(entry.expiresAt) {
+		m.remove(element)
+		return nil, false
+	}
+	m.order.MoveToFront(element)
+	return entry.response, true
+}
+
+func (m *memory) Set(_ context.Context, key string, response *Response, ttl time.Duration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	entry := &memoryEntry{key: key, response: response, expiresAt: time.Now().Add(ttl)}
+	if element, ok := m.entries[key]; ok {
+		element.Value = entry
+		m.order.MoveToFront(element)
+		return
+	}
+	m.entries[key] = m.order.PushFront(entry)
+	for m.order.Len() > m.maxEntries {
+		m.remove(m.order.Back())
+	}
+}
+
+func (m *memory) remove(element *list.Element) {
+	m.order.Remove(element)
+	delete(m.entries, element.Value.(*memoryEntry).key)
+}
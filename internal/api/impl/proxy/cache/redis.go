@@ -0,0 +1,227 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultRedisPoolSize = 10
+	defaultRedisTimeout  = time.Second
+)
+
+var errNil = errors.New("redis: nil")
+
+// RedisOptions are the options of the connection to Redis.
+type RedisOptions struct {
+	// Addr is the address of the server, e.g. localhost:6379
+	Addr     string
+	Username string
+	Password string
+	// DB is the number of the database selected after connecting.
+	DB        int
+	TLSConfig *tls.Config
+	// PoolSize is the maximum number of idle connections kept open.
+	PoolSize int
+	// Timeout applies to the connection and to every command.
+	Timeout time.Duration
+}
+
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// redis is a cache shared by all the Perses servers connected to the same Redis. It speaks the RESP protocol and only
+// uses the commands GET and SET.
+type redis struct {
+	options RedisOptions
+	pool    chan *redisConn
+}
+
+// NewRedis returns a cache storing the responses in Redis, with the TTL managed by Redis.
+func NewRedis(options RedisOptions) Cache {
+	if options.PoolSize <= 0 {
+		options.PoolSize = defaultRedisPoolSize
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = defaultRedisTimeout
+	}
+	return &redis{options: options, pool: make(chan *redisConn, options.PoolSize)}
+}
+
+func (r *redis) Get(ctx context.Context, key string) (*Response, bool) {
+	reply, err := r.do(ctx, "GET", key)
+	if err != nil {
+		if !errors.Is(err, errNil) {
+			logrus.WithError(err).Error("unable to read the response of the query from redis")
+		}
+		return nil, false
+	}
+	response := &Response{}
+	if unmarshalErr := json.Unmarshal([]byte(reply), response); unmarshalErr != nil {
+		logrus.WithError(unmarshalErr).Error("unable to decode the response of the query read from redis")
+		return nil, false
+	}
+	return response, true
+}
+
+func (r *redis) Set(ctx context.Context, key string, response *Response, ttl time.Duration) {
+	data, err := json.Marshal(response)
+	if err != nil {
+		logrus.WithError(err).Error("unable to encode the response of the query")
+		return
+	}
+	if _, err := r.do(ctx, "SET", key, string(data), "PX", strconv.FormatInt(ttl.Milliseconds(), 10)); err != nil {
+		logrus.WithError(err).Error("unable to write the response of the query in redis")
+	}
+}
+
+// do sends the command and returns its reply. errNil is returned when the reply is nil, e.g. for a missing key.
+func (r *redis) do(ctx context.Context, args ...string) (string, error) {
+	c, err := r.getConn(ctx)
+	if err != nil {
+		return "", err
+	}
+	reply, err := c.do(r.deadline(ctx), args...)
+	// A connection can be reused only if the whole reply has been read.
+	var redisErr redisError
+	if err != nil && !errors.Is(err, errNil) && !errors.As(err, &redisErr) {
+		_ = c.conn.Close()
+		return "", err
+	}
+	r.putConn(c)
+	return reply, err
+}
+
+func (r *redis) deadline(ctx context.Context) time.Time {
+	deadline := time.Now().Add(r.options.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		return ctxDeadline
+	}
+	return deadline
+}
+
+func (r *redis) getConn(ctx context.Context) (*redisConn, error) {
+	select {
+	case c := <-r.pool:
+		return c, nil
+	default:
+	}
+	dialer := &net.Dialer{Timeout: r.options.Timeout}
+	var conn net.Conn
+	var err error
+	if r.options.TLSConfig != nil {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: r.options.TLSConfig}).DialContext(ctx, "tcp", r.options.Addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", r.options.Addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	c := &redisConn{conn: conn, reader: bufio.NewReader(conn)}
+	if len(r.options.Password) > 0 {
+		args := []string{"AUTH", r.options.Password}
+		if len(r.options.Username) > 0 {
+			args = []string{"AUTH", r.options.Username, r.options.Password}
+		}
+		if _, authErr := c.do(r.deadline(ctx), args...); authErr != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("unable to authenticate: %w", authErr)
+		}
+	}
+	if r.options.DB != 0 {
+		if _, selectErr := c.do(r.deadline(ctx), "SELECT", strconv.Itoa(r.options.DB)); selectErr != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("unable to select the database %d: %w", r.options.DB, selectErr)
+		}
+	}
+	return c, nil
+}
+
+func (r *redis) putConn(c *redisConn) {
+	select {
+	case r.pool <- c:
+	default:
+		_ = c.conn.Close()
+	}
+}
+
+// redisError is an error returned by Redis. The connection remains usable.
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+func (c *redisConn) do(deadline time.Time, args ...string) (string, error) {
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return "", err
+	}
+	var command strings.Builder
+	fmt.Fprintf(&command, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&command, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(c.conn, command.String()); err != nil {
+		return "", err
+	}
+	return c.readReply()
+}
+
+// readReply reads a reply made of a simple string, an error, an integer or a bulk string.
+func (c *redisConn) readReply() (string, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if len(line) == 0 {
+		return "", fmt.Errorf("redis: empty reply")
+	}
+	switch line[0] {
+	case '+', ':':
+		return line[1:], nil
+	case '-':
+		return "", redisError(line[1:])
+	case '$':
+		size, convErr := strconv.Atoi(line[1:])
+		if convErr != nil {
+			return "", fmt.Errorf("redis: invalid bulk string size %q", line[1:])
+		}
+		if size < 0 {
+			return "", errNil
+		}
+		data := make([]byte, size+2)
+		if _, readErr := io.ReadFull(c.reader, data); readErr != nil {
+			return "", readErr
+		}
+		return string(data[:size]), nil
+	default:
+		return "", fmt.Errorf("redis: unexpected reply %q", line)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedis is a server implementing the commands AUTH, SELECT, GET and SET of Redis.
+type fakeRedis struct {
+	mutex    sync.Mutex
+	password string
+	values   map[string]string
+	ttls     map[string]string
+}
+
+func (f *fakeRedis) serve(t *testing.T, listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(t, conn)
+	}
+}
+
+func (f *fakeRedis) handle(t *testing.T, conn net.Conn) {
+	defer conn.Close() //nolint: errcheck
+	reader := bufio.NewReader(conn)
+	authenticated := len(f.password) == 0
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		f.mutex.Lock()
+		var reply string
+		switch {
+		case args[0] == "AUTH":
+			authenticated = args[len(args)-1] == f.password
+			reply = "+OK\r\n"
+			if !authenticated {
+				reply = "-WRONGPASS invalid password\r\n"
+			}
+		case !authenticated:
+			reply = "-NOAUTH Authentication required.\r\n"
+		case args[0] == "SELECT":
+			reply = "+OK\r\n"
+		case args[0] == "GET":
+			value, ok := f.values[args[1]]
+			reply = "$-1\r\n"
+			if ok {
+				reply = fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+			}
+		case args[0] == "SET":
+			f.values[args[1]] = args[2]
+			f.ttls[args[1]] = args[4]
+			reply = "+OK\r\n"
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+		f.mutex.Unlock()
+		if _, err := io.WriteString(conn, reply); err != nil {
+			t.Log(err)
+			return
+		}
+	}
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+	args := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		if _, err := reader.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		arg, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, strings.TrimSuffix(arg, "\r\n"))
+	}
+	return args, nil
+}
+
+func newFakeRedis(t *testing.T, password string) (*fakeRedis, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	server := &fakeRedis{password: password, values: map[string]string{}, ttls: map[string]string{}}
+	go server.serve(t, listener)
+	return server, listener.Addr().String()
+}
+
+func TestRedis(t *testing.T) {
+	ctx := context.Background()
+	server, addr := newFakeRedis(t, "secret")
+	r := NewRedis(RedisOptions{Addr: addr, Password: "secret", DB: 1})
+
+	_, ok := r.Get(ctx, "key")
+	assert.False(t, ok)
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("Date", "today")
+	r.Set(ctx, "key", NewResponse(http.StatusOK, header, []byte(`{"status":"success"}`)), 30*time.Second)
+	assert.Equal(t, "30000", server.ttls["key"])
+
+	response, ok := r.Get(ctx, "key")
+	require.True(t, ok)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, `{"status":"success"}`, string(response.Body))
+	assert.Equal(t, "application/json", response.Header.Get("Content-Type"))
+	assert.Empty(t, response.Header.Get("Date"))
+}
+
+func TestRedisWrongPassword(t *testing.T) {
+	_, addr := newFakeRedis(t, "secret")
+	r := NewRedis(RedisOptions{Addr: addr, Password: "wrong"})
+	r.Set(context.Background(), "key", &Response{StatusCode: http.StatusOK}, time.Minute)
+	_, ok := r.Get(context.Background(), "key")
+	assert.False(t, ok)
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	apiinterface "github.com/perses/perses/internal/api/interface"
+	"github.com/sirupsen/logrus"
+)
+
+// clickHouseFormat is the output format asked to ClickHouse, so the response has the same shape as the other SQL drivers.
+const clickHouseFormat = "CSVWithNames"
+
+// serveClickHouse executes the query with the HTTP interface of ClickHouse and writes the result as CSV.
+func (s *sqlProxy) serveClickHouse(c echo.Context, q *sqlQuery, tlsConfig *tls.Config) error {
+	body, err := s.queryClickHouse(c.Request().Context(), q.Query, tlsConfig)
+	if err != nil {
+		logrus.WithError(err).Error("unable to execute the query")
+		return apiinterface.InternalError
+	}
+	defer func() {
+		if closeErr := body.Close(); closeErr != nil {
+			logrus.WithError(closeErr).Error("unable to close the ClickHouse response")
+		}
+	}()
+
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv")
+	c.Response().Header().Set(echo.HeaderContentDisposition, "attachment; filename=result.csv")
+	c.Response().WriteHeader(http.StatusOK)
+	if _, err = io.Copy(c.Response(), body); err != nil {
+		logrus.WithError(err).Error("unable to write the query result")
+		return apiinterface.InternalError
+	}
+	return nil
+}
+
+// queryClickHouse sends the query to ClickHouse and returns the body of the response when the query succeeded.
+func (s *sqlProxy) queryClickHouse(ctx context.Context, query string, tlsConfig *tls.Config) (io.ReadCloser, error) {
+	scheme := "http"
+	params := url.Values{}
+	params.Set("database", s.config.Database)
+	params.Set("default_format", clickHouseFormat)
+	client := &http.Client{}
+	if cfg := s.config.ClickHouse; cfg != nil {
+		if cfg.Secure {
+			scheme = "https"
+		}
+		for key, value := range cfg.Settings {
+			params.Set(key, value)
+		}
+		client.Timeout = cfg.Timeout
+	}
+	if tlsConfig != nil {
+		client.Transport = &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: tlsConfig}
+	}
+	u := &url.URL{Scheme: scheme, Host: s.config.Host, Path: "/", RawQuery: params.Encode()}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), strings.NewReader(query))
+	if err != nil {
+		return nil, err
+	}
+	if s.username != "" {
+		req.Header.Set("X-ClickHouse-User", s.username)
+	}
+	if s.password != "" {
+		req.Header.Set("X-ClickHouse-Key", s.password)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		// ClickHouse explains in the body why the query failed
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("ClickHouse responded with the status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return resp.Body, nil
+}
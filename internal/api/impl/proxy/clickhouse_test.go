@@ -0,0 +1,106 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	datasourceSQL "github.com/perses/perses/pkg/model/api/v1/datasource/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLProxy_queryClickHouse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get("X-ClickHouse-User") != "perses" || r.Header.Get("X-ClickHouse-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte("Code: 516. DB::Exception: perses: Authentication failed"))
+			return
+		}
+		if !strings.HasPrefix(string(body), "SELECT") {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Code: 62. DB::Exception: Syntax error"))
+			return
+		}
+		query := r.URL.Query()
+		_, _ = w.Write([]byte("database,format,max_execution_time\n"))
+		_, _ = w.Write([]byte(query.Get("database") + "," + query.Get("default_format") + "," + query.Get("max_execution_time") + "\n"))
+	}))
+	defer server.Close()
+
+	newProxy := func() *sqlProxy {
+		return &sqlProxy{
+			config: &datasourceSQL.Config{
+				Driver:   datasourceSQL.DriverClickHouse,
+				Host:     strings.TrimPrefix(server.URL, "http://"),
+				Database: "otel",
+				ClickHouse: &datasourceSQL.ClickHouseConfig{
+					Settings: map[string]string{"max_execution_time": "30"},
+				},
+			},
+			username: "perses",
+			password: "secret",
+		}
+	}
+
+	testSuite := []struct {
+		name          string
+		query         string
+		password      string
+		result        string
+		errorContains string
+	}{
+		{
+			name:   "successful query",
+			query:  "SELECT 1",
+			result: "database,format,max_execution_time\notel,CSVWithNames,30\n",
+		},
+		{
+			name:          "invalid query",
+			query:         "SELEC 1",
+			errorContains: "status 400: Code: 62",
+		},
+		{
+			name:          "wrong credentials",
+			query:         "SELECT 1",
+			password:      "wrong",
+			errorContains: "status 401",
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.name, func(t *testing.T) {
+			proxy := newProxy()
+			if test.password != "" {
+				proxy.password = test.password
+			}
+			body, err := proxy.queryClickHouse(context.Background(), test.query, nil)
+			if test.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			defer body.Close()
+			result, err := io.ReadAll(body)
+			require.NoError(t, err)
+			assert.Equal(t, test.result, string(result))
+		})
+	}
+}
@@ -28,7 +28,7 @@ import (
 func (e *endpoint) proxyGlobalDatasource(ctx echo.Context, datasourceName string, spec v1.DatasourceSpec) error {
 	path := ctx.Param("*")
 
-	pr, err := newProxy(datasourceName, "", spec, path, e.crypto, func(name string) (*v1.SecretSpec, error) {
+	pr, err := newProxy(datasourceName, "", spec, path, e.crypto, e.newQueryCache(fmt.Sprintf("global/%s", datasourceName), spec), func(name string) (*v1.SecretSpec, error) {
 		return e.getGlobalSecret(datasourceName, name)
 	})
 	if err != nil {
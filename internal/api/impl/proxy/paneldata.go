@@ -0,0 +1,661 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	apiinterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/datasource"
+	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
+	"github.com/perses/perses/internal/api/utils"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	datasourceHTTP "github.com/perses/perses/pkg/model/api/v1/datasource/http"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/prometheus/common/model"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	prometheusQueryKind      = "PrometheusTimeSeriesQuery"
+	prometheusDatasourceKind = "PrometheusDatasource"
+	prometheusQueryRangePath = "/api/v1/query_range"
+	// defaultPanelDataPoints is the number of points of each series when the step is not provided.
+	defaultPanelDataPoints   = 250
+	defaultPanelDataDuration = time.Hour
+	defaultScrapeInterval    = 15 * time.Second
+	// panelVariablePrefix is the prefix of the query parameters giving the values of the variables of the dashboard,
+	// e.g. var-instance=localhost:9090
+	panelVariablePrefix = "var-"
+	// maxErrorBodySize is the maximum size of the body of an error response of a datasource reported in the stream.
+	maxErrorBodySize = 1024
+)
+
+// variableReferenceRegexp matches the references to the variables in the queries: $name, ${name} and ${name:format}.
+var variableReferenceRegexp = regexp.MustCompile(`\$\{(\w+)(?::\w+)?\}|\$(\w+)`)
+
+const (
+	resultEvent   = "result"
+	completeEvent = "complete"
+	errorEvent    = "error"
+	doneEvent     = "done"
+)
+
+// panelDataEvent is an event sent on the stream of the panel data. A query of the panel produces an event "result" for
+// each series, as soon as the series is decoded from the response of the datasource. The query then ends with an
+// event "complete" when it succeeds, or with an event "error" otherwise.
+type panelDataEvent struct {
+	name string
+	// Query is the index of the query in the panel.
+	Query int `json:"query"`
+	// ResultType is the type of the result returned by the datasource, e.g. matrix.
+	ResultType string `json:"resultType,omitempty"`
+	// Series is a series of the result, as returned by the datasource.
+	Series json.RawMessage `json:"series,omitempty"`
+	// Error explains why the query failed.
+	Error string `json:"error,omitempty"`
+}
+
+func (e panelDataEvent) isLast() bool {
+	return e.name == completeEvent || e.name == errorEvent
+}
+
+type panelTimeRange struct {
+	start time.Time
+	end   time.Time
+	step  time.Duration
+}
+
+// panelQuery is a query of the panel ready to be sent to its datasource.
+type panelQuery struct {
+	proxy *httpProxy
+	form  url.Values
+	err   error
+}
+
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+// streamPanelData runs the queries of a panel of a saved dashboard and streams their results with Server-Sent Events.
+// The queries are sent in parallel and each series is sent as soon as it is decoded from the response of its
+// datasource, so the client doesn't wait for the slowest query before displaying the others. The stream ends with an
+// event named "done".
+func (e *endpoint) streamPanelData(ctx echo.Context) error {
+	projectName := ctx.Param(utils.ParamProject)
+	dashboardName := ctx.Param(utils.ParamDashboard)
+	panelName := ctx.Param(utils.ParamPanel)
+	if err := e.checkResourcePermission(ctx, projectName, role.DashboardScope, role.ReadAction, dashboardName); err != nil {
+		return err
+	}
+	db, err := e.dashboard.Get(projectName, dashboardName)
+	if err != nil {
+		if databaseModel.IsKeyNotFound(err) {
+			return apiinterface.HandleNotFoundError(fmt.Sprintf("dashboard %q doesn't exist", dashboardName))
+		}
+		logrus.WithError(err).Errorf("unable to find the dashboard %q, something wrong with the database", dashboardName)
+		return apiinterface.InternalError
+	}
+	panel, ok := db.Spec.Panels[panelName]
+	if !ok {
+		return apiinterface.HandleNotFoundError(fmt.Sprintf("panel %q doesn't exist in the dashboard %q", panelName, dashboardName))
+	}
+	params := ctx.QueryParams()
+	timeRange, err := parsePanelTimeRange(params, db.Spec, time.Now())
+	if err != nil {
+		return apiinterface.HandleBadRequestError(err.Error())
+	}
+	variables := panelVariables(params)
+
+	// The datasources are resolved before starting the stream, as it requires the permissions of the user.
+	queries := make([]*panelQuery, len(panel.Spec.Queries))
+	for i, query := range panel.Spec.Queries {
+		queries[i] = e.preparePanelQuery(ctx, db, query, timeRange, variables)
+	}
+
+	res := ctx.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	// Disable the buffering of the reverse proxies like nginx, so the events are received as soon as they are sent.
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	reqCtx := ctx.Request().Context()
+	events := make(chan panelDataEvent, len(queries))
+	for i, query := range queries {
+		go func() {
+			send := func(event panelDataEvent) bool {
+				event.Query = i
+				select {
+				case events <- event:
+					return true
+				case <-reqCtx.Done():
+					return false
+				}
+			}
+			if queryErr := query.run(reqCtx, send); queryErr != nil {
+				send(panelDataEvent{name: errorEvent, Error: queryErr.Error()})
+				return
+			}
+			send(panelDataEvent{name: completeEvent})
+		}()
+	}
+	for pending := len(queries); pending > 0; {
+		select {
+		case <-reqCtx.Done():
+			// The client has gone away, the queries are canceled with the context of the request.
+			return nil
+		case event := <-events:
+			if event.isLast() {
+				pending--
+			}
+			if writeErr := writeEvent(res, event.name, event); writeErr != nil {
+				logrus.WithError(writeErr).Debug("unable to write the panel data, the client has likely gone away")
+				return nil
+			}
+		}
+	}
+	if writeErr := writeEvent(res, doneEvent, struct{}{}); writeErr != nil {
+		logrus.WithError(writeErr).Debug("unable to end the stream of the panel data")
+	}
+	return nil
+}
+
+func writeEvent(res *echo.Response, name string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", name, payload); err != nil {
+		return err
+	}
+	res.Flush()
+	return nil
+}
+
+// preparePanelQuery resolves the datasource of the query and builds the request to send. The error of a query that
+// cannot be sent is kept in the panelQuery, so it is reported in the stream like the error of the datasource.
+func (e *endpoint) preparePanelQuery(ctx echo.Context, db *v1.Dashboard, query v1.Query, timeRange panelTimeRange, variables map[string][]string) *panelQuery {
+	if query.Spec.Plugin.Kind != prometheusQueryKind {
+		return &panelQuery{err: fmt.Errorf("the query %s cannot be streamed, only the %s are supported", query.Spec.Plugin.Kind, prometheusQueryKind)}
+	}
+	spec, _ := query.Spec.Plugin.Spec.(map[string]interface{})
+	datasourceName := queryDatasourceName(query, spec)
+	if len(datasourceName) > 0 {
+		datasourceName = interpolate(datasourceName, variables)
+		if strings.HasPrefix(datasourceName, "$") {
+			return &panelQuery{err: fmt.Errorf("the value of the variable %s is missing", datasourceName)}
+		}
+	}
+	pr, scrapeInterval, err := e.findPanelDatasource(ctx, db, prometheusDatasourceKind, datasourceName)
+	if err != nil {
+		return &panelQuery{err: err}
+	}
+
+	step := timeRange.step
+	if minStep, ok := spec["minStep"].(string); ok && len(minStep) > 0 {
+		if d, parseErr := model.ParseDuration(interpolate(minStep, variables)); parseErr == nil && time.Duration(d) > step {
+			step = time.Duration(d)
+		}
+	}
+	// The variables defined by Perses for the queries of Prometheus.
+	rangeDuration := timeRange.end.Sub(timeRange.start)
+	rateInterval := max(step+scrapeInterval, 4*scrapeInterval)
+	queryVariables := map[string][]string{
+		"__interval":      {model.Duration(step).String()},
+		"__interval_ms":   {strconv.FormatInt(step.Milliseconds(), 10)},
+		"__range":         {model.Duration(rangeDuration).String()},
+		"__range_s":       {strconv.FormatInt(int64(rangeDuration.Seconds()), 10)},
+		"__range_ms":      {strconv.FormatInt(rangeDuration.Milliseconds(), 10)},
+		"__rate_interval": {model.Duration(rateInterval).String()},
+	}
+	for name, values := range variables {
+		queryVariables[name] = values
+	}
+	promQL, _ := spec["query"].(string)
+	if len(promQL) == 0 {
+		return &panelQuery{err: fmt.Errorf("the query is empty")}
+	}
+	return &panelQuery{
+		proxy: pr,
+		form: url.Values{
+			"query": {interpolate(promQL, queryVariables)},
+			"start": {formatTimestamp(timeRange.start)},
+			"end":   {formatTimestamp(timeRange.end)},
+			"step":  {strconv.FormatFloat(step.Seconds(), 'f', -1, 64)},
+		},
+	}
+}
+
+// run sends the query to its datasource and gives each series of the result to send as soon as it is decoded.
+// It stops when send returns false, i.e. when the client has gone away.
+func (q *panelQuery) run(ctx context.Context, send func(event panelDataEvent) bool) error {
+	if q.err != nil {
+		return q.err
+	}
+	resp, err := q.proxy.post(ctx, q.form)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize+1))
+		response := &prometheusResponse{}
+		if unmarshalErr := json.Unmarshal(body, response); unmarshalErr == nil && len(response.Error) > 0 {
+			return fmt.Errorf("the query failed: %s", response.Error)
+		}
+		return fmt.Errorf("the datasource answered with the status %d: %s", resp.StatusCode, truncate(body))
+	}
+	return decodePrometheusResponse(json.NewDecoder(resp.Body), send)
+}
+
+// decodePrometheusResponse reads the response of Prometheus token by token, so each series of the result is given to
+// send without waiting for the rest of the response.
+func decodePrometheusResponse(decoder *json.Decoder, send func(event panelDataEvent) bool) error {
+	response := &prometheusResponse{}
+	err := decodeObject(decoder, func(key string) error {
+		switch key {
+		case "status":
+			return decoder.Decode(&response.Status)
+		case "error":
+			return decoder.Decode(&response.Error)
+		case "data":
+			return decodePrometheusData(decoder, send)
+		default:
+			return decoder.Decode(&json.RawMessage{})
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("unable to decode the response of the datasource: %w", err)
+	}
+	if response.Status != "success" {
+		return fmt.Errorf("the query failed: %s", response.Error)
+	}
+	return nil
+}
+
+func decodePrometheusData(decoder *json.Decoder, send func(event panelDataEvent) bool) error {
+	var resultType string
+	return decodeObject(decoder, func(key string) error {
+		switch key {
+		case "resultType":
+			return decoder.Decode(&resultType)
+		case "result":
+			token, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			if token == nil {
+				return nil
+			}
+			if delim, ok := token.(json.Delim); !ok || delim != '[' {
+				return fmt.Errorf("the result is not an array")
+			}
+			for decoder.More() {
+				var series json.RawMessage
+				if decodeErr := decoder.Decode(&series); decodeErr != nil {
+					return decodeErr
+				}
+				if !send(panelDataEvent{name: resultEvent, ResultType: resultType, Series: series}) {
+					return context.Canceled
+				}
+			}
+			_, err = decoder.Token()
+			return err
+		default:
+			return decoder.Decode(&json.RawMessage{})
+		}
+	})
+}
+
+// decodeObject reads a JSON object and calls decodeValue for each key, which must read the value of the key.
+func decodeObject(decoder *json.Decoder, decodeValue func(key string) error) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected an object")
+	}
+	for decoder.More() {
+		keyToken, keyErr := decoder.Token()
+		if keyErr != nil {
+			return keyErr
+		}
+		key, _ := keyToken.(string)
+		if valueErr := decodeValue(key); valueErr != nil {
+			return valueErr
+		}
+	}
+	_, err = decoder.Token()
+	return err
+}
+
+// queryDatasourceName returns the name of the datasource targeted by the query. It is empty when the query uses the
+// default datasource.
+func queryDatasourceName(query v1.Query, spec map[string]interface{}) string {
+	if len(query.Spec.Datasource) > 0 {
+		return query.Spec.Datasource
+	}
+	switch selector := spec["datasource"].(type) {
+	case string:
+		return selector
+	case map[string]interface{}:
+		name, _ := selector["name"].(string)
+		return name
+	}
+	return ""
+}
+
+// findPanelDatasource looks for the datasource in the dashboard, then in the project and finally in the global
+// datasources, as the UI does. When the name is empty, the default datasource of the kind is used.
+// It returns the proxy to the datasource with its scrape interval.
+func (e *endpoint) findPanelDatasource(ctx echo.Context, db *v1.Dashboard, kind string, name string) (*httpProxy, time.Duration, error) {
+	projectName := db.Metadata.Project
+	if !e.cfg.DisableLocal {
+		if dtsName, spec := findDashboardDatasource(db, kind, name); spec != nil {
+			return e.newPanelProxy(dtsName, projectName, *spec, func(secretName string) (*v1.SecretSpec, error) {
+				return e.getProjectSecret(projectName, dtsName, secretName)
+			})
+		}
+	}
+	if !e.cfg.Project.Disable {
+		dts, err := e.findProjectDatasource(projectName, kind, name)
+		if err != nil {
+			return nil, 0, err
+		}
+		if dts != nil {
+			if permErr := e.checkPermission(ctx, projectName, role.DatasourceScope, role.ReadAction); permErr != nil {
+				return nil, 0, permErr
+			}
+			dtsName := dts.Metadata.Name
+			return e.newPanelProxy(dtsName, projectName, dts.Spec, func(secretName string) (*v1.SecretSpec, error) {
+				return e.getProjectSecret(projectName, dtsName, secretName)
+			})
+		}
+	}
+	if !e.cfg.Global.Disable {
+		dts, err := e.findGlobalDatasource(kind, name)
+		if err != nil {
+			return nil, 0, err
+		}
+		if dts != nil {
+			if permErr := e.checkPermission(ctx, v1.WildcardProject, role.GlobalDatasourceScope, role.ReadAction); permErr != nil {
+				return nil, 0, permErr
+			}
+			dtsName := dts.Metadata.Name
+			return e.newPanelProxy(dtsName, "", dts.Spec, func(secretName string) (*v1.SecretSpec, error) {
+				return e.getGlobalSecret(dtsName, secretName)
+			})
+		}
+	}
+	if len(name) == 0 {
+		return nil, 0, fmt.Errorf("no default %s found", kind)
+	}
+	return nil, 0, fmt.Errorf("the datasource %q doesn't exist", name)
+}
+
+func findDashboardDatasource(db *v1.Dashboard, kind string, name string) (string, *v1.DatasourceSpec) {
+	if len(name) > 0 {
+		if spec, ok := db.Spec.Datasources[name]; ok && spec.Plugin.Kind == kind {
+			return name, spec
+		}
+		return "", nil
+	}
+	names := make([]string, 0, len(db.Spec.Datasources))
+	for dtsName := range db.Spec.Datasources {
+		names = append(names, dtsName)
+	}
+	sort.Strings(names)
+	for _, dtsName := range names {
+		if spec := db.Spec.Datasources[dtsName]; spec.Default && spec.Plugin.Kind == kind {
+			return dtsName, spec
+		}
+	}
+	return "", nil
+}
+
+func (e *endpoint) findProjectDatasource(projectName string, kind string, name string) (*v1.Datasource, error) {
+	var list []*v1.Datasource
+	if len(name) > 0 {
+		dts, err := e.dts.Get(projectName, name)
+		if err != nil && !databaseModel.IsKeyNotFound(err) {
+			logrus.WithError(err).Errorf("unable to find the datasource %q, something wrong with the database", name)
+			return nil, apiinterface.InternalError
+		}
+		if dts != nil {
+			list = append(list, dts)
+		}
+	} else {
+		isDefault := true
+		var err error
+		list, err = e.dts.List(&datasource.Query{Project: projectName, Kind: kind, Default: &isDefault})
+		if err != nil {
+			logrus.WithError(err).Error("unable to list the default datasources, something wrong with the database")
+			return nil, apiinterface.InternalError
+		}
+	}
+	for _, dts := range list {
+		if dts.Spec.Plugin.Kind == kind {
+			return dts, nil
+		}
+	}
+	return nil, nil
+}
+
+func (e *endpoint) findGlobalDatasource(kind string, name string) (*v1.GlobalDatasource, error) {
+	var list []*v1.GlobalDatasource
+	if len(name) > 0 {
+		dts, err := e.globalDTS.Get(name)
+		if err != nil && !databaseModel.IsKeyNotFound(err) {
+			logrus.WithError(err).Errorf("unable to find the global datasource %q, something wrong with the database", name)
+			return nil, apiinterface.InternalError
+		}
+		if dts != nil {
+			list = append(list, dts)
+		}
+	} else {
+		isDefault := true
+		var err error
+		list, err = e.globalDTS.List(&globaldatasource.Query{Kind: kind, Default: &isDefault})
+		if err != nil {
+			logrus.WithError(err).Error("unable to list the default global datasources, something wrong with the database")
+			return nil, apiinterface.InternalError
+		}
+	}
+	for _, dts := range list {
+		if dts.Spec.Plugin.Kind == kind {
+			return dts, nil
+		}
+	}
+	return nil, nil
+}
+
+// newPanelProxy returns the proxy to the datasource, or a proxy to its direct URL when the datasource doesn't use the
+// proxy of Perses.
+func (e *endpoint) newPanelProxy(datasourceName, projectName string, spec v1.DatasourceSpec, retrieveSecret func(name string) (*v1.SecretSpec, error)) (*httpProxy, time.Duration, error) {
+	pluginSpec, _ := spec.Plugin.Spec.(map[string]interface{})
+	scrapeInterval := defaultScrapeInterval
+	if value, ok := pluginSpec["scrapeInterval"].(string); ok {
+		if d, err := model.ParseDuration(value); err == nil && d > 0 {
+			scrapeInterval = time.Duration(d)
+		}
+	}
+	if directURL, ok := pluginSpec["directUrl"].(string); ok && len(directURL) > 0 {
+		u, err := common.ParseURL(directURL)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid direct URL of the datasource %q: %w", datasourceName, err)
+		}
+		return &httpProxy{config: &datasourceHTTP.Config{URL: u}, path: prometheusQueryRangePath}, scrapeInterval, nil
+	}
+	pr, err := newProxy(datasourceName, projectName, spec, prometheusQueryRangePath, e.crypto, nil, retrieveSecret)
+	if err != nil {
+		return nil, 0, fmt.Errorf("unable to build the proxy of the datasource %q: %w", datasourceName, err)
+	}
+	httpPr, ok := pr.(*httpProxy)
+	if !ok {
+		return nil, 0, fmt.Errorf("the datasource %q doesn't use an HTTP proxy", datasourceName)
+	}
+	return httpPr, scrapeInterval, nil
+}
+
+// parsePanelTimeRange reads the parameters start, end and step of the request. When they are not provided, the time
+// range of the dashboard is used, and the step is computed to get defaultPanelDataPoints points per series.
+func parsePanelTimeRange(params url.Values, spec v1.DashboardSpec, now time.Time) (panelTimeRange, error) {
+	end := now
+	duration := time.Duration(spec.Duration)
+	if duration <= 0 {
+		duration = defaultPanelDataDuration
+	}
+	start := end.Add(-duration)
+	if spec.TimeRange != nil {
+		start, end = spec.TimeRange.Start, spec.TimeRange.End
+	}
+	if value := params.Get("end"); len(value) > 0 {
+		t, err := parseTimestamp(value)
+		if err != nil {
+			return panelTimeRange{}, fmt.Errorf("invalid end: %w", err)
+		}
+		end = t
+		if len(params.Get("start")) == 0 {
+			start = end.Add(-duration)
+		}
+	}
+	if value := params.Get("start"); len(value) > 0 {
+		t, err := parseTimestamp(value)
+		if err != nil {
+			return panelTimeRange{}, fmt.Errorf("invalid start: %w", err)
+		}
+		start = t
+	}
+	if !start.Before(end) {
+		return panelTimeRange{}, fmt.Errorf("start must be before end")
+	}
+	step := (end.Sub(start) / defaultPanelDataPoints).Truncate(time.Second)
+	if value := params.Get("step"); len(value) > 0 {
+		d, err := parseStep(value)
+		if err != nil {
+			return panelTimeRange{}, fmt.Errorf("invalid step: %w", err)
+		}
+		step = d
+	}
+	return panelTimeRange{start: start, end: end, step: max(step, time.Second)}, nil
+}
+
+// parseTimestamp parses a timestamp expressed as a number of seconds or in RFC3339.
+func parseTimestamp(value string) (time.Time, error) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		whole, fraction := math.Modf(seconds)
+		return time.Unix(int64(whole), int64(fraction*float64(time.Second))), nil
+	}
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+// parseStep parses a step expressed as a number of seconds or as a duration (e.g. 30s).
+func parseStep(value string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	d, err := model.ParseDuration(value)
+	return time.Duration(d), err
+}
+
+func formatTimestamp(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixMilli())/1000, 'f', -1, 64)
+}
+
+// panelVariables returns the values of the variables given by the parameters prefixed by var-.
+func panelVariables(params url.Values) map[string][]string {
+	variables := make(map[string][]string)
+	for name, values := range params {
+		if strings.HasPrefix(name, panelVariablePrefix) {
+			variables[strings.TrimPrefix(name, panelVariablePrefix)] = values
+		}
+	}
+	return variables
+}
+
+// interpolate replaces the references to the variables by their values. A variable with many values is replaced by
+// a regular expression matching any of them, e.g. (a|b). The references to unknown variables are kept as is.
+func interpolate(text string, variables map[string][]string) string {
+	return variableReferenceRegexp.ReplaceAllStringFunc(text, func(ref string) string {
+		match := variableReferenceRegexp.FindStringSubmatch(ref)
+		name := match[1]
+		if len(name) == 0 {
+			name = match[2]
+		}
+		values, ok := variables[name]
+		if !ok || len(values) == 0 {
+			return ref
+		}
+		if len(values) == 1 {
+			return values[0]
+		}
+		return "(" + strings.Join(values, "|") + ")"
+	})
+}
+
+func truncate(body []byte) string {
+	if len(body) > maxErrorBodySize {
+		return string(body[:maxErrorBodySize]) + "..."
+	}
+	return string(body)
+}
+
+// post sends the form to the datasource, with the headers and the authentication configured in the proxy.
+// The caller must close the body of the response.
+func (h *httpProxy) post(ctx context.Context, form url.Values) (*http.Response, error) {
+	if !h.isAllowed(http.MethodPost) {
+		return nil, fmt.Errorf("you are not allowed to use this endpoint %q with the HTTP method %s", h.path, http.MethodPost)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.config.URL.JoinPath(h.path).String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	for k, v := range h.config.Headers {
+		req.Header.Set(k, v)
+	}
+	if authErr := h.setupAuthentication(req); authErr != nil {
+		logrus.WithError(authErr).Error("unable to set up the authentication of the request")
+		return nil, fmt.Errorf("unable to set up the authentication of the request")
+	}
+	transport, err := h.prepareTransport()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		logrus.WithError(err).Errorf("unable to query the datasource %q", h.config.URL.String())
+		return nil, fmt.Errorf("the datasource is unreachable")
+	}
+	return resp, nil
+}
@@ -0,0 +1,226 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	datasourceHTTP "github.com/perses/perses/pkg/model/api/v1/datasource/http"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePanelTimeRange(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	spec := v1.DashboardSpec{Duration: common.Duration(6 * time.Hour)}
+	testSuite := []struct {
+		title    string
+		params   url.Values
+		spec     v1.DashboardSpec
+		expected panelTimeRange
+		err      string
+	}{
+		{
+			title:    "duration of the dashboard",
+			params:   url.Values{},
+			spec:     spec,
+			expected: panelTimeRange{start: now.Add(-6 * time.Hour), end: now, step: 86 * time.Second},
+		},
+		{
+			title:  "time range of the dashboard",
+			params: url.Values{},
+			spec: v1.DashboardSpec{TimeRange: &v1.AbsoluteTimeRange{
+				Start: time.Unix(1600000000, 0).UTC(),
+				End:   time.Unix(1600003600, 0).UTC(),
+			}},
+			expected: panelTimeRange{start: time.Unix(1600000000, 0).UTC(), end: time.Unix(1600003600, 0).UTC(), step: 14 * time.Second},
+		},
+		{
+			title:    "end in seconds and step as a duration",
+			params:   url.Values{"end": {"1600000000"}, "step": {"1m"}},
+			spec:     spec,
+			expected: panelTimeRange{start: time.Unix(1600000000, 0).Add(-6 * time.Hour), end: time.Unix(1600000000, 0), step: time.Minute},
+		},
+		{
+			title:    "start and end in RFC3339 and step in seconds",
+			params:   url.Values{"start": {"2020-09-13T12:00:00Z"}, "end": {"2020-09-13T13:00:00Z"}, "step": {"30"}},
+			spec:     spec,
+			expected: panelTimeRange{start: time.Date(2020, 9, 13, 12, 0, 0, 0, time.UTC), end: time.Date(2020, 9, 13, 13, 0, 0, 0, time.UTC), step: 30 * time.Second},
+		},
+		{
+			title:  "start after end",
+			params: url.Values{"start": {"1600000000"}, "end": {"1500000000"}},
+			spec:   spec,
+			err:    "start must be before end",
+		},
+		{
+			title:  "invalid step",
+			params: url.Values{"step": {"often"}},
+			spec:   spec,
+			err:    `invalid step: not a valid duration string: "often"`,
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			result, err := parsePanelTimeRange(test.params, test.spec, now)
+			if len(test.err) > 0 {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, test.expected.start.Equal(result.start), "start: expected %s, got %s", test.expected.start, result.start)
+			assert.True(t, test.expected.end.Equal(result.end), "end: expected %s, got %s", test.expected.end, result.end)
+			assert.Equal(t, test.expected.step, result.step)
+		})
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	variables := map[string][]string{
+		"job":      {"api"},
+		"instance": {"a:9090", "b:9090"},
+		"__range":  {"1h"},
+	}
+	testSuite := []struct {
+		title    string
+		text     string
+		expected string
+	}{
+		{
+			title:    "single value",
+			text:     `up{job="$job"}`,
+			expected: `up{job="api"}`,
+		},
+		{
+			title:    "many values",
+			text:     `up{instance=~"${instance}"}`,
+			expected: `up{instance=~"(a:9090|b:9090)"}`,
+		},
+		{
+			title:    "format",
+			text:     `rate(http_requests_total{job="${job:raw}"}[$__range])`,
+			expected: `rate(http_requests_total{job="api"}[1h])`,
+		},
+		{
+			title:    "unknown variable",
+			text:     `up{job="$unknown"}`,
+			expected: `up{job="$unknown"}`,
+		},
+		{
+			title:    "variable prefixed by another variable",
+			text:     `up{job="$jobs"}`,
+			expected: `up{job="$jobs"}`,
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			assert.Equal(t, test.expected, interpolate(test.text, variables))
+		})
+	}
+}
+
+func TestFindDashboardDatasource(t *testing.T) {
+	db := &v1.Dashboard{Spec: v1.DashboardSpec{Datasources: map[string]*v1.DatasourceSpec{
+		"prom":    {Plugin: common.Plugin{Kind: prometheusDatasourceKind}},
+		"default": {Default: true, Plugin: common.Plugin{Kind: prometheusDatasourceKind}},
+		"tempo":   {Default: true, Plugin: common.Plugin{Kind: "TempoDatasource"}},
+	}}}
+	name, spec := findDashboardDatasource(db, prometheusDatasourceKind, "prom")
+	assert.Equal(t, "prom", name)
+	assert.NotNil(t, spec)
+	name, spec = findDashboardDatasource(db, prometheusDatasourceKind, "")
+	assert.Equal(t, "default", name)
+	assert.NotNil(t, spec)
+	_, spec = findDashboardDatasource(db, prometheusDatasourceKind, "tempo")
+	assert.Nil(t, spec)
+}
+
+func TestPanelQueryRun(t *testing.T) {
+	testSuite := []struct {
+		title      string
+		statusCode int
+		body       string
+		expected   []string
+		err        string
+	}{
+		{
+			title:      "one event per series",
+			statusCode: http.StatusOK,
+			body:       `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{"job":"a"},"values":[[1,"1"]]},{"metric":{"job":"b"},"values":[[1,"2"]]}]}}`,
+			expected: []string{
+				`{"metric":{"job":"a"},"values":[[1,"1"]]}`,
+				`{"metric":{"job":"b"},"values":[[1,"2"]]}`,
+			},
+		},
+		{
+			title:      "empty result",
+			statusCode: http.StatusOK,
+			body:       `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+		},
+		{
+			title:      "error of Prometheus",
+			statusCode: http.StatusBadRequest,
+			body:       `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+			err:        "the query failed: parse error",
+		},
+		{
+			title:      "error of a reverse proxy",
+			statusCode: http.StatusBadGateway,
+			body:       "bad gateway",
+			err:        "the datasource answered with the status 502: bad gateway",
+		},
+		{
+			title:      "truncated response",
+			statusCode: http.StatusOK,
+			body:       `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{"job":"a"},"values":[[1,"1"]]},`,
+			expected:   []string{`{"metric":{"job":"a"},"values":[[1,"1"]]}`},
+			err:        "unable to decode the response of the datasource: unexpected end of JSON input",
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, prometheusQueryRangePath, r.URL.Path)
+				w.WriteHeader(test.statusCode)
+				_, _ = w.Write([]byte(test.body))
+			}))
+			defer server.Close()
+			u, err := common.ParseURL(server.URL)
+			assert.NoError(t, err)
+			query := &panelQuery{
+				proxy: &httpProxy{config: &datasourceHTTP.Config{URL: u}, path: prometheusQueryRangePath},
+				form:  url.Values{"query": {"up"}},
+			}
+			var series []string
+			err = query.run(context.Background(), func(event panelDataEvent) bool {
+				assert.Equal(t, resultEvent, event.name)
+				assert.Equal(t, "matrix", event.ResultType)
+				series = append(series, string(event.Series))
+				return true
+			})
+			if len(test.err) > 0 {
+				assert.EqualError(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expected, series)
+		})
+	}
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/perses/perses/internal/api/authorization"
 	"github.com/perses/perses/internal/api/crypto"
+	"github.com/perses/perses/internal/api/impl/proxy/cache"
 	apiinterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
@@ -113,6 +114,7 @@ type endpoint struct {
 	globalDTS    globaldatasource.DAO
 	crypto       crypto.Crypto
 	authz        authorization.Authorization
+	cache        cache.Cache
 }
 
 func New(cfg config.DatasourceConfig, dashboardDAO dashboard.DAO, secretDAO secret.DAO, globalSecretDAO globalsecret.DAO,
@@ -126,6 +128,7 @@ func New(cfg config.DatasourceConfig, dashboardDAO dashboard.DAO, secretDAO secr
 		globalDTS:    globalDtsDAO,
 		crypto:       crypto,
 		authz:        authz,
+		cache:        newCache(cfg.Cache),
 	}
 }
 
@@ -148,9 +151,16 @@ func (e *endpoint) CollectRoutes(g *route.Group) {
 		g.POST(fmt.Sprintf("/%s/:%s/%s/:%s/%s/:%s", utils.PathProject, utils.ParamProject, utils.PathDashboard, utils.ParamDashboard, utils.PathDatasource, utils.ParamName), e.proxySavedDashboardDatasource, false)
 		g.POST(fmt.Sprintf("/%s/%s/:%s/%s/:%s/%s/*", utils.PathUnsaved, utils.PathProject, utils.ParamProject, utils.PathDashboard, utils.ParamDashboard, utils.PathDatasource), e.proxyUnsavedDashboardDatasource, false)
 	}
+	g.GET(fmt.Sprintf("/%s/:%s/%s/:%s/%s/:%s/stream", utils.PathProject, utils.ParamProject, utils.PathDashboard, utils.ParamDashboard, utils.PathPanel, utils.ParamPanel), e.streamPanelData, false)
 }
 
 func (e *endpoint) checkPermission(ctx echo.Context, projectName string, scope role.Scope, action role.Action) error {
+	return e.checkResourcePermission(ctx, projectName, scope, action, "")
+}
+
+// checkResourcePermission is like checkPermission but considers as well the permissions restricted to the resource
+// with the given name.
+func (e *endpoint) checkResourcePermission(ctx echo.Context, projectName string, scope role.Scope, action role.Action, name string) error {
 	if !e.authz.IsEnabled() {
 		return nil
 	}
@@ -162,7 +172,7 @@ func (e *endpoint) checkPermission(ctx echo.Context, projectName string, scope r
 		return nil
 	}
 
-	if ok := e.authz.HasPermission(ctx, action, projectName, scope); !ok {
+	if ok := e.authz.HasResourcePermission(ctx, action, projectName, scope, name); !ok {
 		return apiinterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission in '%s' project for '%s' kind", action, projectName, scope))
 	}
 
@@ -173,7 +183,7 @@ type proxy interface {
 	serve(c echo.Context) error
 }
 
-func newProxy(datasourceName, projectName string, spec v1.DatasourceSpec, path string, crypto crypto.Crypto, retrieveSecret func(name string) (*v1.SecretSpec, error)) (proxy, error) {
+func newProxy(datasourceName, projectName string, spec v1.DatasourceSpec, path string, crypto crypto.Crypto, queryCache *queryCache, retrieveSecret func(name string) (*v1.SecretSpec, error)) (proxy, error) {
 	cfg, kind, err := datasourcev1.ValidateAndExtract(spec.Plugin.Spec)
 	if err != nil {
 		logrus.WithError(err).Error("unable to build or find the config in the datasource")
@@ -199,10 +209,15 @@ func newProxy(datasourceName, projectName string, spec v1.DatasourceSpec, path s
 				return nil, apiinterface.InternalError
 			}
 		}
+		if queryCache != nil && httpConfig.CacheTTL != nil {
+			queryCache.ttl = time.Duration(*httpConfig.CacheTTL)
+		}
+		queryCache.bind(scrt, httpConfig.Headers)
 		return &httpProxy{
 			config: httpConfig,
 			path:   path,
 			secret: scrt,
+			cache:  queryCache,
 		}, nil
 	case datasourceSQL.ProxyKindName:
 		sqlConfig := cfg.(*datasourceSQL.Config)
@@ -232,23 +247,38 @@ type httpProxy struct {
 	config *datasourceHTTP.Config
 	secret *v1.SecretSpec
 	path   string
+	cache  *queryCache
 }
 
 func (h *httpProxy) serve(c echo.Context) error {
 	req := c.Request()
-	res := c.Response()
 
-	isAllowed := false
+	if !h.isAllowed(req.Method) {
+		return apiinterface.HandleForbiddenError(fmt.Sprintf("you are not allowed to use this endpoint %q with the HTTP method %s", h.path, req.Method))
+	}
+
+	return h.cache.serve(c, h.path, func() error {
+		return h.forward(c)
+	})
+}
+
+// isAllowed tells whether the endpoint can be requested with the method, according to the allowed endpoints of the
+// proxy. Every endpoint is allowed when the list is empty.
+func (h *httpProxy) isAllowed(method string) bool {
+	if len(h.config.AllowedEndpoints) == 0 {
+		return true
+	}
 	for _, allowedEndpoint := range h.config.AllowedEndpoints {
-		if allowedEndpoint.Method == req.Method && len(allowedEndpoint.EndpointPattern.FindAllString(h.path, -1)) > 0 {
-			isAllowed = true
-			break
+		if allowedEndpoint.Method == method && len(allowedEndpoint.EndpointPattern.FindAllString(h.path, -1)) > 0 {
+			return true
 		}
 	}
+	return false
+}
 
-	if len(h.config.AllowedEndpoints) > 0 && !isAllowed {
-		return apiinterface.HandleForbiddenError(fmt.Sprintf("you are not allowed to use this endpoint %q with the HTTP method %s", h.path, req.Method))
-	}
+func (h *httpProxy) forward(c echo.Context) error {
+	req := c.Request()
+	res := c.Response()
 
 	if err := h.prepareRequest(c); err != nil {
 		logrus.WithError(err).Errorf("unable to prepare the request")
@@ -458,6 +488,11 @@ func (s *sqlProxy) serve(c echo.Context) error {
 		return apiinterface.InternalError
 	}
 
+	// ClickHouse is queried through its HTTP interface, there is no SQL connection to open
+	if s.config.Driver == datasourceSQL.DriverClickHouse {
+		return s.serveClickHouse(c, q, tlsConfig)
+	}
+
 	// get the correct SQL driver for address and open connection
 	db, err := s.sqlOpen(tlsConfig)
 	if err != nil {
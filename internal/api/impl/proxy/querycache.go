@@ -0,0 +1,218 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/impl/proxy/cache"
+	"github.com/perses/perses/pkg/model/api/config"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	promConfig "github.com/prometheus/common/config"
+	"github.com/sirupsen/logrus"
+)
+
+// cacheStatusHeader tells the client whether the response has been served from the cache.
+const cacheStatusHeader = "X-Perses-Cache"
+
+func newCache(cfg config.QueryCacheConfig) cache.Cache {
+	if !cfg.Enable {
+		return nil
+	}
+	if cfg.Backend != config.RedisQueryCache {
+		return cache.NewMemory(cfg.MaxEntries)
+	}
+	options := cache.RedisOptions{
+		Addr:     cfg.Redis.Addr,
+		Username: cfg.Redis.Username,
+		Password: string(cfg.Redis.Password),
+		DB:       cfg.Redis.DB,
+		Timeout:  time.Duration(cfg.Redis.Timeout),
+	}
+	if cfg.Redis.TLSConfig != nil {
+		tlsConfig, err := promConfig.NewTLSConfig(cfg.Redis.TLSConfig)
+		if err != nil {
+			logrus.WithError(err).Error("unable to build the TLS config of redis, the responses of the queries won't be cached")
+			return nil
+		}
+		options.TLSConfig = tlsConfig
+	}
+	return cache.NewRedis(options)
+}
+
+// queryCache is the cache of the responses of a single datasource.
+type queryCache struct {
+	cache           cache.Cache
+	scope           string
+	ttl             time.Duration
+	maxResponseSize int
+	// varyHeaders are the headers of the client forwarded to the datasource that are part of the key of the responses.
+	varyHeaders []string
+}
+
+// newQueryCache returns the cache of the responses of the datasource identified by scope, or nil when the cache is disabled.
+// The spec is part of the scope, so a change of the datasource doesn't serve the responses of its previous version.
+func (e *endpoint) newQueryCache(scope string, spec v1.DatasourceSpec) *queryCache {
+	if e.cache == nil {
+		return nil
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		logrus.WithError(err).Errorf("unable to encode the datasource %q, the responses of its queries won't be cached", scope)
+		return nil
+	}
+	hash := sha256.Sum256(data)
+	return &queryCache{
+		cache:           e.cache,
+		scope:           scope + "/" + hex.EncodeToString(hash[:]),
+		ttl:             time.Duration(e.cfg.Cache.DefaultTTL),
+		maxResponseSize: e.cfg.Cache.MaxResponseSize,
+		varyHeaders:     cache.IdentityHeaders,
+	}
+}
+
+// bind makes the responses depend on the secret of the datasource, so a rotated secret doesn't serve the responses
+// fetched with the previous one. The identity headers overridden by the datasource are no longer part of the key, as
+// the ones sent by the client are not forwarded.
+func (q *queryCache) bind(scrt *v1.SecretSpec, headers map[string]string) {
+	if q == nil {
+		return
+	}
+	if scrt != nil {
+		fingerprint, err := secretFingerprint(scrt)
+		if err != nil {
+			logrus.WithError(err).Errorf("unable to read the secret of the datasource %q, the responses of its queries won't be cached", q.scope)
+			q.ttl = 0
+			return
+		}
+		q.scope += "/" + fingerprint
+	}
+	overridden := make(map[string]bool)
+	for name := range headers {
+		overridden[http.CanonicalHeaderKey(name)] = true
+	}
+	if scrt != nil && (scrt.BasicAuth != nil || scrt.Authorization != nil || scrt.OAuth != nil) {
+		overridden["Authorization"] = true
+	}
+	var varyHeaders []string
+	for _, name := range cache.IdentityHeaders {
+		if !overridden[name] {
+			varyHeaders = append(varyHeaders, name)
+		}
+	}
+	q.varyHeaders = varyHeaders
+}
+
+// secretFingerprint returns a hash of the secret, including the credentials read from files.
+func secretFingerprint(scrt *v1.SecretSpec) (string, error) {
+	data, err := json.Marshal(scrt)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	hash.Write(data)
+	if scrt.BasicAuth != nil {
+		password, passwordErr := scrt.BasicAuth.GetPassword()
+		if passwordErr != nil {
+			return "", passwordErr
+		}
+		hash.Write([]byte(password))
+	}
+	if scrt.Authorization != nil {
+		credentials, credentialsErr := scrt.Authorization.GetCredentials()
+		if credentialsErr != nil {
+			return "", credentialsErr
+		}
+		hash.Write([]byte(credentials))
+	}
+	if scrt.OAuth != nil {
+		clientSecret, clientSecretErr := scrt.OAuth.GetClientSecret()
+		if clientSecretErr != nil {
+			return "", clientSecretErr
+		}
+		hash.Write([]byte(clientSecret))
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// serve writes the cached response of the request if there is one. Otherwise, it records the response written by
+// next and stores it in the cache when it is successful.
+func (q *queryCache) serve(c echo.Context, path string, next func() error) error {
+	if q == nil || q.ttl <= 0 {
+		return next()
+	}
+	req := c.Request()
+	res := c.Response()
+	key, ok := cache.Key(q.scope, req, path, q.ttl, q.varyHeaders)
+	if !ok {
+		return next()
+	}
+	if cached, found := q.cache.Get(req.Context(), key); found {
+		for name, values := range cached.Header {
+			res.Header()[name] = values
+		}
+		res.Header().Set(cacheStatusHeader, "HIT")
+		res.WriteHeader(cached.StatusCode)
+		_, err := res.Write(cached.Body)
+		return err
+	}
+	res.Header().Set(cacheStatusHeader, "MISS")
+	recorder := &responseRecorder{ResponseWriter: res.Writer, maxSize: q.maxResponseSize}
+	res.Writer = recorder
+	err := next()
+	res.Writer = recorder.ResponseWriter
+	if err != nil || res.Status != http.StatusOK || recorder.overflow {
+		return err
+	}
+	// The response is stored even if the client has gone away in the meantime.
+	q.cache.Set(context.WithoutCancel(req.Context()), key, cache.NewResponse(res.Status, res.Header(), recorder.body.Bytes()), q.ttl)
+	return nil
+}
+
+// responseRecorder keeps a copy of the body written, as long as it doesn't exceed maxSize.
+type responseRecorder struct {
+	http.ResponseWriter
+	body     bytes.Buffer
+	maxSize  int
+	overflow bool
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if !r.overflow {
+		if r.body.Len()+len(b) > r.maxSize {
+			r.overflow = true
+			r.body.Reset()
+		} else {
+			r.body.Write(b)
+		}
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
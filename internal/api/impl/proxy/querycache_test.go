@@ -0,0 +1,93 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/impl/proxy/cache"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	datasourceHTTP "github.com/perses/perses/pkg/model/api/v1/datasource/http"
+	"github.com/perses/perses/pkg/model/api/v1/secret"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPProxyCacheIdentityHeaders(t *testing.T) {
+	calls := 0
+	datasource := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer datasource.Close()
+
+	newHTTPProxy := func(scrt *v1.SecretSpec) *httpProxy {
+		config := &datasourceHTTP.Config{URL: common.MustParseURL(datasource.URL)}
+		queryCache := &queryCache{
+			cache:           cache.NewMemory(10),
+			scope:           "global/prometheus",
+			ttl:             time.Minute,
+			maxResponseSize: 1024,
+			varyHeaders:     cache.IdentityHeaders,
+		}
+		queryCache.bind(scrt, config.Headers)
+		return &httpProxy{config: config, path: "/api/v1/query", secret: scrt, cache: queryCache}
+	}
+	query := func(pr *httpProxy, authorization string) (string, string) {
+		req := httptest.NewRequest(http.MethodGet, "/proxy/globaldatasources/prometheus/api/v1/query?query=up", nil)
+		req.Header.Set("Authorization", authorization)
+		rec := httptest.NewRecorder()
+		require.NoError(t, pr.serve(echo.New().NewContext(req, rec)))
+		return rec.Body.String(), rec.Header().Get(cacheStatusHeader)
+	}
+
+	// the authorization of the client is forwarded, so each user gets the responses fetched with his credentials
+	pr := newHTTPProxy(nil)
+	body, status := query(pr, "Bearer alice")
+	assert.Equal(t, "Bearer alice", body)
+	assert.Equal(t, "MISS", status)
+	body, status = query(pr, "Bearer bob")
+	assert.Equal(t, "Bearer bob", body)
+	assert.Equal(t, "MISS", status)
+	body, status = query(pr, "Bearer alice")
+	assert.Equal(t, "Bearer alice", body)
+	assert.Equal(t, "HIT", status)
+	assert.Equal(t, 2, calls)
+
+	// the authorization is overridden by the secret of the datasource, so the responses are shared
+	scrt := &v1.SecretSpec{Authorization: &secret.Authorization{Type: "Bearer", Credentials: "token"}}
+	pr = newHTTPProxy(scrt)
+	_, status = query(pr, "Bearer alice")
+	assert.Equal(t, "MISS", status)
+	body, status = query(pr, "Bearer bob")
+	assert.Equal(t, "Bearer token", body)
+	assert.Equal(t, "HIT", status)
+	assert.Equal(t, 3, calls)
+}
+
+func TestQueryCacheBindSecret(t *testing.T) {
+	scope := func(credentials string) string {
+		q := &queryCache{scope: "global/prometheus"}
+		q.bind(&v1.SecretSpec{Authorization: &secret.Authorization{Type: "Bearer", Credentials: credentials}}, nil)
+		return q.scope
+	}
+	// a rotated secret doesn't serve the responses fetched with the previous one
+	assert.NotEqual(t, scope("before"), scope("after"))
+	assert.Equal(t, scope("before"), scope("before"))
+}
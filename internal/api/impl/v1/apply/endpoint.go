@@ -0,0 +1,431 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	"github.com/perses/perses/internal/api/crypto"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/dependency"
+	dashboardImpl "github.com/perses/perses/internal/api/impl/v1/dashboard"
+	datasourceImpl "github.com/perses/perses/internal/api/impl/v1/datasource"
+	globalDatasourceImpl "github.com/perses/perses/internal/api/impl/v1/globaldatasource"
+	globalVariableImpl "github.com/perses/perses/internal/api/impl/v1/globalvariable"
+	libraryPanelImpl "github.com/perses/perses/internal/api/impl/v1/librarypanel"
+	secretImpl "github.com/perses/perses/internal/api/impl/v1/secret"
+	variableImpl "github.com/perses/perses/internal/api/impl/v1/variable"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/datasource"
+	"github.com/perses/perses/internal/api/interface/v1/librarypanel"
+	"github.com/perses/perses/internal/api/interface/v1/secret"
+	"github.com/perses/perses/internal/api/interface/v1/variable"
+	"github.com/perses/perses/internal/api/plugin/schema"
+	"github.com/perses/perses/internal/api/route"
+	"github.com/perses/perses/internal/api/utils"
+	"github.com/perses/perses/internal/api/webhook"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/config"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/sirupsen/logrus"
+)
+
+const pathApply = "apply"
+
+// kindOrder is the order in which the resources of a project are applied, so a resource is applied after the
+// resources it can reference.
+var kindOrder = []v1.Kind{v1.KindSecret, v1.KindDatasource, v1.KindVariable, v1.KindLibraryPanel, v1.KindDashboard}
+
+type dao[T modelAPI.Entity] interface {
+	Get(project string, name string) (T, error)
+	Update(entity T) error
+	Delete(project string, name string) error
+}
+
+// applied is a resource created or updated by an applier.
+type applied struct {
+	action modelAPI.ApplyAction
+	// rollback restores the previous state of the database.
+	rollback func() error
+	// previous and current are the resource before and after the change as returned by the service, for the audit log.
+	// previous is nil for a creation.
+	previous modelAPI.Entity
+	current  modelAPI.Entity
+}
+
+// applier creates or updates the resources of a kind.
+type applier interface {
+	exists(parameters apiInterface.Parameters) (bool, error)
+	// get returns the resource as returned by the service, e.g. without the sensitive data of a secret.
+	get(parameters apiInterface.Parameters) (modelAPI.Entity, error)
+	apply(ctx echo.Context, entity modelAPI.Entity, parameters apiInterface.Parameters) (*applied, error)
+}
+
+type kindApplier[T modelAPI.Entity, K modelAPI.Entity, V databaseModel.Query] struct {
+	service apiInterface.Service[T, K, V]
+	dao     dao[T]
+}
+
+func (a *kindApplier[T, K, V]) exists(parameters apiInterface.Parameters) (bool, error) {
+	_, err := a.dao.Get(parameters.Project, parameters.Name)
+	if err == nil {
+		return true, nil
+	}
+	if databaseModel.IsKeyNotFound(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+func (a *kindApplier[T, K, V]) get(parameters apiInterface.Parameters) (modelAPI.Entity, error) {
+	entity, err := a.service.Get(parameters)
+	if err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (a *kindApplier[T, K, V]) apply(ctx echo.Context, entity modelAPI.Entity, parameters apiInterface.Parameters) (*applied, error) {
+	typedEntity, ok := entity.(T)
+	if !ok {
+		return nil, fmt.Errorf("unexpected resource %T", entity)
+	}
+	previous, err := a.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		if !databaseModel.IsKeyNotFound(err) {
+			return nil, err
+		}
+		current, createErr := a.service.Create(ctx, typedEntity)
+		if createErr != nil {
+			return nil, createErr
+		}
+		return &applied{
+			action: modelAPI.ApplyActionCreated,
+			rollback: func() error {
+				return a.dao.Delete(parameters.Project, parameters.Name)
+			},
+			current: current,
+		}, nil
+	}
+	current, updateErr := a.service.Update(ctx, typedEntity, parameters)
+	if updateErr != nil {
+		return nil, updateErr
+	}
+	return &applied{
+		action: modelAPI.ApplyActionUpdated,
+		rollback: func() error {
+			return a.dao.Update(previous)
+		},
+		current: current,
+	}, nil
+}
+
+type resource struct {
+	kind       v1.Kind
+	entity     modelAPI.Entity
+	parameters apiInterface.Parameters
+}
+
+type endpoint struct {
+	appliers map[v1.Kind]applier
+	// transactional is set when the database supports the transactions. The resources of a project are then applied
+	// in a single transaction, with services built on top of it by newAppliers.
+	transactional databaseModel.TransactionalDAO
+	cfg           config.Config
+	schema        schema.Schema
+	crypto        crypto.Crypto
+	webhook       webhook.Webhook
+	authz         authorization.Authorization
+	audit         audit.Service
+	readonly      bool
+	caseSensitive bool
+}
+
+// NewEndpoint creates the endpoint applying a list of resources in one request.
+func NewEndpoint(cfg config.Config, serviceManager dependency.ServiceManager, persistenceManager dependency.PersistenceManager, readonly bool, caseSensitive bool) route.Endpoint {
+	e := &endpoint{
+		appliers: map[v1.Kind]applier{
+			v1.KindDashboard:    &kindApplier[*v1.Dashboard, *v1.Dashboard, *dashboard.Query]{service: serviceManager.GetDashboard(), dao: persistenceManager.GetDashboard()},
+			v1.KindDatasource:   &kindApplier[*v1.Datasource, *v1.Datasource, *datasource.Query]{service: serviceManager.GetDatasource(), dao: persistenceManager.GetDatasource()},
+			v1.KindLibraryPanel: &kindApplier[*v1.LibraryPanel, *v1.LibraryPanel, *librarypanel.Query]{service: serviceManager.GetLibraryPanel(), dao: persistenceManager.GetLibraryPanel()},
+			v1.KindSecret:       &kindApplier[*v1.Secret, *v1.PublicSecret, *secret.Query]{service: serviceManager.GetSecret(), dao: persistenceManager.GetSecret()},
+			v1.KindVariable:     &kindApplier[*v1.Variable, *v1.Variable, *variable.Query]{service: serviceManager.GetVariable(), dao: persistenceManager.GetVariable()},
+		},
+		cfg:           cfg,
+		schema:        serviceManager.GetSchema(),
+		crypto:        serviceManager.GetCrypto(),
+		webhook:       serviceManager.GetWebhook(),
+		authz:         serviceManager.GetAuthorization(),
+		audit:         serviceManager.GetAudit(),
+		readonly:      readonly,
+		caseSensitive: caseSensitive,
+	}
+	if transactional, ok := persistenceManager.GetPersesDAO().(databaseModel.TransactionalDAO); ok {
+		e.transactional = transactional
+	}
+	return e
+}
+
+// newAppliers creates the appliers of the resources with services and DAOs using the given database, e.g. a
+// transaction. The webhooks receive the events through wh.
+func (e *endpoint) newAppliers(persesDAO databaseModel.DAO, wh webhook.Webhook) map[v1.Kind]applier {
+	dashboardDAO := dashboardImpl.NewDAO(persesDAO)
+	datasourceDAO := datasourceImpl.NewDAO(persesDAO)
+	libraryPanelDAO := libraryPanelImpl.NewDAO(persesDAO)
+	secretDAO := secretImpl.NewDAO(persesDAO)
+	variableDAO := variableImpl.NewDAO(persesDAO)
+	dashboardService := dashboardImpl.NewService(e.cfg, dashboardDAO, globalVariableImpl.NewDAO(persesDAO), variableDAO, libraryPanelDAO, datasourceDAO, globalDatasourceImpl.NewDAO(persesDAO), e.schema, wh)
+	return map[v1.Kind]applier{
+		v1.KindDashboard:    &kindApplier[*v1.Dashboard, *v1.Dashboard, *dashboard.Query]{service: dashboardService, dao: dashboardDAO},
+		v1.KindDatasource:   &kindApplier[*v1.Datasource, *v1.Datasource, *datasource.Query]{service: datasourceImpl.NewService(datasourceDAO, e.schema, wh), dao: datasourceDAO},
+		v1.KindLibraryPanel: &kindApplier[*v1.LibraryPanel, *v1.LibraryPanel, *librarypanel.Query]{service: libraryPanelImpl.NewService(libraryPanelDAO, e.schema), dao: libraryPanelDAO},
+		v1.KindSecret:       &kindApplier[*v1.Secret, *v1.PublicSecret, *secret.Query]{service: secretImpl.NewService(secretDAO, e.crypto), dao: secretDAO},
+		v1.KindVariable:     &kindApplier[*v1.Variable, *v1.Variable, *variable.Query]{service: variableImpl.NewService(variableDAO, e.schema), dao: variableDAO},
+	}
+}
+
+func (e *endpoint) CollectRoutes(g *route.Group) {
+	if e.readonly {
+		return
+	}
+	g.POST(fmt.Sprintf("/%s", pathApply), e.Apply, false)
+}
+
+// Apply creates or updates a list of resources. The resources are applied project by project: when a resource of a
+// project cannot be applied, none of the resources of this project is applied, and the next projects are not applied.
+// With a database supporting the transactions, the resources of a project are applied in a single transaction.
+// Otherwise, the resources of the project already applied are restored to their previous state.
+func (e *endpoint) Apply(ctx echo.Context) error {
+	var rawEntities []json.RawMessage
+	if err := ctx.Bind(&rawEntities); err != nil {
+		return apiInterface.HandleBadRequestError(err.Error())
+	}
+	if len(rawEntities) == 0 {
+		return apiInterface.HandleBadRequestError("no resource to apply")
+	}
+	projects, resources, err := e.parseResources(rawEntities)
+	if err != nil {
+		return err
+	}
+	// Every permission is checked before applying anything.
+	for _, project := range projects {
+		for _, r := range resources[project] {
+			if permErr := e.checkPermission(ctx, r); permErr != nil {
+				return permErr
+			}
+		}
+	}
+
+	results := make([]modelAPI.ApplyResult, 0, len(rawEntities))
+	for _, project := range projects {
+		projectResults, applyErr := e.applyProject(ctx, project, resources[project])
+		if applyErr != nil {
+			return applyErr
+		}
+		results = append(results, projectResults...)
+	}
+	return ctx.JSON(http.StatusOK, results)
+}
+
+// parseResources decodes the resources and groups them by project, in the order the projects first appear.
+func (e *endpoint) parseResources(rawEntities []json.RawMessage) ([]string, map[string][]resource, error) {
+	var projects []string
+	resources := make(map[string][]resource)
+	for i, raw := range rawEntities {
+		header := struct {
+			Kind v1.Kind `json:"kind"`
+		}{}
+		if err := json.Unmarshal(raw, &header); err != nil {
+			return nil, nil, apiInterface.HandleBadRequestError(fmt.Sprintf("resource %d: %s", i, err))
+		}
+		if _, ok := e.appliers[header.Kind]; !ok {
+			return nil, nil, apiInterface.HandleBadRequestError(fmt.Sprintf("resource %d: kind %q cannot be applied, supported kinds are %s", i, header.Kind, supportedKinds()))
+		}
+		entity, err := v1.GetStruct(header.Kind)
+		if err != nil {
+			return nil, nil, apiInterface.HandleBadRequestError(fmt.Sprintf("resource %d: %s", i, err))
+		}
+		if unmarshalErr := json.Unmarshal(raw, entity); unmarshalErr != nil {
+			return nil, nil, apiInterface.HandleBadRequestError(fmt.Sprintf("resource %d: %s", i, unmarshalErr))
+		}
+		entity.GetMetadata().Flatten(e.caseSensitive)
+		parameters := apiInterface.Parameters{
+			Project: utils.GetMetadataProject(entity.GetMetadata()),
+			Name:    entity.GetMetadata().GetName(),
+		}
+		if len(parameters.Project) == 0 {
+			return nil, nil, apiInterface.HandleBadRequestError(fmt.Sprintf("resource %d: %s %q has no project", i, header.Kind, parameters.Name))
+		}
+		if _, ok := resources[parameters.Project]; !ok {
+			projects = append(projects, parameters.Project)
+		}
+		for _, r := range resources[parameters.Project] {
+			if r.kind == header.Kind && r.parameters.Name == parameters.Name {
+				return nil, nil, apiInterface.HandleBadRequestError(fmt.Sprintf("resource %d: %s %q is applied twice in the project %q", i, header.Kind, parameters.Name, parameters.Project))
+			}
+		}
+		resources[parameters.Project] = append(resources[parameters.Project], resource{kind: header.Kind, entity: entity, parameters: parameters})
+	}
+	for _, project := range projects {
+		sort.SliceStable(resources[project], func(i, j int) bool {
+			return slices.Index(kindOrder, resources[project][i].kind) < slices.Index(kindOrder, resources[project][j].kind)
+		})
+	}
+	return projects, resources, nil
+}
+
+func (e *endpoint) checkPermission(ctx echo.Context, r resource) error {
+	if !e.authz.IsEnabled() {
+		return nil
+	}
+	exists, err := e.appliers[r.kind].exists(r.parameters)
+	if err != nil {
+		return err
+	}
+	action := role.CreateAction
+	if exists {
+		action = role.UpdateAction
+	}
+	scope, err := role.GetScope(string(r.kind))
+	if err != nil {
+		return err
+	}
+	if ok := e.authz.HasPermission(ctx, action, r.parameters.Project, *scope); !ok {
+		return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission in '%s' project for '%s' kind", action, r.parameters.Project, *scope))
+	}
+	return nil
+}
+
+func (e *endpoint) applyProject(ctx echo.Context, project string, resources []resource) ([]modelAPI.ApplyResult, error) {
+	var results []modelAPI.ApplyResult
+	var changes []*applied
+	var failed resource
+	var err error
+	if e.transactional != nil {
+		// The webhooks are only called once the transaction is committed.
+		wh := &deferredWebhook{}
+		err = e.transactional.InTransaction(func(tx databaseModel.DAO) error {
+			var applyErr error
+			results, changes, failed, applyErr = e.applyResources(ctx, e.newAppliers(tx, wh), project, resources)
+			return applyErr
+		})
+		if err == nil {
+			wh.flush(ctx, e.webhook)
+		}
+	} else {
+		results, changes, failed, err = e.applyResources(ctx, e.appliers, project, resources)
+		if err != nil {
+			if rollbackErr := rollbackAll(changes); rollbackErr != nil {
+				logrus.WithError(rollbackErr).Errorf("unable to restore the resources applied in the project %q", project)
+			}
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply the %s %q, the resources of the project %q have not been applied: %w", failed.kind, failed.parameters.Name, project, err)
+	}
+	// The changes are only recorded once every resource of the project is applied, as they are rolled back otherwise.
+	for _, change := range changes {
+		if change.action == modelAPI.ApplyActionCreated {
+			e.audit.Created(ctx, change.current)
+		} else {
+			e.audit.Updated(ctx, change.previous, change.current)
+		}
+	}
+	return results, nil
+}
+
+// applyResources applies the resources in order, and stops at the first one failing, which is returned with the error.
+func (e *endpoint) applyResources(ctx echo.Context, appliers map[v1.Kind]applier, project string, resources []resource) ([]modelAPI.ApplyResult, []*applied, resource, error) {
+	results := make([]modelAPI.ApplyResult, 0, len(resources))
+	changes := make([]*applied, 0, len(resources))
+	for _, r := range resources {
+		var previous modelAPI.Entity
+		if e.audit.IsEnabled() {
+			// The error is ignored: the resource doesn't exist yet, or applying it reports the error.
+			previous, _ = appliers[r.kind].get(r.parameters)
+		}
+		result, err := appliers[r.kind].apply(ctx, r.entity, r.parameters)
+		if err != nil {
+			return nil, changes, r, err
+		}
+		result.previous = previous
+		changes = append(changes, result)
+		results = append(results, modelAPI.ApplyResult{
+			Kind:    string(r.kind),
+			Project: project,
+			Name:    r.parameters.Name,
+			Action:  result.action,
+		})
+	}
+	return results, changes, resource{}, nil
+}
+
+// deferredWebhook keeps the events of the changes made in a transaction, to send them once it is committed.
+type deferredWebhook struct {
+	events []func(ctx echo.Context, wh webhook.Webhook)
+}
+
+func (d *deferredWebhook) Created(_ echo.Context, entity modelAPI.Entity) {
+	d.events = append(d.events, func(ctx echo.Context, wh webhook.Webhook) {
+		wh.Created(ctx, entity)
+	})
+}
+
+func (d *deferredWebhook) Updated(_ echo.Context, previous modelAPI.Entity, current modelAPI.Entity) {
+	d.events = append(d.events, func(ctx echo.Context, wh webhook.Webhook) {
+		wh.Updated(ctx, previous, current)
+	})
+}
+
+func (d *deferredWebhook) Deleted(_ echo.Context, kind v1.Kind, project string, name string) {
+	d.events = append(d.events, func(ctx echo.Context, wh webhook.Webhook) {
+		wh.Deleted(ctx, kind, project, name)
+	})
+}
+
+func (d *deferredWebhook) flush(ctx echo.Context, wh webhook.Webhook) {
+	for _, event := range d.events {
+		event(ctx, wh)
+	}
+}
+
+// rollbackAll restores the resources in the reverse order they were applied.
+func rollbackAll(changes []*applied) error {
+	var errs []error
+	for i := len(changes) - 1; i >= 0; i-- {
+		if err := changes[i].rollback(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func supportedKinds() string {
+	kinds := make([]string, 0, len(kindOrder))
+	for _, kind := range kindOrder {
+		kinds = append(kinds, string(kind))
+	}
+	return strings.Join(kinds, ", ")
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/perses/common/async"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
+)
+
+// NewCleaner returns the task deleting the events of the audit log older than the retention.
+func NewCleaner(dao audit.DAO, retention time.Duration) async.SimpleTask {
+	return &cleaner{
+		dao:       dao,
+		retention: retention,
+	}
+}
+
+type cleaner struct {
+	async.SimpleTask
+	dao       audit.DAO
+	retention time.Duration
+}
+
+func (c *cleaner) String() string {
+	return "audit log cleaner"
+}
+
+func (c *cleaner) Execute(_ context.Context, _ context.CancelFunc) error {
+	return c.dao.DeleteBefore(time.Now().Add(-c.retention))
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
+	"github.com/perses/perses/internal/api/route"
+	"github.com/perses/perses/internal/api/utils"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+type endpoint struct {
+	service       audit.Service
+	authz         authorization.Authorization
+	caseSensitive bool
+}
+
+// NewEndpoint creates the endpoint querying the audit log. It is only registered when the audit log is enabled.
+func NewEndpoint(service audit.Service, authz authorization.Authorization, caseSensitive bool) route.Endpoint {
+	return &endpoint{
+		service:       service,
+		authz:         authz,
+		caseSensitive: caseSensitive,
+	}
+}
+
+func (e *endpoint) CollectRoutes(g *route.Group) {
+	if !e.service.IsEnabled() {
+		return
+	}
+	g.GET(fmt.Sprintf("/%s", utils.PathAudit), e.List, false)
+}
+
+// List returns the events of the audit log matching the filters, from the most recent to the oldest.
+func (e *endpoint) List(ctx echo.Context) error {
+	if e.authz.IsEnabled() && !e.authz.HasPermission(ctx, role.ReadAction, v1.WildcardProject, role.AuditScope) {
+		return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' global permission for '%s' kind", role.ReadAction, role.AuditScope))
+	}
+	q := &audit.Query{}
+	if err := ctx.Bind(q); err != nil {
+		return apiInterface.HandleBadRequestError(err.Error())
+	}
+	if !q.Start.IsZero() && !q.End.IsZero() && q.End.Before(q.Start) {
+		return apiInterface.HandleBadRequestError("the end of the time range cannot be before its start")
+	}
+	if q.Limit <= 0 {
+		q.Limit = defaultLimit
+	} else if q.Limit > maxLimit {
+		q.Limit = maxLimit
+	}
+	if !e.caseSensitive {
+		q.Project = strings.ToLower(q.Project)
+	}
+	events, err := e.service.List(q)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, events)
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// event is the document storing an event of the audit log.
+type event struct {
+	Kind     string         `json:"kind" yaml:"kind"`
+	Metadata v1.Metadata    `json:"metadata" yaml:"metadata"`
+	Spec     api.AuditEvent `json:"spec" yaml:"spec"`
+}
+
+func (e *event) GetMetadata() api.Metadata {
+	return &e.Metadata
+}
+
+func (e *event) GetKind() string {
+	return e.Kind
+}
+
+func (e *event) GetSpec() interface{} {
+	return e.Spec
+}
+
+type dao struct {
+	audit.DAO
+	client databaseModel.DAO
+	kind   v1.Kind
+}
+
+func NewDAO(persesDAO databaseModel.DAO) audit.DAO {
+	return &dao{
+		client: persesDAO,
+		kind:   v1.KindAuditEvent,
+	}
+}
+
+func (d *dao) Create(entity *api.AuditEvent) error {
+	id, err := generateID(entity.Timestamp)
+	if err != nil {
+		return err
+	}
+	entity.ID = id
+	return d.client.Create(&event{
+		Kind:     string(d.kind),
+		Metadata: v1.Metadata{Name: id},
+		Spec:     *entity,
+	})
+}
+
+func (d *dao) List(q *audit.Query) ([]*api.AuditEvent, error) {
+	var events []*event
+	if err := d.client.Query(q, &events); err != nil {
+		return nil, err
+	}
+	// The SQL database applies the whole query. The other databases only select the events by their ID, so the other
+	// filters, the order and the limit are applied here as well.
+	result := make([]*api.AuditEvent, 0, len(events))
+	for _, e := range events {
+		e.Spec.ID = e.Metadata.Name
+		if matchQuery(&e.Spec, q) {
+			result = append(result, &e.Spec)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].ID > result[j].ID
+	})
+	if q.Limit > 0 && len(result) > q.Limit {
+		result = result[:q.Limit]
+	}
+	return result, nil
+}
+
+func (d *dao) DeleteBefore(t time.Time) error {
+	// The end of the time range is included.
+	return d.client.DeleteByQuery(&audit.Query{End: t.Add(-time.Nanosecond)})
+}
+
+func matchQuery(e *api.AuditEvent, q *audit.Query) bool {
+	if len(q.Project) > 0 && e.Project != q.Project {
+		return false
+	}
+	if len(q.User) > 0 && e.User != q.User {
+		return false
+	}
+	if len(q.Kind) > 0 && !strings.EqualFold(e.Kind, q.Kind) {
+		return false
+	}
+	return q.MatchID(e.ID)
+}
+
+// generateID returns a unique ID for an event. It starts with the timestamp, so the events are stored in the order
+// they are recorded.
+func generateID(timestamp time.Time) (string, error) {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("unable to generate the ID of the audit event: %w", err)
+	}
+	return audit.EventID(timestamp, hex.EncodeToString(suffix)), nil
+}
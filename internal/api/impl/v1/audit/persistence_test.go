@@ -0,0 +1,111 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"testing"
+	"time"
+
+	databaseFile "github.com/perses/perses/internal/api/database/file"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDAO(t *testing.T, start time.Time) audit.DAO {
+	d := NewDAO(&databaseFile.DAO{Folder: t.TempDir(), Extension: config.JSONExtension, CaseSensitive: true})
+	events := []*api.AuditEvent{
+		{Timestamp: start, Action: api.AuditActionCreated, Kind: "Dashboard", Project: "perses", Name: "a", User: "alice"},
+		{Timestamp: start.Add(time.Minute), Action: api.AuditActionUpdated, Kind: "Dashboard", Project: "perses", Name: "a", User: "bob"},
+		{Timestamp: start.Add(2 * time.Minute), Action: api.AuditActionCreated, Kind: "Datasource", Project: "perses", Name: "b", User: "alice"},
+		{Timestamp: start.Add(3 * time.Minute), Action: api.AuditActionDeleted, Kind: "Dashboard", Project: "other", Name: "c", User: "alice"},
+	}
+	for _, e := range events {
+		require.NoError(t, d.Create(e))
+	}
+	return d
+}
+
+func summarize(events []*api.AuditEvent) []string {
+	result := make([]string, 0, len(events))
+	for _, e := range events {
+		result = append(result, string(e.Action)+" "+e.Name)
+	}
+	return result
+}
+
+func TestDAO_List(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := newTestDAO(t, start)
+	testSuite := []struct {
+		title    string
+		query    *audit.Query
+		expected []string
+	}{
+		{
+			title:    "every event",
+			query:    &audit.Query{},
+			expected: []string{"deleted c", "created b", "updated a", "created a"},
+		},
+		{
+			title:    "filters",
+			query:    &audit.Query{Project: "perses", User: "alice", Kind: "dashboard"},
+			expected: []string{"created a"},
+		},
+		{
+			title:    "time range including its bounds",
+			query:    &audit.Query{Start: start.Add(time.Minute), End: start.Add(2 * time.Minute)},
+			expected: []string{"created b", "updated a"},
+		},
+		{
+			title:    "limit",
+			query:    &audit.Query{Limit: 3},
+			expected: []string{"deleted c", "created b", "updated a"},
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			events, err := d.List(test.query)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, summarize(events))
+		})
+	}
+}
+
+func TestDAO_ListCursor(t *testing.T) {
+	d := newTestDAO(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	var result []string
+	q := &audit.Query{Limit: 3}
+	for {
+		events, err := d.List(q)
+		require.NoError(t, err)
+		result = append(result, summarize(events)...)
+		if len(events) < q.Limit {
+			break
+		}
+		q.Cursor = events[len(events)-1].ID
+	}
+	assert.Equal(t, []string{"deleted c", "created b", "updated a", "created a"}, result)
+}
+
+func TestDAO_DeleteBefore(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := newTestDAO(t, start)
+	require.NoError(t, d.DeleteBefore(start.Add(2*time.Minute)))
+	events, err := d.List(&audit.Query{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deleted c", "created b"}, summarize(events))
+}
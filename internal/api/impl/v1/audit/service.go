@@ -0,0 +1,121 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
+	"github.com/perses/perses/internal/api/utils"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/config"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+type service struct {
+	audit.Service
+	dao       audit.DAO
+	authz     authorization.Authorization
+	isEnabled bool
+	user      string
+}
+
+func NewService(cfg config.Audit, dao audit.DAO, authz authorization.Authorization) audit.Service {
+	return &service{
+		dao:       dao,
+		authz:     authz,
+		isEnabled: cfg.Enable,
+	}
+}
+
+func (s *service) IsEnabled() bool {
+	return s.isEnabled
+}
+
+func (s *service) Created(ctx echo.Context, current api.Entity) {
+	event := newEvent(api.AuditActionCreated, v1.Kind(current.GetKind()), utils.GetMetadataProject(current.GetMetadata()), current.GetMetadata().GetName())
+	event.NewSpecHash = hashSpec(current)
+	s.record(ctx, event)
+}
+
+func (s *service) Updated(ctx echo.Context, previous api.Entity, current api.Entity) {
+	event := newEvent(api.AuditActionUpdated, v1.Kind(current.GetKind()), utils.GetMetadataProject(current.GetMetadata()), current.GetMetadata().GetName())
+	event.OldSpecHash = hashSpec(previous)
+	event.NewSpecHash = hashSpec(current)
+	s.record(ctx, event)
+}
+
+func (s *service) Deleted(ctx echo.Context, kind v1.Kind, project string, name string, previous api.Entity) {
+	event := newEvent(api.AuditActionDeleted, kind, project, name)
+	event.OldSpecHash = hashSpec(previous)
+	s.record(ctx, event)
+}
+
+func (s *service) List(q *audit.Query) ([]*api.AuditEvent, error) {
+	return s.dao.List(q)
+}
+
+func (s *service) WithUser(user string) audit.Service {
+	withUser := *s
+	withUser.user = user
+	return &withUser
+}
+
+func (s *service) record(ctx echo.Context, event *api.AuditEvent) {
+	if !s.isEnabled {
+		return
+	}
+	if len(s.user) > 0 {
+		event.User = s.user
+	} else if ctx != nil && s.authz.IsEnabled() {
+		username, err := s.authz.GetUsername(ctx)
+		if err != nil {
+			logrus.WithError(err).Debugf("unable to get the user who changed the %s %q", event.Kind, event.Name)
+		}
+		event.User = username
+	}
+	if err := s.dao.Create(event); err != nil {
+		logrus.WithError(err).Errorf("unable to record in the audit log that the %s %q has been %s", event.Kind, event.Name, event.Action)
+	}
+}
+
+func newEvent(action api.AuditAction, kind v1.Kind, project string, name string) *api.AuditEvent {
+	return &api.AuditEvent{
+		Timestamp: time.Now().UTC(),
+		Action:    action,
+		Kind:      string(kind),
+		Project:   project,
+		Name:      name,
+	}
+}
+
+// hashSpec returns the SHA-256 of the JSON spec of the entity, or an empty string when the entity is nil.
+func hashSpec(entity api.Entity) string {
+	if entity == nil {
+		return ""
+	}
+	data, err := json.Marshal(entity.GetSpec())
+	if err != nil {
+		logrus.WithError(err).Errorf("unable to hash the spec of the %s %q", entity.GetKind(), entity.GetMetadata().GetName())
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,341 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/brunoga/deep"
+	"github.com/labstack/echo/v4"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	dashboardModel "github.com/perses/perses/pkg/model/api/v1/dashboard"
+	datasourceModel "github.com/perses/perses/pkg/model/api/v1/datasource"
+	datasourceHTTP "github.com/perses/perses/pkg/model/api/v1/datasource/http"
+	datasourceSQL "github.com/perses/perses/pkg/model/api/v1/datasource/sql"
+	variableModel "github.com/perses/perses/pkg/model/api/v1/variable"
+)
+
+// datasourceResolution is the result of the lookup of a datasource used by the dashboard in the target project.
+type datasourceResolution struct {
+	// spec is the copy of the datasource of the source project to add to the dashboard. It is nil when the datasource
+	// exists in the target project or when it cannot be copied.
+	spec *v1.DatasourceSpec
+	// broken is the reason why the reference will break. It is empty when the reference is valid in the target project.
+	broken string
+}
+
+// mover rewrites the references of a dashboard to the resources of its project, so the dashboard can be saved in
+// another project.
+type mover struct {
+	*service
+	source      string
+	target      string
+	entity      *v1.Dashboard
+	datasources map[string]datasourceResolution
+	result      *v1.DashboardMoveResult
+}
+
+// Move moves or copies the dashboard to another project. The library panels, the project variables and the
+// datasources of the source project missing in the target project are copied in the dashboard when possible. The other
+// references are reported as broken, and the dashboard is not saved unless the request forces it.
+func (s *service) Move(ctx echo.Context, parameters apiInterface.Parameters, request *v1.DashboardMoveRequest) (*v1.DashboardMoveResult, error) {
+	if err := request.Validate(); err != nil {
+		return nil, apiInterface.HandleBadRequestError(err.Error())
+	}
+	name := request.Name
+	if len(name) == 0 {
+		name = parameters.Name
+	}
+	if request.Project == parameters.Project && name == parameters.Name {
+		return nil, apiInterface.HandleBadRequestError(fmt.Sprintf("the dashboard %q is already in the project %q", name, request.Project))
+	}
+	source, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	// The references are resolved first, so a panel or a variable copied in the dashboard has the last content of the
+	// resource it refers to.
+	if resolveErr := s.resolveRefs(source, false); resolveErr != nil {
+		return nil, resolveErr
+	}
+	entity, err := deep.Copy(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy entity: %w", err)
+	}
+	entity.Metadata.Name = name
+	entity.Metadata.Project = request.Project
+
+	m := &mover{
+		service:     s,
+		source:      parameters.Project,
+		target:      request.Project,
+		entity:      entity,
+		datasources: make(map[string]datasourceResolution),
+		result:      &v1.DashboardMoveResult{Dashboard: entity},
+	}
+	if m.source != m.target {
+		if rewriteErr := m.rewrite(); rewriteErr != nil {
+			return nil, rewriteErr
+		}
+	}
+	if len(m.result.Broken) > 0 && !request.Force && !request.DryRun {
+		return nil, apiInterface.HandleBadRequestError(fmt.Sprintf("the following references will break in the project %q: %s. Use force to move the dashboard anyway", m.target, formatReferences(m.result.Broken)))
+	}
+	if request.DryRun {
+		if resolveErr := s.resolveRefs(entity, true); resolveErr != nil {
+			return nil, resolveErr
+		}
+		if validateErr := s.Validate(entity); validateErr != nil {
+			return nil, validateErr
+		}
+		return m.result, nil
+	}
+	created, err := s.create(entity)
+	if err != nil {
+		return nil, err
+	}
+	s.webhook.Created(ctx, created)
+	m.result.Dashboard = created
+	if !request.Copy {
+		if deleteErr := s.Delete(ctx, parameters); deleteErr != nil {
+			return nil, deleteErr
+		}
+	}
+	return m.result, nil
+}
+
+func (m *mover) rewrite() error {
+	panelKeys := make([]string, 0, len(m.entity.Spec.Panels))
+	for key := range m.entity.Spec.Panels {
+		panelKeys = append(panelKeys, key)
+	}
+	sort.Strings(panelKeys)
+	for _, key := range panelKeys {
+		panel := m.entity.Spec.Panels[key]
+		if panel == nil {
+			continue
+		}
+		path := fmt.Sprintf("spec.panels.%s", key)
+		local, err := m.rewriteLibraryPanel(panel, path)
+		if err != nil {
+			return err
+		}
+		if !local {
+			// The panel is resolved from the library panel of the target project.
+			continue
+		}
+		for i, query := range panel.Spec.Queries {
+			if checkErr := m.checkDatasource(query.Spec, fmt.Sprintf("%s.spec.queries[%d]", path, i)); checkErr != nil {
+				return checkErr
+			}
+		}
+	}
+	for i := range m.entity.Spec.Variables {
+		v := &m.entity.Spec.Variables[i]
+		path := fmt.Sprintf("spec.variables[%d]", i)
+		local, err := m.rewriteVariable(v, path)
+		if err != nil {
+			return err
+		}
+		if !local || v.Kind != variableModel.KindList {
+			continue
+		}
+		listSpec, ok := v.Spec.(*dashboardModel.ListVariableSpec)
+		if !ok {
+			continue
+		}
+		if checkErr := m.checkDatasource(v1.QuerySpec{Plugin: listSpec.Plugin}, path); checkErr != nil {
+			return checkErr
+		}
+	}
+	return nil
+}
+
+// rewriteLibraryPanel detaches the panel from its library panel when the library panel doesn't exist in the target
+// project. The panel keeps the spec of the library panel. It returns whether the spec of the panel is part of the
+// dashboard.
+func (m *mover) rewriteLibraryPanel(panel *v1.Panel, path string) (bool, error) {
+	name := panel.LibraryPanelName()
+	if len(name) == 0 {
+		return true, nil
+	}
+	exists, err := found(m.libraryPanelDAO.Get(m.target, name))
+	if err != nil || exists {
+		return false, err
+	}
+	panel.LibraryPanel = ""
+	panel.Ref = nil
+	m.rewritten(v1.KindLibraryPanel, name, path, fmt.Sprintf("the library panel doesn't exist in the project %q, its spec is copied in the panel", m.target))
+	return true, nil
+}
+
+// rewriteVariable detaches the variable from the project variable it refers to when the project variable doesn't
+// exist in the target project. The variable keeps the content of the project variable. It returns whether the spec of
+// the variable is part of the dashboard.
+func (m *mover) rewriteVariable(v *dashboardModel.Variable, path string) (bool, error) {
+	if v.Ref == nil {
+		return true, nil
+	}
+	if v.Ref.Resource != v1.PluralKindMap[v1.KindVariable] {
+		// Global variables are available in every project.
+		return false, nil
+	}
+	exists, err := found(m.projectVarDAO.Get(m.target, v.Ref.Name))
+	if err != nil || exists {
+		return false, err
+	}
+	name := v.Ref.Name
+	v.Ref = nil
+	m.rewritten(v1.KindVariable, name, path, fmt.Sprintf("the variable doesn't exist in the project %q, its content is copied in the dashboard", m.target))
+	return true, nil
+}
+
+// checkDatasource verifies the datasource targeted by a query or a variable exists in the target project. When the
+// datasource only exists in the source project, it is copied in the dashboard.
+func (m *mover) checkDatasource(spec v1.QuerySpec, path string) error {
+	kind, name := datasourceSelector(spec)
+	// The default datasource and the datasources selected by a variable are resolved when the dashboard is displayed.
+	if len(name) == 0 || strings.HasPrefix(name, "$") {
+		return nil
+	}
+	if _, ok := m.entity.Spec.Datasources[name]; ok {
+		return nil
+	}
+	key := fmt.Sprintf("%s/%s", kind, name)
+	resolution, ok := m.datasources[key]
+	if !ok {
+		var err error
+		resolution, err = m.resolveDatasource(kind, name)
+		if err != nil {
+			return err
+		}
+		m.datasources[key] = resolution
+		if resolution.spec != nil {
+			if m.entity.Spec.Datasources == nil {
+				m.entity.Spec.Datasources = make(map[string]*v1.DatasourceSpec)
+			}
+			m.entity.Spec.Datasources[name] = resolution.spec
+			m.rewritten(v1.KindDatasource, name, path, fmt.Sprintf("the datasource doesn't exist in the project %q, it is copied in the dashboard", m.target))
+			return nil
+		}
+	}
+	if len(resolution.broken) > 0 {
+		m.result.Broken = append(m.result.Broken, v1.DashboardReference{Kind: v1.KindDatasource, Name: name, Path: path, Reason: resolution.broken})
+	}
+	return nil
+}
+
+func (m *mover) resolveDatasource(kind string, name string) (datasourceResolution, error) {
+	dts, err := m.dtsDAO.Get(m.target, name)
+	if err != nil && !databaseModel.IsKeyNotFound(err) {
+		return datasourceResolution{}, err
+	}
+	if err == nil && isKind(dts.Spec, kind) {
+		return datasourceResolution{}, nil
+	}
+	globalDts, err := m.globalDtsDAO.Get(name)
+	if err != nil && !databaseModel.IsKeyNotFound(err) {
+		return datasourceResolution{}, err
+	}
+	if err == nil && isKind(globalDts.Spec, kind) {
+		return datasourceResolution{}, nil
+	}
+	sourceDts, err := m.dtsDAO.Get(m.source, name)
+	if err != nil {
+		if databaseModel.IsKeyNotFound(err) {
+			return datasourceResolution{broken: "the datasource doesn't exist"}, nil
+		}
+		return datasourceResolution{}, err
+	}
+	if !isKind(sourceDts.Spec, kind) {
+		return datasourceResolution{broken: fmt.Sprintf("the datasource is not a %s", kind)}, nil
+	}
+	if m.isDatasourceDisable {
+		return datasourceResolution{broken: fmt.Sprintf("the datasource doesn't exist in the project %q and local datasources are disabled", m.target)}, nil
+	}
+	if secret := datasourceSecret(sourceDts.Spec); len(secret) > 0 {
+		return datasourceResolution{broken: fmt.Sprintf("the datasource doesn't exist in the project %q and uses the secret %q of the project %q", m.target, secret, m.source)}, nil
+	}
+	spec, err := deep.Copy(sourceDts.Spec)
+	if err != nil {
+		return datasourceResolution{}, fmt.Errorf("unable to copy the datasource %q: %w", name, err)
+	}
+	// The datasource is only used by its name, it must not replace the default datasource of the target project.
+	spec.Default = false
+	return datasourceResolution{spec: &spec}, nil
+}
+
+func (m *mover) rewritten(kind v1.Kind, name string, path string, reason string) {
+	m.result.Rewritten = append(m.result.Rewritten, v1.DashboardReference{Kind: kind, Name: name, Path: path, Reason: reason})
+}
+
+// found returns whether the resource returned by a DAO exists.
+func found[T any](_ T, err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+	if databaseModel.IsKeyNotFound(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// datasourceSelector returns the kind and the name of the datasource targeted by a query or a variable. The name is
+// empty when the default datasource of the kind is used.
+func datasourceSelector(spec v1.QuerySpec) (string, string) {
+	pluginSpec, _ := spec.Plugin.Spec.(map[string]interface{})
+	var kind, name string
+	switch selector := pluginSpec["datasource"].(type) {
+	case string:
+		name = selector
+	case map[string]interface{}:
+		kind, _ = selector["kind"].(string)
+		name, _ = selector["name"].(string)
+	}
+	if len(spec.Datasource) > 0 {
+		name = spec.Datasource
+	}
+	return kind, name
+}
+
+func isKind(spec v1.DatasourceSpec, kind string) bool {
+	return len(kind) == 0 || spec.Plugin.Kind == kind
+}
+
+// datasourceSecret returns the name of the secret used by the proxy of the datasource, if any.
+func datasourceSecret(spec v1.DatasourceSpec) string {
+	cfg, _, err := datasourceModel.ValidateAndExtract(spec.Plugin.Spec)
+	if err != nil {
+		return ""
+	}
+	switch c := cfg.(type) {
+	case *datasourceHTTP.Config:
+		return c.Secret
+	case *datasourceSQL.Config:
+		return c.Secret
+	}
+	return ""
+}
+
+func formatReferences(references []v1.DashboardReference) string {
+	messages := make([]string, 0, len(references))
+	for _, ref := range references {
+		messages = append(messages, fmt.Sprintf("%s %q at %s (%s)", ref.Kind, ref.Name, ref.Path, ref.Reason))
+	}
+	return strings.Join(messages, ", ")
+}
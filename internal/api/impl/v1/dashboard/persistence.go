@@ -22,6 +22,26 @@ import (
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 )
 
+// history is the document storing the previous versions of a dashboard. It has the same project and name as the
+// dashboard.
+type history struct {
+	Kind     string             `json:"kind" yaml:"kind"`
+	Metadata v1.ProjectMetadata `json:"metadata" yaml:"metadata"`
+	Spec     []*v1.Dashboard    `json:"spec" yaml:"spec"`
+}
+
+func (h *history) GetMetadata() api.Metadata {
+	return &h.Metadata
+}
+
+func (h *history) GetKind() string {
+	return h.Kind
+}
+
+func (h *history) GetSpec() interface{} {
+	return h.Spec
+}
+
 type dao struct {
 	dashboard.DAO
 	client databaseModel.DAO
@@ -44,11 +64,21 @@ func (d *dao) Update(entity *v1.Dashboard) error {
 }
 
 func (d *dao) Delete(project string, name string) error {
-	return d.client.Delete(d.kind, v1.NewProjectMetadata(project, name))
+	if err := d.client.Delete(d.kind, v1.NewProjectMetadata(project, name)); err != nil {
+		return err
+	}
+	// The dashboard may have no history if it has never been updated.
+	if err := d.client.Delete(v1.KindDashboardHistory, v1.NewProjectMetadata(project, name)); err != nil && !databaseModel.IsKeyNotFound(err) {
+		return err
+	}
+	return nil
 }
 
 func (d *dao) DeleteAll(project string) error {
-	return d.client.DeleteByQuery(&dashboard.Query{Project: project})
+	if err := d.client.DeleteByQuery(&dashboard.Query{Project: project}); err != nil {
+		return err
+	}
+	return d.client.DeleteByQuery(&dashboard.HistoryQuery{Project: project})
 }
 
 func (d *dao) Get(project string, name string) (*v1.Dashboard, error) {
@@ -79,3 +109,22 @@ func (d *dao) MetadataList(q *dashboard.Query) ([]api.Entity, error) {
 func (d *dao) RawMetadataList(q *dashboard.Query) ([]json.RawMessage, error) {
 	return d.client.RawMetadataQuery(q, d.kind)
 }
+
+func (d *dao) GetHistory(project string, name string) ([]*v1.Dashboard, error) {
+	entity := &history{}
+	if err := d.client.Get(v1.KindDashboardHistory, v1.NewProjectMetadata(project, name), entity); err != nil {
+		if databaseModel.IsKeyNotFound(err) {
+			return []*v1.Dashboard{}, nil
+		}
+		return nil, err
+	}
+	return entity.Spec, nil
+}
+
+func (d *dao) UpdateHistory(project string, name string, versions []*v1.Dashboard) error {
+	return d.client.Upsert(&history{
+		Kind:     string(v1.KindDashboardHistory),
+		Metadata: *v1.NewProjectMetadata(project, name),
+		Spec:     versions,
+	})
+}
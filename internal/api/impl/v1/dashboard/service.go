@@ -16,18 +16,27 @@ package dashboard
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/brunoga/deep"
 	"github.com/labstack/echo/v4"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
 	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/datasource"
+	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
 	"github.com/perses/perses/internal/api/interface/v1/globalvariable"
+	"github.com/perses/perses/internal/api/interface/v1/librarypanel"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
 	"github.com/perses/perses/internal/api/plugin/schema"
 	"github.com/perses/perses/internal/api/validate"
+	"github.com/perses/perses/internal/api/webhook"
 	"github.com/perses/perses/pkg/model/api"
 	"github.com/perses/perses/pkg/model/api/config"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	dashboardModel "github.com/perses/perses/pkg/model/api/v1/dashboard"
+	variableModel "github.com/perses/perses/pkg/model/api/v1/variable"
 	"github.com/sirupsen/logrus"
 )
 
@@ -36,33 +45,51 @@ type service struct {
 	dao                 dashboard.DAO
 	globalVarDAO        globalvariable.DAO
 	projectVarDAO       variable.DAO
+	libraryPanelDAO     librarypanel.DAO
+	dtsDAO              datasource.DAO
+	globalDtsDAO        globaldatasource.DAO
 	sch                 schema.Schema
 	isDatasourceDisable bool
 	isVariableDisable   bool
 	customRules         []*config.CustomLintRule
+	maxVersions         int
+	webhook             webhook.Webhook
 }
 
-func NewService(cfg config.Config, dao dashboard.DAO, globalVarDAO globalvariable.DAO, projectVarDAO variable.DAO, sch schema.Schema) dashboard.Service {
+func NewService(cfg config.Config, dao dashboard.DAO, globalVarDAO globalvariable.DAO, projectVarDAO variable.DAO, libraryPanelDAO librarypanel.DAO, dtsDAO datasource.DAO, globalDtsDAO globaldatasource.DAO, sch schema.Schema, wh webhook.Webhook) dashboard.Service {
 	return &service{
 		dao:                 dao,
 		globalVarDAO:        globalVarDAO,
 		projectVarDAO:       projectVarDAO,
+		libraryPanelDAO:     libraryPanelDAO,
+		dtsDAO:              dtsDAO,
+		globalDtsDAO:        globalDtsDAO,
 		sch:                 sch,
 		isDatasourceDisable: cfg.Datasource.DisableLocal,
 		isVariableDisable:   cfg.Variable.DisableLocal,
 		customRules:         cfg.Dashboard.CustomLintRules,
+		maxVersions:         cfg.Dashboard.MaxVersions,
+		webhook:             wh,
 	}
 }
 
-func (s *service) Create(_ echo.Context, entity *v1.Dashboard) (*v1.Dashboard, error) {
+func (s *service) Create(ctx echo.Context, entity *v1.Dashboard) (*v1.Dashboard, error) {
 	copyEntity, err := deep.Copy(entity)
 	if err != nil {
 		return nil, fmt.Errorf("failed to copy entity: %w", err)
 	}
-	return s.create(copyEntity)
+	result, err := s.create(copyEntity)
+	if err != nil {
+		return nil, err
+	}
+	s.webhook.Created(ctx, result)
+	return result, nil
 }
 
 func (s *service) create(entity *v1.Dashboard) (*v1.Dashboard, error) {
+	if err := s.resolveRefs(entity, true); err != nil {
+		return nil, err
+	}
 	// verify this new dashboard passes the validation
 	if err := s.Validate(entity); err != nil {
 		return nil, err
@@ -76,15 +103,15 @@ func (s *service) create(entity *v1.Dashboard) (*v1.Dashboard, error) {
 	return entity, nil
 }
 
-func (s *service) Update(_ echo.Context, entity *v1.Dashboard, parameters apiInterface.Parameters) (*v1.Dashboard, error) {
+func (s *service) Update(ctx echo.Context, entity *v1.Dashboard, parameters apiInterface.Parameters) (*v1.Dashboard, error) {
 	copyEntity, err := deep.Copy(entity)
 	if err != nil {
 		return nil, fmt.Errorf("failed to copy entity: %w", err)
 	}
-	return s.update(copyEntity, parameters)
+	return s.update(ctx, copyEntity, parameters)
 }
 
-func (s *service) update(entity *v1.Dashboard, parameters apiInterface.Parameters) (*v1.Dashboard, error) {
+func (s *service) update(ctx echo.Context, entity *v1.Dashboard, parameters apiInterface.Parameters) (*v1.Dashboard, error) {
 	if entity.Metadata.Name != parameters.Name {
 		logrus.Debugf("name in dashboard %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
 		return nil, apiInterface.HandleBadRequestError("metadata.name and the name in the http path request don't match")
@@ -95,6 +122,9 @@ func (s *service) update(entity *v1.Dashboard, parameters apiInterface.Parameter
 		logrus.Debugf("project in dashboard %q and project from the http request %q don't match", entity.Metadata.Project, parameters.Project)
 		return nil, apiInterface.HandleBadRequestError("metadata.project and the project name in the http path request don't match")
 	}
+	if err := s.resolveRefs(entity, true); err != nil {
+		return nil, err
+	}
 
 	// verify this new dashboard passes the validation
 	if err := s.Validate(entity); err != nil {
@@ -106,20 +136,76 @@ func (s *service) update(entity *v1.Dashboard, parameters apiInterface.Parameter
 	if err != nil {
 		return nil, err
 	}
+	// keep the previous version, so it can be restored later
+	if historyErr := s.addToHistory(oldEntity); historyErr != nil {
+		logrus.WithError(historyErr).Errorf("unable to store the previous version of the dashboard %q", entity.Metadata.Name)
+		return nil, historyErr
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 	if updateErr := s.dao.Update(entity); updateErr != nil {
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the dashboard %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
+	s.webhook.Updated(ctx, oldEntity, entity)
 	return entity, nil
 }
 
-func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
-	return s.dao.Delete(parameters.Project, parameters.Name)
+func (s *service) addToHistory(previous *v1.Dashboard) error {
+	versions, err := s.dao.GetHistory(previous.Metadata.Project, previous.Metadata.Name)
+	if err != nil {
+		return err
+	}
+	versions = append(versions, previous)
+	if s.maxVersions > 0 && len(versions) > s.maxVersions {
+		versions = versions[len(versions)-s.maxVersions:]
+	}
+	return s.dao.UpdateHistory(previous.Metadata.Project, previous.Metadata.Name, versions)
+}
+
+func (s *service) ListVersions(parameters apiInterface.Parameters) ([]*v1.Dashboard, error) {
+	// The dashboard must exist, otherwise an empty history would be returned.
+	if _, err := s.dao.Get(parameters.Project, parameters.Name); err != nil {
+		return nil, err
+	}
+	versions, err := s.dao.GetHistory(parameters.Project, parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(versions)
+	return versions, nil
+}
+
+func (s *service) RestoreVersion(ctx echo.Context, parameters apiInterface.Parameters, version uint64) (*v1.Dashboard, error) {
+	versions, err := s.dao.GetHistory(parameters.Project, parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, previous := range versions {
+		if previous.Metadata.Version == version {
+			return s.update(ctx, previous, parameters)
+		}
+	}
+	return nil, apiInterface.HandleNotFoundError(fmt.Sprintf("version %d of the dashboard %q not found", version, parameters.Name))
+}
+
+func (s *service) Delete(ctx echo.Context, parameters apiInterface.Parameters) error {
+	if err := s.dao.Delete(parameters.Project, parameters.Name); err != nil {
+		return err
+	}
+	s.webhook.Deleted(ctx, v1.KindDashboard, parameters.Project, parameters.Name)
+	return nil
 }
 
 func (s *service) Get(parameters apiInterface.Parameters) (*v1.Dashboard, error) {
-	return s.dao.Get(parameters.Project, parameters.Name)
+	entity, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	// The library panels and the variables referenced may have been updated since the dashboard has been saved.
+	if resolveErr := s.resolveRefs(entity, false); resolveErr != nil {
+		return nil, resolveErr
+	}
+	return entity, nil
 }
 
 func (s *service) List(q *dashboard.Query, params apiInterface.Parameters) ([]*v1.Dashboard, error) {
@@ -184,6 +270,111 @@ func (s *service) Validate(entity *v1.Dashboard) error {
 	return nil
 }
 
+// resolveRefs replaces the panels and the variables referring to an external resource (a LibraryPanel, a project or a
+// global variable) by the content of this resource.
+// When strict is false, a panel or a variable referring to a resource that doesn't exist anymore keeps its current spec.
+func (s *service) resolveRefs(entity *v1.Dashboard, strict bool) error {
+	if err := s.resolveLibraryPanels(entity, strict); err != nil {
+		return err
+	}
+	return s.resolveVariables(entity, strict)
+}
+
+// resolveLibraryPanels replaces the spec of the panels referring to a LibraryPanel by the spec of this LibraryPanel.
+func (s *service) resolveLibraryPanels(entity *v1.Dashboard, strict bool) error {
+	for panelKey, panel := range entity.Spec.Panels {
+		if panel == nil {
+			continue
+		}
+		name := panel.LibraryPanelName()
+		if len(name) == 0 {
+			continue
+		}
+		libraryPanel, err := s.libraryPanelDAO.Get(entity.Metadata.Project, name)
+		if err != nil {
+			if !databaseModel.IsKeyNotFound(err) {
+				return err
+			}
+			if strict {
+				return apiInterface.HandleBadRequestError(fmt.Sprintf("panel %q refers to the library panel %q that doesn't exist in the project %q", panelKey, name, entity.Metadata.Project))
+			}
+			logrus.Warnf("library panel %q used by the dashboard %q not found, the panel %q keeps its last known spec", name, entity.Metadata.Name, panelKey)
+			continue
+		}
+		spec, err := deep.Copy(libraryPanel.Spec)
+		if err != nil {
+			return fmt.Errorf("unable to copy the library panel %q: %w", name, err)
+		}
+		panel.Spec = spec
+	}
+	return nil
+}
+
+// resolveVariables replaces the kind and the spec of the variables referring to a project or a global variable by the
+// ones of this variable. The variable keeps its name.
+func (s *service) resolveVariables(entity *v1.Dashboard, strict bool) error {
+	for i := range entity.Spec.Variables {
+		v := &entity.Spec.Variables[i]
+		if v.Ref == nil {
+			continue
+		}
+		varSpec, err := s.getReferencedVariable(entity.Metadata.Project, v.Ref)
+		if err != nil {
+			if !databaseModel.IsKeyNotFound(err) {
+				return err
+			}
+			if strict {
+				return apiInterface.HandleBadRequestError(fmt.Sprintf("variable %q refers to %q that doesn't exist", v.Spec.GetName(), v.Ref))
+			}
+			logrus.Warnf("%q used by the dashboard %q not found, the variable %q keeps its last known spec", v.Ref, entity.Metadata.Name, v.Spec.GetName())
+			continue
+		}
+		resolved, err := toDashboardVariable(v.Spec.GetName(), varSpec)
+		if err != nil {
+			return fmt.Errorf("unable to copy %q: %w", v.Ref, err)
+		}
+		v.Kind = resolved.Kind
+		v.Spec = resolved.Spec
+	}
+	return nil
+}
+
+func (s *service) getReferencedVariable(project string, ref *common.ExternalRef) (v1.VariableSpec, error) {
+	if ref.Resource == v1.PluralKindMap[v1.KindGlobalVariable] {
+		globalVar, err := s.globalVarDAO.Get(ref.Name)
+		if err != nil {
+			return v1.VariableSpec{}, err
+		}
+		return globalVar.Spec, nil
+	}
+	projectVar, err := s.projectVarDAO.Get(project, ref.Name)
+	if err != nil {
+		return v1.VariableSpec{}, err
+	}
+	return projectVar.Spec, nil
+}
+
+// toDashboardVariable converts the spec of a project or a global variable to a variable of dashboard with the given
+// name.
+func toDashboardVariable(name string, varSpec v1.VariableSpec) (dashboardModel.Variable, error) {
+	switch spec := varSpec.Spec.(type) {
+	case *variableModel.ListSpec:
+		listSpec, err := deep.Copy(*spec)
+		if err != nil {
+			return dashboardModel.Variable{}, err
+		}
+		return dashboardModel.Variable{Kind: variableModel.KindList, Spec: &dashboardModel.ListVariableSpec{ListSpec: listSpec, Name: name}}, nil
+	case *variableModel.TextSpec:
+		textSpec, err := deep.Copy(*spec)
+		if err != nil {
+			return dashboardModel.Variable{}, err
+		}
+		return dashboardModel.Variable{Kind: variableModel.KindText, Spec: &dashboardModel.TextVariableSpec{TextSpec: textSpec, Name: name}}, nil
+	default:
+		return dashboardModel.Variable{}, fmt.Errorf("unknown variable kind %q", varSpec.Kind)
+	}
+}
+
 func (s *service) collectProjectVariables(project string) ([]*v1.Variable, error) {
 	if len(project) == 0 {
 		return nil, nil
@@ -0,0 +1,114 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboardmove
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/route"
+	"github.com/perses/perses/internal/api/toolbox"
+	"github.com/perses/perses/internal/api/utils"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+const pathMove = "move"
+
+type endpoint struct {
+	service       dashboard.Service
+	authz         authorization.Authorization
+	audit         audit.Service
+	readonly      bool
+	caseSensitive bool
+}
+
+// NewEndpoint creates the endpoint moving or copying a dashboard to another project.
+func NewEndpoint(service dashboard.Service, authz authorization.Authorization, auditService audit.Service, readonly bool, caseSensitive bool) route.Endpoint {
+	return &endpoint{
+		service:       service,
+		authz:         authz,
+		audit:         auditService,
+		readonly:      readonly,
+		caseSensitive: caseSensitive,
+	}
+}
+
+func (e *endpoint) CollectRoutes(g *route.Group) {
+	if e.readonly {
+		return
+	}
+	g.POST(fmt.Sprintf("/%s/:%s/%s/:%s/%s", utils.PathProject, utils.ParamProject, utils.PathDashboard, utils.ParamName, pathMove), e.Move, false)
+}
+
+// Move moves or copies the dashboard to the project of the request. Reading the dashboard and creating it in the
+// target project are required, deleting it from the source project as well when the dashboard is moved.
+func (e *endpoint) Move(ctx echo.Context) error {
+	parameters := toolbox.ExtractParameters(ctx, e.caseSensitive)
+	request := &v1.DashboardMoveRequest{}
+	if err := ctx.Bind(request); err != nil {
+		return apiInterface.HandleBadRequestError(err.Error())
+	}
+	request.Flatten(e.caseSensitive)
+	if err := e.checkPermission(ctx, role.ReadAction, parameters.Project, parameters.Name); err != nil {
+		return err
+	}
+	if !request.DryRun {
+		targetName := request.Name
+		if len(targetName) == 0 {
+			targetName = parameters.Name
+		}
+		if err := e.checkPermission(ctx, role.CreateAction, request.Project, targetName); err != nil {
+			return err
+		}
+		if !request.Copy {
+			if err := e.checkPermission(ctx, role.DeleteAction, parameters.Project, parameters.Name); err != nil {
+				return err
+			}
+		}
+	}
+	var previous modelAPI.Entity
+	if e.audit.IsEnabled() && !request.Copy {
+		if current, getErr := e.service.Get(parameters); getErr == nil {
+			previous = current
+		}
+	}
+	result, err := e.service.Move(ctx, parameters, request)
+	if err != nil {
+		return err
+	}
+	if !request.DryRun {
+		e.audit.Created(ctx, result.Dashboard)
+		if !request.Copy {
+			e.audit.Deleted(ctx, v1.KindDashboard, parameters.Project, parameters.Name, previous)
+		}
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (e *endpoint) checkPermission(ctx echo.Context, action role.Action, project string, name string) error {
+	if !e.authz.IsEnabled() {
+		return nil
+	}
+	if ok := e.authz.HasResourcePermission(ctx, action, project, role.DashboardScope, name); !ok {
+		return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission in '%s' project for '%s' kind", action, project, role.DashboardScope))
+	}
+	return nil
+}
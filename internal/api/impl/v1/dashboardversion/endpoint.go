@@ -0,0 +1,111 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboardversion
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/route"
+	"github.com/perses/perses/internal/api/toolbox"
+	"github.com/perses/perses/internal/api/utils"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+const (
+	pathVersions = "versions"
+	pathRestore  = "restore"
+	paramVersion = "version"
+)
+
+type endpoint struct {
+	service       dashboard.Service
+	authz         authorization.Authorization
+	audit         audit.Service
+	readonly      bool
+	caseSensitive bool
+}
+
+// NewEndpoint creates the endpoint listing the previous versions of the dashboards and restoring them.
+func NewEndpoint(service dashboard.Service, authz authorization.Authorization, auditService audit.Service, readonly bool, caseSensitive bool) route.Endpoint {
+	return &endpoint{
+		service:       service,
+		authz:         authz,
+		audit:         auditService,
+		readonly:      readonly,
+		caseSensitive: caseSensitive,
+	}
+}
+
+func (e *endpoint) CollectRoutes(g *route.Group) {
+	subGroup := g.Group(fmt.Sprintf("/%s/:%s/%s/:%s/%s", utils.PathProject, utils.ParamProject, utils.PathDashboard, utils.ParamName, pathVersions))
+	if !e.readonly {
+		subGroup.POST(fmt.Sprintf("/:%s/%s", paramVersion, pathRestore), e.Restore, false)
+	}
+	subGroup.GET("", e.List, false)
+}
+
+// List returns the previous versions of the dashboard, from the most recent to the oldest.
+func (e *endpoint) List(ctx echo.Context) error {
+	parameters := toolbox.ExtractParameters(ctx, e.caseSensitive)
+	if err := e.checkPermission(ctx, role.ReadAction, parameters.Project, parameters.Name); err != nil {
+		return err
+	}
+	versions, err := e.service.ListVersions(parameters)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, versions)
+}
+
+// Restore replaces the dashboard by one of its previous versions and returns the updated dashboard.
+func (e *endpoint) Restore(ctx echo.Context) error {
+	parameters := toolbox.ExtractParameters(ctx, e.caseSensitive)
+	version, err := strconv.ParseUint(ctx.Param(paramVersion), 10, 64)
+	if err != nil {
+		return apiInterface.HandleBadRequestError(fmt.Sprintf("version %q is not a valid version number", ctx.Param(paramVersion)))
+	}
+	if permErr := e.checkPermission(ctx, role.UpdateAction, parameters.Project, parameters.Name); permErr != nil {
+		return permErr
+	}
+	var previous modelAPI.Entity
+	if e.audit.IsEnabled() {
+		if current, getErr := e.service.Get(parameters); getErr == nil {
+			previous = current
+		}
+	}
+	entity, err := e.service.RestoreVersion(ctx, parameters, version)
+	if err != nil {
+		return err
+	}
+	e.audit.Updated(ctx, previous, entity)
+	return ctx.JSON(http.StatusOK, entity)
+}
+
+func (e *endpoint) checkPermission(ctx echo.Context, action role.Action, project string, name string) error {
+	if !e.authz.IsEnabled() {
+		return nil
+	}
+	if ok := e.authz.HasResourcePermission(ctx, action, project, role.DashboardScope, name); !ok {
+		return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission in '%s' project for '%s' kind", action, project, role.DashboardScope))
+	}
+	return nil
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
 	"github.com/perses/perses/internal/api/plugin/schema"
 	"github.com/perses/perses/internal/api/validate"
+	"github.com/perses/perses/internal/api/webhook"
 	"github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
@@ -30,23 +31,30 @@ import (
 
 type service struct {
 	datasource.Service
-	dao datasource.DAO
-	sch schema.Schema
+	dao     datasource.DAO
+	sch     schema.Schema
+	webhook webhook.Webhook
 }
 
-func NewService(dao datasource.DAO, sch schema.Schema) datasource.Service {
+func NewService(dao datasource.DAO, sch schema.Schema, wh webhook.Webhook) datasource.Service {
 	return &service{
-		dao: dao,
-		sch: sch,
+		dao:     dao,
+		sch:     sch,
+		webhook: wh,
 	}
 }
 
-func (s *service) Create(_ echo.Context, entity *v1.Datasource) (*v1.Datasource, error) {
+func (s *service) Create(ctx echo.Context, entity *v1.Datasource) (*v1.Datasource, error) {
 	copyEntity, err := deep.Copy(entity)
 	if err != nil {
 		return nil, fmt.Errorf("failed to copy entity: %w", err)
 	}
-	return s.create(copyEntity)
+	result, err := s.create(copyEntity)
+	if err != nil {
+		return nil, err
+	}
+	s.webhook.Created(ctx, result)
+	return result, nil
 }
 
 func (s *service) create(entity *v1.Datasource) (*v1.Datasource, error) {
@@ -61,15 +69,15 @@ func (s *service) create(entity *v1.Datasource) (*v1.Datasource, error) {
 	return entity, nil
 }
 
-func (s *service) Update(_ echo.Context, entity *v1.Datasource, parameters apiInterface.Parameters) (*v1.Datasource, error) {
+func (s *service) Update(ctx echo.Context, entity *v1.Datasource, parameters apiInterface.Parameters) (*v1.Datasource, error) {
 	copyEntity, err := deep.Copy(entity)
 	if err != nil {
 		return nil, fmt.Errorf("failed to copy entity: %w", err)
 	}
-	return s.update(copyEntity, parameters)
+	return s.update(ctx, copyEntity, parameters)
 }
 
-func (s *service) update(entity *v1.Datasource, parameters apiInterface.Parameters) (*v1.Datasource, error) {
+func (s *service) update(ctx echo.Context, entity *v1.Datasource, parameters apiInterface.Parameters) (*v1.Datasource, error) {
 	if err := s.validate(entity); err != nil {
 		return nil, apiInterface.HandleBadRequestError(err.Error())
 	}
@@ -93,11 +101,16 @@ func (s *service) update(entity *v1.Datasource, parameters apiInterface.Paramete
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the Datasource %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
+	s.webhook.Updated(ctx, oldEntity, entity)
 	return entity, nil
 }
 
-func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
-	return s.dao.Delete(parameters.Project, parameters.Name)
+func (s *service) Delete(ctx echo.Context, parameters apiInterface.Parameters) error {
+	if err := s.dao.Delete(parameters.Project, parameters.Name); err != nil {
+		return err
+	}
+	s.webhook.Deleted(ctx, v1.KindDatasource, parameters.Project, parameters.Name)
+	return nil
 }
 
 func (s *service) Get(parameters apiInterface.Parameters) (*v1.Datasource, error) {
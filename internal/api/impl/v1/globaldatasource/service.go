@@ -23,6 +23,7 @@ import (
 	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
 	"github.com/perses/perses/internal/api/plugin/schema"
 	"github.com/perses/perses/internal/api/validate"
+	"github.com/perses/perses/internal/api/webhook"
 	"github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
@@ -30,23 +31,30 @@ import (
 
 type service struct {
 	globaldatasource.Service
-	dao globaldatasource.DAO
-	sch schema.Schema
+	dao     globaldatasource.DAO
+	sch     schema.Schema
+	webhook webhook.Webhook
 }
 
-func NewService(dao globaldatasource.DAO, sch schema.Schema) globaldatasource.Service {
+func NewService(dao globaldatasource.DAO, sch schema.Schema, wh webhook.Webhook) globaldatasource.Service {
 	return &service{
-		dao: dao,
-		sch: sch,
+		dao:     dao,
+		sch:     sch,
+		webhook: wh,
 	}
 }
 
-func (s *service) Create(_ echo.Context, entity *v1.GlobalDatasource) (*v1.GlobalDatasource, error) {
+func (s *service) Create(ctx echo.Context, entity *v1.GlobalDatasource) (*v1.GlobalDatasource, error) {
 	copyEntity, err := deep.Copy(entity)
 	if err != nil {
 		return nil, fmt.Errorf("failed to copy entity: %w", err)
 	}
-	return s.create(copyEntity)
+	result, err := s.create(copyEntity)
+	if err != nil {
+		return nil, err
+	}
+	s.webhook.Created(ctx, result)
+	return result, nil
 }
 
 func (s *service) create(entity *v1.GlobalDatasource) (*v1.GlobalDatasource, error) {
@@ -61,15 +69,15 @@ func (s *service) create(entity *v1.GlobalDatasource) (*v1.GlobalDatasource, err
 	return entity, nil
 }
 
-func (s *service) Update(_ echo.Context, entity *v1.GlobalDatasource, parameters apiInterface.Parameters) (*v1.GlobalDatasource, error) {
+func (s *service) Update(ctx echo.Context, entity *v1.GlobalDatasource, parameters apiInterface.Parameters) (*v1.GlobalDatasource, error) {
 	copyEntity, err := deep.Copy(entity)
 	if err != nil {
 		return nil, fmt.Errorf("failed to copy entity: %w", err)
 	}
-	return s.update(copyEntity, parameters)
+	return s.update(ctx, copyEntity, parameters)
 }
 
-func (s *service) update(entity *v1.GlobalDatasource, parameters apiInterface.Parameters) (*v1.GlobalDatasource, error) {
+func (s *service) update(ctx echo.Context, entity *v1.GlobalDatasource, parameters apiInterface.Parameters) (*v1.GlobalDatasource, error) {
 	if err := s.validate(entity); err != nil {
 		return nil, apiInterface.HandleBadRequestError(err.Error())
 	}
@@ -87,11 +95,16 @@ func (s *service) update(entity *v1.GlobalDatasource, parameters apiInterface.Pa
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the GlobalDatasource %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
+	s.webhook.Updated(ctx, oldEntity, entity)
 	return entity, nil
 }
 
-func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
-	return s.dao.Delete(parameters.Name)
+func (s *service) Delete(ctx echo.Context, parameters apiInterface.Parameters) error {
+	if err := s.dao.Delete(parameters.Name); err != nil {
+		return err
+	}
+	s.webhook.Deleted(ctx, v1.KindGlobalDatasource, "", parameters.Name)
+	return nil
 }
 
 func (s *service) Get(parameters apiInterface.Parameters) (*v1.GlobalDatasource, error) {
@@ -0,0 +1,112 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globaldatasource
+
+import (
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
+	"github.com/perses/perses/internal/api/plugin/schema"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDAO struct {
+	globaldatasource.DAO
+	entities map[string]*v1.GlobalDatasource
+}
+
+func (d *fakeDAO) Create(entity *v1.GlobalDatasource) error {
+	d.entities[entity.Metadata.Name] = entity
+	return nil
+}
+
+func (d *fakeDAO) Update(entity *v1.GlobalDatasource) error {
+	d.entities[entity.Metadata.Name] = entity
+	return nil
+}
+
+func (d *fakeDAO) Delete(name string) error {
+	if _, ok := d.entities[name]; !ok {
+		return &databaseModel.Error{Key: name, Code: databaseModel.ErrorCodeNotFound}
+	}
+	delete(d.entities, name)
+	return nil
+}
+
+func (d *fakeDAO) Get(name string) (*v1.GlobalDatasource, error) {
+	entity, ok := d.entities[name]
+	if !ok {
+		return nil, &databaseModel.Error{Key: name, Code: databaseModel.ErrorCodeNotFound}
+	}
+	return entity, nil
+}
+
+type fakeSchema struct {
+	schema.Schema
+}
+
+func (s *fakeSchema) ValidateDatasource(_ common.Plugin, _ string) error {
+	return nil
+}
+
+type fakeWebhook struct {
+	events []api.WebhookEvent
+}
+
+func (w *fakeWebhook) Created(_ echo.Context, entity api.Entity) {
+	w.events = append(w.events, api.WebhookEvent{Kind: entity.GetKind(), Name: entity.GetMetadata().GetName(), Action: api.WebhookActionCreated})
+}
+
+func (w *fakeWebhook) Updated(_ echo.Context, _ api.Entity, current api.Entity) {
+	w.events = append(w.events, api.WebhookEvent{Kind: current.GetKind(), Name: current.GetMetadata().GetName(), Action: api.WebhookActionUpdated})
+}
+
+func (w *fakeWebhook) Deleted(_ echo.Context, kind v1.Kind, project string, name string) {
+	w.events = append(w.events, api.WebhookEvent{Kind: string(kind), Project: project, Name: name, Action: api.WebhookActionDeleted})
+}
+
+func newGlobalDatasource(name string) *v1.GlobalDatasource {
+	return &v1.GlobalDatasource{
+		Kind:     v1.KindGlobalDatasource,
+		Metadata: v1.Metadata{Name: name},
+		Spec:     v1.DatasourceSpec{Plugin: common.Plugin{Kind: "PrometheusDatasource", Spec: map[string]interface{}{}}},
+	}
+}
+
+func TestServiceWebhook(t *testing.T) {
+	wh := &fakeWebhook{}
+	svc := NewService(&fakeDAO{entities: map[string]*v1.GlobalDatasource{}}, &fakeSchema{}, wh)
+	parameters := apiInterface.Parameters{Name: "prometheus"}
+
+	_, err := svc.Create(nil, newGlobalDatasource("prometheus"))
+	require.NoError(t, err)
+	_, err = svc.Update(nil, newGlobalDatasource("prometheus"), parameters)
+	require.NoError(t, err)
+	require.NoError(t, svc.Delete(nil, parameters))
+	// A failed change doesn't notify the webhooks.
+	assert.Error(t, svc.Delete(nil, parameters))
+
+	assert.Equal(t, []api.WebhookEvent{
+		{Kind: "GlobalDatasource", Name: "prometheus", Action: api.WebhookActionCreated},
+		{Kind: "GlobalDatasource", Name: "prometheus", Action: api.WebhookActionUpdated},
+		{Kind: "GlobalDatasource", Name: "prometheus", Action: api.WebhookActionDeleted},
+	}, wh.events)
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/perses/perses/internal/api/authorization"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
 	"github.com/perses/perses/internal/api/interface/v1/globalrolebinding"
 	"github.com/perses/perses/internal/api/route"
 	"github.com/perses/perses/internal/api/toolbox"
@@ -32,9 +33,9 @@ type endpoint struct {
 	readonly bool
 }
 
-func NewEndpoint(service globalrolebinding.Service, authz authorization.Authorization, readonly bool, caseSensitive bool) route.Endpoint {
+func NewEndpoint(service globalrolebinding.Service, authz authorization.Authorization, auditService audit.Service, readonly bool, caseSensitive bool) route.Endpoint {
 	return &endpoint{
-		toolbox:  toolbox.New[*v1.GlobalRoleBinding, *v1.GlobalRoleBinding, *globalrolebinding.Query](service, authz, v1.KindGlobalRoleBinding, caseSensitive),
+		toolbox:  toolbox.New[*v1.GlobalRoleBinding, *v1.GlobalRoleBinding, *globalrolebinding.Query](service, authz, auditService, v1.KindGlobalRoleBinding, caseSensitive),
 		readonly: readonly,
 	}
 }
@@ -24,10 +24,12 @@ import (
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
 	"github.com/perses/perses/internal/api/interface/v1/folder"
+	"github.com/perses/perses/internal/api/interface/v1/librarypanel"
 	"github.com/perses/perses/internal/api/interface/v1/project"
 	"github.com/perses/perses/internal/api/interface/v1/role"
 	"github.com/perses/perses/internal/api/interface/v1/rolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/secret"
+	"github.com/perses/perses/internal/api/interface/v1/snapshot"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
 	"github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
@@ -37,28 +39,32 @@ import (
 
 type service struct {
 	project.Service
-	dao            project.DAO
-	folderDAO      folder.DAO
-	datasourceDAO  datasource.DAO
-	dashboardDAO   dashboard.DAO
-	roleDAO        role.DAO
-	roleBindingDAO rolebinding.DAO
-	secretDAO      secret.DAO
-	variableDAO    variable.DAO
-	authz          authorization.Authorization
+	dao             project.DAO
+	folderDAO       folder.DAO
+	datasourceDAO   datasource.DAO
+	dashboardDAO    dashboard.DAO
+	libraryPanelDAO librarypanel.DAO
+	roleDAO         role.DAO
+	roleBindingDAO  rolebinding.DAO
+	secretDAO       secret.DAO
+	snapshotDAO     snapshot.DAO
+	variableDAO     variable.DAO
+	authz           authorization.Authorization
 }
 
-func NewService(dao project.DAO, folderDAO folder.DAO, datasourceDAO datasource.DAO, dashboardDAO dashboard.DAO, roleDAO role.DAO, roleBindingDAO rolebinding.DAO, secretDAO secret.DAO, variableDAO variable.DAO, authz authorization.Authorization) project.Service {
+func NewService(dao project.DAO, folderDAO folder.DAO, datasourceDAO datasource.DAO, dashboardDAO dashboard.DAO, libraryPanelDAO librarypanel.DAO, roleDAO role.DAO, roleBindingDAO rolebinding.DAO, secretDAO secret.DAO, snapshotDAO snapshot.DAO, variableDAO variable.DAO, authz authorization.Authorization) project.Service {
 	return &service{
-		dao:            dao,
-		folderDAO:      folderDAO,
-		datasourceDAO:  datasourceDAO,
-		dashboardDAO:   dashboardDAO,
-		roleDAO:        roleDAO,
-		roleBindingDAO: roleBindingDAO,
-		secretDAO:      secretDAO,
-		variableDAO:    variableDAO,
-		authz:          authz,
+		dao:             dao,
+		folderDAO:       folderDAO,
+		datasourceDAO:   datasourceDAO,
+		dashboardDAO:    dashboardDAO,
+		libraryPanelDAO: libraryPanelDAO,
+		roleDAO:         roleDAO,
+		roleBindingDAO:  roleBindingDAO,
+		secretDAO:       secretDAO,
+		snapshotDAO:     snapshotDAO,
+		variableDAO:     variableDAO,
+		authz:           authz,
 	}
 }
 
@@ -150,6 +156,10 @@ func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) err
 		logrus.WithError(err).Error("unable to delete all dashboards")
 		return err
 	}
+	if err := s.libraryPanelDAO.DeleteAll(projectName); err != nil {
+		logrus.WithError(err).Error("unable to delete all libraryPanels")
+		return err
+	}
 	if err := s.datasourceDAO.DeleteAll(projectName); err != nil {
 		logrus.WithError(err).Error("unable to delete all datasources")
 		return err
@@ -158,6 +168,10 @@ func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) err
 		logrus.WithError(err).Error("unable to delete all secrets")
 		return err
 	}
+	if err := s.snapshotDAO.DeleteAll(projectName); err != nil {
+		logrus.WithError(err).Error("unable to delete all snapshots")
+		return err
+	}
 	if err := s.variableDAO.DeleteAll(projectName); err != nil {
 		logrus.WithError(err).Error("unable to delete all variables")
 		return err
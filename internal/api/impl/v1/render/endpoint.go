@@ -0,0 +1,219 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/route"
+	"github.com/perses/perses/internal/api/toolbox"
+	"github.com/perses/perses/internal/api/utils"
+	clientConfig "github.com/perses/perses/pkg/client/config"
+	"github.com/perses/perses/pkg/model/api/config"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+const (
+	pathRender = "render"
+	// rendererPath is the path of the renderer service taking the screenshots.
+	rendererPath = "render"
+	formatParam  = "format"
+	widthParam   = "width"
+	heightParam  = "height"
+)
+
+type Format string
+
+const (
+	FormatPNG Format = "png"
+	FormatPDF Format = "pdf"
+)
+
+var contentTypes = map[Format]string{
+	FormatPNG: "image/png",
+	FormatPDF: "application/pdf",
+}
+
+// forwardedHeaders are the headers of the request passed to the renderer service, so it loads the dashboard with
+// the credentials of the user.
+var forwardedHeaders = []string{echo.HeaderAuthorization, echo.HeaderCookie}
+
+// rendererRequest is the body sent to the renderer service.
+type rendererRequest struct {
+	// URL is the URL of the dashboard in the Perses UI.
+	URL    string `json:"url"`
+	Format Format `json:"format"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	// Timeout is the maximum duration the renderer service can spend loading the dashboard.
+	Timeout string `json:"timeout"`
+	// Headers are the headers the renderer service sets when loading the dashboard.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+type endpoint struct {
+	cfg              config.Rendering
+	client           *http.Client
+	headers          map[string]string
+	dashboardService dashboard.Service
+	authz            authorization.Authorization
+	caseSensitive    bool
+}
+
+// NewEndpoint creates the endpoint rendering the dashboards as a PNG image or as a PDF document through the renderer
+// service. The routes are only registered when the rendering is enabled.
+func NewEndpoint(cfg config.Rendering, dashboardService dashboard.Service, authz authorization.Authorization, caseSensitive bool) (route.Endpoint, error) {
+	e := &endpoint{
+		cfg:              cfg,
+		dashboardService: dashboardService,
+		authz:            authz,
+		caseSensitive:    caseSensitive,
+	}
+	if !cfg.Enable {
+		return e, nil
+	}
+	restClient, err := clientConfig.NewRESTClient(*cfg.Renderer)
+	if err != nil {
+		return nil, err
+	}
+	e.client = restClient.Client
+	e.headers = restClient.Headers
+	return e, nil
+}
+
+func (e *endpoint) CollectRoutes(g *route.Group) {
+	if !e.cfg.Enable {
+		return
+	}
+	subGroup := g.Group(fmt.Sprintf("/%s/:%s/%s", utils.PathProject, utils.ParamProject, utils.PathDashboard))
+	subGroup.GET(fmt.Sprintf("/:%s/%s", utils.ParamName, pathRender), e.Render, false)
+}
+
+// Render returns the dashboard rendered as a PNG image or as a PDF document.
+// The query parameters other than format, width and height (e.g. start, end or var-<name>) are passed to the UI.
+func (e *endpoint) Render(ctx echo.Context) error {
+	parameters := toolbox.ExtractParameters(ctx, e.caseSensitive)
+	request, err := e.buildRendererRequest(ctx, parameters)
+	if err != nil {
+		return err
+	}
+	if e.authz.IsEnabled() {
+		if ok := e.authz.HasResourcePermission(ctx, role.ReadAction, parameters.Project, role.DashboardScope, parameters.Name); !ok {
+			return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission in '%s' project for '%s' kind", role.ReadAction, parameters.Project, role.DashboardScope))
+		}
+	}
+	if _, getErr := e.dashboardService.Get(parameters); getErr != nil {
+		if databaseModel.IsKeyNotFound(getErr) {
+			return apiInterface.HandleNotFoundError(fmt.Sprintf("dashboard %q doesn't exist in the project %q", parameters.Name, parameters.Project))
+		}
+		return apiInterface.HandleError(getErr)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx.Request().Context(), time.Duration(e.cfg.Timeout))
+	defer cancel()
+	resp, err := e.callRenderer(timeoutCtx, request)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadGateway, fmt.Sprintf("unable to render the dashboard: %s", err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return echo.NewHTTPError(http.StatusBadGateway, fmt.Sprintf("the renderer service returned the status %d: %s", resp.StatusCode, message))
+	}
+	return ctx.Stream(http.StatusOK, contentTypes[request.Format], resp.Body)
+}
+
+func (e *endpoint) buildRendererRequest(ctx echo.Context, parameters apiInterface.Parameters) (*rendererRequest, error) {
+	query := ctx.QueryParams()
+	format := FormatPNG
+	if value := query.Get(formatParam); len(value) > 0 {
+		format = Format(value)
+	}
+	if _, ok := contentTypes[format]; !ok {
+		return nil, apiInterface.HandleBadRequestError(fmt.Sprintf("format %q not supported, it must be %q or %q", format, FormatPNG, FormatPDF))
+	}
+	width, err := sizeParam(query.Get(widthParam), widthParam, e.cfg.Width, e.cfg.MaxWidth)
+	if err != nil {
+		return nil, err
+	}
+	height, err := sizeParam(query.Get(heightParam), heightParam, e.cfg.Height, e.cfg.MaxHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	// The other parameters select the time range and the values of the variables in the UI.
+	uiQuery := ctx.Request().URL.Query()
+	for _, param := range []string{formatParam, widthParam, heightParam} {
+		uiQuery.Del(param)
+	}
+	dashboardURL := e.cfg.PersesURL.JoinPath(utils.PathProject, parameters.Project, utils.PathDashboard, parameters.Name)
+	dashboardURL.RawQuery = uiQuery.Encode()
+
+	headers := make(map[string]string)
+	for _, header := range forwardedHeaders {
+		if value := ctx.Request().Header.Get(header); len(value) > 0 {
+			headers[header] = value
+		}
+	}
+	return &rendererRequest{
+		URL:     dashboardURL.String(),
+		Format:  format,
+		Width:   width,
+		Height:  height,
+		Timeout: e.cfg.Timeout.String(),
+		Headers: headers,
+	}, nil
+}
+
+func (e *endpoint) callRenderer(ctx context.Context, request *rendererRequest) (*http.Response, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.cfg.Renderer.URL.JoinPath(rendererPath).String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+	return e.client.Do(req)
+}
+
+func sizeParam(value string, name string, defaultValue int, maxValue int) (int, error) {
+	if len(value) == 0 {
+		return defaultValue, nil
+	}
+	size, err := strconv.Atoi(value)
+	if err != nil || size <= 0 {
+		return 0, apiInterface.HandleBadRequestError(fmt.Sprintf("%s must be a positive integer, got %q", name, value))
+	}
+	if size > maxValue {
+		return 0, apiInterface.HandleBadRequestError(fmt.Sprintf("%s must not be greater than %d, got %d", name, maxValue, size))
+	}
+	return size, nil
+}
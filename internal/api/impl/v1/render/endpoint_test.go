@@ -0,0 +1,294 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/perses/perses/internal/api/authorization"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	clientConfig "github.com/perses/perses/pkg/client/config"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/config"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ = authorization.Authorization(&testRBAC{})
+var _ = dashboard.Service(&mockDashboardService{})
+
+type testRBAC struct {
+	allow bool
+}
+
+func (t *testRBAC) GetUser(_ echo.Context) (any, error) {
+	return nil, nil
+}
+
+func (t *testRBAC) GetUsername(_ echo.Context) (string, error) {
+	return "", nil
+}
+
+func (t *testRBAC) Middleware(_ middleware.Skipper) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			return next(c)
+		}
+	}
+}
+
+func (t *testRBAC) GetPermissions(_ echo.Context) (map[string][]*role.Permission, error) {
+	return map[string][]*role.Permission{}, nil
+}
+
+func (t *testRBAC) HasPermission(_ echo.Context, _ role.Action, _ string, _ role.Scope) bool {
+	return t.allow
+}
+
+func (t *testRBAC) HasResourcePermission(_ echo.Context, _ role.Action, _ string, _ role.Scope, _ string) bool {
+	return t.allow
+}
+
+func (t *testRBAC) IsEnabled() bool {
+	return true
+}
+
+func (t *testRBAC) RefreshPermissions() error {
+	return nil
+}
+
+func (t *testRBAC) GetUserProjects(_ echo.Context, _ role.Action, _ role.Scope) ([]string, error) {
+	panic("unimplemented")
+}
+
+func (t *testRBAC) GetUserResources(_ echo.Context, _ role.Action, _ role.Scope) (map[string][]string, error) {
+	panic("unimplemented")
+}
+
+type mockDashboardService struct {
+	dashboard *v1.Dashboard
+	err       error
+}
+
+func (*mockDashboardService) Validate(_ *v1.Dashboard) error {
+	panic("unimplemented")
+}
+
+func (*mockDashboardService) Create(_ echo.Context, _ *v1.Dashboard) (*v1.Dashboard, error) {
+	panic("unimplemented")
+}
+
+func (*mockDashboardService) Update(_ echo.Context, _ *v1.Dashboard, _ apiInterface.Parameters) (*v1.Dashboard, error) {
+	panic("unimplemented")
+}
+
+func (*mockDashboardService) Delete(_ echo.Context, _ apiInterface.Parameters) error {
+	panic("unimplemented")
+}
+
+func (m *mockDashboardService) Get(_ apiInterface.Parameters) (*v1.Dashboard, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.dashboard != nil {
+		return m.dashboard, nil
+	}
+	return nil, &databaseModel.Error{Key: "mydashboard", Code: databaseModel.ErrorCodeNotFound}
+}
+
+func (*mockDashboardService) List(_ *dashboard.Query, _ apiInterface.Parameters) ([]*v1.Dashboard, error) {
+	panic("unimplemented")
+}
+
+func (*mockDashboardService) RawList(_ *dashboard.Query, _ apiInterface.Parameters) ([]json.RawMessage, error) {
+	panic("unimplemented")
+}
+
+func (*mockDashboardService) MetadataList(_ *dashboard.Query, _ apiInterface.Parameters) ([]api.Entity, error) {
+	panic("unimplemented")
+}
+
+func (*mockDashboardService) RawMetadataList(_ *dashboard.Query, _ apiInterface.Parameters) ([]json.RawMessage, error) {
+	panic("unimplemented")
+}
+
+func (*mockDashboardService) ListVersions(_ apiInterface.Parameters) ([]*v1.Dashboard, error) {
+	panic("unimplemented")
+}
+
+func (*mockDashboardService) RestoreVersion(_ echo.Context, _ apiInterface.Parameters, _ uint64) (*v1.Dashboard, error) {
+	panic("unimplemented")
+}
+
+func (*mockDashboardService) Move(_ echo.Context, _ apiInterface.Parameters, _ *v1.DashboardMoveRequest) (*v1.DashboardMoveResult, error) {
+	panic("unimplemented")
+}
+
+func newTestEndpoint(t *testing.T, rendererURL string, rbac *testRBAC, dashboardService *mockDashboardService) *endpoint {
+	renderer, err := url.Parse(rendererURL)
+	require.NoError(t, err)
+	perses, err := url.Parse("http://perses.example.com")
+	require.NoError(t, err)
+	cfg := config.Rendering{
+		Enable:    true,
+		Renderer:  &clientConfig.RestConfigClient{URL: &common.URL{URL: renderer}},
+		PersesURL: &common.URL{URL: perses},
+		Timeout:   common.Duration(time.Second),
+		Width:     1600,
+		Height:    900,
+		MaxWidth:  4096,
+		MaxHeight: 4096,
+	}
+	e, err := NewEndpoint(cfg, dashboardService, rbac, true)
+	require.NoError(t, err)
+	return e.(*endpoint)
+}
+
+func newTestContext(target string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer token")
+	rec := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, rec)
+	ctx.SetParamNames("project", "name")
+	ctx.SetParamValues("myproject", "mydashboard")
+	return ctx, rec
+}
+
+func TestRender(t *testing.T) {
+	var received rendererRequest
+	renderer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/render", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		_, _ = io.WriteString(w, "%PDF-1.7")
+	}))
+	defer renderer.Close()
+
+	endpoint := newTestEndpoint(t, renderer.URL, &testRBAC{true}, &mockDashboardService{dashboard: &v1.Dashboard{}})
+	ctx, rec := newTestContext("/api/v1/projects/myproject/dashboards/mydashboard/render?format=pdf&width=800&start=1h&var-job=node")
+	require.NoError(t, endpoint.Render(ctx))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/pdf", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "%PDF-1.7", rec.Body.String())
+	assert.Equal(t, rendererRequest{
+		URL:     "http://perses.example.com/projects/myproject/dashboards/mydashboard?start=1h&var-job=node",
+		Format:  FormatPDF,
+		Width:   800,
+		Height:  900,
+		Timeout: "1s",
+		Headers: map[string]string{echo.HeaderAuthorization: "Bearer token"},
+	}, received)
+}
+
+func TestRenderErrors(t *testing.T) {
+	renderer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer renderer.Close()
+
+	testSuite := []struct {
+		title            string
+		target           string
+		rbac             *testRBAC
+		dashboardService *mockDashboardService
+		expectedErr      error
+		expectedStatus   int
+	}{
+		{
+			title:            "unsupported format",
+			target:           "/render?format=jpeg",
+			rbac:             &testRBAC{true},
+			dashboardService: &mockDashboardService{dashboard: &v1.Dashboard{}},
+			expectedErr:      apiInterface.BadRequestError,
+		},
+		{
+			title:            "invalid width",
+			target:           "/render?width=-1",
+			rbac:             &testRBAC{true},
+			dashboardService: &mockDashboardService{dashboard: &v1.Dashboard{}},
+			expectedErr:      apiInterface.BadRequestError,
+		},
+		{
+			title:            "width greater than the maximum",
+			target:           "/render?width=5000",
+			rbac:             &testRBAC{true},
+			dashboardService: &mockDashboardService{dashboard: &v1.Dashboard{}},
+			expectedErr:      apiInterface.BadRequestError,
+		},
+		{
+			title:            "height greater than the maximum",
+			target:           "/render?height=10000",
+			rbac:             &testRBAC{true},
+			dashboardService: &mockDashboardService{dashboard: &v1.Dashboard{}},
+			expectedErr:      apiInterface.BadRequestError,
+		},
+		{
+			title:            "not allowed",
+			target:           "/render",
+			rbac:             &testRBAC{false},
+			dashboardService: &mockDashboardService{dashboard: &v1.Dashboard{}},
+			expectedErr:      apiInterface.ForbiddenError,
+		},
+		{
+			title:            "dashboard doesn't exist",
+			target:           "/render",
+			rbac:             &testRBAC{true},
+			dashboardService: &mockDashboardService{},
+			expectedErr:      apiInterface.NotFoundError,
+		},
+		{
+			title:            "dashboard can't be retrieved",
+			target:           "/render",
+			rbac:             &testRBAC{true},
+			dashboardService: &mockDashboardService{err: fmt.Errorf("connection refused")},
+			expectedStatus:   http.StatusInternalServerError,
+		},
+		{
+			title:            "renderer failure",
+			target:           "/render",
+			rbac:             &testRBAC{true},
+			dashboardService: &mockDashboardService{dashboard: &v1.Dashboard{}},
+			expectedStatus:   http.StatusBadGateway,
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			endpoint := newTestEndpoint(t, renderer.URL, test.rbac, test.dashboardService)
+			ctx, _ := newTestContext(test.target)
+			err := endpoint.Render(ctx)
+			require.Error(t, err)
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+				return
+			}
+			var httpErr *echo.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, test.expectedStatus, httpErr.Code)
+		})
+	}
+}
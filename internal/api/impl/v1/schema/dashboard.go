@@ -0,0 +1,113 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/json"
+	"path"
+	"reflect"
+
+	"github.com/invopop/jsonschema"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+	"github.com/perses/perses/pkg/model/api/v1/variable"
+)
+
+const (
+	definitionsPrefix = "#/$defs/"
+	// durationPattern is the format of common.Duration, e.g. 1h30m.
+	durationPattern = `^(([0-9]+)y)?(([0-9]+)w)?(([0-9]+)d)?(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?$`
+)
+
+// kindSpec is one of the possible types of a spec chosen by the kind, e.g. a ListVariableSpec for a ListVariable.
+type kindSpec struct {
+	kind string
+	spec interface{}
+}
+
+// The specs of the variables and of the layouts are interfaces: the actual type depends on the kind.
+var (
+	variableSpecs = []kindSpec{
+		{kind: string(variable.KindList), spec: dashboard.ListVariableSpec{}},
+		{kind: string(variable.KindText), spec: dashboard.TextVariableSpec{}},
+	}
+	layoutSpecs = []kindSpec{
+		{kind: string(dashboard.KindGridLayout), spec: dashboard.GridLayoutSpec{}},
+	}
+)
+
+// generateDashboard returns the JSON Schema of the Dashboard resource, generated from its Go model.
+func generateDashboard() ([]byte, error) {
+	r := &jsonschema.Reflector{
+		Namer:  definitionName,
+		Mapper: mapType,
+	}
+	result := r.Reflect(&v1.Dashboard{})
+	result.Title = string(v1.KindDashboard)
+	// The types of the specs chosen by the kind are not reachable from the Dashboard struct, so their definitions are
+	// generated apart.
+	for _, k := range append(variableSpecs, layoutSpecs...) {
+		for name, definition := range r.Reflect(k.spec).Definitions {
+			result.Definitions[name] = definition
+		}
+	}
+	if definition, ok := result.Definitions[definitionName(reflect.TypeOf(v1.Dashboard{}))]; ok {
+		if kind, ok := definition.Properties.Get("kind"); ok {
+			kind.Const = string(v1.KindDashboard)
+		}
+	}
+	return json.Marshal(result)
+}
+
+func definitionName(t reflect.Type) string {
+	return path.Base(t.PkgPath()) + "." + t.Name()
+}
+
+// mapType returns the schema of the types whose JSON representation differs from their Go structure. It returns nil
+// for the other types, so they are reflected.
+func mapType(t reflect.Type) *jsonschema.Schema {
+	switch t {
+	case reflect.TypeOf(common.Duration(0)):
+		return &jsonschema.Schema{Type: "string", Pattern: durationPattern}
+	case reflect.TypeOf(common.URL{}):
+		return &jsonschema.Schema{Type: "string", Format: "uri"}
+	case reflect.TypeOf(variable.DefaultValue{}):
+		return &jsonschema.Schema{OneOf: []*jsonschema.Schema{
+			{Type: "string"},
+			{Type: "array", Items: &jsonschema.Schema{Type: "string"}},
+		}}
+	case reflect.TypeOf(dashboard.Variable{}):
+		return oneOfKind(variableSpecs)
+	case reflect.TypeOf(dashboard.Layout{}):
+		return oneOfKind(layoutSpecs)
+	}
+	return nil
+}
+
+func oneOfKind(specs []kindSpec) *jsonschema.Schema {
+	result := &jsonschema.Schema{}
+	for _, k := range specs {
+		properties := jsonschema.NewProperties()
+		properties.Set("kind", &jsonschema.Schema{Type: "string", Const: k.kind})
+		properties.Set("spec", &jsonschema.Schema{Ref: definitionsPrefix + definitionName(reflect.TypeOf(k.spec))})
+		result.OneOf = append(result.OneOf, &jsonschema.Schema{
+			Type:                 "object",
+			Properties:           properties,
+			Required:             []string{"kind", "spec"},
+			AdditionalProperties: jsonschema.FalseSchema,
+		})
+	}
+	return result
+}
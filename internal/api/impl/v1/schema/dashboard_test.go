@@ -0,0 +1,44 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateDashboard(t *testing.T) {
+	data, err := generateDashboard()
+	require.NoError(t, err)
+
+	var result struct {
+		Ref         string                            `json:"$ref"`
+		Definitions map[string]map[string]interface{} `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal(data, &result))
+	assert.Equal(t, "#/$defs/v1.Dashboard", result.Ref)
+	for _, name := range []string{"v1.Dashboard", "v1.DashboardSpec", "v1.Panel", "dashboard.ListVariableSpec", "dashboard.TextVariableSpec", "dashboard.GridLayoutSpec"} {
+		assert.Contains(t, result.Definitions, name)
+	}
+
+	spec := result.Definitions["v1.DashboardSpec"]["properties"].(map[string]interface{})
+	assert.Equal(t, "string", spec["duration"].(map[string]interface{})["type"])
+	variables := spec["variables"].(map[string]interface{})["items"].(map[string]interface{})
+	assert.Len(t, variables["oneOf"], 2)
+	kind := result.Definitions["v1.Dashboard"]["properties"].(map[string]interface{})["kind"].(map[string]interface{})
+	assert.Equal(t, "Dashboard", kind["const"])
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/route"
+)
+
+const mimeApplicationSchemaJSON = "application/schema+json"
+
+type endpoint struct {
+	dashboard []byte
+}
+
+// NewEndpoint returns the endpoint exposing the JSON Schema of the resources, so external tools (e.g. an IDE or a
+// linter in another language) can validate them. The schemas are generated once, when the endpoint is created.
+func NewEndpoint() (route.Endpoint, error) {
+	dashboardSchema, err := generateDashboard()
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate the JSON Schema of the dashboard: %w", err)
+	}
+	return &endpoint{dashboard: dashboardSchema}, nil
+}
+
+func (e *endpoint) CollectRoutes(g *route.Group) {
+	group := g.Group("/schemas")
+	group.GET("/dashboard", e.GetDashboard, true)
+}
+
+func (e *endpoint) GetDashboard(ctx echo.Context) error {
+	return ctx.Blob(http.StatusOK, mimeApplicationSchemaJSON, e.dashboard)
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/route"
+	"github.com/perses/perses/internal/api/search"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+const defaultLimit = 50
+
+type request struct {
+	// Query is the list of the terms to search, separated by spaces.
+	Query string `query:"q"`
+	// Project restricts the search to a single project.
+	Project string `query:"project"`
+	// Limit is the maximum number of results.
+	Limit int `query:"limit"`
+}
+
+type endpoint struct {
+	svc           search.Search
+	authz         authorization.Authorization
+	caseSensitive bool
+}
+
+func NewEndpoint(svc search.Search, authz authorization.Authorization, caseSensitive bool) route.Endpoint {
+	return &endpoint{
+		svc:           svc,
+		authz:         authz,
+		caseSensitive: caseSensitive,
+	}
+}
+
+func (e *endpoint) CollectRoutes(g *route.Group) {
+	g.GET("/search", e.Search, false)
+}
+
+func (e *endpoint) Search(ctx echo.Context) error {
+	req := &request{}
+	if err := ctx.Bind(req); err != nil {
+		return apiInterface.HandleBadRequestError(err.Error())
+	}
+	if len(strings.TrimSpace(req.Query)) == 0 {
+		return apiInterface.HandleBadRequestError("the query parameter 'q' is required")
+	}
+	if req.Limit <= 0 {
+		req.Limit = defaultLimit
+	}
+	projects, err := e.getProjects(ctx, req.Project)
+	if err != nil {
+		return err
+	}
+	results, err := e.svc.Search(req.Query, projects, req.Limit)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, results)
+}
+
+// getProjects returns the projects the search is done in: the projects whose dashboards the user can read, restricted
+// to the project requested if any.
+func (e *endpoint) getProjects(ctx echo.Context, project string) ([]string, error) {
+	projects := []string{v1.WildcardProject}
+	if e.authz.IsEnabled() {
+		var err error
+		projects, err = e.authz.GetUserProjects(ctx, role.ReadAction, role.DashboardScope)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(project) == 0 {
+		return projects, nil
+	}
+	if !e.caseSensitive {
+		project = strings.ToLower(project)
+	}
+	if slices.Contains(projects, v1.WildcardProject) || slices.Contains(projects, project) {
+		return []string{project}, nil
+	}
+	return []string{}, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
+	"github.com/perses/perses/internal/api/interface/v1/snapshot"
+	"github.com/perses/perses/internal/api/route"
+	"github.com/perses/perses/internal/api/toolbox"
+	"github.com/perses/perses/internal/api/utils"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+const pathSnapshot = "snapshot"
+
+type endpoint struct {
+	toolbox       toolbox.Toolbox[*v1.Snapshot, *snapshot.Query]
+	service       snapshot.Service
+	authz         authorization.Authorization
+	audit         audit.Service
+	readonly      bool
+	caseSensitive bool
+}
+
+// NewEndpoint creates the endpoint taking the snapshots of the dashboards and managing them. A snapshot is immutable,
+// so it can only be created from a dashboard, read and deleted.
+func NewEndpoint(service snapshot.Service, authz authorization.Authorization, auditService audit.Service, readonly bool, caseSensitive bool) route.Endpoint {
+	return &endpoint{
+		toolbox:       toolbox.New[*v1.Snapshot, *v1.Snapshot, *snapshot.Query](service, authz, auditService, v1.KindSnapshot, caseSensitive),
+		service:       service,
+		authz:         authz,
+		audit:         auditService,
+		readonly:      readonly,
+		caseSensitive: caseSensitive,
+	}
+}
+
+func (e *endpoint) CollectRoutes(g *route.Group) {
+	group := g.Group(fmt.Sprintf("/%s", utils.PathSnapshot))
+	subGroup := g.Group(fmt.Sprintf("/%s/:%s/%s", utils.PathProject, utils.ParamProject, utils.PathSnapshot))
+	dashboardGroup := g.Group(fmt.Sprintf("/%s/:%s/%s/:%s", utils.PathProject, utils.ParamProject, utils.PathDashboard, utils.ParamName))
+	if !e.readonly {
+		dashboardGroup.POST(fmt.Sprintf("/%s", pathSnapshot), e.CreateFromDashboard, false)
+		subGroup.DELETE(fmt.Sprintf("/:%s", utils.ParamName), e.Delete, false)
+	}
+	group.GET("", e.List, false)
+	subGroup.GET("", e.List, false)
+	subGroup.GET(fmt.Sprintf("/:%s", utils.ParamName), e.Get, false)
+}
+
+// CreateFromDashboard takes a snapshot of the dashboard with the query results sent in the body of the request.
+// Reading the dashboard and creating the snapshot are both required.
+func (e *endpoint) CreateFromDashboard(ctx echo.Context) error {
+	parameters := toolbox.ExtractParameters(ctx, e.caseSensitive)
+	if err := e.checkPermission(ctx, role.ReadAction, parameters.Project, role.DashboardScope, parameters.Name); err != nil {
+		return err
+	}
+	if err := e.checkPermission(ctx, role.CreateAction, parameters.Project, role.SnapshotScope, ""); err != nil {
+		return err
+	}
+	request := &v1.SnapshotRequest{}
+	if err := ctx.Bind(request); err != nil {
+		return apiInterface.HandleBadRequestError(err.Error())
+	}
+	entity, err := e.service.CreateFromDashboard(ctx, parameters, request)
+	if err != nil {
+		return err
+	}
+	e.audit.Created(ctx, entity)
+	return ctx.JSON(http.StatusOK, entity)
+}
+
+func (e *endpoint) Delete(ctx echo.Context) error {
+	return e.toolbox.Delete(ctx)
+}
+
+func (e *endpoint) Get(ctx echo.Context) error {
+	return e.toolbox.Get(ctx)
+}
+
+func (e *endpoint) List(ctx echo.Context) error {
+	q := &snapshot.Query{}
+	return e.toolbox.List(ctx, q)
+}
+
+func (e *endpoint) checkPermission(ctx echo.Context, action role.Action, project string, scope role.Scope, name string) error {
+	if !e.authz.IsEnabled() {
+		return nil
+	}
+	if ok := e.authz.HasResourcePermission(ctx, action, project, scope, name); !ok {
+		return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission in '%s' project for '%s' kind", action, project, scope))
+	}
+	return nil
+}
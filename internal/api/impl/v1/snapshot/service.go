@@ -0,0 +1,149 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/brunoga/deep"
+	"github.com/labstack/echo/v4"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/snapshot"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+)
+
+type service struct {
+	snapshot.Service
+	dao              snapshot.DAO
+	dashboardService dashboard.Service
+}
+
+func NewService(dao snapshot.DAO, dashboardService dashboard.Service) snapshot.Service {
+	return &service{
+		dao:              dao,
+		dashboardService: dashboardService,
+	}
+}
+
+func (s *service) Create(_ echo.Context, entity *v1.Snapshot) (*v1.Snapshot, error) {
+	copyEntity, err := deep.Copy(entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy entity: %w", err)
+	}
+	return s.create(copyEntity)
+}
+
+func (s *service) create(entity *v1.Snapshot) (*v1.Snapshot, error) {
+	// Update the time contains in the entity
+	entity.Metadata.CreateNow()
+	if err := s.dao.Create(entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (s *service) CreateFromDashboard(_ echo.Context, parameters apiInterface.Parameters, request *v1.SnapshotRequest) (*v1.Snapshot, error) {
+	if err := request.Validate(); err != nil {
+		return nil, apiInterface.HandleBadRequestError(err.Error())
+	}
+	// The dashboard service resolves the library panels, so the snapshot doesn't depend on them.
+	dash, err := s.dashboardService.Get(parameters)
+	if err != nil {
+		return nil, err
+	}
+	for panelKey := range request.Data {
+		if _, ok := dash.Spec.Panels[panelKey]; !ok {
+			return nil, apiInterface.HandleBadRequestError(fmt.Sprintf("panel %q doesn't exist in the dashboard %q", panelKey, dash.Metadata.Name))
+		}
+	}
+	name := request.Name
+	if len(name) == 0 {
+		name = fmt.Sprintf("%s-%d", dash.Metadata.Name, time.Now().Unix())
+	}
+	if idErr := common.ValidateID(name); idErr != nil {
+		return nil, apiInterface.HandleBadRequestError(idErr.Error())
+	}
+	entity := &v1.Snapshot{
+		Kind:     v1.KindSnapshot,
+		Metadata: *v1.NewProjectMetadata(dash.Metadata.Project, name),
+		Spec: v1.SnapshotSpec{
+			Dashboard:     dash.Metadata.Name,
+			TimeRange:     request.TimeRange,
+			DashboardSpec: dash.Spec,
+			Data:          request.Data,
+		},
+	}
+	return s.create(entity)
+}
+
+func (s *service) Update(_ echo.Context, _ *v1.Snapshot, _ apiInterface.Parameters) (*v1.Snapshot, error) {
+	return nil, apiInterface.HandleBadRequestError("a snapshot is immutable, it cannot be updated")
+}
+
+func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
+	return s.dao.Delete(parameters.Project, parameters.Name)
+}
+
+func (s *service) Get(parameters apiInterface.Parameters) (*v1.Snapshot, error) {
+	return s.dao.Get(parameters.Project, parameters.Name)
+}
+
+func (s *service) List(q *snapshot.Query, params apiInterface.Parameters) ([]*v1.Snapshot, error) {
+	query, err := manageQuery(q, params)
+	if err != nil {
+		return nil, err
+	}
+	return s.dao.List(query)
+}
+
+func (s *service) RawList(q *snapshot.Query, params apiInterface.Parameters) ([]json.RawMessage, error) {
+	query, err := manageQuery(q, params)
+	if err != nil {
+		return nil, err
+	}
+	return s.dao.RawList(query)
+}
+
+func (s *service) MetadataList(q *snapshot.Query, params apiInterface.Parameters) ([]api.Entity, error) {
+	query, err := manageQuery(q, params)
+	if err != nil {
+		return nil, err
+	}
+	return s.dao.MetadataList(query)
+}
+
+func (s *service) RawMetadataList(q *snapshot.Query, params apiInterface.Parameters) ([]json.RawMessage, error) {
+	query, err := manageQuery(q, params)
+	if err != nil {
+		return nil, err
+	}
+	return s.dao.RawMetadataList(query)
+}
+
+func manageQuery(q *snapshot.Query, params apiInterface.Parameters) (*snapshot.Query, error) {
+	// Query is copied because it can be modified by the toolbox.go: listWhenPermissionIsActivated(...) and need to `q` need to keep initial value
+	query, err := deep.Copy(q)
+	if err != nil {
+		return nil, fmt.Errorf("unable to copy the query: %w", err)
+	}
+	if len(query.Project) == 0 {
+		query.Project = params.Project
+	}
+	return query, nil
+}
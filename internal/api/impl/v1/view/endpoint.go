@@ -52,7 +52,7 @@ func (e *endpoint) view(ctx echo.Context) error {
 	}
 
 	if e.authz.IsEnabled() {
-		if ok := e.authz.HasPermission(ctx, role.ReadAction, result.Project, role.DashboardScope); !ok {
+		if ok := e.authz.HasResourcePermission(ctx, role.ReadAction, result.Project, role.DashboardScope, result.Dashboard); !ok {
 			return apiInterface.HandleUnauthorizedError(fmt.Sprintf("missing '%s' permission in '%s' project for '%s' kind", role.ReadAction, result.Project, role.DashboardScope))
 		}
 	}
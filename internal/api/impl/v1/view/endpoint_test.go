@@ -67,6 +67,10 @@ func (t *testRBAC) HasPermission(_ echo.Context, _ role.Action, _ string, _ role
 	return t.allow
 }
 
+func (t *testRBAC) HasResourcePermission(_ echo.Context, _ role.Action, _ string, _ role.Scope, _ string) bool {
+	return t.allow
+}
+
 func (t *testRBAC) IsEnabled() bool {
 	return true
 }
@@ -79,6 +83,10 @@ func (t *testRBAC) GetUserProjects(_ echo.Context, _ role.Action, _ role.Scope)
 	panic("unimplemented")
 }
 
+func (t *testRBAC) GetUserResources(_ echo.Context, _ role.Action, _ role.Scope) (map[string][]string, error) {
+	panic("unimplemented")
+}
+
 type mockDashboardService struct {
 	dashboard *v1.Dashboard
 }
@@ -123,6 +131,18 @@ func (*mockDashboardService) RawMetadataList(_ *dashboard.Query, _ apiInterface.
 	panic("unimplemented")
 }
 
+func (*mockDashboardService) ListVersions(_ apiInterface.Parameters) ([]*v1.Dashboard, error) {
+	panic("unimplemented")
+}
+
+func (*mockDashboardService) RestoreVersion(_ echo.Context, _ apiInterface.Parameters, _ uint64) (*v1.Dashboard, error) {
+	panic("unimplemented")
+}
+
+func (*mockDashboardService) Move(_ echo.Context, _ apiInterface.Parameters, _ *v1.DashboardMoveRequest) (*v1.DashboardMoveResult, error) {
+	panic("unimplemented")
+}
+
 func TestEndpoint(t *testing.T) {
 	endpoint := NewEndpoint(NewMetricsViewService(), &testRBAC{true}, &mockDashboardService{&v1.Dashboard{}}).(*endpoint)
 
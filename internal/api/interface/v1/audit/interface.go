@@ -0,0 +1,112 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// Query selects the events of the audit log. Every filter is optional.
+type Query struct {
+	databaseModel.Query
+	// Project is the exact name of the project of the resources changed.
+	Project string `query:"project"`
+	// User is the exact username of the user who made the changes.
+	User string `query:"user"`
+	// Kind is the kind of the resources changed. It is not case-sensitive.
+	Kind string `query:"kind"`
+	// Start and End restrict the events to a time range, both included. They use the RFC 3339 format.
+	Start time.Time `query:"start"`
+	End   time.Time `query:"end"`
+	// Cursor is the ID of the last event of the previous page. Only the events recorded before it are returned.
+	Cursor string `query:"cursor"`
+	// Limit is the maximum number of events returned. There is no limit when it is zero.
+	Limit int `query:"limit"`
+}
+
+// EventID returns the ID of an event recorded at the given time. It starts with the timestamp, padded to a fixed
+// length, so sorting the IDs sorts the events in the order they are recorded.
+func EventID(timestamp time.Time, suffix string) string {
+	return fmt.Sprintf("%s-%s", timeKey(timestamp), suffix)
+}
+
+func timeKey(t time.Time) string {
+	return fmt.Sprintf("%019d", t.UnixNano())
+}
+
+// IDRange returns the range of the IDs of the events matching the time range and the cursor of the query. from is
+// included and to is excluded. An empty bound doesn't restrict the range.
+func (q *Query) IDRange() (from string, to string) {
+	if !q.Start.IsZero() {
+		from = timeKey(q.Start)
+	}
+	if !q.End.IsZero() {
+		to = timeKey(q.End.Add(time.Nanosecond))
+	}
+	if len(q.Cursor) > 0 && (len(to) == 0 || q.Cursor < to) {
+		to = q.Cursor
+	}
+	return from, to
+}
+
+// MatchID returns true when the ID is in the IDRange of the query.
+func (q *Query) MatchID(id string) bool {
+	from, to := q.IDRange()
+	return (len(from) == 0 || id >= from) && (len(to) == 0 || id < to)
+}
+
+func (q *Query) GetMetadataOnlyQueryParam() bool {
+	return false
+}
+
+func (q *Query) IsRawQueryAllowed() bool {
+	return false
+}
+
+func (q *Query) IsRawMetadataQueryAllowed() bool {
+	return false
+}
+
+type DAO interface {
+	Create(event *api.AuditEvent) error
+	// List returns the events matching the query, from the most recent to the oldest. At most q.Limit events are
+	// returned when it is positive.
+	List(q *Query) ([]*api.AuditEvent, error)
+	// DeleteBefore deletes the events recorded before the given time.
+	DeleteBefore(t time.Time) error
+}
+
+// Service records the changes of the resources in the audit log. The changes are already done when they are recorded,
+// so a failure to record an event is only logged.
+type Service interface {
+	// IsEnabled returns false when the audit log is disabled. The events are then dropped.
+	IsEnabled() bool
+	Created(ctx echo.Context, current api.Entity)
+	// Updated records the update of a resource. previous can be nil when the resource couldn't be retrieved before the
+	// update.
+	Updated(ctx echo.Context, previous api.Entity, current api.Entity)
+	// Deleted records the deletion of a resource. previous can be nil when the resource couldn't be retrieved before the
+	// deletion.
+	Deleted(ctx echo.Context, kind v1.Kind, project string, name string, previous api.Entity)
+	List(q *Query) ([]*api.AuditEvent, error)
+	// WithUser returns a Service recording the changes as made by the given user, whatever the context. It is used for
+	// the changes that don't come from a request, like the ones of the provisioning.
+	WithUser(user string) Service
+}
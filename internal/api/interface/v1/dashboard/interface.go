@@ -16,6 +16,7 @@ package dashboard
 import (
 	"encoding/json"
 
+	"github.com/labstack/echo/v4"
 	databaseModel "github.com/perses/perses/internal/api/database/model"
 	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/pkg/model/api"
@@ -37,6 +38,17 @@ func (q *Query) GetMetadataOnlyQueryParam() bool {
 	return q.MetadataOnly
 }
 
+func (q *Query) GetNamePrefix() string {
+	return q.NamePrefix
+}
+
+// Restrict restricts the query to the dashboards of the project whose names start with namePrefix. It is used to list
+// the dashboards the user can read through the permissions restricted to some dashboards.
+func (q *Query) Restrict(project string, namePrefix string) {
+	q.Project = project
+	q.NamePrefix = namePrefix
+}
+
 func (q *Query) IsRawQueryAllowed() bool {
 	return true
 }
@@ -45,6 +57,27 @@ func (q *Query) IsRawMetadataQueryAllowed() bool {
 	return true
 }
 
+// HistoryQuery selects the previous versions of the dashboards of a project.
+type HistoryQuery struct {
+	databaseModel.Query
+	// NamePrefix is a prefix of the name of the dashboards.
+	NamePrefix string
+	// Project is the exact name of the project.
+	Project string
+}
+
+func (q *HistoryQuery) GetMetadataOnlyQueryParam() bool {
+	return false
+}
+
+func (q *HistoryQuery) IsRawQueryAllowed() bool {
+	return false
+}
+
+func (q *HistoryQuery) IsRawMetadataQueryAllowed() bool {
+	return false
+}
+
 type DAO interface {
 	Create(entity *v1.Dashboard) error
 	Update(entity *v1.Dashboard) error
@@ -55,9 +88,20 @@ type DAO interface {
 	RawList(q *Query) ([]json.RawMessage, error)
 	MetadataList(q *Query) ([]api.Entity, error)
 	RawMetadataList(q *Query) ([]json.RawMessage, error)
+	// GetHistory returns the previous versions of the dashboard, from the oldest to the most recent.
+	GetHistory(project string, name string) ([]*v1.Dashboard, error)
+	UpdateHistory(project string, name string, versions []*v1.Dashboard) error
 }
 
 type Service interface {
 	apiInterface.Service[*v1.Dashboard, *v1.Dashboard, *Query]
 	Validate(entity *v1.Dashboard) error
+	// ListVersions returns the previous versions of the dashboard, from the most recent to the oldest.
+	ListVersions(parameters apiInterface.Parameters) ([]*v1.Dashboard, error)
+	// RestoreVersion replaces the dashboard by one of its previous versions. The replaced dashboard is kept in the
+	// history like for any update.
+	RestoreVersion(ctx echo.Context, parameters apiInterface.Parameters, version uint64) (*v1.Dashboard, error)
+	// Move moves or copies the dashboard to another project, rewriting the references to the resources of its project
+	// when possible.
+	Move(ctx echo.Context, parameters apiInterface.Parameters, request *v1.DashboardMoveRequest) (*v1.DashboardMoveResult, error)
 }
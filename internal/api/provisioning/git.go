@@ -0,0 +1,255 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provisioning
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/perses/common/async"
+	"github.com/perses/perses/internal/api/dependency"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/datasource"
+	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
+	"github.com/perses/perses/internal/cli/file"
+	"github.com/perses/perses/internal/cli/resource"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/config"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+// provisionedAnnotation is set on the dashboards and the datasources provisioned from a Git repository. Its value
+// identifies the repository, so the resources whose file has been removed are found again after a restart.
+const provisionedAnnotation = "perses.dev/provisioned-from"
+
+// auditUser is the user recorded in the audit log for the changes made by the Git provisioning.
+const auditUser = "provisioning"
+
+// NewGit returns the task provisioning the resources of the Git repositories. Each repository is cloned in its own
+// folder inside gitFolder, then pulled at each execution.
+func NewGit(serviceManager dependency.ServiceManager, repositories []config.GitRepository, gitFolder string, caseSensitive bool) async.SimpleTask {
+	return &gitProvisioning{
+		provisioning: provisioning{
+			serviceManager: serviceManager,
+			audit:          serviceManager.GetAudit().WithUser(auditUser),
+			caseSensitive:  caseSensitive,
+		},
+		repositories: repositories,
+		gitFolder:    gitFolder,
+	}
+}
+
+type resourceKey struct {
+	kind    modelV1.Kind
+	project string
+	name    string
+}
+
+type gitProvisioning struct {
+	provisioning
+	repositories []config.GitRepository
+	gitFolder    string
+}
+
+func (p *gitProvisioning) Execute(ctx context.Context, _ context.CancelFunc) error {
+	for _, repository := range p.repositories {
+		if err := p.provisionRepository(ctx, repository); err != nil {
+			logrus.WithError(err).Errorf("unable to provision the resources of the git repository %q", redactURL(repository.URL))
+		}
+	}
+	return nil
+}
+
+func (p *gitProvisioning) String() string {
+	return "git provisioning service"
+}
+
+func (p *gitProvisioning) provisionRepository(ctx context.Context, repository config.GitRepository) error {
+	id := repositoryFolder(repository)
+	dir := filepath.Join(p.gitFolder, id)
+	if err := syncRepository(ctx, repository, dir); err != nil {
+		return err
+	}
+	entities, errs := file.UnmarshalEntitiesFromDirectory(filepath.Join(dir, repository.Path))
+	for _, err := range errs {
+		logrus.WithError(err).Warningf("unable to load every entity from the git repository %q", redactURL(repository.URL))
+	}
+	for _, entity := range entities {
+		annotateEntity(entity, id)
+	}
+	p.applyEntity(entities)
+	if !repository.Prune {
+		return nil
+	}
+	// A file that can't be read could be a resource still present, so nothing is deleted.
+	if len(errs) > 0 {
+		return nil
+	}
+	current := make(map[resourceKey]bool, len(entities))
+	for _, entity := range entities {
+		current[newResourceKey(entity)] = true
+	}
+	for _, entity := range p.listProvisioned(id) {
+		if !current[newResourceKey(entity)] {
+			p.deleteEntity(entity)
+		}
+	}
+	return nil
+}
+
+func newResourceKey(entity modelAPI.Entity) resourceKey {
+	return resourceKey{
+		kind:    modelV1.Kind(entity.GetKind()),
+		project: resource.GetProject(entity.GetMetadata(), ""),
+		name:    entity.GetMetadata().GetName(),
+	}
+}
+
+// annotateEntity marks the dashboards and the datasources as provisioned from the repository identified by id.
+func annotateEntity(entity modelAPI.Entity, id string) {
+	var metadata *modelV1.Metadata
+	switch e := entity.(type) {
+	case *modelV1.Dashboard:
+		metadata = &e.Metadata.Metadata
+	case *modelV1.Datasource:
+		metadata = &e.Metadata.Metadata
+	case *modelV1.GlobalDatasource:
+		metadata = &e.Metadata
+	default:
+		return
+	}
+	if metadata.Annotations == nil {
+		metadata.Annotations = make(map[string]string)
+	}
+	metadata.Annotations[provisionedAnnotation] = id
+}
+
+// listProvisioned returns the dashboards and the datasources stored in the database that have been provisioned from
+// the repository identified by id. A kind whose resources can't be listed is skipped, so nothing of it is deleted.
+func (p *gitProvisioning) listProvisioned(id string) []modelAPI.Entity {
+	var entities []modelAPI.Entity
+	dashboards, err := p.serviceManager.GetDashboard().List(&dashboard.Query{}, apiInterface.Parameters{})
+	if err != nil {
+		logrus.WithError(err).Error("unable to list the dashboards provisioned from the git repository")
+	}
+	for _, d := range dashboards {
+		if d.Metadata.Annotations[provisionedAnnotation] == id {
+			entities = append(entities, d)
+		}
+	}
+	datasources, err := p.serviceManager.GetDatasource().List(&datasource.Query{}, apiInterface.Parameters{})
+	if err != nil {
+		logrus.WithError(err).Error("unable to list the datasources provisioned from the git repository")
+	}
+	for _, d := range datasources {
+		if d.Metadata.Annotations[provisionedAnnotation] == id {
+			entities = append(entities, d)
+		}
+	}
+	globalDatasources, err := p.serviceManager.GetGlobalDatasource().List(&globaldatasource.Query{}, apiInterface.Parameters{})
+	if err != nil {
+		logrus.WithError(err).Error("unable to list the global datasources provisioned from the git repository")
+	}
+	for _, d := range globalDatasources {
+		if d.Metadata.Annotations[provisionedAnnotation] == id {
+			entities = append(entities, d)
+		}
+	}
+	return entities
+}
+
+// deleteEntity deletes a resource whose file has been removed from the repository. Only the dashboards and the
+// datasources are deleted.
+func (p *gitProvisioning) deleteEntity(entity modelAPI.Entity) {
+	key := newResourceKey(entity)
+	param := apiInterface.Parameters{
+		Name:    key.name,
+		Project: key.project,
+	}
+	var err error
+	switch key.kind {
+	case modelV1.KindDashboard:
+		err = p.serviceManager.GetDashboard().Delete(nil, param)
+	case modelV1.KindDatasource:
+		err = p.serviceManager.GetDatasource().Delete(nil, param)
+	case modelV1.KindGlobalDatasource:
+		err = p.serviceManager.GetGlobalDatasource().Delete(nil, param)
+	default:
+		return
+	}
+	if err != nil {
+		logrus.WithError(err).Errorf("unable to delete the %q %q removed from the git repository", key.kind, key.name)
+		return
+	}
+	p.audit.Deleted(nil, key.kind, key.project, key.name, entity)
+}
+
+// syncRepository clones the repository in the folder dir, or pulls it when it has already been cloned.
+func syncRepository(ctx context.Context, repository config.GitRepository, dir string) error {
+	if _, err := os.Stat(filepath.Join(dir, ".git")); errors.Is(err, os.ErrNotExist) {
+		if mkdirErr := os.MkdirAll(filepath.Dir(dir), 0750); mkdirErr != nil {
+			return mkdirErr
+		}
+		args := []string{"clone", "--depth", "1"}
+		if len(repository.Branch) > 0 {
+			args = append(args, "--branch", repository.Branch)
+		}
+		// The URL comes after "--", so it is never read as an option of the command.
+		return runGit(ctx, "", append(args, "--", repository.URL, dir)...)
+	}
+	ref := "HEAD"
+	if len(repository.Branch) > 0 {
+		ref = repository.Branch
+	}
+	if err := runGit(ctx, dir, "fetch", "--depth", "1", "origin", ref); err != nil {
+		return err
+	}
+	return runGit(ctx, dir, "reset", "--hard", "FETCH_HEAD")
+}
+
+func runGit(ctx context.Context, dir string, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...) // #nosec G204 -- the arguments come from the configuration
+	cmd.Dir = dir
+	// Never wait for credentials on a terminal.
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// repositoryFolder returns a folder name unique for the URL and the branch of the repository.
+func repositoryFolder(repository config.GitRepository) string {
+	hash := sha256.Sum256([]byte(repository.URL + "#" + repository.Branch))
+	return hex.EncodeToString(hash[:8])
+}
+
+// redactURL hides the password of the URL of a repository, to log it.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Redacted()
+}
@@ -0,0 +1,361 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provisioning
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/dependency"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/datasource"
+	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
+	"github.com/perses/perses/internal/cli/resource"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/config"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore keeps the resources in memory, like the database would.
+type fakeStore[T modelAPI.Entity] map[string]T
+
+func storeKey(project string, name string) string {
+	return project + "/" + name
+}
+
+func (s fakeStore[T]) create(entity T) (T, error) {
+	key := storeKey(resource.GetProject(entity.GetMetadata(), ""), entity.GetMetadata().GetName())
+	if _, ok := s[key]; ok {
+		var empty T
+		return empty, &databaseModel.Error{Key: key, Code: databaseModel.ErrorCodeConflict}
+	}
+	s[key] = entity
+	return entity, nil
+}
+
+func (s fakeStore[T]) update(entity T) (T, error) {
+	s[storeKey(resource.GetProject(entity.GetMetadata(), ""), entity.GetMetadata().GetName())] = entity
+	return entity, nil
+}
+
+func (s fakeStore[T]) get(parameters apiInterface.Parameters) (T, error) {
+	entity, ok := s[storeKey(parameters.Project, parameters.Name)]
+	if !ok {
+		return entity, &databaseModel.Error{Key: parameters.Name, Code: databaseModel.ErrorCodeNotFound}
+	}
+	return entity, nil
+}
+
+func (s fakeStore[T]) delete(parameters apiInterface.Parameters) error {
+	delete(s, storeKey(parameters.Project, parameters.Name))
+	return nil
+}
+
+func (s fakeStore[T]) list() []T {
+	result := make([]T, 0, len(s))
+	for _, entity := range s {
+		result = append(result, entity)
+	}
+	return result
+}
+
+type fakeDashboardService struct {
+	dashboard.Service
+	store fakeStore[*modelV1.Dashboard]
+}
+
+func (s *fakeDashboardService) Create(_ echo.Context, entity *modelV1.Dashboard) (*modelV1.Dashboard, error) {
+	return s.store.create(entity)
+}
+
+func (s *fakeDashboardService) Update(_ echo.Context, entity *modelV1.Dashboard, _ apiInterface.Parameters) (*modelV1.Dashboard, error) {
+	return s.store.update(entity)
+}
+
+func (s *fakeDashboardService) Get(parameters apiInterface.Parameters) (*modelV1.Dashboard, error) {
+	return s.store.get(parameters)
+}
+
+func (s *fakeDashboardService) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
+	return s.store.delete(parameters)
+}
+
+func (s *fakeDashboardService) List(_ *dashboard.Query, _ apiInterface.Parameters) ([]*modelV1.Dashboard, error) {
+	return s.store.list(), nil
+}
+
+type fakeDatasourceService struct {
+	datasource.Service
+	store fakeStore[*modelV1.Datasource]
+}
+
+func (s *fakeDatasourceService) Create(_ echo.Context, entity *modelV1.Datasource) (*modelV1.Datasource, error) {
+	return s.store.create(entity)
+}
+
+func (s *fakeDatasourceService) Update(_ echo.Context, entity *modelV1.Datasource, _ apiInterface.Parameters) (*modelV1.Datasource, error) {
+	return s.store.update(entity)
+}
+
+func (s *fakeDatasourceService) Get(parameters apiInterface.Parameters) (*modelV1.Datasource, error) {
+	return s.store.get(parameters)
+}
+
+func (s *fakeDatasourceService) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
+	return s.store.delete(parameters)
+}
+
+func (s *fakeDatasourceService) List(_ *datasource.Query, _ apiInterface.Parameters) ([]*modelV1.Datasource, error) {
+	return s.store.list(), nil
+}
+
+type fakeGlobalDatasourceService struct {
+	globaldatasource.Service
+}
+
+func (s *fakeGlobalDatasourceService) List(_ *globaldatasource.Query, _ apiInterface.Parameters) ([]*modelV1.GlobalDatasource, error) {
+	return nil, nil
+}
+
+// fakeAuditService keeps the events recorded in the audit log.
+type fakeAuditService struct {
+	audit.Service
+	user   string
+	events []string
+}
+
+func (s *fakeAuditService) IsEnabled() bool {
+	return true
+}
+
+func (s *fakeAuditService) WithUser(user string) audit.Service {
+	s.user = user
+	return s
+}
+
+func (s *fakeAuditService) Created(_ echo.Context, current modelAPI.Entity) {
+	s.events = append(s.events, fmt.Sprintf("%s created %s", s.user, current.GetMetadata().GetName()))
+}
+
+func (s *fakeAuditService) Updated(_ echo.Context, _ modelAPI.Entity, current modelAPI.Entity) {
+	s.events = append(s.events, fmt.Sprintf("%s updated %s", s.user, current.GetMetadata().GetName()))
+}
+
+func (s *fakeAuditService) Deleted(_ echo.Context, _ modelV1.Kind, _ string, name string, _ modelAPI.Entity) {
+	s.events = append(s.events, fmt.Sprintf("%s deleted %s", s.user, name))
+}
+
+type fakeServiceManager struct {
+	dependency.ServiceManager
+	audit       *fakeAuditService
+	dashboards  *fakeDashboardService
+	datasources *fakeDatasourceService
+}
+
+func newFakeServiceManager() *fakeServiceManager {
+	return &fakeServiceManager{
+		audit:       &fakeAuditService{},
+		dashboards:  &fakeDashboardService{store: make(fakeStore[*modelV1.Dashboard])},
+		datasources: &fakeDatasourceService{store: make(fakeStore[*modelV1.Datasource])},
+	}
+}
+
+func (m *fakeServiceManager) GetAudit() audit.Service {
+	return m.audit
+}
+
+func (m *fakeServiceManager) GetDashboard() dashboard.Service {
+	return m.dashboards
+}
+
+func (m *fakeServiceManager) GetDatasource() datasource.Service {
+	return m.datasources
+}
+
+func (m *fakeServiceManager) GetGlobalDatasource() globaldatasource.Service {
+	return &fakeGlobalDatasourceService{}
+}
+
+func dashboardFile(name string) string {
+	return dashboardFileWithDuration(name, "1h")
+}
+
+func dashboardFileWithDuration(name string, duration string) string {
+	return fmt.Sprintf(`{"kind": "Dashboard", "metadata": {"name": %q, "project": "perses"}, "spec": {"duration": %q}}`, name, duration)
+}
+
+func datasourceFile(name string) string {
+	return fmt.Sprintf(`{"kind": "Datasource", "metadata": {"name": %q, "project": "perses"}, "spec": {"default": false, "plugin": {"kind": "PrometheusDatasource", "spec": {}}}}`, name)
+}
+
+// testRepository is a bare repository, cloned by the provisioning, and a working copy to push the changes to it.
+type testRepository struct {
+	work   string
+	remote string
+}
+
+func newTestRepository(t *testing.T, files map[string]string) *testRepository {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	root := t.TempDir()
+	r := &testRepository{
+		work:   filepath.Join(root, "work"),
+		remote: filepath.Join(root, "remote.git"),
+	}
+	require.NoError(t, runGit(context.Background(), "", "init", r.work))
+	r.commit(t, files)
+	require.NoError(t, runGit(context.Background(), "", "clone", "--bare", r.work, r.remote))
+	return r
+}
+
+// commit writes the files (an empty content removes the file) and commits them. The commit is pushed when the bare
+// repository exists.
+func (r *testRepository) commit(t *testing.T, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(r.work, name)
+		if len(content) == 0 {
+			require.NoError(t, os.Remove(path))
+			continue
+		}
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+	require.NoError(t, runGit(context.Background(), r.work, "add", "--all"))
+	require.NoError(t, runGit(context.Background(), r.work, "-c", "user.name=perses", "-c", "user.email=perses@example.com", "-c", "commit.gpgsign=false", "commit", "--message", "update"))
+	if _, err := os.Stat(r.remote); err == nil {
+		require.NoError(t, runGit(context.Background(), r.work, "push", r.remote, "HEAD"))
+	}
+}
+
+func (r *testRepository) url() string {
+	return "file://" + r.remote
+}
+
+func TestSyncRepository(t *testing.T) {
+	r := newTestRepository(t, map[string]string{"dashboards/a.json": dashboardFile("a")})
+	dir := filepath.Join(t.TempDir(), "clone")
+	repository := config.GitRepository{URL: r.url()}
+
+	require.NoError(t, syncRepository(context.Background(), repository, dir))
+	content, err := os.ReadFile(filepath.Join(dir, "dashboards", "a.json"))
+	require.NoError(t, err)
+	assert.Equal(t, dashboardFile("a"), string(content))
+
+	r.commit(t, map[string]string{"dashboards/a.json": "", "dashboards/b.json": dashboardFile("b")})
+	require.NoError(t, syncRepository(context.Background(), repository, dir))
+	assert.NoFileExists(t, filepath.Join(dir, "dashboards", "a.json"))
+	assert.FileExists(t, filepath.Join(dir, "dashboards", "b.json"))
+}
+
+func TestSyncRepositoryBranch(t *testing.T) {
+	r := newTestRepository(t, map[string]string{"a.json": dashboardFile("a")})
+	require.NoError(t, runGit(context.Background(), r.work, "checkout", "-b", "staging"))
+	r.commit(t, map[string]string{"b.json": dashboardFile("b")})
+
+	dir := filepath.Join(t.TempDir(), "clone")
+	repository := config.GitRepository{URL: r.url(), Branch: "staging"}
+	require.NoError(t, syncRepository(context.Background(), repository, dir))
+	assert.FileExists(t, filepath.Join(dir, "b.json"))
+
+	r.commit(t, map[string]string{"c.json": dashboardFile("c")})
+	require.NoError(t, syncRepository(context.Background(), repository, dir))
+	assert.FileExists(t, filepath.Join(dir, "c.json"))
+}
+
+func TestSyncRepositoryURLIsNotAnOption(t *testing.T) {
+	r := newTestRepository(t, map[string]string{"a.json": dashboardFile("a")})
+	marker := filepath.Join(t.TempDir(), "marker")
+	repository := config.GitRepository{URL: "--upload-pack=touch " + marker}
+	// Read as an option, the URL would make git clone the bare repository given as the destination with the command
+	// of the URL.
+	assert.Error(t, syncRepository(context.Background(), repository, r.remote))
+	assert.NoFileExists(t, marker)
+}
+
+func TestGitProvisioningPrune(t *testing.T) {
+	r := newTestRepository(t, map[string]string{
+		"a.json":    dashboardFile("a"),
+		"b.json":    dashboardFile("b"),
+		"prom.json": datasourceFile("prom"),
+	})
+	serviceManager := newFakeServiceManager()
+	// A dashboard created by a user must never be deleted.
+	manual := &modelV1.Dashboard{Kind: modelV1.KindDashboard, Metadata: *modelV1.NewProjectMetadata("perses", "manual")}
+	_, err := serviceManager.dashboards.Create(nil, manual)
+	require.NoError(t, err)
+
+	gitFolder := t.TempDir()
+	repositories := []config.GitRepository{{URL: r.url(), Prune: true}}
+	require.NoError(t, NewGit(serviceManager, repositories, gitFolder, true).Execute(context.Background(), nil))
+	assert.Len(t, serviceManager.dashboards.store, 3)
+	assert.Len(t, serviceManager.datasources.store, 1)
+	assert.Equal(t, repositoryFolder(repositories[0]), serviceManager.dashboards.store["perses/a"].Metadata.Annotations[provisionedAnnotation])
+
+	r.commit(t, map[string]string{"b.json": "", "prom.json": ""})
+	// A new task, as after a restart of Perses, still finds the resources provisioned from the repository.
+	require.NoError(t, NewGit(serviceManager, repositories, gitFolder, true).Execute(context.Background(), nil))
+	assert.Contains(t, serviceManager.dashboards.store, "perses/a")
+	assert.Contains(t, serviceManager.dashboards.store, "perses/manual")
+	assert.NotContains(t, serviceManager.dashboards.store, "perses/b")
+	assert.Empty(t, serviceManager.datasources.store)
+}
+
+func TestGitProvisioningWithoutPrune(t *testing.T) {
+	r := newTestRepository(t, map[string]string{
+		"a.json": dashboardFile("a"),
+		"b.json": dashboardFile("b"),
+	})
+	serviceManager := newFakeServiceManager()
+	gitFolder := t.TempDir()
+	repositories := []config.GitRepository{{URL: r.url()}}
+	require.NoError(t, NewGit(serviceManager, repositories, gitFolder, true).Execute(context.Background(), nil))
+
+	r.commit(t, map[string]string{"b.json": ""})
+	require.NoError(t, NewGit(serviceManager, repositories, gitFolder, true).Execute(context.Background(), nil))
+	assert.Contains(t, serviceManager.dashboards.store, "perses/a")
+	assert.Contains(t, serviceManager.dashboards.store, "perses/b")
+}
+
+func TestGitProvisioningAudit(t *testing.T) {
+	r := newTestRepository(t, map[string]string{
+		"a.json": dashboardFile("a"),
+		"b.json": dashboardFile("b"),
+	})
+	serviceManager := newFakeServiceManager()
+	gitFolder := t.TempDir()
+	repositories := []config.GitRepository{{URL: r.url(), Prune: true}}
+	task := NewGit(serviceManager, repositories, gitFolder, true)
+	require.NoError(t, task.Execute(context.Background(), nil))
+	assert.ElementsMatch(t, []string{"provisioning created a", "provisioning created b"}, serviceManager.audit.events)
+
+	// Applying the same resources again doesn't change them, so nothing is recorded.
+	serviceManager.audit.events = nil
+	require.NoError(t, task.Execute(context.Background(), nil))
+	assert.Empty(t, serviceManager.audit.events)
+
+	r.commit(t, map[string]string{"a.json": dashboardFileWithDuration("a", "6h"), "b.json": ""})
+	require.NoError(t, task.Execute(context.Background(), nil))
+	assert.Equal(t, []string{"provisioning updated a", "provisioning deleted b"}, serviceManager.audit.events)
+}
@@ -14,13 +14,16 @@
 package provisioning
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/perses/common/async"
 	databaseModel "github.com/perses/perses/internal/api/database/model"
 	"github.com/perses/perses/internal/api/dependency"
 	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
 	"github.com/perses/perses/internal/cli/file"
 	"github.com/perses/perses/internal/cli/resource"
 	modelAPI "github.com/perses/perses/pkg/model/api"
@@ -28,7 +31,7 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-type insertFunc func() (modelAPI.Entity, error)
+type entityFunc func() (modelAPI.Entity, error)
 
 func New(serviceManager dependency.ServiceManager, folders []string, caseSensitive bool) async.SimpleTask {
 	return &provisioning{
@@ -41,8 +44,10 @@ func New(serviceManager dependency.ServiceManager, folders []string, caseSensiti
 type provisioning struct {
 	async.SimpleTask
 	serviceManager dependency.ServiceManager
-	folders        []string
-	caseSensitive  bool
+	// audit records the changes in the audit log. It is nil when the changes are not recorded.
+	audit         audit.Service
+	folders       []string
+	caseSensitive bool
 }
 
 func (p *provisioning) Execute(_ context.Context, _ context.CancelFunc) error {
@@ -79,16 +84,19 @@ func (p *provisioning) applyEntity(entities []modelAPI.Entity) {
 			Name:    name,
 			Project: project,
 		}
-		createFun, updateFunc, svcErr := p.getService(entity, param)
+		createFun, updateFunc, getFunc, svcErr := p.getService(entity, param)
 		if svcErr != nil {
 			logrus.WithError(svcErr).Warningf("unable to retrieve the service associated to %q", kind)
 			continue
 		}
 
 		// the document doesn't exist, so we have to create it.
-		_, createErr := createFun()
+		created, createErr := createFun()
 
 		if createErr == nil {
+			if p.audit != nil {
+				p.audit.Created(nil, created)
+			}
 			continue
 		}
 
@@ -97,13 +105,46 @@ func (p *provisioning) applyEntity(entities []modelAPI.Entity) {
 			continue
 		}
 
-		if _, updateError := updateFunc(); updateError != nil {
+		previous := p.getPrevious(getFunc)
+		updated, updateError := updateFunc()
+		if updateError != nil {
 			logrus.WithError(updateError).Errorf("unable to update the %q %q", kind, name)
+			continue
 		}
+		p.recordUpdate(previous, updated)
+	}
+}
+
+// getPrevious returns the entity before its update, so the audit log can record the hash of its spec. It returns nil
+// when the changes are not recorded or when the entity cannot be retrieved.
+func (p *provisioning) getPrevious(getFunc entityFunc) modelAPI.Entity {
+	if p.audit == nil || !p.audit.IsEnabled() {
+		return nil
+	}
+	previous, err := getFunc()
+	if err != nil {
+		logrus.WithError(err).Debug("unable to get the entity before its update")
+		return nil
+	}
+	return previous
+}
+
+// recordUpdate records the update in the audit log. The entities are applied again at each execution, so an update
+// that doesn't change the spec is not recorded.
+func (p *provisioning) recordUpdate(previous modelAPI.Entity, current modelAPI.Entity) {
+	if p.audit == nil || (previous != nil && isSameSpec(previous, current)) {
+		return
 	}
+	p.audit.Updated(nil, previous, current)
+}
+
+func isSameSpec(previous modelAPI.Entity, current modelAPI.Entity) bool {
+	previousSpec, previousErr := json.Marshal(previous.GetSpec())
+	currentSpec, currentErr := json.Marshal(current.GetSpec())
+	return previousErr == nil && currentErr == nil && bytes.Equal(previousSpec, currentSpec)
 }
 
-func (p *provisioning) getService(object modelAPI.Entity, parameters apiInterface.Parameters) (createFunc insertFunc, updateFunc insertFunc, err error) {
+func (p *provisioning) getService(object modelAPI.Entity, parameters apiInterface.Parameters) (createFunc entityFunc, updateFunc entityFunc, getFunc entityFunc, err error) {
 	switch entity := object.(type) {
 	case *modelV1.Dashboard:
 		svc := p.serviceManager.GetDashboard()
@@ -112,6 +153,9 @@ func (p *provisioning) getService(object modelAPI.Entity, parameters apiInterfac
 			},
 			func() (modelAPI.Entity, error) {
 				return svc.Update(nil, entity, parameters)
+			},
+			func() (modelAPI.Entity, error) {
+				return svc.Get(parameters)
 			}, nil
 	case *modelV1.Datasource:
 		svc := p.serviceManager.GetDatasource()
@@ -120,6 +164,9 @@ func (p *provisioning) getService(object modelAPI.Entity, parameters apiInterfac
 			},
 			func() (modelAPI.Entity, error) {
 				return svc.Update(nil, entity, parameters)
+			},
+			func() (modelAPI.Entity, error) {
+				return svc.Get(parameters)
 			}, nil
 	case *modelV1.Folder:
 		svc := p.serviceManager.GetFolder()
@@ -128,6 +175,9 @@ func (p *provisioning) getService(object modelAPI.Entity, parameters apiInterfac
 			},
 			func() (modelAPI.Entity, error) {
 				return svc.Update(nil, entity, parameters)
+			},
+			func() (modelAPI.Entity, error) {
+				return svc.Get(parameters)
 			}, nil
 	case *modelV1.GlobalDatasource:
 		svc := p.serviceManager.GetGlobalDatasource()
@@ -136,6 +186,9 @@ func (p *provisioning) getService(object modelAPI.Entity, parameters apiInterfac
 			},
 			func() (modelAPI.Entity, error) {
 				return svc.Update(nil, entity, parameters)
+			},
+			func() (modelAPI.Entity, error) {
+				return svc.Get(parameters)
 			}, nil
 	case *modelV1.GlobalRole:
 		svc := p.serviceManager.GetGlobalRole()
@@ -144,6 +197,9 @@ func (p *provisioning) getService(object modelAPI.Entity, parameters apiInterfac
 			},
 			func() (modelAPI.Entity, error) {
 				return svc.Update(nil, entity, parameters)
+			},
+			func() (modelAPI.Entity, error) {
+				return svc.Get(parameters)
 			}, nil
 	case *modelV1.GlobalRoleBinding:
 		svc := p.serviceManager.GetGlobalRoleBinding()
@@ -152,6 +208,9 @@ func (p *provisioning) getService(object modelAPI.Entity, parameters apiInterfac
 			},
 			func() (modelAPI.Entity, error) {
 				return svc.Update(nil, entity, parameters)
+			},
+			func() (modelAPI.Entity, error) {
+				return svc.Get(parameters)
 			}, nil
 	case *modelV1.GlobalSecret:
 		svc := p.serviceManager.GetGlobalSecret()
@@ -160,6 +219,9 @@ func (p *provisioning) getService(object modelAPI.Entity, parameters apiInterfac
 			},
 			func() (modelAPI.Entity, error) {
 				return svc.Update(nil, entity, parameters)
+			},
+			func() (modelAPI.Entity, error) {
+				return svc.Get(parameters)
 			}, nil
 	case *modelV1.GlobalVariable:
 		svc := p.serviceManager.GetGlobalVariable()
@@ -168,6 +230,20 @@ func (p *provisioning) getService(object modelAPI.Entity, parameters apiInterfac
 			},
 			func() (modelAPI.Entity, error) {
 				return svc.Update(nil, entity, parameters)
+			},
+			func() (modelAPI.Entity, error) {
+				return svc.Get(parameters)
+			}, nil
+	case *modelV1.LibraryPanel:
+		svc := p.serviceManager.GetLibraryPanel()
+		return func() (modelAPI.Entity, error) {
+				return svc.Create(nil, entity)
+			},
+			func() (modelAPI.Entity, error) {
+				return svc.Update(nil, entity, parameters)
+			},
+			func() (modelAPI.Entity, error) {
+				return svc.Get(parameters)
 			}, nil
 	case *modelV1.Project:
 		svc := p.serviceManager.GetProject()
@@ -176,6 +252,9 @@ func (p *provisioning) getService(object modelAPI.Entity, parameters apiInterfac
 			},
 			func() (modelAPI.Entity, error) {
 				return svc.Update(nil, entity, parameters)
+			},
+			func() (modelAPI.Entity, error) {
+				return svc.Get(parameters)
 			}, nil
 	case *modelV1.Role:
 		svc := p.serviceManager.GetRole()
@@ -184,6 +263,9 @@ func (p *provisioning) getService(object modelAPI.Entity, parameters apiInterfac
 			},
 			func() (modelAPI.Entity, error) {
 				return svc.Update(nil, entity, parameters)
+			},
+			func() (modelAPI.Entity, error) {
+				return svc.Get(parameters)
 			}, nil
 	case *modelV1.RoleBinding:
 		svc := p.serviceManager.GetRoleBinding()
@@ -192,6 +274,9 @@ func (p *provisioning) getService(object modelAPI.Entity, parameters apiInterfac
 			},
 			func() (modelAPI.Entity, error) {
 				return svc.Update(nil, entity, parameters)
+			},
+			func() (modelAPI.Entity, error) {
+				return svc.Get(parameters)
 			}, nil
 	case *modelV1.Secret:
 		svc := p.serviceManager.GetSecret()
@@ -200,6 +285,9 @@ func (p *provisioning) getService(object modelAPI.Entity, parameters apiInterfac
 			},
 			func() (modelAPI.Entity, error) {
 				return svc.Update(nil, entity, parameters)
+			},
+			func() (modelAPI.Entity, error) {
+				return svc.Get(parameters)
 			}, nil
 	case *modelV1.User:
 		svc := p.serviceManager.GetUser()
@@ -208,6 +296,9 @@ func (p *provisioning) getService(object modelAPI.Entity, parameters apiInterfac
 			},
 			func() (modelAPI.Entity, error) {
 				return svc.Update(nil, entity, parameters)
+			},
+			func() (modelAPI.Entity, error) {
+				return svc.Get(parameters)
 			}, nil
 	case *modelV1.Variable:
 		svc := p.serviceManager.GetVariable()
@@ -216,9 +307,12 @@ func (p *provisioning) getService(object modelAPI.Entity, parameters apiInterfac
 			},
 			func() (modelAPI.Entity, error) {
 				return svc.Update(nil, entity, parameters)
+			},
+			func() (modelAPI.Entity, error) {
+				return svc.Get(parameters)
 			}, nil
 	// We don't support the provisioning of the following resources: EphemeralDashboard
 	default:
-		return nil, nil, fmt.Errorf("resource %q not supported by the provisioning service", entity.GetKind())
+		return nil, nil, nil, fmt.Errorf("resource %q not supported by the provisioning service", entity.GetKind())
 	}
 }
@@ -0,0 +1,226 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package search provides the full-text search across the dashboards. The dashboards are indexed in memory, and the
+// index is rebuilt periodically.
+package search
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/perses/common/async"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+// fieldWeights is the score given to a term found in a field.
+var fieldWeights = map[api.SearchField]int{
+	api.SearchFieldName:             10,
+	api.SearchFieldDescription:      5,
+	api.SearchFieldPanelTitle:       3,
+	api.SearchFieldPanelDescription: 2,
+	api.SearchFieldQuery:            1,
+}
+
+// queryFields are the fields of the spec of the query plugins containing the expression, e.g. the PromQL expression of
+// a PrometheusTimeSeriesQuery.
+var queryFields = []string{"query", "expr"}
+
+type Search interface {
+	async.SimpleTask
+	// Search returns the dashboards of the projects matching all the terms of the query, the most relevant first.
+	// The project "*" stands for all the projects. When limit is positive, at most limit results are returned.
+	Search(query string, projects []string, limit int) ([]api.SearchResult, error)
+}
+
+type field struct {
+	name  api.SearchField
+	panel string
+	value string
+	// normalized is the value in lower case, so the search is case-insensitive.
+	normalized string
+}
+
+// document is a dashboard in the index.
+type document struct {
+	project     string
+	name        string
+	displayName string
+	fields      []field
+}
+
+type search struct {
+	async.SimpleTask
+	dao       dashboard.DAO
+	mutex     sync.RWMutex
+	documents []document
+	indexed   bool
+}
+
+func New(dao dashboard.DAO) Search {
+	return &search{dao: dao}
+}
+
+func (s *search) Execute(_ context.Context, _ context.CancelFunc) error {
+	if err := s.refresh(); err != nil {
+		logrus.WithError(err).Error("unable to refresh the search index of the dashboards")
+	}
+	return nil
+}
+
+func (s *search) String() string {
+	return "dashboard search index"
+}
+
+func (s *search) Search(query string, projects []string, limit int) ([]api.SearchResult, error) {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 || len(projects) == 0 {
+		return []api.SearchResult{}, nil
+	}
+	if !s.isIndexed() {
+		// The index is built on the first search if the task has not run yet.
+		if err := s.refresh(); err != nil {
+			return nil, err
+		}
+	}
+	allProjects := slices.Contains(projects, v1.WildcardProject)
+
+	s.mutex.RLock()
+	results := []api.SearchResult{}
+	for _, doc := range s.documents {
+		if !allProjects && !slices.Contains(projects, doc.project) {
+			continue
+		}
+		if result, ok := doc.match(terms); ok {
+			results = append(results, result)
+		}
+	}
+	s.mutex.RUnlock()
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].Project != results[j].Project {
+			return results[i].Project < results[j].Project
+		}
+		return results[i].Name < results[j].Name
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+func (s *search) isIndexed() bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.indexed
+}
+
+func (s *search) refresh() error {
+	dashboards, err := s.dao.List(&dashboard.Query{})
+	if err != nil {
+		return err
+	}
+	documents := make([]document, 0, len(dashboards))
+	for _, d := range dashboards {
+		documents = append(documents, newDocument(d))
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.documents = documents
+	s.indexed = true
+	return nil
+}
+
+func newDocument(d *v1.Dashboard) document {
+	doc := document{
+		project: d.Metadata.Project,
+		name:    d.Metadata.Name,
+	}
+	doc.add(api.SearchFieldName, "", d.Metadata.Name)
+	if d.Spec.Display != nil {
+		doc.displayName = d.Spec.Display.Name
+		doc.add(api.SearchFieldName, "", d.Spec.Display.Name)
+		doc.add(api.SearchFieldDescription, "", d.Spec.Display.Description)
+	}
+	panelKeys := make([]string, 0, len(d.Spec.Panels))
+	for key := range d.Spec.Panels {
+		panelKeys = append(panelKeys, key)
+	}
+	sort.Strings(panelKeys)
+	for _, key := range panelKeys {
+		panel := d.Spec.Panels[key]
+		if panel == nil {
+			continue
+		}
+		doc.add(api.SearchFieldPanelTitle, key, panel.Spec.Display.Name)
+		doc.add(api.SearchFieldPanelDescription, key, panel.Spec.Display.Description)
+		for _, query := range panel.Spec.Queries {
+			spec, ok := query.Spec.Plugin.Spec.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, name := range queryFields {
+				if expr, isString := spec[name].(string); isString {
+					doc.add(api.SearchFieldQuery, key, expr)
+				}
+			}
+		}
+	}
+	return doc
+}
+
+func (d *document) add(name api.SearchField, panel string, value string) {
+	if len(value) == 0 {
+		return
+	}
+	d.fields = append(d.fields, field{name: name, panel: panel, value: value, normalized: strings.ToLower(value)})
+}
+
+// match returns the dashboard as a result if every term is found in at least one of its fields. The score is the sum
+// of the weights of the fields containing a term, for every term.
+func (d document) match(terms []string) (api.SearchResult, bool) {
+	result := api.SearchResult{
+		Project:     d.project,
+		Name:        d.name,
+		DisplayName: d.displayName,
+	}
+	matched := make([]bool, len(d.fields))
+	for _, term := range terms {
+		found := false
+		for i, f := range d.fields {
+			if strings.Contains(f.normalized, term) {
+				found = true
+				matched[i] = true
+				result.Score += fieldWeights[f.name]
+			}
+		}
+		if !found {
+			return api.SearchResult{}, false
+		}
+	}
+	for i, f := range d.fields {
+		if matched[i] {
+			result.Matches = append(result.Matches, api.SearchMatch{Field: f.name, Panel: f.panel, Value: f.value})
+		}
+	}
+	return result, true
+}
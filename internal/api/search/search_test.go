@@ -0,0 +1,101 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"testing"
+
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockDAO struct {
+	dashboard.DAO
+	dashboards []*v1.Dashboard
+}
+
+func (m *mockDAO) List(_ *dashboard.Query) ([]*v1.Dashboard, error) {
+	return m.dashboards, nil
+}
+
+func newDashboard(project string, name string, description string, panels map[string]*v1.Panel) *v1.Dashboard {
+	return &v1.Dashboard{
+		Kind: v1.KindDashboard,
+		Metadata: v1.ProjectMetadata{
+			Metadata:               v1.Metadata{Name: name},
+			ProjectMetadataWrapper: v1.ProjectMetadataWrapper{Project: project},
+		},
+		Spec: v1.DashboardSpec{
+			Display: &common.Display{Description: description},
+			Panels:  panels,
+		},
+	}
+}
+
+func newPanel(title string, query string) *v1.Panel {
+	return &v1.Panel{
+		Kind: "Panel",
+		Spec: v1.PanelSpec{
+			Display: v1.PanelDisplay{Name: title},
+			Queries: []v1.Query{{
+				Kind: "TimeSeriesQuery",
+				Spec: v1.QuerySpec{Plugin: common.Plugin{
+					Kind: "PrometheusTimeSeriesQuery",
+					Spec: map[string]interface{}{"query": query},
+				}},
+			}},
+		},
+	}
+}
+
+func TestSearch(t *testing.T) {
+	s := New(&mockDAO{dashboards: []*v1.Dashboard{
+		newDashboard("infra", "node", "The CPU and the memory of the nodes", map[string]*v1.Panel{
+			"cpu": newPanel("CPU usage", "rate(node_cpu_seconds_total[5m])"),
+		}),
+		newDashboard("infra", "cpu", "", nil),
+		newDashboard("app", "api", "", map[string]*v1.Panel{
+			"latency": newPanel("Latency", "histogram_quantile(0.9, http_request_duration_seconds_bucket)"),
+		}),
+	}})
+
+	results, err := s.Search("CPU", []string{v1.WildcardProject}, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	// The match in the name ranks the dashboard cpu first.
+	assert.Equal(t, "cpu", results[0].Name)
+	assert.Equal(t, "node", results[1].Name)
+	assert.Equal(t, []api.SearchMatch{
+		{Field: api.SearchFieldDescription, Value: "The CPU and the memory of the nodes"},
+		{Field: api.SearchFieldPanelTitle, Panel: "cpu", Value: "CPU usage"},
+		{Field: api.SearchFieldQuery, Panel: "cpu", Value: "rate(node_cpu_seconds_total[5m])"},
+	}, results[1].Matches)
+
+	results, err = s.Search("cpu memory", []string{v1.WildcardProject}, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "node", results[0].Name)
+
+	results, err = s.Search("http_request", []string{"infra"}, 0)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	results, err = s.Search("cpu", []string{"infra"}, 1)
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+}
@@ -16,11 +16,15 @@ package toolbox
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/brunoga/deep"
 	"github.com/labstack/echo/v4"
 	"github.com/perses/common/async"
 	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/utils"
 	"github.com/perses/perses/pkg/model/api"
 	modelV1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/perses/pkg/model/api/v1/role"
@@ -57,8 +61,18 @@ func (t *toolbox[T, K, V]) listWhenPermissionIsActivated(ctx echo.Context, param
 	if err != nil {
 		return nil, err
 	}
+	// The permissions restricted to some resources (e.g. to some dashboards) give access to these resources only, not to
+	// the whole project.
+	resources, err := t.authz.GetUserResources(ctx, role.ReadAction, *scope)
+	if err != nil {
+		return nil, err
+	}
 	if permErr := t.checkPermissionList(ctx, parameters, scope); permErr != nil {
-		return nil, permErr
+		if len(parameters.Project) == 0 || (len(resources[parameters.Project]) == 0 && len(resources[modelV1.WildcardProject]) == 0) {
+			return nil, permErr
+		}
+		// The user can only read some resources of the project.
+		return t.listRestrictedResources(parameters, q, resources, nil)
 	}
 	// Get the list of the project the user has access to, depending on the current scope.
 	projects, err := t.authz.GetUserProjects(ctx, role.ReadAction, *scope)
@@ -67,7 +81,7 @@ func (t *toolbox[T, K, V]) listWhenPermissionIsActivated(ctx echo.Context, param
 	}
 
 	// If there is no project associated with the user, then we should just return an empty list.
-	if len(projects) == 0 {
+	if len(projects) == 0 && len(resources) == 0 {
 		return []api.Entity{}, nil
 	}
 
@@ -98,22 +112,128 @@ func (t *toolbox[T, K, V]) listWhenPermissionIsActivated(ctx echo.Context, param
 		if requestErr != nil {
 			return nil, requestErr
 		}
-		switch typedList := listResult.(type) {
-		case []api.Entity:
-			for _, entity := range typedList {
+		result = t.appendList(result, listResult)
+	}
+	if len(resources) > 0 {
+		// Add the resources the user can read in the projects he doesn't have access to.
+		restricted, restrictedErr := t.listRestrictedResources(parameters, q, resources, projects)
+		if restrictedErr != nil {
+			return nil, restrictedErr
+		}
+		result = append(result, restricted...)
+	}
+	return result, nil
+}
+
+// restrictableQuery is implemented by the queries of the resources that can be targeted by the permissions restricted
+// to some resources (e.g. the dashboards).
+type restrictableQuery interface {
+	GetNamePrefix() string
+	// Restrict restricts the query to the resources of the project whose names start with namePrefix.
+	Restrict(project string, namePrefix string)
+}
+
+// listRestrictedResources lists the resources the user can read through the permissions restricted to some
+// resources, in the project of the parameters or in every project when it's empty. The projects in excludedProjects
+// are skipped, as their resources are already listed. Each resource is queried by its project and its name, so the
+// database doesn't return the other resources.
+func (t *toolbox[T, K, V]) listRestrictedResources(parameters apiInterface.Parameters, q V, resources map[string][]string, excludedProjects []string) ([]any, error) {
+	result := make([]any, 0)
+	restrictable, ok := any(q).(restrictableQuery)
+	if !ok {
+		return result, nil
+	}
+	namePrefix := restrictable.GetNamePrefix()
+	if !t.caseSensitive {
+		namePrefix = strings.ToLower(namePrefix)
+	}
+	seen := make(map[string]bool)
+	projects := slices.Sorted(maps.Keys(resources))
+	for _, resourceProject := range projects {
+		project := resourceProject
+		if project == modelV1.WildcardProject {
+			project = parameters.Project
+		} else if (len(parameters.Project) > 0 && project != parameters.Project) || slices.Contains(excludedProjects, project) {
+			continue
+		}
+		for _, name := range resources[resourceProject] {
+			if !strings.HasPrefix(name, namePrefix) {
+				continue
+			}
+			query, err := deep.Copy(q)
+			if err != nil {
+				return nil, fmt.Errorf("unable to copy the query: %w", err)
+			}
+			any(query).(restrictableQuery).Restrict(project, name)
+			param := parameters
+			param.Project = project
+			list, listErr := t.metadataOrFullList(param, query)
+			if listErr != nil {
+				return nil, listErr
+			}
+			// The query matches the names starting with the name of the resource, so the exact name is checked.
+			list = t.filterList(list, func(itemProject string, itemName string) bool {
+				key := itemProject + "/" + itemName
+				if itemName != name || slices.Contains(excludedProjects, itemProject) || seen[key] {
+					return false
+				}
+				seen[key] = true
+				return true
+			})
+			result = t.appendList(result, list)
+		}
+	}
+	return result, nil
+}
+
+func (t *toolbox[T, K, V]) appendList(result []any, list any) []any {
+	switch typedList := list.(type) {
+	case []api.Entity:
+		for _, entity := range typedList {
+			result = append(result, entity)
+		}
+	case []K:
+		for _, entity := range typedList {
+			result = append(result, entity)
+		}
+	case []json.RawMessage:
+		for _, entity := range typedList {
+			result = append(result, entity)
+		}
+	}
+	return result
+}
+
+// filterList keeps the items of the list for which keep, called with the project and the name of the item, returns
+// true.
+func (t *toolbox[T, K, V]) filterList(list any, keep func(project string, name string) bool) any {
+	switch typedList := list.(type) {
+	case []K:
+		result := make([]K, 0, len(typedList))
+		for _, entity := range typedList {
+			if keep(utils.GetMetadataProject(entity.GetMetadata()), entity.GetMetadata().GetName()) {
 				result = append(result, entity)
 			}
-		case []K:
-			for _, entity := range typedList {
+		}
+		return result
+	case []api.Entity:
+		result := make([]api.Entity, 0, len(typedList))
+		for _, entity := range typedList {
+			if keep(utils.GetMetadataProject(entity.GetMetadata()), entity.GetMetadata().GetName()) {
 				result = append(result, entity)
 			}
-		case []json.RawMessage:
-			for _, entity := range typedList {
+		}
+		return result
+	case []json.RawMessage:
+		result := make([]json.RawMessage, 0, len(typedList))
+		for _, entity := range typedList {
+			if keep(gjson.GetBytes(entity, "metadata.project").String(), gjson.GetBytes(entity, "metadata.name").String()) {
 				result = append(result, entity)
 			}
 		}
+		return result
 	}
-	return result, nil
+	return list
 }
 
 func (t *toolbox[T, K, V]) listProjectWhenPermissionIsActivated(parameters apiInterface.Parameters, projects []string, query V) (any, error) {
@@ -0,0 +1,219 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toolbox
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+// restrictedRBAC gives a full access to the projects and an access to the resources restricted by project.
+type restrictedRBAC struct {
+	projects  []string
+	resources map[string][]string
+}
+
+func (r *restrictedRBAC) GetUser(_ echo.Context) (any, error) {
+	return nil, nil
+}
+
+func (r *restrictedRBAC) GetUsername(_ echo.Context) (string, error) {
+	return "user", nil
+}
+
+func (r *restrictedRBAC) Middleware(_ middleware.Skipper) echo.MiddlewareFunc {
+	return nil
+}
+
+func (r *restrictedRBAC) IsEnabled() bool {
+	return true
+}
+
+func (r *restrictedRBAC) GetUserProjects(_ echo.Context, _ role.Action, _ role.Scope) ([]string, error) {
+	return r.projects, nil
+}
+
+func (r *restrictedRBAC) HasPermission(_ echo.Context, _ role.Action, project string, _ role.Scope) bool {
+	return slices.Contains(r.projects, project)
+}
+
+func (r *restrictedRBAC) HasResourcePermission(ctx echo.Context, action role.Action, project string, scope role.Scope, name string) bool {
+	return r.HasPermission(ctx, action, project, scope) || slices.Contains(r.resources[project], name) || slices.Contains(r.resources[v1.WildcardProject], name)
+}
+
+func (r *restrictedRBAC) GetUserResources(_ echo.Context, _ role.Action, _ role.Scope) (map[string][]string, error) {
+	return r.resources, nil
+}
+
+func (r *restrictedRBAC) GetPermissions(_ echo.Context) (map[string][]*role.Permission, error) {
+	return nil, nil
+}
+
+func (r *restrictedRBAC) RefreshPermissions() error {
+	return nil
+}
+
+// listDashboardService lists the dashboards like the database and records the queries it receives.
+type listDashboardService struct {
+	dashboard.Service
+	dashboards []*v1.Dashboard
+	queries    []dashboard.Query
+}
+
+func (s *listDashboardService) List(q *dashboard.Query, params apiInterface.Parameters) ([]*v1.Dashboard, error) {
+	query := *q
+	if len(query.Project) == 0 {
+		query.Project = params.Project
+	}
+	s.queries = append(s.queries, query)
+	var result []*v1.Dashboard
+	for _, dash := range s.dashboards {
+		if (len(query.Project) == 0 || dash.Metadata.Project == query.Project) && strings.HasPrefix(dash.Metadata.Name, query.NamePrefix) {
+			result = append(result, dash)
+		}
+	}
+	return result, nil
+}
+
+func (s *listDashboardService) RawList(q *dashboard.Query, params apiInterface.Parameters) ([]json.RawMessage, error) {
+	list, err := s.List(q, params)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]json.RawMessage, 0, len(list))
+	for _, dash := range list {
+		data, marshalErr := json.Marshal(dash)
+		if marshalErr != nil {
+			return nil, marshalErr
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (s *listDashboardService) MetadataList(q *dashboard.Query, params apiInterface.Parameters) ([]api.Entity, error) {
+	list, err := s.List(q, params)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]api.Entity, 0, len(list))
+	for _, dash := range list {
+		result = append(result, dash)
+	}
+	return result, nil
+}
+
+func (s *listDashboardService) RawMetadataList(q *dashboard.Query, params apiInterface.Parameters) ([]json.RawMessage, error) {
+	return s.RawList(q, params)
+}
+
+func newDashboard(project string, name string) *v1.Dashboard {
+	return &v1.Dashboard{Kind: v1.KindDashboard, Metadata: *v1.NewProjectMetadata(project, name)}
+}
+
+func TestListRestrictedResources(t *testing.T) {
+	dashboards := []*v1.Dashboard{
+		newDashboard("project0", "cpu"),
+		newDashboard("project0", "cpu-details"),
+		newDashboard("project0", "memory"),
+		newDashboard("project0", "overview"),
+		newDashboard("project1", "cpu"),
+		newDashboard("project1", "overview"),
+		newDashboard("project2", "overview"),
+		newDashboard("project2", "disk"),
+	}
+	testSuite := []struct {
+		title           string
+		authz           *restrictedRBAC
+		project         string
+		namePrefix      string
+		expectedErr     bool
+		expectedNames   []string
+		expectedQueries []dashboard.Query
+	}{
+		{
+			title:         "only the restricted dashboards of a project are listed",
+			authz:         &restrictedRBAC{resources: map[string][]string{"project0": {"cpu", "unknown"}}},
+			project:       "project0",
+			expectedNames: []string{"project0/cpu"},
+			expectedQueries: []dashboard.Query{
+				{Project: "project0", NamePrefix: "cpu"},
+				{Project: "project0", NamePrefix: "unknown"},
+			},
+		},
+		{
+			title:       "a project without any restricted dashboard is forbidden",
+			authz:       &restrictedRBAC{resources: map[string][]string{"project0": {"cpu"}}},
+			project:     "project1",
+			expectedErr: true,
+		},
+		{
+			title:           "the prefix of the query is applied to the restricted dashboards",
+			authz:           &restrictedRBAC{resources: map[string][]string{"project0": {"cpu", "memory"}}},
+			project:         "project0",
+			namePrefix:      "mem",
+			expectedNames:   []string{"project0/memory"},
+			expectedQueries: []dashboard.Query{{Project: "project0", NamePrefix: "memory"}},
+		},
+		{
+			title: "the restricted dashboards are added to the dashboards of the projects",
+			authz: &restrictedRBAC{
+				projects:  []string{"project1"},
+				resources: map[string][]string{"project0": {"cpu"}, v1.WildcardProject: {"overview"}},
+			},
+			expectedNames: []string{"project1/cpu", "project1/overview", "project0/cpu", "project0/overview", "project2/overview"},
+			expectedQueries: []dashboard.Query{
+				{Project: "project1"},
+				{Project: "", NamePrefix: "overview"},
+				{Project: "project0", NamePrefix: "cpu"},
+			},
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			service := &listDashboardService{dashboards: dashboards}
+			tb := New[*v1.Dashboard, *v1.Dashboard, *dashboard.Query](service, test.authz, nil, v1.KindDashboard, false).(*toolbox[*v1.Dashboard, *v1.Dashboard, *dashboard.Query])
+			ctx := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+			list, err := tb.list(ctx, apiInterface.Parameters{Project: test.project}, &dashboard.Query{NamePrefix: test.namePrefix})
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			data, err := json.Marshal(list)
+			require.NoError(t, err)
+			var names []string
+			for _, item := range gjson.ParseBytes(data).Array() {
+				names = append(names, item.Get("metadata.project").String()+"/"+item.Get("metadata.name").String())
+			}
+			assert.ElementsMatch(t, test.expectedNames, names)
+			assert.ElementsMatch(t, test.expectedQueries, service.queries)
+		})
+	}
+}
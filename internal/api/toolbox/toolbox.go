@@ -22,6 +22,7 @@ import (
 	"github.com/perses/perses/internal/api/authorization"
 	databaseModel "github.com/perses/perses/internal/api/database/model"
 	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/audit"
 	"github.com/perses/perses/internal/api/utils"
 	"github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
@@ -60,10 +61,11 @@ type Toolbox[T api.Entity, K databaseModel.Query] interface {
 	List(ctx echo.Context, q K) error
 }
 
-func New[T api.Entity, K api.Entity, V databaseModel.Query](service apiInterface.Service[T, K, V], authz authorization.Authorization, kind v1.Kind, caseSensitive bool) Toolbox[T, V] {
+func New[T api.Entity, K api.Entity, V databaseModel.Query](service apiInterface.Service[T, K, V], authz authorization.Authorization, auditService audit.Service, kind v1.Kind, caseSensitive bool) Toolbox[T, V] {
 	return &toolbox[T, K, V]{
 		service:       service,
 		authz:         authz,
+		audit:         auditService,
 		kind:          kind,
 		caseSensitive: caseSensitive,
 	}
@@ -73,6 +75,7 @@ type toolbox[T api.Entity, K api.Entity, V databaseModel.Query] struct {
 	Toolbox[T, V]
 	service       apiInterface.Service[T, K, V]
 	authz         authorization.Authorization
+	audit         audit.Service
 	kind          v1.Kind
 	caseSensitive bool
 }
@@ -131,11 +134,16 @@ func (t *toolbox[T, K, V]) checkPermission(ctx echo.Context, entity api.Entity,
 		projectName = parameters.Name
 	}
 
+	name := parameters.Name
 	if len(projectName) == 0 && entity != nil {
 		// Retrieving project name from payload if project name not provided in the url
 		projectName = utils.GetMetadataProject(entity.GetMetadata())
 	}
-	if ok := t.authz.HasPermission(ctx, action, projectName, *scope); !ok {
+	if len(name) == 0 && entity != nil {
+		name = entity.GetMetadata().GetName()
+	}
+	// The permissions restricted to some resources (e.g. to some dashboards of a project) are considered as well.
+	if ok := t.authz.HasResourcePermission(ctx, action, projectName, *scope, name); !ok {
 		return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission in '%s' project for '%s' kind", action, projectName, *scope))
 	}
 	return nil
@@ -153,6 +161,7 @@ func (t *toolbox[T, K, V]) Create(ctx echo.Context, entity T) error {
 	if err != nil {
 		return err
 	}
+	t.audit.Created(ctx, newEntity)
 	return ctx.JSON(http.StatusOK, newEntity)
 }
 
@@ -164,10 +173,12 @@ func (t *toolbox[T, K, V]) Update(ctx echo.Context, entity T) error {
 	if err := t.checkPermission(ctx, entity, parameters, role.UpdateAction); err != nil {
 		return err
 	}
+	previous := t.getPrevious(parameters)
 	newEntity, err := t.service.Update(ctx, entity, parameters)
 	if err != nil {
 		return err
 	}
+	t.audit.Updated(ctx, previous, newEntity)
 	return ctx.JSON(http.StatusOK, newEntity)
 }
 
@@ -176,9 +187,11 @@ func (t *toolbox[T, K, V]) Delete(ctx echo.Context) error {
 	if err := t.checkPermission(ctx, nil, parameters, role.DeleteAction); err != nil {
 		return err
 	}
+	previous := t.getPrevious(parameters)
 	if err := t.service.Delete(ctx, parameters); err != nil {
 		return err
 	}
+	t.audit.Deleted(ctx, t.kind, parameters.Project, parameters.Name, previous)
 	return ctx.NoContent(http.StatusNoContent)
 }
 
@@ -208,6 +221,20 @@ func (t *toolbox[T, K, V]) List(ctx echo.Context, query V) error {
 	return ctx.JSON(http.StatusOK, list)
 }
 
+// getPrevious returns the entity before its update or deletion, so the audit log can record the hash of its spec. It
+// returns nil when the audit log is disabled or when the entity cannot be retrieved. In the latter case, the update or
+// the deletion reports the error.
+func (t *toolbox[T, K, V]) getPrevious(parameters apiInterface.Parameters) api.Entity {
+	if !t.audit.IsEnabled() {
+		return nil
+	}
+	previous, err := t.service.Get(parameters)
+	if err != nil {
+		return nil
+	}
+	return previous
+}
+
 func (t *toolbox[T, K, V]) bind(ctx echo.Context, entity api.Entity) error {
 	if !isJSONContentType(ctx) {
 		return apiInterface.UnsupportedMediaType
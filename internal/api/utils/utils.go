@@ -22,6 +22,7 @@ import (
 const (
 	ParamDashboard         = "dashboard"
 	ParamName              = "name"
+	ParamPanel             = "panel"
 	ParamProject           = "project"
 	APIPrefix              = "/api"
 	PathAuth               = "auth"
@@ -36,6 +37,7 @@ const (
 	AuthKindOIDC           = "oidc"
 	AuthKindOAuth          = "oauth"
 	APIV1Prefix            = "/api/v1"
+	PathAudit              = "audit"
 	PathDashboard          = "dashboards"
 	PathDatasource         = "datasources"
 	PathEphemeralDashboard = "ephemeraldashboards"
@@ -45,10 +47,13 @@ const (
 	PathGlobalRoleBinding  = "globalrolebindings"
 	PathGlobalSecret       = "globalsecrets"
 	PathGlobalVariable     = "globalvariables"
+	PathLibraryPanel       = "librarypanels"
+	PathPanel              = "panels"
 	PathProject            = "projects"
 	PathRole               = "roles"
 	PathRoleBinding        = "rolebindings"
 	PathSecret             = "secrets"
+	PathSnapshot           = "snapshots"
 	PathUnsaved            = "unsaved"
 	PathUser               = "users"
 	PathVariable           = "variables"
@@ -60,7 +65,7 @@ const MetricNamespace = "perses"
 
 // ProjectResourcePathList is containing the list of the resource path that is part of a project.
 var ProjectResourcePathList = []string{
-	PathDashboard, PathDatasource, PathFolder, PathRole, PathRoleBinding, PathSecret, PathVariable,
+	PathDashboard, PathDatasource, PathFolder, PathLibraryPanel, PathRole, PathRoleBinding, PathSecret, PathSnapshot, PathVariable,
 }
 
 func GetNameParameter(ctx echo.Context) string {
@@ -0,0 +1,223 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhook notifies the webhooks set in the configuration when a resource is created, updated or deleted, e.g.
+// to feed an audit pipeline or to trigger a GitOps reconciliation.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	clientConfig "github.com/perses/perses/pkg/client/config"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/config"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+// diffDepth is the number of levels of the spec compared to summarize an update. With 2 levels, a change of a panel is
+// reported as "~ panels.<key>" rather than "~ panels".
+const diffDepth = 2
+
+// Webhook sends the events to the webhooks in the background, so a webhook failing or slow to answer doesn't affect
+// the request changing the resource. The failures are only logged.
+type Webhook interface {
+	Created(ctx echo.Context, entity api.Entity)
+	Updated(ctx echo.Context, previous api.Entity, current api.Entity)
+	Deleted(ctx echo.Context, kind v1.Kind, project string, name string)
+}
+
+type target struct {
+	name    string
+	url     string
+	client  *http.Client
+	headers map[string]string
+	timeout time.Duration
+}
+
+type webhook struct {
+	targets []*target
+	authz   authorization.Authorization
+}
+
+// New returns the Webhook sending the events to the webhooks of the configuration. When there is no webhook, the
+// events are dropped.
+func New(cfg []config.Webhook, authz authorization.Authorization) (Webhook, error) {
+	w := &webhook{authz: authz}
+	for _, c := range cfg {
+		restClient, err := clientConfig.NewRESTClient(*c.Endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create the client of the webhook %q: %w", c.Name, err)
+		}
+		w.targets = append(w.targets, &target{
+			name:    c.Name,
+			url:     c.Endpoint.URL.String(),
+			client:  restClient.Client,
+			headers: restClient.Headers,
+			timeout: time.Duration(c.Timeout),
+		})
+	}
+	return w, nil
+}
+
+func (w *webhook) Created(ctx echo.Context, entity api.Entity) {
+	w.notify(ctx, w.newEvent(api.WebhookActionCreated, entity))
+}
+
+func (w *webhook) Updated(ctx echo.Context, previous api.Entity, current api.Entity) {
+	event := w.newEvent(api.WebhookActionUpdated, current)
+	diff, err := summarizeDiff(previous.GetSpec(), current.GetSpec())
+	if err != nil {
+		logrus.WithError(err).Errorf("unable to summarize the changes of the %s %q", event.Kind, event.Name)
+	}
+	event.Diff = diff
+	w.notify(ctx, event)
+}
+
+func (w *webhook) Deleted(ctx echo.Context, kind v1.Kind, project string, name string) {
+	w.notify(ctx, api.WebhookEvent{
+		Kind:    string(kind),
+		Project: project,
+		Name:    name,
+		Action:  api.WebhookActionDeleted,
+	})
+}
+
+func (w *webhook) newEvent(action api.WebhookAction, entity api.Entity) api.WebhookEvent {
+	event := api.WebhookEvent{
+		Kind:   entity.GetKind(),
+		Name:   entity.GetMetadata().GetName(),
+		Action: action,
+	}
+	if metadata, ok := entity.GetMetadata().(*v1.ProjectMetadata); ok {
+		event.Project = metadata.Project
+	}
+	return event
+}
+
+func (w *webhook) notify(ctx echo.Context, event api.WebhookEvent) {
+	if len(w.targets) == 0 {
+		return
+	}
+	event.Timestamp = time.Now().UTC()
+	if ctx != nil && w.authz.IsEnabled() {
+		username, err := w.authz.GetUsername(ctx)
+		if err != nil {
+			logrus.WithError(err).Debugf("unable to get the user who changed the %s %q", event.Kind, event.Name)
+		}
+		event.Actor = username
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		logrus.WithError(err).Errorf("unable to marshal the webhook event of the %s %q", event.Kind, event.Name)
+		return
+	}
+	for _, t := range w.targets {
+		go t.send(body, event)
+	}
+}
+
+func (t *target) send(body []byte, event api.WebhookEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), t.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(body))
+	if err != nil {
+		logrus.WithError(err).Errorf("unable to create the request to the webhook %q", t.name)
+		return
+	}
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	for key, value := range t.headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		logrus.WithError(err).Errorf("unable to notify the webhook %q that the %s %q has been %s", t.name, event.Kind, event.Name, event.Action)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		logrus.Errorf("the webhook %q answered with the status %d to the notification that the %s %q has been %s", t.name, resp.StatusCode, event.Kind, event.Name, event.Action)
+	}
+}
+
+// summarizeDiff compares the JSON representations of two specs and returns one line per field added (+), removed (-) or
+// modified (~).
+func summarizeDiff(previous interface{}, current interface{}) ([]string, error) {
+	previousObject, err := toJSONObject(previous)
+	if err != nil {
+		return nil, err
+	}
+	currentObject, err := toJSONObject(current)
+	if err != nil {
+		return nil, err
+	}
+	return compareObjects("", previousObject, currentObject, diffDepth), nil
+}
+
+func compareObjects(prefix string, previous map[string]interface{}, current map[string]interface{}, depth int) []string {
+	keys := make([]string, 0, len(previous)+len(current))
+	for key := range previous {
+		keys = append(keys, key)
+	}
+	for key := range current {
+		if _, ok := previous[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var result []string
+	for _, key := range keys {
+		path := prefix + key
+		previousValue, inPrevious := previous[key]
+		currentValue, inCurrent := current[key]
+		switch {
+		case !inPrevious:
+			result = append(result, "+ "+path)
+		case !inCurrent:
+			result = append(result, "- "+path)
+		case reflect.DeepEqual(previousValue, currentValue):
+			continue
+		default:
+			previousObject, isPreviousObject := previousValue.(map[string]interface{})
+			currentObject, isCurrentObject := currentValue.(map[string]interface{})
+			if isPreviousObject && isCurrentObject && depth > 1 {
+				result = append(result, compareObjects(path+".", previousObject, currentObject, depth-1)...)
+			} else {
+				result = append(result, "~ "+path)
+			}
+		}
+	}
+	return result
+}
+
+func toJSONObject(value interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]interface{})
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/perses/perses/internal/api/authorization"
+	clientConfig "github.com/perses/perses/pkg/client/config"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/config"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type disabledAuthorization struct {
+	authorization.Authorization
+}
+
+func (a *disabledAuthorization) IsEnabled() bool {
+	return false
+}
+
+func TestSummarizeDiff(t *testing.T) {
+	previous := map[string]interface{}{
+		"display":  map[string]interface{}{"name": "Overview"},
+		"duration": "1h",
+		"panels": map[string]interface{}{
+			"cpu":    map[string]interface{}{"kind": "Panel", "title": "CPU"},
+			"memory": map[string]interface{}{"kind": "Panel"},
+		},
+		"variables": []interface{}{"job"},
+	}
+	current := map[string]interface{}{
+		"display": map[string]interface{}{"name": "Overview"},
+		"panels": map[string]interface{}{
+			"cpu":  map[string]interface{}{"kind": "Panel", "title": "CPU usage"},
+			"disk": map[string]interface{}{"kind": "Panel"},
+		},
+		"variables":       []interface{}{"job", "instance"},
+		"refreshInterval": "30s",
+	}
+	diff, err := summarizeDiff(previous, current)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"- duration",
+		"~ panels.cpu",
+		"+ panels.disk",
+		"- panels.memory",
+		"+ refreshInterval",
+		"~ variables",
+	}, diff)
+}
+
+func TestNotify(t *testing.T) {
+	events := make(chan api.WebhookEvent, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("X-Token"))
+		var event api.WebhookEvent
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		events <- event
+	}))
+	defer server.Close()
+
+	url, err := common.ParseURL(server.URL)
+	require.NoError(t, err)
+	w, err := New([]config.Webhook{{
+		Name:     "audit",
+		Endpoint: &clientConfig.RestConfigClient{URL: url, Headers: map[string]string{"X-Token": "token"}},
+		Timeout:  common.Duration(time.Second),
+	}}, &disabledAuthorization{})
+	require.NoError(t, err)
+
+	w.Deleted(nil, v1.KindDashboard, "perses", "overview")
+	select {
+	case event := <-events:
+		assert.Equal(t, "Dashboard", event.Kind)
+		assert.Equal(t, "perses", event.Project)
+		assert.Equal(t, "overview", event.Name)
+		assert.Equal(t, api.WebhookActionDeleted, event.Action)
+		assert.Empty(t, event.Actor)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the webhook has not been notified")
+	}
+}
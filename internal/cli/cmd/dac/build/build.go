@@ -14,19 +14,26 @@
 package build
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	persesCMD "github.com/perses/perses/internal/cli/cmd"
 	"github.com/perses/perses/internal/cli/config"
+	"github.com/perses/perses/internal/cli/file"
 	"github.com/perses/perses/internal/cli/opt"
 	"github.com/perses/perses/internal/cli/output"
+	"github.com/perses/perses/pkg/client/api"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/v1/common"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -45,10 +52,16 @@ type option struct {
 	opt.FileOption
 	opt.DirectoryOption
 	opt.OutputOption
-	writer    io.Writer
-	errWriter io.Writer
-	args      []string
-	Mode      string
+	writer             io.Writer
+	errWriter          io.Writer
+	args               []string
+	Mode               string
+	watch              bool
+	watchInterval      time.Duration
+	previewProject     string
+	previewTTL         common.Duration
+	previewTTLAsString string
+	apiClient          api.ClientInterface
 }
 
 func (o *option) Complete(args []string) error {
@@ -56,6 +69,22 @@ func (o *option) Complete(args []string) error {
 	if outputErr := o.OutputOption.Complete(); outputErr != nil {
 		return outputErr
 	}
+	if len(o.previewProject) == 0 {
+		return nil
+	}
+	if !o.watch {
+		return fmt.Errorf("the flag --preview-project can only be used with the flag --watch")
+	}
+	ttl, err := common.ParseDuration(o.previewTTLAsString)
+	if err != nil {
+		return err
+	}
+	o.previewTTL = ttl
+	apiClient, err := config.Global.GetAPIClient()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
 	return nil
 }
 
@@ -63,6 +92,9 @@ func (o *option) Validate() error {
 	if o.Mode != modeFile && o.Mode != modeStdout {
 		return fmt.Errorf("invalid mode provided: must be either `file` or `stdout`")
 	}
+	if o.watch && o.watchInterval <= 0 {
+		return fmt.Errorf("invalid watch interval provided: must be greater than 0")
+	}
 
 	if o.File != "" {
 		return o.FileOption.Validate()
@@ -71,13 +103,93 @@ func (o *option) Validate() error {
 }
 
 func (o *option) Execute() error {
+	if o.watch {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		return o.watchSources(ctx)
+	}
+	_, err := o.buildSources()
+	return err
+}
+
+// buildSources builds the given DaC file or every DaC file of the given directory.
+// It returns the output of each file built, indexed by the path of the file.
+func (o *option) buildSources() (map[string][]byte, error) {
 	if o.File != "" {
-		return o.processFile(o.File, filepath.Ext(o.File))
+		cmdOutput, err := o.processFile(o.File, filepath.Ext(o.File))
+		if err != nil {
+			return nil, err
+		}
+		return map[string][]byte{o.File: cmdOutput}, nil
+	}
+
+	files, err := SourceFiles(o.Directory)
+	if err != nil {
+		return nil, fmt.Errorf("error processing directory %q: %v", o.Directory, err)
 	}
 
+	outputs := make(map[string][]byte, len(files))
 	var errs []error
-	// Else it's a directory, thus walk it and process each file
-	err := filepath.Walk(o.Directory, func(path string, info os.FileInfo, err error) error {
+	for _, path := range files {
+		cmdOutput, processErr := o.processFile(path, filepath.Ext(path))
+		if processErr != nil {
+			// Append the error to highlight the issue to the user on a later stage but don't stop the processing
+			errs = append(errs, fmt.Errorf("error processing file %q: %w", path, processErr))
+			continue
+		}
+		outputs[path] = cmdOutput
+	}
+
+	if len(errs) > 0 {
+		_, _ = fmt.Fprintln(o.errWriter, "  FAILED:")
+		for _, e := range errs {
+			_, _ = fmt.Fprintln(o.errWriter, e.Error())
+		}
+		return nil, fmt.Errorf("processing directory %q failed, see the message(s) above", o.Directory)
+	}
+
+	return outputs, nil
+}
+
+// processFile builds the given file and returns the command output. The output is nil when the file has been skipped.
+func (o *option) processFile(file string, extension string) ([]byte, error) {
+	if extension != goExtension && extension != cueExtension {
+		return nil, output.HandleString(o.writer, fmt.Sprintf("skipping %q because it is neither a `cue` or `go` file", file))
+	}
+
+	cmdOutput, err := Run(file, o.Output, o.args)
+	if err != nil {
+		return nil, err
+	}
+
+	// If mode = stdout, print the command result on the standard output & don't go further
+	if o.Mode == modeStdout {
+		return cmdOutput, output.HandleString(o.writer, string(cmdOutput))
+	}
+
+	// Otherwise, create an output file under the output directory:
+
+	// Create the folder (+ any parent folder if applicable) where to store the output
+	err = os.MkdirAll(filepath.Join(config.Global.Dac.OutputFolder, filepath.Dir(file)), 0750)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the output folder: %v", err)
+	}
+
+	// Build the path of the file where to store the command output
+	outputFilePath := o.buildOutputFilePath(file)
+
+	// Write the output to the file
+	if writeErr := os.WriteFile(outputFilePath, cmdOutput, 0644); writeErr != nil { // nolint: gosec
+		return nil, fmt.Errorf("error writing to %s: %v", outputFilePath, writeErr)
+	}
+	return cmdOutput, output.HandleString(o.writer, fmt.Sprintf("Succesfully built %s at %s", file, outputFilePath))
+}
+
+// SourceFiles returns the DaC files (Go or CUE) of the directory and of its sub-directories, except the folders that
+// cannot contain a DaC file (e.g. .git or the output folder).
+func SourceFiles(directory string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(directory, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -92,54 +204,65 @@ func (o *option) Execute() error {
 
 		extension := filepath.Ext(path)
 		if extension == goExtension || extension == cueExtension {
-			err = o.processFile(path, extension)
-			if err != nil {
-				// Append the error to highlight the issue to the user on a later stage but don't stop the processing
-				errs = append(errs, fmt.Errorf("error processing file %q: %w", path, err))
-			}
+			files = append(files, path)
 		} else {
 			logrus.Debugf("file %q has been ignored", path)
 		}
 
 		return nil
 	})
+	return files, err
+}
 
-	if err != nil {
-		return fmt.Errorf("error processing directory %q: %v", o.Directory, err)
+// Entities builds the given DaC file, or every DaC file of the given directory, and returns the resulting resources.
+// Nothing is written in the output folder.
+func Entities(dacFile string, directory string) ([]modelAPI.Entity, error) {
+	sources := []string{dacFile}
+	if len(dacFile) == 0 {
+		var err error
+		if sources, err = SourceFiles(directory); err != nil {
+			return nil, fmt.Errorf("error processing directory %q: %v", directory, err)
+		}
 	}
-
-	if len(errs) > 0 {
-		_, _ = fmt.Fprintln(o.errWriter, "  FAILED:")
-		for _, e := range errs {
-			_, _ = fmt.Fprintln(o.errWriter, e.Error())
+	var entities []modelAPI.Entity
+	for _, source := range sources {
+		data, err := Run(source, output.JSONOutput, nil)
+		if err != nil {
+			return nil, err
+		}
+		built, err := file.UnmarshalEntitiesFromData(data, source)
+		if err != nil {
+			return nil, err
 		}
-		return fmt.Errorf("processing directory %q failed, see the message(s) above", o.Directory)
+		entities = append(entities, built...)
 	}
-
-	return nil
+	return entities, nil
 }
 
-func (o *option) processFile(file string, extension string) error {
+// Run runs the given DaC file and returns the resulting resource(s) in the given output format (yaml or json).
+// The args are given to the Go programs only.
+func Run(file string, outputFormat string, args []string) ([]byte, error) {
 	var cmd *exec.Cmd
 
-	if extension == goExtension { //nolint: staticcheck
+	switch filepath.Ext(file) {
+	case goExtension:
 		// The command `go run` must be executed in the directory where the file is located.
 		// That's because, go is searching the go.mod file, first in the current directory, then in the parent directories.
 		// So when using multiple go submodules, to ensure it is the correct go.mod considered,
 		// we must be in the closest directory to the file.
 		folder := filepath.Dir(file)
 		extractedFile := filepath.Base(file)
-		cmd = exec.Command("go", "run", extractedFile, "--output", o.Output, strings.Join(o.args, " ")) // #nosec
+		cmd = exec.Command("go", "run", extractedFile, "--output", outputFormat, strings.Join(args, " ")) // #nosec
 		cmd.Dir = folder
-	} else if extension == cueExtension {
+	case cueExtension:
 		// NB: most of the work of the `build` command is actually made by the `eval` command of the cue CLI.
 		// NB2: Since cue is written in Go, we could consider relying on its code instead of going the exec way.
 		//      However, the cue code is (for now at least) not well packaged for such an external reuse.
 		//      See https://github.com/cue-lang/cue/blob/master/cmd/cue/cmd/eval.go#L87
 		// NB3: #nosec is needed here even if the user-fed parts of the command are sanitized upstream
-		cmd = exec.Command("cue", "eval", file, "--out", o.Output, "--concrete") // #nosec
-	} else {
-		return output.HandleString(o.writer, fmt.Sprintf("skipping %q because it is neither a `cue` or `go` file", file))
+		cmd = exec.Command("cue", "eval", file, "--out", outputFormat, "--concrete") // #nosec
+	default:
+		return nil, fmt.Errorf("%q is neither a `cue` or `go` file", file)
 	}
 
 	// Capture the output of the command
@@ -147,32 +270,11 @@ func (o *option) processFile(file string, extension string) error {
 	if err != nil {
 		var exitErr *exec.ExitError
 		if errors.As(err, &exitErr) {
-			return fmt.Errorf("failed to build %s: %s", file, string(exitErr.Stderr))
+			return nil, fmt.Errorf("failed to build %s: %s", file, string(exitErr.Stderr))
 		}
-		return err
-	}
-
-	// If mode = stdout, print the command result on the standard output & don't go further
-	if o.Mode == modeStdout {
-		return output.HandleString(o.writer, string(cmdOutput))
-	}
-
-	// Otherwise, create an output file under the output directory:
-
-	// Create the folder (+ any parent folder if applicable) where to store the output
-	err = os.MkdirAll(filepath.Join(config.Global.Dac.OutputFolder, filepath.Dir(file)), 0750)
-	if err != nil {
-		return fmt.Errorf("error creating the output folder: %v", err)
+		return nil, err
 	}
-
-	// Build the path of the file where to store the command output
-	outputFilePath := o.buildOutputFilePath(file)
-
-	// Write the output to the file
-	if writeErr := os.WriteFile(outputFilePath, cmdOutput, 0644); writeErr != nil { // nolint: gosec
-		return fmt.Errorf("error writing to %s: %v", outputFilePath, writeErr)
-	}
-	return output.HandleString(o.writer, fmt.Sprintf("Succesfully built %s at %s", file, outputFilePath))
+	return cmdOutput, nil
 }
 
 // buildOutputFilePath generates the output file path based on the input file path
@@ -207,6 +309,9 @@ For Go, file must comply with "go run": the file package must be main and a main
 
 NB: "percli dac build -f my_dashboard.cue -m stdout" is basically doing the same as "cue eval my_dashboard.cue", however be aware that "percli dac build -d mydir -m stdout" is not equivalent to "cue eval mydir": in the case of percli each CUE file encountered in the directory is evaluated independently.
 And "percli dac build -f main.go -m stdout" is basically doing the same as "go run main.go"
+
+With the flag --watch, the command keeps running and rebuilds the DaC file(s) each time a Go or CUE file changes in the watched directory (or in the directory of the given file), until it is interrupted (Ctrl+C).
+A failed build is reported without stopping the watch. When the flag --preview-project is also set, the dashboards built are applied as ephemeral dashboards in the given project after each successful build, so the result can be checked right away in the UI.
 `,
 		Example: `
 # build a given file
@@ -226,6 +331,12 @@ percli dac build -d my_dashboards && percli apply -d built
 
 # build a given file as JSON providing extra arguments to the Go program (This is only applicable for Go files)
 percli dac build -f main.go -ojson -- --arg1=value1 --arg2=value2
+
+# rebuild the files under a given directory each time one of them changes
+percli dac build -d my_dashboards --watch
+
+# rebuild a given file on change & refresh its preview in the project "dev" each time
+percli dac build -f main.go --watch --preview-project dev --preview-ttl 2h
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return persesCMD.Run(o, cmd, args)
@@ -236,6 +347,10 @@ percli dac build -f main.go -ojson -- --arg1=value1 --arg2=value2
 	opt.AddDirectoryFlags(cmd, &o.DirectoryOption)
 	opt.AddOutputFlags(cmd, &o.OutputOption)
 	opt.MarkFileAndDirFlagsAsXOR(cmd)
+	cmd.Flags().BoolVarP(&o.watch, "watch", "w", false, "Watch the DaC file(s) and rebuild them on every change, until the command is interrupted.")
+	cmd.Flags().DurationVar(&o.watchInterval, "watch-interval", time.Second, "How often the DaC file(s) are checked for changes when --watch is set.")
+	cmd.Flags().StringVar(&o.previewProject, "preview-project", "", "If provided with --watch, the dashboards built are applied as ephemeral dashboards in this project after each successful build.")
+	cmd.Flags().StringVar(&o.previewTTLAsString, "preview-ttl", "1d", "Time To Live of the ephemeral dashboards created with --preview-project.")
 
 	return cmd
 }
@@ -16,9 +16,11 @@ package build
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/perses/perses/internal/cli/config"
 	cmdTest "github.com/perses/perses/internal/cli/test"
@@ -62,6 +64,18 @@ func TestDacBuildCMD(t *testing.T) {
 			IsErrorExpected: true,
 			ExpectedMessage: "if any flags in the group [file directory] are set none of the others can be; [directory file] were all set",
 		},
+		{
+			Title:           "preview project set without watch",
+			Args:            []string{"-f", "testdata/go/main.go", "--preview-project", "dev"},
+			IsErrorExpected: true,
+			ExpectedMessage: "the flag --preview-project can only be used with the flag --watch",
+		},
+		{
+			Title:           "invalid watch interval",
+			Args:            []string{"-f", "testdata/go/main.go", "--watch", "--watch-interval", "0s"},
+			IsErrorExpected: true,
+			ExpectedMessage: "invalid watch interval provided: must be greater than 0",
+		},
 		{
 			Title:           "nominal case with a single Go file",
 			Args:            []string{"-f", "testdata/go/main.go"},
@@ -124,3 +138,38 @@ func TestDacBuildCMD(t *testing.T) {
 	}
 	cmdTest.ExecuteSuiteTest(t, NewCMD, testSuiteCUE)
 }
+
+func TestSnapshotSources(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(source, []byte("package main\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	// Not a DaC file, so it must not be watched
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("readme"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	before, err := snapshotSources(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(before) != 1 {
+		t.Fatalf("expected 1 file watched, got %d", len(before))
+	}
+
+	if err = os.WriteFile(source, []byte("package main\n\nfunc main() {}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err = os.Chtimes(source, later, later); err != nil {
+		t.Fatal(err)
+	}
+	after, err := snapshotSources(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after[source] == before[source] {
+		t.Fatalf("expected the modification of %q to be detected", source)
+	}
+}
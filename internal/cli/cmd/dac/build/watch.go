@@ -0,0 +1,154 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/perses/perses/internal/api/utils"
+	"github.com/perses/perses/internal/cli/file"
+	"github.com/perses/perses/internal/cli/output"
+	"github.com/perses/perses/internal/cli/service"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/sirupsen/logrus"
+)
+
+// fileState is what is used to detect that a source file has been modified.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// watchedDirectory returns the directory to watch. When a single file is built, its whole directory is watched, since
+// the file usually depends on other files next to it (Go package, CUE imports, etc.).
+func (o *option) watchedDirectory() string {
+	if o.File != "" {
+		return filepath.Dir(o.File)
+	}
+	return o.Directory
+}
+
+// snapshotSources returns the state of every DaC file of the given directory.
+func snapshotSources(directory string) (map[string]fileState, error) {
+	files, err := SourceFiles(directory)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := make(map[string]fileState, len(files))
+	for _, path := range files {
+		info, statErr := os.Stat(path)
+		if statErr != nil {
+			// The file may have been removed in the meantime, it will be considered as such.
+			continue
+		}
+		snapshot[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+	}
+	return snapshot, nil
+}
+
+// watchSources builds the DaC file(s), then rebuilds them each time a source file is added, modified or removed,
+// until the context is done. A failed build is reported but doesn't stop the watch.
+func (o *option) watchSources(ctx context.Context) error {
+	directory := o.watchedDirectory()
+	snapshot, err := snapshotSources(directory)
+	if err != nil {
+		return fmt.Errorf("error watching directory %q: %v", directory, err)
+	}
+	o.rebuild()
+	logrus.Infof("watching %q for changes, press Ctrl+C to stop", directory)
+
+	ticker := time.NewTicker(o.watchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			newSnapshot, snapshotErr := snapshotSources(directory)
+			if snapshotErr != nil {
+				logrus.WithError(snapshotErr).Errorf("unable to check the files of the directory %q", directory)
+				continue
+			}
+			if maps.Equal(snapshot, newSnapshot) {
+				continue
+			}
+			snapshot = newSnapshot
+			o.rebuild()
+		}
+	}
+}
+
+// rebuild builds the DaC file(s) and refreshes the previews when a preview project is set.
+// Errors are printed on the error output, so they don't interrupt the watch.
+func (o *option) rebuild() {
+	outputs, err := o.buildSources()
+	if err != nil {
+		_, _ = fmt.Fprintln(o.errWriter, err.Error())
+		return
+	}
+	if len(o.previewProject) == 0 {
+		return
+	}
+	if previewErr := o.preview(outputs); previewErr != nil {
+		_, _ = fmt.Fprintln(o.errWriter, previewErr.Error())
+	}
+}
+
+// preview applies the dashboards built as ephemeral dashboards in the preview project.
+func (o *option) preview(outputs map[string][]byte) error {
+	svc, err := service.New(modelV1.KindEphemeralDashboard, o.previewProject, o.apiClient)
+	if err != nil {
+		return err
+	}
+	for source, data := range outputs {
+		if len(data) == 0 {
+			continue
+		}
+		entities, unmarshalErr := file.UnmarshalEntitiesFromData(data, source)
+		if unmarshalErr != nil {
+			return unmarshalErr
+		}
+		for _, entity := range entities {
+			dashboard, ok := entity.(*modelV1.Dashboard)
+			if !ok {
+				continue
+			}
+			ephemeralDashboard := &modelV1.EphemeralDashboard{
+				Kind: modelV1.KindEphemeralDashboard,
+				Metadata: modelV1.ProjectMetadata{
+					Metadata:               modelV1.Metadata{Name: dashboard.Metadata.Name},
+					ProjectMetadataWrapper: modelV1.ProjectMetadataWrapper{Project: o.previewProject},
+				},
+				Spec: modelV1.EphemeralDashboardSpec{
+					EphemeralDashboardSpecBase: modelV1.EphemeralDashboardSpecBase{TTL: o.previewTTL},
+					DashboardSpec:              dashboard.Spec,
+				},
+			}
+			if upsertErr := service.Upsert(svc, ephemeralDashboard); upsertErr != nil {
+				return upsertErr
+			}
+			previewURL := common.NewURL(o.apiClient.RESTClient().BaseURL, utils.PathProject, o.previewProject, utils.PathEphemeralDashboard, dashboard.Metadata.Name)
+			if handleErr := output.HandleString(o.writer, fmt.Sprintf("Preview of %s available at %s", dashboard.Metadata.Name, previewURL.String())); handleErr != nil {
+				return handleErr
+			}
+		}
+	}
+	return nil
+}
@@ -15,6 +15,7 @@ package dac
 
 import (
 	"github.com/perses/perses/internal/cli/cmd/dac/build"
+	"github.com/perses/perses/internal/cli/cmd/dac/decompile"
 	"github.com/perses/perses/internal/cli/cmd/dac/diff"
 	"github.com/perses/perses/internal/cli/cmd/dac/preview"
 	"github.com/perses/perses/internal/cli/cmd/dac/setup"
@@ -34,6 +35,7 @@ func NewCMD() *cobra.Command {
 		Short: "Commands related to Dashboard-as-Code",
 	}
 	cmd.AddCommand(build.NewCMD())
+	cmd.AddCommand(decompile.NewCMD())
 	cmd.AddCommand(diff.NewCMD())
 	cmd.AddCommand(preview.NewCMD())
 	cmd.AddCommand(setup.NewCMD())
@@ -0,0 +1,99 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decompile
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	persesCMD "github.com/perses/perses/internal/cli/cmd"
+	"github.com/perses/perses/internal/cli/file"
+	"github.com/perses/perses/internal/cli/output"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/spf13/cobra"
+)
+
+const goLanguage = "go"
+
+type option struct {
+	persesCMD.Option
+	writer    io.Writer
+	errWriter io.Writer
+	file      string
+	language  string
+	dashboard modelV1.Dashboard
+}
+
+func (o *option) Complete(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("you need to give the file of the dashboard to decompile")
+	}
+	o.file = args[0]
+	o.language = strings.ToLower(o.language)
+	return file.Unmarshal(o.file, &o.dashboard)
+}
+
+func (o *option) Validate() error {
+	if o.language != goLanguage {
+		return fmt.Errorf("language %q is not supported", o.language)
+	}
+	return nil
+}
+
+func (o *option) Execute() error {
+	src, warnings, err := generateGo(&o.dashboard)
+	if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		if warningErr := output.HandleString(o.errWriter, fmt.Sprintf("warning: %s", warning)); warningErr != nil {
+			return warningErr
+		}
+	}
+	_, err = o.writer.Write(src)
+	return err
+}
+
+func (o *option) SetWriter(writer io.Writer) {
+	o.writer = writer
+}
+
+func (o *option) SetErrWriter(errWriter io.Writer) {
+	o.errWriter = errWriter
+}
+
+func NewCMD() *cobra.Command {
+	o := &option{}
+	cmd := &cobra.Command{
+		Use:   "decompile <file>",
+		Short: "Generate the Dashboard-as-Code program reproducing an existing dashboard",
+		Long: `
+Generate the Dashboard-as-Code program that builds the given dashboard (JSON or YAML), e.g. to start coding a dashboard
+built with the UI. The program is printed on the standard output.
+
+The plugins are set with their raw spec: you may replace them with the helpers of the SDK of the plugins.
+What can't be reproduced is reported on the standard error.
+`,
+		Example: `
+# Generate the Go program reproducing a dashboard
+percli dac decompile dashboard.json --lang go > main.go
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return persesCMD.Run(o, cmd, args)
+		},
+	}
+	cmd.Flags().StringVar(&o.language, "lang", goLanguage, "Language of the generated program. Possible value: go.")
+	return cmd
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decompile
+
+import (
+	"os"
+	"testing"
+
+	"github.com/perses/perses/internal/cli/file"
+	cmdTest "github.com/perses/perses/internal/cli/test"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDacDecompileCMD(t *testing.T) {
+	testSuite := []cmdTest.Suite{
+		{
+			Title:           "missing file",
+			Args:            []string{},
+			IsErrorExpected: true,
+			ExpectedMessage: "you need to give the file of the dashboard to decompile",
+		},
+		{
+			Title:           "unsupported language",
+			Args:            []string{"testdata/dashboard.json", "--lang", "cue"},
+			IsErrorExpected: true,
+			ExpectedMessage: `language "cue" is not supported`,
+		},
+	}
+	cmdTest.ExecuteSuiteTest(t, NewCMD, testSuite)
+}
+
+func TestGenerateGo(t *testing.T) {
+	var dashboard modelV1.Dashboard
+	require.NoError(t, file.Unmarshal("testdata/dashboard.json", &dashboard))
+	expected, err := os.ReadFile("testdata/main.go.golden")
+	require.NoError(t, err)
+
+	src, warnings, err := generateGo(&dashboard)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(src))
+	assert.Equal(t, []string{`panel "orphan" skipped: it is not part of any layout`}, warnings)
+}
+
+func TestGoString(t *testing.T) {
+	assert.Equal(t, `"up"`, goString("up"))
+	assert.Equal(t, "`up{job=\"node\"}`", goString(`up{job="node"}`))
+	assert.Equal(t, `"a\"b`+"`"+`c"`, goString("a\"b`c"))
+}
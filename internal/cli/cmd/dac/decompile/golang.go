@@ -0,0 +1,697 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decompile
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+	"github.com/perses/perses/pkg/model/api/v1/variable"
+)
+
+const (
+	panelRefPrefix     = "#/spec/panels/"
+	gridLayoutKind     = "Grid"
+	gridColumns        = 24
+	defaultPanelWidth  = 12
+	defaultPanelHeight = 8
+	defaultDuration    = time.Hour
+)
+
+// goPackages maps the qualifier used in the generated code to the path of the package. The packages are imported only
+// when used.
+var goPackages = map[string]string{
+	"flag":       "flag",
+	"time":       "time",
+	"sdk":        "github.com/perses/perses/go-sdk",
+	"dashboard":  "github.com/perses/perses/go-sdk/dashboard",
+	"datasource": "github.com/perses/perses/go-sdk/datasource",
+	"link":       "github.com/perses/perses/go-sdk/link",
+	"panel":      "github.com/perses/perses/go-sdk/panel",
+	"panelgroup": "github.com/perses/perses/go-sdk/panel-group",
+	"query":      "github.com/perses/perses/go-sdk/query",
+	"transform":  "github.com/perses/perses/go-sdk/transform",
+	"listVar":    "github.com/perses/perses/go-sdk/variable/list-variable",
+	"txtVar":     "github.com/perses/perses/go-sdk/variable/text-variable",
+	"v1":         "github.com/perses/perses/pkg/model/api/v1",
+	"common":     "github.com/perses/perses/pkg/model/api/v1/common",
+	"variable":   "github.com/perses/perses/pkg/model/api/v1/variable",
+}
+
+// aliasedPackages are the packages imported with an alias, as in the examples of the documentation.
+var aliasedPackages = map[string]bool{
+	"listVar": true,
+	"txtVar":  true,
+}
+
+var sortConstants = map[variable.Sort]string{
+	variable.SortNone:                            "SortNone",
+	variable.SortAlphabeticalAsc:                 "SortAlphabeticalAsc",
+	variable.SortAlphabeticalDesc:                "SortAlphabeticalDesc",
+	variable.SortNumericalAsc:                    "SortNumericalAsc",
+	variable.SortNumericalDesc:                   "SortNumericalDesc",
+	variable.SortAlphabeticalCaseInsensitiveAsc:  "SortAlphabeticalCaseInsensitiveAsc",
+	variable.SortAlphabeticalCaseInsensitiveDesc: "SortAlphabeticalCaseInsensitiveDesc",
+}
+
+// goGenerator writes the Go program building a dashboard with the builders of the Go SDK.
+type goGenerator struct {
+	imports map[string]bool
+	// warnings lists what the program doesn't reproduce exactly.
+	warnings []string
+}
+
+// generateGo returns the source of a Go program that builds the given dashboard with the Go SDK, and the warnings about
+// what the program doesn't reproduce exactly.
+func generateGo(d *modelV1.Dashboard) ([]byte, []string, error) {
+	g := &goGenerator{imports: make(map[string]bool)}
+	options, err := g.dashboardOptions(d)
+	if err != nil {
+		return nil, nil, err
+	}
+	body := fmt.Sprintf(`func main() {
+	%s.Parse()
+	exec := %s.NewExec()
+	builder, buildErr := %s
+	exec.BuildDashboard(builder, buildErr)
+}
+`, g.use("flag"), g.use("sdk"), call(g.use("dashboard")+".New", append([]string{goString(d.Metadata.Name)}, options...)...))
+
+	var src strings.Builder
+	src.WriteString("package main\n\n")
+	src.WriteString(g.importBlock())
+	src.WriteString("\n")
+	src.WriteString(body)
+	result, err := format.Source([]byte(src.String()))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to format the generated code: %w", err)
+	}
+	return result, g.warnings, nil
+}
+
+func (g *goGenerator) use(qualifier string) string {
+	g.imports[qualifier] = true
+	return qualifier
+}
+
+func (g *goGenerator) warn(format string, args ...interface{}) {
+	g.warnings = append(g.warnings, fmt.Sprintf(format, args...))
+}
+
+// importBlock returns the imports of the used packages: the standard library first, then the other packages, both sorted
+// by path.
+func (g *goGenerator) importBlock() string {
+	var std, others []string
+	for qualifier := range g.imports {
+		path := goPackages[qualifier]
+		line := strconv.Quote(path)
+		if aliasedPackages[qualifier] {
+			line = qualifier + " " + line
+		}
+		if strings.Contains(path, ".") {
+			others = append(others, line)
+		} else {
+			std = append(std, line)
+		}
+	}
+	byPath := func(lines []string) {
+		sort.Slice(lines, func(i, j int) bool {
+			return importPath(lines[i]) < importPath(lines[j])
+		})
+	}
+	byPath(std)
+	byPath(others)
+	var block strings.Builder
+	block.WriteString("import (\n")
+	for _, line := range std {
+		block.WriteString("\t" + line + "\n")
+	}
+	if len(std) > 0 && len(others) > 0 {
+		block.WriteString("\n")
+	}
+	for _, line := range others {
+		block.WriteString("\t" + line + "\n")
+	}
+	block.WriteString(")\n")
+	return block.String()
+}
+
+func importPath(line string) string {
+	return line[strings.Index(line, `"`):]
+}
+
+func (g *goGenerator) dashboardOptions(d *modelV1.Dashboard) ([]string, error) {
+	db := g.use("dashboard")
+	var options []string
+	if len(d.Metadata.Project) > 0 {
+		options = append(options, call(db+".ProjectName", goString(d.Metadata.Project)))
+	}
+	options = append(options, g.displayOptions(d.Spec.Display)...)
+	if duration := time.Duration(d.Spec.Duration); duration != 0 && duration != defaultDuration {
+		options = append(options, call(db+".Duration", g.goDuration(duration)))
+	}
+	if d.Spec.TimeRange != nil {
+		options = append(options, call(db+".TimeRange", g.goTime(d.Spec.TimeRange.Start), g.goTime(d.Spec.TimeRange.End)))
+	}
+	if d.Spec.RefreshInterval != 0 {
+		options = append(options, call(db+".RefreshInterval", g.goDuration(time.Duration(d.Spec.RefreshInterval))))
+	}
+	if len(d.Spec.RefreshIntervals) > 0 {
+		intervals := make([]string, 0, len(d.Spec.RefreshIntervals))
+		for _, interval := range d.Spec.RefreshIntervals {
+			intervals = append(intervals, g.goDuration(time.Duration(interval)))
+		}
+		options = append(options, call(db+".RefreshIntervals", intervals...))
+	}
+	if len(d.Spec.Timezone) > 0 {
+		options = append(options, call(db+".Timezone", goString(d.Spec.Timezone)))
+	}
+	for _, l := range d.Spec.Links {
+		options = append(options, call(db+".AddLink", append([]string{goString(l.Name), goString(l.URL)}, g.linkOptions(l, false)...)...))
+	}
+	extras, err := g.extraOptions(db+".Extra", d.Spec.Extensions)
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, extras...)
+
+	for _, name := range sortedKeys(d.Spec.Datasources) {
+		dsOptions, dsErr := g.datasourceOptions(d.Spec.Datasources[name])
+		if dsErr != nil {
+			return nil, fmt.Errorf("datasource %q: %w", name, dsErr)
+		}
+		options = append(options, call(db+".AddDatasource", append([]string{goString(name)}, dsOptions...)...))
+	}
+
+	for _, v := range d.Spec.Variables {
+		option, varErr := g.variableOption(v)
+		if varErr != nil {
+			return nil, varErr
+		}
+		options = append(options, option)
+	}
+
+	groups, err := g.panelGroupOptions(d.Spec.Layouts, d.Spec.Panels)
+	if err != nil {
+		return nil, err
+	}
+	return append(options, groups...), nil
+}
+
+// displayOptions sets the display name and the description of the dashboard. dashboard.Name only sets the display name
+// when it is not a valid name, and dashboard.Description only sets the description when it is not a valid name either,
+// so a finalizer sets them otherwise.
+func (g *goGenerator) displayOptions(display *common.Display) []string {
+	if display == nil {
+		return nil
+	}
+	db := g.use("dashboard")
+	var options, finalized []string
+	if len(display.Name) > 0 {
+		if common.ValidateID(display.Name) != nil {
+			options = append(options, call(db+".Name", goString(display.Name)))
+		} else {
+			finalized = append(finalized, fmt.Sprintf("d.Spec.Display.Name = %s", goString(display.Name)))
+		}
+	}
+	if len(display.Description) > 0 {
+		if common.ValidateDescription(display.Description) != nil {
+			options = append(options, call(db+".Description", goString(display.Description)))
+		} else {
+			finalized = append(finalized, fmt.Sprintf("d.Spec.Display.Description = %s", goString(display.Description)))
+		}
+	}
+	if len(finalized) > 0 {
+		options = append(options, fmt.Sprintf(`%s.AddFinalizer(func(d *%s.Dashboard) error {
+	if d.Spec.Display == nil {
+		d.Spec.Display = &%s.Display{}
+	}
+	%s
+	return nil
+})`, db, g.use("v1"), g.use("common"), strings.Join(finalized, "\n")))
+	}
+	return options
+}
+
+func (g *goGenerator) datasourceOptions(spec *modelV1.DatasourceSpec) ([]string, error) {
+	ds := g.use("datasource")
+	var options []string
+	if spec.Default {
+		options = append(options, call(ds+".Default", "true"))
+	}
+	plugin, err := g.goPlugin(spec.Plugin)
+	if err != nil {
+		return nil, err
+	}
+	return append(options, call(ds+".Plugin", plugin)), nil
+}
+
+func (g *goGenerator) variableOption(v dashboard.Variable) (string, error) {
+	// The spec of a variable referring to a project or a global variable is set by the server.
+	if v.Ref != nil {
+		if v.Ref.Resource == modelV1.PluralKindMap[modelV1.KindGlobalVariable] {
+			return call(g.use("dashboard")+".AddGlobalVariableRef", goString(v.Ref.Name)), nil
+		}
+		return call(g.use("dashboard")+".AddVariableRef", goString(v.Ref.Name)), nil
+	}
+	switch spec := v.Spec.(type) {
+	case *dashboard.ListVariableSpec:
+		return g.listVariableOption(spec)
+	case *dashboard.TextVariableSpec:
+		return g.textVariableOption(spec)
+	default:
+		return "", fmt.Errorf("unknown variable spec %+v", v.Spec)
+	}
+}
+
+func (g *goGenerator) listVariableOption(spec *dashboard.ListVariableSpec) (string, error) {
+	lv := g.use("listVar")
+	plugin, err := g.goPlugin(spec.Plugin)
+	if err != nil {
+		return "", fmt.Errorf("variable %q: %w", spec.Name, err)
+	}
+	options := []string{call(lv+".Plugin", plugin)}
+	if spec.Display != nil {
+		if len(spec.Display.Name) > 0 {
+			options = append(options, call(lv+".DisplayName", goString(spec.Display.Name)))
+		}
+		if len(spec.Display.Description) > 0 {
+			options = append(options, call(lv+".Description", goString(spec.Display.Description)))
+		}
+		if spec.Display.Hidden {
+			options = append(options, call(lv+".Hidden", "true"))
+		}
+	}
+	if spec.AllowMultiple {
+		options = append(options, call(lv+".AllowMultiple", "true"))
+	}
+	if spec.AllowAllValue {
+		options = append(options, call(lv+".AllowAllValue", "true"))
+	}
+	if len(spec.CustomAllValue) > 0 {
+		options = append(options, call(lv+".CustomAllValue", goString(spec.CustomAllValue)))
+	}
+	if len(spec.CapturingRegexp) > 0 {
+		options = append(options, call(lv+".CapturingRegexp", goString(spec.CapturingRegexp)))
+	}
+	if spec.Sort != nil {
+		options = append(options, call(lv+".SortingBy", g.goSort(*spec.Sort)))
+	}
+	if spec.Refresh != nil {
+		switch *spec.Refresh {
+		case variable.RefreshOnDashboardLoad:
+			options = append(options, call(lv+".RefreshOnDashboardLoad"))
+		case variable.RefreshOnTimeRangeChange:
+			options = append(options, call(lv+".RefreshOnTimeRangeChange"))
+		}
+	}
+	if spec.DefaultValue != nil {
+		if spec.DefaultValue.SliceValues != nil {
+			values := make([]string, 0, len(spec.DefaultValue.SliceValues))
+			for _, value := range spec.DefaultValue.SliceValues {
+				values = append(values, goString(value))
+			}
+			options = append(options, call(lv+".DefaultValues", values...))
+		} else {
+			options = append(options, call(lv+".DefaultValue", goString(spec.DefaultValue.SingleValue)))
+		}
+	}
+	extras, err := g.extraOptions(lv+".Extra", spec.Extensions)
+	if err != nil {
+		return "", fmt.Errorf("variable %q: %w", spec.Name, err)
+	}
+	options = append(options, extras...)
+	return call(g.use("dashboard")+".AddVariable", goString(spec.Name), call(lv+".List", options...)), nil
+}
+
+func (g *goGenerator) textVariableOption(spec *dashboard.TextVariableSpec) (string, error) {
+	tv := g.use("txtVar")
+	options := []string{goString(spec.Value)}
+	if spec.Constant {
+		options = append(options, call(tv+".Constant", "true"))
+	}
+	if spec.Display != nil {
+		if len(spec.Display.Name) > 0 {
+			options = append(options, call(tv+".DisplayName", goString(spec.Display.Name)))
+		}
+		if len(spec.Display.Description) > 0 {
+			options = append(options, call(tv+".Description", goString(spec.Display.Description)))
+		}
+		if spec.Display.Hidden {
+			options = append(options, call(tv+".Hidden", "true"))
+		}
+	}
+	extras, err := g.extraOptions(tv+".Extra", spec.Extensions)
+	if err != nil {
+		return "", fmt.Errorf("variable %q: %w", spec.Name, err)
+	}
+	options = append(options, extras...)
+	return call(g.use("dashboard")+".AddVariable", goString(spec.Name), call(tv+".Text", options...)), nil
+}
+
+// panelGroupOptions turns each grid layout into a panel group. When the panels are placed like the automatic flow of
+// the Go SDK does, i.e. one after the other with the same size, the size is set for the group. Otherwise, each panel is
+// placed with panelgroup.PanelAt.
+func (g *goGenerator) panelGroupOptions(layouts []dashboard.Layout, panels map[string]*modelV1.Panel) ([]string, error) {
+	pg := g.use("panelgroup")
+	var options []string
+	used := make(map[string]bool)
+	for i, layout := range layouts {
+		var spec dashboard.GridLayoutSpec
+		switch s := layout.Spec.(type) {
+		case dashboard.GridLayoutSpec:
+			spec = s
+		case *dashboard.GridLayoutSpec:
+			spec = *s
+		default:
+			g.warn("layout #%d of kind %q skipped: only the grid layouts are supported", i, layout.Kind)
+			continue
+		}
+		if layout.Kind != gridLayoutKind {
+			g.warn("layout #%d of kind %q skipped: only the grid layouts are supported", i, layout.Kind)
+			continue
+		}
+		title := ""
+		var groupOptions []string
+		if spec.Display != nil {
+			title = spec.Display.Title
+			if spec.Display.Collapse != nil {
+				groupOptions = append(groupOptions, call(pg+".Collapsed", strconv.FormatBool(!spec.Display.Collapse.Open)))
+			}
+		}
+		items := append([]dashboard.GridItem(nil), spec.Items...)
+		sort.SliceStable(items, func(a, b int) bool {
+			if items[a].Y != items[b].Y {
+				return items[a].Y < items[b].Y
+			}
+			return items[a].X < items[b].X
+		})
+		regular := len(items) > 0 && isRegularFlow(items)
+		if regular {
+			if width := items[0].Width; width != defaultPanelWidth {
+				groupOptions = append(groupOptions, call(pg+".PanelWidth", strconv.Itoa(width)))
+			}
+			if height := items[0].Height; height != defaultPanelHeight {
+				groupOptions = append(groupOptions, call(pg+".PanelHeight", strconv.Itoa(height)))
+			}
+		}
+		for _, item := range items {
+			if item.Content == nil {
+				continue
+			}
+			key := strings.TrimPrefix(item.Content.Ref, panelRefPrefix)
+			p, ok := panels[key]
+			if !ok || p == nil {
+				g.warn("panel group %q: the panel %q doesn't exist, it is skipped", title, key)
+				continue
+			}
+			used[key] = true
+			var position []string
+			if !regular {
+				position = []string{fmt.Sprintf("%s.PanelAt(%d, %d, %d, %d)", pg, item.X, item.Y, item.Width, item.Height)}
+			}
+			// The spec of a panel referring to a library panel is set by the server.
+			if name := p.LibraryPanelName(); len(name) > 0 {
+				groupOptions = append(groupOptions, call(pg+".AddPanelRef", append([]string{goString(name)}, position...)...))
+				continue
+			}
+			panelOptions, err := g.panelOptions(p)
+			if err != nil {
+				return nil, fmt.Errorf("panel %q: %w", key, err)
+			}
+			groupOptions = append(groupOptions, call(pg+".AddPanel", append([]string{goString(p.Spec.Display.Name)}, append(position, panelOptions...)...)...))
+		}
+		options = append(options, call(g.use("dashboard")+".AddPanelGroup", append([]string{goString(title)}, groupOptions...)...))
+	}
+	for _, key := range sortedKeys(panels) {
+		if !used[key] {
+			g.warn("panel %q skipped: it is not part of any layout", key)
+		}
+	}
+	return options, nil
+}
+
+// isRegularFlow tells if the items (sorted by position) are placed like the Go SDK places the panels of a group.
+func isRegularFlow(items []dashboard.GridItem) bool {
+	width, height := items[0].Width, items[0].Height
+	for i, item := range items {
+		if item.Width != width || item.Height != height ||
+			item.X != (i*width)%gridColumns || item.Y != (i*width)/gridColumns*height {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *goGenerator) panelOptions(p *modelV1.Panel) ([]string, error) {
+	pn := g.use("panel")
+	var options []string
+	if len(p.Spec.Display.Description) > 0 {
+		options = append(options, call(pn+".Description", goString(p.Spec.Display.Description)))
+	}
+	plugin, err := g.goPlugin(p.Spec.Plugin)
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, call(pn+".Plugin", plugin))
+	for i, q := range p.Spec.Queries {
+		queryPlugin, queryErr := g.goPlugin(q.Spec.Plugin)
+		if queryErr != nil {
+			return nil, fmt.Errorf("query %d: %w", i, queryErr)
+		}
+		queryOptions := []string{call(g.use("query")+".Plugin", queryPlugin)}
+		if len(q.Spec.Datasource) > 0 {
+			queryOptions = append(queryOptions, call(g.use("query")+".Datasource", goString(q.Spec.Datasource)))
+		}
+		options = append(options, call(pn+".AddQuery", queryOptions...))
+	}
+	for _, l := range p.Spec.Links {
+		options = append(options, call(pn+".AddLink", append([]string{goString(l.URL)}, g.linkOptions(l, true)...)...))
+	}
+	if transforms := g.transformOptions(p); len(transforms) > 0 {
+		// One transform per line, none of them being the main argument of the call.
+		options = append(options, pn+".Transform(\n"+strings.Join(transforms, ",\n")+",\n)")
+	}
+	if len(p.Spec.AlertRule) > 0 {
+		options = append(options, call(pn+".AlertRuleRef", goString(p.Spec.AlertRule)))
+	}
+	extras, err := g.extraOptions(pn+".Extra", p.Spec.Extensions)
+	if err != nil {
+		return nil, err
+	}
+	return append(options, extras...), nil
+}
+
+// transformOptions returns the transforms of the panel. The Go SDK has no option for the disabled transforms, so they
+// are skipped.
+func (g *goGenerator) transformOptions(p *modelV1.Panel) []string {
+	var options []string
+	for _, t := range p.Spec.Transforms {
+		switch spec := t.Spec.(type) {
+		case *dashboard.MergeSeriesTransformSpec:
+			if spec.Disabled {
+				g.warn("panel %q: disabled transform %q skipped", p.Spec.Display.Name, t.Kind)
+				continue
+			}
+			options = append(options, call(g.use("transform")+".MergeSeries"))
+		case *dashboard.JoinByLabelTransformSpec:
+			if spec.Disabled {
+				g.warn("panel %q: disabled transform %q skipped", p.Spec.Display.Name, t.Kind)
+				continue
+			}
+			labels := make([]string, 0, len(spec.Labels))
+			for _, label := range spec.Labels {
+				labels = append(labels, goString(label))
+			}
+			options = append(options, call(g.use("transform")+".JoinByLabel", labels...))
+		case *dashboard.CalculateTransformSpec:
+			if spec.Disabled {
+				g.warn("panel %q: disabled transform %q skipped", p.Spec.Display.Name, t.Kind)
+				continue
+			}
+			options = append(options, fmt.Sprintf("%s.Calculate(%s, %s)", g.use("transform"), goString(spec.Name), goString(spec.Expression)))
+		}
+	}
+	return options
+}
+
+// linkOptions returns the options of the link. The name is an option of the panel links only, it is an argument of
+// dashboard.AddLink.
+func (g *goGenerator) linkOptions(l modelV1.Link, withName bool) []string {
+	ln := g.use("link")
+	var options []string
+	if withName && len(l.Name) > 0 {
+		options = append(options, call(ln+".Name", goString(l.Name)))
+	}
+	if len(l.Tooltip) > 0 {
+		options = append(options, call(ln+".Tooltip", goString(l.Tooltip)))
+	}
+	if l.RenderVariables {
+		options = append(options, call(ln+".RenderVariable", "true"))
+	}
+	if l.TargetBlank {
+		options = append(options, call(ln+".TargetBlank", "true"))
+	}
+	return options
+}
+
+func (g *goGenerator) extraOptions(function string, extensions map[string]interface{}) ([]string, error) {
+	var options []string
+	for _, key := range sortedKeys(extensions) {
+		value, err := g.goJSONValue(extensions[key])
+		if err != nil {
+			return nil, fmt.Errorf("extension %q: %w", key, err)
+		}
+		options = append(options, call(function, goString(key), value))
+	}
+	return options, nil
+}
+
+func (g *goGenerator) goPlugin(plugin common.Plugin) (string, error) {
+	fields := []string{"Kind: " + goString(plugin.Kind)}
+	if plugin.Spec != nil {
+		spec, err := g.goJSONValue(plugin.Spec)
+		if err != nil {
+			return "", err
+		}
+		fields = append(fields, "Spec: "+spec)
+	}
+	return composite(g.use("common")+".Plugin", fields), nil
+}
+
+// goJSONValue returns the Go literal of a value, as it is represented in JSON: the objects become
+// map[string]interface{} with sorted keys and the arrays []interface{}.
+func (g *goGenerator) goJSONValue(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	var decoded interface{}
+	if unmarshalErr := json.Unmarshal(data, &decoded); unmarshalErr != nil {
+		return "", unmarshalErr
+	}
+	return goLiteral(decoded), nil
+}
+
+func goLiteral(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "nil"
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return goString(v)
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, goLiteral(item))
+		}
+		return composite("[]interface{}", items)
+	case map[string]interface{}:
+		fields := make([]string, 0, len(v))
+		for _, key := range sortedKeys(v) {
+			fields = append(fields, goString(key)+": "+goLiteral(v[key]))
+		}
+		return composite("map[string]interface{}", fields)
+	default:
+		return fmt.Sprintf("%#v", v)
+	}
+}
+
+// goString returns the Go literal of a string, as a raw string when it contains double quotes (e.g. a PromQL query
+// with matchers) and it can be one.
+func goString(s string) string {
+	if strings.Contains(s, `"`) && !strings.ContainsAny(s, "`\r") && utf8.ValidString(s) {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+func (g *goGenerator) goDuration(d time.Duration) string {
+	if d == 0 {
+		return "0"
+	}
+	t := g.use("time")
+	units := []struct {
+		duration time.Duration
+		name     string
+	}{
+		{time.Hour, "Hour"},
+		{time.Minute, "Minute"},
+		{time.Second, "Second"},
+		{time.Millisecond, "Millisecond"},
+	}
+	for _, unit := range units {
+		if d%unit.duration == 0 {
+			return fmt.Sprintf("%d * %s.%s", d/unit.duration, t, unit.name)
+		}
+	}
+	return fmt.Sprintf("%s.Duration(%d)", t, int64(d))
+}
+
+func (g *goGenerator) goTime(value time.Time) string {
+	t := g.use("time")
+	value = value.UTC()
+	return fmt.Sprintf("%s.Date(%d, %s.%s, %d, %d, %d, %d, %d, %s.UTC)", t, value.Year(), t, value.Month(), value.Day(),
+		value.Hour(), value.Minute(), value.Second(), value.Nanosecond(), t)
+}
+
+func (g *goGenerator) goSort(sort variable.Sort) string {
+	v := g.use("variable")
+	if constant, ok := sortConstants[sort]; ok {
+		return v + "." + constant
+	}
+	return fmt.Sprintf("%s.Sort(%s)", v, goString(string(sort)))
+}
+
+// call returns a function call. Like in the examples of the documentation, the first argument is on the line of the
+// function, unless it spans several lines, and the others on their own lines. gofmt indents them.
+func call(function string, args ...string) string {
+	switch {
+	case len(args) < 2:
+		return function + "(" + strings.Join(args, "") + ")"
+	case strings.Contains(args[0], "\n"):
+		return function + "(\n" + strings.Join(args, ",\n") + ",\n)"
+	default:
+		return function + "(" + args[0] + ",\n" + strings.Join(args[1:], ",\n") + ",\n)"
+	}
+}
+
+// composite returns a composite literal, with an element per line.
+func composite(typ string, elements []string) string {
+	if len(elements) == 0 {
+		return typ + "{}"
+	}
+	return typ + "{\n" + strings.Join(elements, ",\n") + ",\n}"
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/kylelemons/godebug/diff"
 	persesCMD "github.com/perses/perses/internal/cli/cmd"
+	"github.com/perses/perses/internal/cli/cmd/dac/build"
 	"github.com/perses/perses/internal/cli/config"
 	"github.com/perses/perses/internal/cli/file"
 	"github.com/perses/perses/internal/cli/opt"
@@ -30,6 +31,7 @@ import (
 	"github.com/perses/perses/internal/cli/resource"
 	"github.com/perses/perses/pkg/client/api"
 	"github.com/perses/perses/pkg/client/perseshttp"
+	modelAPI "github.com/perses/perses/pkg/model/api"
 	modelV1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -37,13 +39,27 @@ import (
 
 const statusNew = "NEW"
 const statusUpdated = "UPDATED"
+const statusUnchanged = "UNCHANGED"
 const statusError = "ERROR"
 
+const (
+	modeFile   = "file"
+	modeStdout = "stdout"
+)
+
+const (
+	formatText      = "text"
+	formatJSONPatch = "json-patch"
+)
+
 type diffResult struct {
 	Project   string `json:"project" yaml:"project"`
 	Dashboard string `json:"dashboard" yaml:"dashboard"`
 	Status    string `json:"status" yaml:"status"`
-	Diff      string `json:"diff,omitempty" yaml:"diff,omitempty"`
+	// Diff is the path of the diff file in the file mode, or the diff itself in the stdout mode with the text format.
+	Diff string `json:"diff,omitempty" yaml:"diff,omitempty"`
+	// Patch is the JSON patch of the dashboard spec in the stdout mode with the json-patch format.
+	Patch []patchOperation `json:"patch,omitempty" yaml:"patch,omitempty"`
 }
 
 func marshalIndent(dashboard *modelV1.Dashboard) ([]byte, error) {
@@ -72,12 +88,18 @@ type option struct {
 	errWriter  io.Writer
 	apiClient  api.ClientInterface
 	dashboards []*modelV1.Dashboard
+	build      bool
+	mode       string
+	format     string
 }
 
 func (o *option) Complete(args []string) error {
 	if len(args) > 0 {
 		return fmt.Errorf("no args are supported by the command 'dac diff'")
 	}
+	if o.build && len(o.Directory) == 0 && len(o.File) == 0 {
+		return fmt.Errorf("you need to set the flag --directory or --file to build the DaC file(s) to diff")
+	}
 	if len(o.Directory) == 0 && len(o.File) == 0 {
 		o.Directory = config.Global.Dac.OutputFolder
 		if len(o.Directory) == 0 {
@@ -93,64 +115,95 @@ func (o *option) Complete(args []string) error {
 }
 
 func (o *option) Validate() error {
+	if o.mode != modeFile && o.mode != modeStdout {
+		return fmt.Errorf("--mode must be %q or %q", modeFile, modeStdout)
+	}
+	if o.format != formatText && o.format != formatJSONPatch {
+		return fmt.Errorf("--format must be %q or %q", formatText, formatJSONPatch)
+	}
 	return nil
 }
 
 func (o *option) Execute() error {
-	// Create the output folder (+ any parent folder if applicable) where to store the diff files
-	err := os.MkdirAll(config.Global.Dac.OutputFolder, 0750)
-	if err != nil {
-		return fmt.Errorf("error creating the output folder: %v", err)
+	if o.mode == modeFile {
+		// Create the output folder (+ any parent folder if applicable) where to store the diff files
+		err := os.MkdirAll(config.Global.Dac.OutputFolder, 0750)
+		if err != nil {
+			return fmt.Errorf("error creating the output folder: %v", err)
+		}
 	}
 
 	var result []diffResult
 	for _, updatedDashboard := range o.dashboards {
 		project := resource.GetProject(updatedDashboard.GetMetadata(), o.Project)
-		status, filePath := o.processDashboardDiff(updatedDashboard, project)
+		r := o.processDashboardDiff(updatedDashboard, project)
+		r.Dashboard = updatedDashboard.Metadata.Name
+		r.Project = project
+		result = append(result, r)
 
-		result = append(result, diffResult{
-			Dashboard: updatedDashboard.Metadata.Name,
-			Project:   project,
-			Status:    status,
-			Diff:      filePath,
-		})
-
-		if filePath != "" {
-			logrus.Infof("%s successfully generated", filePath)
+		if o.mode == modeFile && r.Diff != "" {
+			logrus.Infof("%s successfully generated", r.Diff)
 		}
 	}
 
 	return output.Handle(o.writer, o.Output, result)
 }
 
-func (o *option) processDashboardDiff(updatedDashboard *modelV1.Dashboard, project string) (string, string) {
+func (o *option) processDashboardDiff(updatedDashboard *modelV1.Dashboard, project string) diffResult {
 	currentDashboard, err := o.apiClient.V1().Dashboard(project).Get(updatedDashboard.Metadata.Name)
 	if err != nil {
 		if errors.Is(err, perseshttp.RequestNotFoundError) {
 			logrus.Infof("No dashboard %s found in project %s, skipping diff generation", updatedDashboard.Metadata.Name, project)
-			return statusNew, ""
+			return diffResult{Status: statusNew}
 		}
 		logrus.WithError(err).Errorf("Unknown error while fetching dashboard %s in project %s", updatedDashboard.Metadata.Name, project)
-		return statusError, ""
+		return diffResult{Status: statusError}
 	}
 
-	diff, err := dashboardDiff(currentDashboard, updatedDashboard)
+	// The patch is computed whatever the format, to tell if the dashboard is unchanged.
+	patch, err := jsonPatch(currentDashboard.Spec, updatedDashboard.Spec)
+	if err == nil && len(patch) == 0 {
+		return diffResult{Status: statusUnchanged}
+	}
+	var content []byte
+	extension := "diff"
+	if err == nil && o.format == formatJSONPatch {
+		extension = "patch.json"
+		content, err = json.MarshalIndent(patch, "", "  ")
+	} else if err == nil {
+		var textDiff string
+		textDiff, err = dashboardDiff(currentDashboard, updatedDashboard)
+		content = []byte(textDiff)
+	}
 	if err != nil {
 		logrus.WithError(err).Warningf("Diff generation failed for dashboard %s in project %s", updatedDashboard.Metadata.Name, project)
-		return statusError, ""
+		return diffResult{Status: statusError}
+	}
+
+	if o.mode == modeStdout {
+		if o.format == formatJSONPatch {
+			return diffResult{Status: statusUpdated, Patch: patch}
+		}
+		return diffResult{Status: statusUpdated, Diff: string(content)}
 	}
 
-	filePath := path.Join(config.Global.Dac.OutputFolder, fmt.Sprintf("%s-%s.diff", project, currentDashboard.Metadata.Name))
-	if err := os.WriteFile(filePath, []byte(diff), 0644); err != nil { // nolint: gosec
+	filePath := path.Join(config.Global.Dac.OutputFolder, fmt.Sprintf("%s-%s.%s", project, currentDashboard.Metadata.Name, extension))
+	if err := os.WriteFile(filePath, content, 0644); err != nil { // nolint: gosec
 		logrus.WithError(err).Warningf("Unable to write the diff file for dashboard %s in project %s", updatedDashboard.Metadata.Name, project)
-		return statusError, ""
+		return diffResult{Status: statusError}
 	}
 
-	return statusUpdated, filePath
+	return diffResult{Status: statusUpdated, Diff: filePath}
 }
 
 func (o *option) setDashboards() error {
-	entities, err := file.UnmarshalEntities(o.File, o.Directory)
+	var entities []modelAPI.Entity
+	var err error
+	if o.build {
+		entities, err = build.Entities(o.File, o.Directory)
+	} else {
+		entities, err = file.UnmarshalEntities(o.File, o.Directory)
+	}
 	if err != nil {
 		return err
 	}
@@ -178,8 +231,14 @@ func NewCMD() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "diff (-f [FILENAME] | -d [DIRECTORY_NAME])",
 		Short: "Generate diff(s) between online dashboard(s) and local one(s)",
+		Long: `Generate the diff between the spec of each local dashboard and the one of the dashboard with the same project and name on the server.
+By default, the diffs are written in the output folder of the 'dac' command. With '--mode stdout', they are printed instead.
+With the flag --build, the given DaC file(s) are built first (see 'percli dac build').`,
 		Example: `
 percli dac diff -d ./build
+
+# build the DaC file and print its diff against the server as a JSON patch
+percli dac diff --build -f main.go -m stdout --format json-patch -ojson
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return persesCMD.Run(o, cmd, args)
@@ -188,5 +247,9 @@ percli dac diff -d ./build
 	opt.AddProjectFlags(cmd, &o.ProjectOption)
 	opt.AddFileFlags(cmd, &o.FileOption)
 	opt.AddDirectoryFlags(cmd, &o.DirectoryOption)
+	opt.AddOutputFlags(cmd, &o.OutputOption)
+	cmd.Flags().BoolVar(&o.build, "build", false, "Build the given DaC file(s) (Go or CUE) before computing the diff(s)")
+	cmd.Flags().StringVarP(&o.mode, "mode", "m", modeFile, "Mode for the diff(s). Must be either `file` to save them in the output folder, or `stdout` to print them.")
+	cmd.Flags().StringVar(&o.format, "format", formatText, "Format of the diff(s). Must be either `text` for a line diff, or `json-patch` for a JSON patch (RFC 6902) of the dashboard spec.")
 	return cmd
 }
@@ -29,6 +29,14 @@ func TestDiffCMD(t *testing.T) {
 			IsErrorExpected: true,
 			ExpectedMessage: "you are not connected to any API",
 		},
+		{
+			Title:           "build without file or directory",
+			Args:            []string{"--build"},
+			APIClient:       fakeapi.New(),
+			Config:          config.Config{Dac: config.Dac{OutputFolder: "./emptybuild"}},
+			IsErrorExpected: true,
+			ExpectedMessage: "you need to set the flag --directory or --file to build the DaC file(s) to diff",
+		},
 		{
 			Title:           "no dashboard",
 			Args:            []string{},
@@ -0,0 +1,115 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// patchOperation is an operation of a JSON patch (RFC 6902).
+type patchOperation struct {
+	Op    string      `json:"op" yaml:"op"`
+	Path  string      `json:"path" yaml:"path"`
+	Value interface{} `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// jsonPatch returns the JSON patch turning the JSON representation of previous into the one of after.
+// The arrays are compared index by index.
+func jsonPatch(previous interface{}, after interface{}) ([]patchOperation, error) {
+	previousValue, err := toJSONValue(previous)
+	if err != nil {
+		return nil, err
+	}
+	afterValue, err := toJSONValue(after)
+	if err != nil {
+		return nil, err
+	}
+	var operations []patchOperation
+	comparePatch("", previousValue, afterValue, &operations)
+	return operations, nil
+}
+
+func toJSONValue(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var result interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func comparePatch(path string, previous interface{}, after interface{}, operations *[]patchOperation) {
+	switch previousValue := previous.(type) {
+	case map[string]interface{}:
+		afterValue, ok := after.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(previousValue)+len(afterValue))
+		for key := range previousValue {
+			keys = append(keys, key)
+		}
+		for key := range afterValue {
+			if _, exist := previousValue[key]; !exist {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			keyPath := path + "/" + escapePointer(key)
+			previousItem, inPrevious := previousValue[key]
+			afterItem, inAfter := afterValue[key]
+			switch {
+			case !inAfter:
+				*operations = append(*operations, patchOperation{Op: "remove", Path: keyPath})
+			case !inPrevious:
+				*operations = append(*operations, patchOperation{Op: "add", Path: keyPath, Value: afterItem})
+			default:
+				comparePatch(keyPath, previousItem, afterItem, operations)
+			}
+		}
+		return
+	case []interface{}:
+		afterValue, ok := after.([]interface{})
+		if !ok {
+			break
+		}
+		for i := 0; i < len(previousValue) && i < len(afterValue); i++ {
+			comparePatch(fmt.Sprintf("%s/%d", path, i), previousValue[i], afterValue[i], operations)
+		}
+		for i := len(previousValue); i < len(afterValue); i++ {
+			*operations = append(*operations, patchOperation{Op: "add", Path: fmt.Sprintf("%s/%d", path, i), Value: afterValue[i]})
+		}
+		// The items are removed from the end, so the indexes of the remaining ones don't change.
+		for i := len(previousValue) - 1; i >= len(afterValue); i-- {
+			*operations = append(*operations, patchOperation{Op: "remove", Path: fmt.Sprintf("%s/%d", path, i)})
+		}
+		return
+	}
+	if !reflect.DeepEqual(previous, after) {
+		*operations = append(*operations, patchOperation{Op: "replace", Path: path, Value: after})
+	}
+}
+
+// escapePointer escapes a key to be used in a JSON pointer (RFC 6901).
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPatch(t *testing.T) {
+	previous := map[string]interface{}{
+		"duration":  "1h",
+		"variables": []interface{}{"a", "b", "c"},
+		"panels":    map[string]interface{}{"cpu": map[string]interface{}{"title": "CPU"}, "a/b": 1},
+	}
+	after := map[string]interface{}{
+		"duration":        "6h",
+		"variables":       []interface{}{"a", "x"},
+		"panels":          map[string]interface{}{"cpu": map[string]interface{}{"title": "CPU usage"}},
+		"refreshInterval": "30s",
+	}
+	patch, err := jsonPatch(previous, after)
+	require.NoError(t, err)
+	assert.Equal(t, []patchOperation{
+		{Op: "replace", Path: "/duration", Value: "6h"},
+		{Op: "remove", Path: "/panels/a~1b"},
+		{Op: "replace", Path: "/panels/cpu/title", Value: "CPU usage"},
+		{Op: "add", Path: "/refreshInterval", Value: "30s"},
+		{Op: "replace", Path: "/variables/1", Value: "x"},
+		{Op: "remove", Path: "/variables/2"},
+	}, patch)
+
+	patch, err = jsonPatch(previous, previous)
+	require.NoError(t, err)
+	assert.Empty(t, patch)
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/perses/perses/internal/api/utils"
 	persesCMD "github.com/perses/perses/internal/cli/cmd"
+	"github.com/perses/perses/internal/cli/cmd/dac/build"
 	"github.com/perses/perses/internal/cli/config"
 	"github.com/perses/perses/internal/cli/file"
 	"github.com/perses/perses/internal/cli/opt"
@@ -30,6 +31,7 @@ import (
 	"github.com/perses/perses/internal/cli/service"
 	"github.com/perses/perses/pkg/client/api"
 	"github.com/perses/perses/pkg/client/perseshttp"
+	modelAPI "github.com/perses/perses/pkg/model/api"
 	modelV1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/perses/pkg/model/api/v1/common"
 	"github.com/sirupsen/logrus"
@@ -70,12 +72,16 @@ type option struct {
 	ttl          common.Duration
 	ttlAsAString string
 	prefix       string
+	build        bool
 }
 
 func (o *option) Complete(args []string) error {
 	if len(args) > 0 {
 		return fmt.Errorf("no args are supported by the command 'dac preview'")
 	}
+	if o.build && len(o.Directory) == 0 && len(o.File) == 0 {
+		return fmt.Errorf("you need to set the flag --directory or --file to build the DaC file(s) to preview")
+	}
 	if len(o.Directory) == 0 && len(o.File) == 0 {
 		o.Directory = config.Global.Dac.OutputFolder
 		if len(o.Directory) == 0 {
@@ -124,7 +130,7 @@ func (o *option) Execute() error {
 }
 
 func (o *option) setDashboards() error {
-	entities, err := file.UnmarshalEntities(o.File, o.Directory)
+	entities, err := o.loadEntities()
 	if err != nil {
 		return err
 	}
@@ -139,6 +145,14 @@ func (o *option) setDashboards() error {
 	return nil
 }
 
+// loadEntities reads the resources already built, or builds the DaC file(s) first when the flag --build is set.
+func (o *option) loadEntities() ([]modelAPI.Entity, error) {
+	if o.build {
+		return build.Entities(o.File, o.Directory)
+	}
+	return file.UnmarshalEntities(o.File, o.Directory)
+}
+
 func (o *option) computeEphemeralDashboardName(dashboardName string) string {
 	var result strings.Builder
 	if len(o.prefix) > 0 {
@@ -186,9 +200,13 @@ func NewCMD() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "preview (-f [FILENAME] | -d [DIRECTORY_NAME])",
 		Short: "Generate preview(s) of dashboard(s)",
-		Long:  "Creates ephemeral dashboard(s) based on the dashboard(s) built locally. As a response it gives the list of the URL for each dashboard preview.",
+		Long: `Creates ephemeral dashboard(s) based on the dashboard(s) built locally. As a response it gives the list of the URL for each dashboard preview.
+With the flag --build, the given DaC file(s) are built first (see 'percli dac build'), so the preview doesn't require a separate build step.`,
 		Example: `
 percli dac preview -d ./build
+
+# build the DaC file and preview the resulting dashboard(s) in one step, e.g. in the CI of a pull request
+percli dac preview --build -f main.go --prefix pr-42 --ttl 2d
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return persesCMD.Run(o, cmd, args)
@@ -200,5 +218,6 @@ percli dac preview -d ./build
 	opt.AddDirectoryFlags(cmd, &o.DirectoryOption)
 	cmd.Flags().StringVar(&o.ttlAsAString, "ttl", "1d", "Time To Live of the dashboard preview")
 	cmd.Flags().StringVar(&o.prefix, "prefix", "", "If provided, it is used to prefix the dashboard preview name")
+	cmd.Flags().BoolVar(&o.build, "build", false, "Build the given DaC file(s) (Go or CUE) before creating the preview(s)")
 	return cmd
 }
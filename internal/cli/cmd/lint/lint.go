@@ -36,12 +36,19 @@ type option struct {
 	persesCMD.Option
 	opt.FileOption
 	opt.DirectoryOption
+	opt.ProjectOption
 	writer         io.Writer
 	errWriter      io.Writer
 	pluginPath     string
 	customRulePath string
 	customRules    []*apiConfig.CustomLintRule
 	online         bool
+	ruleSeverities map[string]string
+	severities     map[string]Severity
+	failOn         string
+	failOnSeverity Severity
+	maxPanels      int
+	rules          []Rule
 	sch            schema.Schema
 	apiClient      api.ClientInterface
 }
@@ -77,11 +84,48 @@ func (o *option) Complete(args []string) error {
 			return err
 		}
 		o.customRules = cfg.Dashboard.CustomLintRules
+		// Without local resources, the dashboards of the project are retrieved from the server.
+		if len(o.File) == 0 && len(o.Directory) == 0 {
+			if projectErr := o.ProjectOption.Complete(); projectErr != nil {
+				return projectErr
+			}
+		}
+	}
+	return o.completeRules()
+}
+
+func (o *option) completeRules() error {
+	o.rules = defaultRules(o.maxPanels)
+	knownRules := make(map[string]bool, len(o.rules))
+	for _, rule := range o.rules {
+		knownRules[rule.Name()] = true
 	}
+	o.severities = make(map[string]Severity, len(o.ruleSeverities))
+	for name, value := range o.ruleSeverities {
+		if !knownRules[name] {
+			return fmt.Errorf("unknown lint rule %q", name)
+		}
+		severity, err := parseSeverity(value)
+		if err != nil {
+			return fmt.Errorf("rule %q: %w", name, err)
+		}
+		o.severities[name] = severity
+	}
+	severity, err := parseSeverity(o.failOn)
+	if err != nil {
+		return fmt.Errorf("invalid value set to the flag --fail-on: %w", err)
+	}
+	o.failOnSeverity = severity
 	return nil
 }
 
 func (o *option) Validate() error {
+	if len(o.File) == 0 && len(o.Directory) == 0 && !o.online {
+		return fmt.Errorf("one of the flags file, directory or online is required")
+	}
+	if len(o.Project) > 0 && (len(o.File) > 0 || len(o.Directory) > 0) {
+		return fmt.Errorf("the flag project can only be used to lint the dashboards of the server")
+	}
 	return nil
 }
 
@@ -99,11 +143,51 @@ func (o *option) Execute() error {
 		if len(errorList) > 0 {
 			return errorList[0]
 		}
+	} else {
+		// The dashboards stored on the server are already valid, only the best practices are checked.
+		dashboards, err := o.apiClient.V1().Dashboard(o.Project).List("")
+		if err != nil {
+			return err
+		}
+		return o.checkBestPractices(dashboards)
 	}
 	if validateErr := o.validate(entities); validateErr != nil {
 		return validateErr
 	}
-	return output.HandleString(o.writer, "your resources look good")
+	var dashboards []*modelV1.Dashboard
+	for _, entity := range entities {
+		if dashboard, ok := entity.(*modelV1.Dashboard); ok {
+			dashboards = append(dashboards, dashboard)
+		}
+	}
+	return o.checkBestPractices(dashboards)
+}
+
+// checkBestPractices runs the lint rules against the dashboards and prints the findings. It fails if a finding has at
+// least the severity set by the flag --fail-on.
+func (o *option) checkBestPractices(dashboards []*modelV1.Dashboard) error {
+	var findings []Finding
+	for _, dashboard := range dashboards {
+		findings = append(findings, checkDashboard(dashboard, o.rules, o.severities)...)
+	}
+	if len(findings) == 0 {
+		return output.HandleString(o.writer, "your resources look good")
+	}
+	failures := 0
+	data := make([][]string, 0, len(findings))
+	for _, finding := range findings {
+		if o.failOnSeverity != SeverityOff && severityLevels[finding.Severity] >= severityLevels[o.failOnSeverity] {
+			failures++
+		}
+		data = append(data, []string{string(finding.Severity), finding.Project, finding.Dashboard, finding.Rule, finding.Message})
+	}
+	if err := output.HandlerTable(o.writer, []string{"SEVERITY", "PROJECT", "DASHBOARD", "RULE", "MESSAGE"}, data); err != nil {
+		return err
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d issue(s) with a severity of at least %s found", failures, o.failOnSeverity)
+	}
+	return nil
 }
 
 func (o *option) SetWriter(writer io.Writer) {
@@ -179,6 +263,15 @@ func NewCMD() *cobra.Command {
 The lint command will check statically that your resources are valid.
 It doesn't necessary mean you won't face any issue when applying them.
 
+The dashboards are also checked against the following best practices:
+  * panel-description: the panels (except the Markdown ones) have a description. Default severity: info.
+  * promql-counter-rate: the counters are queried with rate(), irate() or increase(). Default severity: warning.
+  * max-panels: the dashboard doesn't have more panels than --max-panels. Default severity: warning.
+  * unused-variables: the variables are used by the dashboard. Default severity: warning.
+
+The severity of each rule can be changed with --rule.severity, and a rule is disabled with the severity "off".
+The command fails when an issue has at least the severity set by --fail-on.
+
 JSON and YAML formats are accepted.
 `,
 		Example: `
@@ -190,6 +283,12 @@ cat resources.json | percli lint -f -
 
 # Use a remote server to make additional validation (useful only for the datasources and the dashboards)
 percli lint -f ./resources.json --online
+
+# Fail on the warnings too, and disable the check of the panel descriptions
+percli lint -d ./dashboards --fail-on warning --rule.severity panel-description=off
+
+# Check the best practices on the dashboards of a project stored on the server
+percli lint --online --project my-project
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return persesCMD.Run(o, cmd, args)
@@ -197,10 +296,15 @@ percli lint -f ./resources.json --online
 	}
 	opt.AddFileFlags(cmd, &o.FileOption)
 	opt.AddDirectoryFlags(cmd, &o.DirectoryOption)
-	opt.MarkFileAndDirFlagsAsXOR(cmd)
+	opt.AddProjectFlags(cmd, &o.ProjectOption)
+	// The file and the directory are not required, since the dashboards can be retrieved from the server.
+	cmd.MarkFlagsMutuallyExclusive("file", "directory")
 	cmd.Flags().StringVar(&o.customRulePath, "custom-rule.path", "", "Path to the custom rules.")
 	cmd.Flags().StringVar(&o.pluginPath, "plugin.path", "", "Path to the Perses plugins.")
-	cmd.Flags().BoolVar(&o.online, "online", false, "When enable, it can request the API to make additional validation")
+	cmd.Flags().BoolVar(&o.online, "online", false, "When enable, it can request the API to make additional validation. Without file or directory, the dashboards of the project are retrieved from the server.")
+	cmd.Flags().StringToStringVar(&o.ruleSeverities, "rule.severity", nil, "Severity of the lint rules, e.g. panel-description=off,max-panels=error. The severity is one of off, info, warning or error.")
+	cmd.Flags().StringVar(&o.failOn, "fail-on", string(SeverityError), "Minimal severity of the issues making the command fail: info, warning or error. Use off to never fail.")
+	cmd.Flags().IntVar(&o.maxPanels, "max-panels", 30, "Maximum number of panels in a dashboard, checked by the rule max-panels.")
 	// When "online" flag is used, the CLI will call the endpoint /validate that will then use the schema from the server.
 	// So no need to use / load the plugins with the CLI.
 	cmd.MarkFlagsMutuallyExclusive("plugin.path", "online")
@@ -25,7 +25,7 @@ func TestLintCMD(t *testing.T) {
 			Title:           "empty args",
 			Args:            []string{},
 			IsErrorExpected: true,
-			ExpectedMessage: `at least one of the flags in the group [file directory] is required`,
+			ExpectedMessage: `one of the flags file, directory or online is required`,
 		},
 		{
 			Title:           "use args",
@@ -53,6 +53,31 @@ func TestLintCMD(t *testing.T) {
 			ExpectedMessage: `your resources look good
 `,
 		},
+		{
+			Title:           "lint a dashboard failing on the warnings",
+			Args:            []string{"-f", "../../test/sample_resources/dashboard_lint.json", "--fail-on", "warning"},
+			IsErrorExpected: true,
+			ExpectedMessage: "2 issue(s) with a severity of at least warning found",
+		},
+		{
+			Title:           "lint a dashboard with the rules disabled",
+			Args:            []string{"-f", "../../test/sample_resources/dashboard_lint.json", "--rule.severity", "panel-description=off,promql-counter-rate=off,unused-variables=off"},
+			IsErrorExpected: false,
+			ExpectedMessage: `your resources look good
+`,
+		},
+		{
+			Title:           "lint with an unknown rule",
+			Args:            []string{"-f", "../../test/sample_resources/dashboard_lint.json", "--rule.severity", "panel-title=off"},
+			IsErrorExpected: true,
+			ExpectedMessage: `unknown lint rule "panel-title"`,
+		},
+		{
+			Title:           "lint with an unknown severity",
+			Args:            []string{"-f", "../../test/sample_resources/dashboard_lint.json", "--fail-on", "critical"},
+			IsErrorExpected: true,
+			ExpectedMessage: `invalid value set to the flag --fail-on: unknown severity "critical", it should be one of off, info, warning or error`,
+		},
 	}
 	cmdTest.ExecuteSuiteTest(t, NewCMD, testSuite)
 }
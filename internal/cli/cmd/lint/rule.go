@@ -0,0 +1,260 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+)
+
+type Severity string
+
+const (
+	SeverityOff     Severity = "off"
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+var severityLevels = map[Severity]int{
+	SeverityOff:     0,
+	SeverityInfo:    1,
+	SeverityWarning: 2,
+	SeverityError:   3,
+}
+
+func parseSeverity(value string) (Severity, error) {
+	s := Severity(strings.ToLower(value))
+	if _, ok := severityLevels[s]; !ok {
+		return "", fmt.Errorf("unknown severity %q, it should be one of off, info, warning or error", value)
+	}
+	return s, nil
+}
+
+// Rule is a best practice checked on the dashboards. To add a rule, implement this interface and add it to the list
+// returned by defaultRules.
+type Rule interface {
+	// Name identifies the rule in the flag --rule.severity and in the findings.
+	Name() string
+	// DefaultSeverity is the severity of the findings when the flag --rule.severity doesn't set it.
+	DefaultSeverity() Severity
+	// Check returns a message for each issue found in the dashboard.
+	Check(dashboard *modelV1.Dashboard) []string
+}
+
+// Finding is an issue found by a rule in a dashboard.
+type Finding struct {
+	Rule      string
+	Severity  Severity
+	Project   string
+	Dashboard string
+	Message   string
+}
+
+func defaultRules(maxPanels int) []Rule {
+	return []Rule{
+		&panelDescriptionRule{},
+		&promQLRateRule{},
+		&maxPanelsRule{max: maxPanels},
+		&unusedVariableRule{},
+	}
+}
+
+// checkDashboard runs the rules against the dashboard. The severity of a rule can be overridden by the map severities,
+// and a rule with the severity off is skipped.
+func checkDashboard(dashboard *modelV1.Dashboard, rules []Rule, severities map[string]Severity) []Finding {
+	var findings []Finding
+	for _, rule := range rules {
+		severity, ok := severities[rule.Name()]
+		if !ok {
+			severity = rule.DefaultSeverity()
+		}
+		if severity == SeverityOff {
+			continue
+		}
+		for _, message := range rule.Check(dashboard) {
+			findings = append(findings, Finding{
+				Rule:      rule.Name(),
+				Severity:  severity,
+				Project:   dashboard.Metadata.Project,
+				Dashboard: dashboard.Metadata.Name,
+				Message:   message,
+			})
+		}
+	}
+	return findings
+}
+
+const markdownPanelKind = "Markdown"
+
+// panelDescriptionRule reports the panels without description. The Markdown panels are ignored, since they are their
+// own description.
+type panelDescriptionRule struct{}
+
+func (r *panelDescriptionRule) Name() string {
+	return "panel-description"
+}
+
+func (r *panelDescriptionRule) DefaultSeverity() Severity {
+	return SeverityInfo
+}
+
+func (r *panelDescriptionRule) Check(dashboard *modelV1.Dashboard) []string {
+	var messages []string
+	for _, key := range sortedPanelKeys(dashboard) {
+		p := dashboard.Spec.Panels[key]
+		if p.Spec.Plugin.Kind == markdownPanelKind || len(strings.TrimSpace(p.Spec.Display.Description)) > 0 {
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("panel %q has no description", panelName(key, p)))
+	}
+	return messages
+}
+
+const (
+	promTimeSeriesQueryKind = "PrometheusTimeSeriesQuery"
+	promQuerySpecField      = "query"
+)
+
+var (
+	promStringRegexp = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'|` + "`[^`]*`")
+	// promCounterRegexp matches the metrics following the naming convention of the counters.
+	promCounterRegexp = regexp.MustCompile(`(?:^|[^a-zA-Z0-9_:$])([a-zA-Z_:][a-zA-Z0-9_:]*_total)\b`)
+	promRateRegexp    = regexp.MustCompile(`\b(?:rate|irate|increase|resets)\s*\(`)
+)
+
+// promQLRateRule reports the Prometheus queries using a counter without rate(), irate() or increase(): the raw value of a
+// counter grows without bound and is rarely meaningful.
+type promQLRateRule struct{}
+
+func (r *promQLRateRule) Name() string {
+	return "promql-counter-rate"
+}
+
+func (r *promQLRateRule) DefaultSeverity() Severity {
+	return SeverityWarning
+}
+
+func (r *promQLRateRule) Check(dashboard *modelV1.Dashboard) []string {
+	var messages []string
+	for _, key := range sortedPanelKeys(dashboard) {
+		p := dashboard.Spec.Panels[key]
+		for i, q := range p.Spec.Queries {
+			if q.Spec.Plugin.Kind != promTimeSeriesQueryKind {
+				continue
+			}
+			spec, err := decodePluginSpec(q.Spec.Plugin)
+			if err != nil {
+				continue
+			}
+			expr, _ := spec[promQuerySpecField].(string)
+			expr = promStringRegexp.ReplaceAllString(expr, `""`)
+			match := promCounterRegexp.FindStringSubmatch(expr)
+			if match == nil || promRateRegexp.MatchString(expr) {
+				continue
+			}
+			messages = append(messages, fmt.Sprintf("panel %q, query %d: the counter %s is used without rate(), irate() or increase()", panelName(key, p), i, match[1]))
+		}
+	}
+	return messages
+}
+
+// maxPanelsRule reports the dashboards with too many panels, which are slow to load and hard to read.
+type maxPanelsRule struct {
+	max int
+}
+
+func (r *maxPanelsRule) Name() string {
+	return "max-panels"
+}
+
+func (r *maxPanelsRule) DefaultSeverity() Severity {
+	return SeverityWarning
+}
+
+func (r *maxPanelsRule) Check(dashboard *modelV1.Dashboard) []string {
+	if r.max <= 0 || len(dashboard.Spec.Panels) <= r.max {
+		return nil
+	}
+	return []string{fmt.Sprintf("the dashboard has %d panels, more than the maximum of %d", len(dashboard.Spec.Panels), r.max)}
+}
+
+// unusedVariableRule reports the variables used neither by the panels, the layouts, the links, the datasources nor the
+// other variables.
+type unusedVariableRule struct{}
+
+func (r *unusedVariableRule) Name() string {
+	return "unused-variables"
+}
+
+func (r *unusedVariableRule) DefaultSeverity() Severity {
+	return SeverityWarning
+}
+
+func (r *unusedVariableRule) Check(dashboard *modelV1.Dashboard) []string {
+	var messages []string
+	for i, v := range dashboard.Spec.Variables {
+		name := v.Spec.GetName()
+		// Everything but the variable itself can use it.
+		spec := dashboard.Spec
+		spec.Variables = append(spec.Variables[:i:i], spec.Variables[i+1:]...)
+		data, err := json.Marshal(spec)
+		if err != nil {
+			continue
+		}
+		usage := regexp.MustCompile(`\$\{?` + regexp.QuoteMeta(name) + `\b`)
+		if !usage.Match(data) {
+			messages = append(messages, fmt.Sprintf("variable %q is not used", name))
+		}
+	}
+	return messages
+}
+
+func sortedPanelKeys(dashboard *modelV1.Dashboard) []string {
+	keys := make([]string, 0, len(dashboard.Spec.Panels))
+	for key := range dashboard.Spec.Panels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func panelName(key string, p *modelV1.Panel) string {
+	if len(p.Spec.Display.Name) > 0 {
+		return p.Spec.Display.Name
+	}
+	return key
+}
+
+// decodePluginSpec returns the spec of the plugin as a generic map, whatever the type used to unmarshal it.
+func decodePluginSpec(plugin common.Plugin) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	if plugin.Spec == nil {
+		return result, nil
+	}
+	data, err := json.Marshal(plugin.Spec)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
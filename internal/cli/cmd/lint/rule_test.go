@@ -0,0 +1,79 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"testing"
+
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+	"github.com/perses/perses/pkg/model/api/v1/variable"
+	"github.com/stretchr/testify/assert"
+)
+
+func promPanel(name string, description string, query string) *modelV1.Panel {
+	return &modelV1.Panel{
+		Kind: "Panel",
+		Spec: modelV1.PanelSpec{
+			Display: modelV1.PanelDisplay{Name: name, Description: description},
+			Plugin:  common.Plugin{Kind: "TimeSeriesChart"},
+			Queries: []modelV1.Query{{
+				Kind: "TimeSeriesQuery",
+				Spec: modelV1.QuerySpec{Plugin: common.Plugin{
+					Kind: "PrometheusTimeSeriesQuery",
+					Spec: map[string]interface{}{"query": query},
+				}},
+			}},
+		},
+	}
+}
+
+func textVariable(name string) dashboard.Variable {
+	return dashboard.Variable{
+		Kind: "TextVariable",
+		Spec: &dashboard.TextVariableSpec{TextSpec: variable.TextSpec{Value: "value"}, Name: name},
+	}
+}
+
+func TestCheckDashboard(t *testing.T) {
+	d := &modelV1.Dashboard{
+		Kind:     modelV1.KindDashboard,
+		Metadata: modelV1.ProjectMetadata{Metadata: modelV1.Metadata{Name: "node"}, ProjectMetadataWrapper: modelV1.ProjectMetadataWrapper{Project: "perses"}},
+		Spec: modelV1.DashboardSpec{
+			Variables: []dashboard.Variable{textVariable("instance"), textVariable("namespace"), textVariable("name")},
+			Panels: map[string]*modelV1.Panel{
+				"cpu":      promPanel("CPU", "CPU usage", `sum(rate(node_cpu_seconds_total{instance="$instance"}[5m]))`),
+				"requests": promPanel("Requests", "", `sum(http_requests_total{namespace="${namespace}", path="/api_total"})`),
+				"notes":    {Kind: "Panel", Spec: modelV1.PanelSpec{Plugin: common.Plugin{Kind: "Markdown"}}},
+			},
+		},
+	}
+	findings := checkDashboard(d, defaultRules(2), nil)
+	assert.Equal(t, []Finding{
+		{Rule: "panel-description", Severity: SeverityInfo, Project: "perses", Dashboard: "node", Message: `panel "Requests" has no description`},
+		{Rule: "promql-counter-rate", Severity: SeverityWarning, Project: "perses", Dashboard: "node", Message: `panel "Requests", query 0: the counter http_requests_total is used without rate(), irate() or increase()`},
+		{Rule: "max-panels", Severity: SeverityWarning, Project: "perses", Dashboard: "node", Message: "the dashboard has 3 panels, more than the maximum of 2"},
+		{Rule: "unused-variables", Severity: SeverityWarning, Project: "perses", Dashboard: "node", Message: `variable "name" is not used`},
+	}, findings)
+
+	findings = checkDashboard(d, defaultRules(0), map[string]Severity{
+		"panel-description":   SeverityOff,
+		"promql-counter-rate": SeverityError,
+		"unused-variables":    SeverityOff,
+	})
+	assert.Equal(t, []Finding{
+		{Rule: "promql-counter-rate", Severity: SeverityError, Project: "perses", Dashboard: "node", Message: `panel "Requests", query 0: the counter http_requests_total is used without rate(), irate() or increase()`},
+	}, findings)
+}
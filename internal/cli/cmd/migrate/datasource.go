@@ -0,0 +1,243 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/perses/perses/internal/cli/output"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/datasource/http"
+	"github.com/perses/perses/pkg/model/api/v1/secret"
+)
+
+const (
+	grafanaDirectAccess      = "direct"
+	grafanaHeaderNamePrefix  = "httpHeaderName"
+	grafanaHeaderValuePrefix = "httpHeaderValue"
+	secretNameSuffix         = "-secret"
+)
+
+// grafanaDatasourceKinds maps the Grafana datasource types to the Perses datasource plugins.
+var grafanaDatasourceKinds = map[string]string{
+	"prometheus": "PrometheusDatasource",
+	"loki":       "LokiDatasource",
+	"tempo":      "TempoDatasource",
+}
+
+var invalidNameCharacters = regexp.MustCompile("[^a-zA-Z0-9_.-]+")
+
+// grafanaDatasourceProvisioning is a Grafana datasource provisioning file.
+// See https://grafana.com/docs/grafana/latest/administration/provisioning/#data-sources
+type grafanaDatasourceProvisioning struct {
+	Datasources []grafanaDatasource `json:"datasources" yaml:"datasources"`
+}
+
+type grafanaDatasource struct {
+	Name           string                 `json:"name" yaml:"name"`
+	Type           string                 `json:"type" yaml:"type"`
+	Access         string                 `json:"access" yaml:"access"`
+	URL            string                 `json:"url" yaml:"url"`
+	IsDefault      bool                   `json:"isDefault" yaml:"isDefault"`
+	BasicAuth      bool                   `json:"basicAuth" yaml:"basicAuth"`
+	BasicAuthUser  string                 `json:"basicAuthUser" yaml:"basicAuthUser"`
+	JSONData       map[string]interface{} `json:"jsonData" yaml:"jsonData"`
+	SecureJSONData map[string]string      `json:"secureJsonData" yaml:"secureJsonData"`
+}
+
+func (g grafanaDatasource) jsonString(key string) string {
+	value, _ := g.JSONData[key].(string)
+	return value
+}
+
+func (g grafanaDatasource) jsonBool(key string) bool {
+	value, _ := g.JSONData[key].(bool)
+	return value
+}
+
+// headers returns the custom headers of the datasource, declared by the pairs jsonData.httpHeaderName<N> and
+// secureJsonData.httpHeaderValue<N>.
+func (g grafanaDatasource) headers() map[string]string {
+	result := make(map[string]string)
+	for key := range g.JSONData {
+		if !strings.HasPrefix(key, grafanaHeaderNamePrefix) {
+			continue
+		}
+		index := strings.TrimPrefix(key, grafanaHeaderNamePrefix)
+		if name := g.jsonString(key); len(name) > 0 {
+			result[name] = g.SecureJSONData[grafanaHeaderValuePrefix+index]
+		}
+	}
+	return result
+}
+
+// migrateDatasources converts the datasources of a Grafana provisioning file to Perses datasources, with the secrets
+// holding their credentials. The resources are global when project is empty. The datasources of an unsupported type are
+// skipped with a warning.
+func migrateDatasources(provisioning grafanaDatasourceProvisioning, project string, errWriter io.Writer) ([]modelAPI.Entity, error) {
+	var secrets, datasources []modelAPI.Entity
+	for _, g := range provisioning.Datasources {
+		kind, ok := grafanaDatasourceKinds[g.Type]
+		if !ok {
+			if err := output.HandleString(errWriter, fmt.Sprintf("datasource %q skipped: the type %q is not supported", g.Name, g.Type)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		name := invalidNameCharacters.ReplaceAllString(g.Name, "-")
+		secretSpec, headers := migrateDatasourceSecret(g)
+		pluginSpec, err := migrateDatasourcePluginSpec(g, headers)
+		if err != nil {
+			return nil, fmt.Errorf("datasource %q: %w", g.Name, err)
+		}
+		if secretSpec != nil {
+			secretName := name + secretNameSuffix
+			pluginSpec.setSecret(secretName)
+			secrets = append(secrets, newSecret(project, secretName, *secretSpec))
+		}
+		spec := modelV1.DatasourceSpec{
+			Display: &common.Display{Name: g.Name},
+			Default: g.IsDefault,
+			Plugin:  common.Plugin{Kind: kind, Spec: pluginSpec.toMap()},
+		}
+		datasources = append(datasources, newDatasource(project, name, spec))
+	}
+	// The secrets come first, so the resources can be applied in order.
+	return append(secrets, datasources...), nil
+}
+
+// migrateDatasourceSecret returns the secret holding the credentials of the datasource, or nil if the datasource has
+// none. The Authorization header becomes the authorization of the secret, the other headers are returned.
+func migrateDatasourceSecret(g grafanaDatasource) (*modelV1.SecretSpec, map[string]string) {
+	spec := &modelV1.SecretSpec{}
+	isEmpty := true
+	headers := g.headers()
+	if g.BasicAuth {
+		spec.BasicAuth = &secret.BasicAuth{
+			Username: g.BasicAuthUser,
+			Password: g.SecureJSONData["basicAuthPassword"],
+		}
+		isEmpty = false
+	} else if value, ok := headers["Authorization"]; ok {
+		delete(headers, "Authorization")
+		authType, credentials, found := strings.Cut(value, " ")
+		if !found {
+			authType, credentials = "Bearer", value
+		}
+		spec.Authorization = &secret.Authorization{Type: authType, Credentials: credentials}
+		isEmpty = false
+	}
+	tlsConfig := &secret.TLSConfig{
+		CA:                 g.SecureJSONData["tlsCACert"],
+		Cert:               g.SecureJSONData["tlsClientCert"],
+		Key:                g.SecureJSONData["tlsClientKey"],
+		ServerName:         g.jsonString("serverName"),
+		InsecureSkipVerify: g.jsonBool("tlsSkipVerify"),
+	}
+	if *tlsConfig != (secret.TLSConfig{}) {
+		spec.TLSConfig = tlsConfig
+		isEmpty = false
+	}
+	if isEmpty {
+		return nil, headers
+	}
+	return spec, headers
+}
+
+// datasourcePluginSpec is the spec of the Prometheus, Loki and Tempo datasource plugins.
+type datasourcePluginSpec struct {
+	directURL      string
+	proxy          *http.Proxy
+	scrapeInterval string
+}
+
+func migrateDatasourcePluginSpec(g grafanaDatasource, headers map[string]string) (*datasourcePluginSpec, error) {
+	spec := &datasourcePluginSpec{}
+	if g.Type == "prometheus" {
+		spec.scrapeInterval = g.jsonString("timeInterval")
+	}
+	if g.Access == grafanaDirectAccess {
+		spec.directURL = g.URL
+		return spec, nil
+	}
+	url, err := common.ParseURL(g.URL)
+	if err != nil {
+		return nil, err
+	}
+	spec.proxy = &http.Proxy{
+		Kind: "HTTPProxy",
+		Spec: http.Config{URL: url},
+	}
+	if len(headers) > 0 {
+		spec.proxy.Spec.Headers = headers
+	}
+	return spec, nil
+}
+
+// setSecret sets the secret used to contact the datasource. The secret is only used by the proxy: a datasource accessed
+// directly by the browser cannot use it.
+func (s *datasourcePluginSpec) setSecret(name string) {
+	if s.proxy != nil {
+		s.proxy.Spec.Secret = name
+	}
+}
+
+func (s *datasourcePluginSpec) toMap() map[string]interface{} {
+	result := make(map[string]interface{})
+	if len(s.directURL) > 0 {
+		result["directUrl"] = s.directURL
+	}
+	if s.proxy != nil {
+		result["proxy"] = s.proxy
+	}
+	if len(s.scrapeInterval) > 0 {
+		result["scrapeInterval"] = s.scrapeInterval
+	}
+	return result
+}
+
+func newSecret(project string, name string, spec modelV1.SecretSpec) modelAPI.Entity {
+	if len(project) == 0 {
+		return &modelV1.GlobalSecret{
+			Kind:     modelV1.KindGlobalSecret,
+			Metadata: modelV1.Metadata{Name: name},
+			Spec:     spec,
+		}
+	}
+	return &modelV1.Secret{
+		Kind:     modelV1.KindSecret,
+		Metadata: *modelV1.NewProjectMetadata(project, name),
+		Spec:     spec,
+	}
+}
+
+func newDatasource(project string, name string, spec modelV1.DatasourceSpec) modelAPI.Entity {
+	if len(project) == 0 {
+		return &modelV1.GlobalDatasource{
+			Kind:     modelV1.KindGlobalDatasource,
+			Metadata: modelV1.Metadata{Name: name},
+			Spec:     spec,
+		}
+	}
+	return &modelV1.Datasource{
+		Kind:     modelV1.KindDatasource,
+		Metadata: *modelV1.NewProjectMetadata(project, name),
+		Spec:     spec,
+	}
+}
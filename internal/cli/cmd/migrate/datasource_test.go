@@ -0,0 +1,144 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"bytes"
+	"testing"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/datasource/http"
+	"github.com/perses/perses/pkg/model/api/v1/secret"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateDatasources(t *testing.T) {
+	provisioning := grafanaDatasourceProvisioning{
+		Datasources: []grafanaDatasource{
+			{
+				Name:          "Prometheus Prod",
+				Type:          "prometheus",
+				URL:           "https://prometheus.example.com",
+				IsDefault:     true,
+				BasicAuth:     true,
+				BasicAuthUser: "admin",
+				JSONData:      map[string]interface{}{"timeInterval": "15s", "tlsSkipVerify": true},
+				SecureJSONData: map[string]string{
+					"basicAuthPassword": "password",
+				},
+			},
+			{
+				Name:           "Loki",
+				Type:           "loki",
+				URL:            "http://loki:3100",
+				JSONData:       map[string]interface{}{"httpHeaderName1": "Authorization", "httpHeaderName2": "X-Scope-OrgID"},
+				SecureJSONData: map[string]string{"httpHeaderValue1": "Bearer token", "httpHeaderValue2": "tenant"},
+			},
+			{
+				Name:   "Tempo",
+				Type:   "tempo",
+				Access: "direct",
+				URL:    "http://tempo:3200",
+			},
+			{
+				Name: "MySQL",
+				Type: "mysql",
+			},
+		},
+	}
+	errWriter := &bytes.Buffer{}
+	entities, err := migrateDatasources(provisioning, "infra", errWriter)
+	require.NoError(t, err)
+	assert.Equal(t, "datasource \"MySQL\" skipped: the type \"mysql\" is not supported\n", errWriter.String())
+
+	assert.Equal(t, []modelAPI.Entity{
+		&modelV1.Secret{
+			Kind:     modelV1.KindSecret,
+			Metadata: *modelV1.NewProjectMetadata("infra", "Prometheus-Prod-secret"),
+			Spec: modelV1.SecretSpec{
+				BasicAuth: &secret.BasicAuth{Username: "admin", Password: "password"},
+				TLSConfig: &secret.TLSConfig{InsecureSkipVerify: true},
+			},
+		},
+		&modelV1.Secret{
+			Kind:     modelV1.KindSecret,
+			Metadata: *modelV1.NewProjectMetadata("infra", "Loki-secret"),
+			Spec: modelV1.SecretSpec{
+				Authorization: &secret.Authorization{Type: "Bearer", Credentials: "token"},
+			},
+		},
+		&modelV1.Datasource{
+			Kind:     modelV1.KindDatasource,
+			Metadata: *modelV1.NewProjectMetadata("infra", "Prometheus-Prod"),
+			Spec: modelV1.DatasourceSpec{
+				Display: &common.Display{Name: "Prometheus Prod"},
+				Default: true,
+				Plugin: common.Plugin{
+					Kind: "PrometheusDatasource",
+					Spec: map[string]interface{}{
+						"proxy": &http.Proxy{
+							Kind: "HTTPProxy",
+							Spec: http.Config{URL: common.MustParseURL("https://prometheus.example.com"), Secret: "Prometheus-Prod-secret"},
+						},
+						"scrapeInterval": "15s",
+					},
+				},
+			},
+		},
+		&modelV1.Datasource{
+			Kind:     modelV1.KindDatasource,
+			Metadata: *modelV1.NewProjectMetadata("infra", "Loki"),
+			Spec: modelV1.DatasourceSpec{
+				Display: &common.Display{Name: "Loki"},
+				Plugin: common.Plugin{
+					Kind: "LokiDatasource",
+					Spec: map[string]interface{}{
+						"proxy": &http.Proxy{
+							Kind: "HTTPProxy",
+							Spec: http.Config{
+								URL:     common.MustParseURL("http://loki:3100"),
+								Headers: map[string]string{"X-Scope-OrgID": "tenant"},
+								Secret:  "Loki-secret",
+							},
+						},
+					},
+				},
+			},
+		},
+		&modelV1.Datasource{
+			Kind:     modelV1.KindDatasource,
+			Metadata: *modelV1.NewProjectMetadata("infra", "Tempo"),
+			Spec: modelV1.DatasourceSpec{
+				Display: &common.Display{Name: "Tempo"},
+				Plugin: common.Plugin{
+					Kind: "TempoDatasource",
+					Spec: map[string]interface{}{"directUrl": "http://tempo:3200"},
+				},
+			},
+		},
+	}, entities)
+}
+
+func TestMigrateDatasourcesGlobal(t *testing.T) {
+	provisioning := grafanaDatasourceProvisioning{
+		Datasources: []grafanaDatasource{{Name: "prometheus", Type: "prometheus", URL: "http://prometheus:9090"}},
+	}
+	entities, err := migrateDatasources(provisioning, "", &bytes.Buffer{})
+	require.NoError(t, err)
+	require.Len(t, entities, 1)
+	assert.Equal(t, string(modelV1.KindGlobalDatasource), entities[0].GetKind())
+}
@@ -69,6 +69,13 @@ const (
 	customResourceShortFormat migrationFormat = "cr"
 )
 
+type migrationKind string
+
+const (
+	dashboardKind  migrationKind = "dashboard"
+	datasourceKind migrationKind = "datasource"
+)
+
 type option struct {
 	persesCMD.Option
 	opt.FileOption
@@ -83,6 +90,7 @@ type option struct {
 	mig             migrate.Migration
 	apiClient       api.ClientInterface
 	migrationFormat migrationFormat
+	migrationKind   migrationKind
 }
 
 func (o *option) Complete(args []string) error {
@@ -135,10 +143,24 @@ func (o *option) Validate() error {
 	if o.migrationFormat != nativeFormat && o.migrationFormat != customResourceFormat && o.migrationFormat != customResourceShortFormat {
 		return fmt.Errorf("invalid value for flag --format: %s", o.migrationFormat)
 	}
+	if o.migrationKind != dashboardKind && o.migrationKind != datasourceKind {
+		return fmt.Errorf("invalid value for flag --kind: %s", o.migrationKind)
+	}
+	if o.migrationKind == datasourceKind {
+		if o.migrationFormat != nativeFormat {
+			return fmt.Errorf("only the format 'native' is supported when migrating datasources")
+		}
+		if o.online {
+			return fmt.Errorf("the flag --online is not supported when migrating datasources")
+		}
+	}
 	return nil
 }
 
 func (o *option) Execute() error {
+	if o.migrationKind == datasourceKind {
+		return o.executeDatasourceMigration()
+	}
 	var grafanaDashboard json.RawMessage
 	if err := file.Unmarshal(o.File, &grafanaDashboard); err != nil {
 		return err
@@ -161,6 +183,18 @@ func (o *option) Execute() error {
 	return output.Handle(o.writer, o.Output, persesDashboard)
 }
 
+func (o *option) executeDatasourceMigration() error {
+	var provisioning grafanaDatasourceProvisioning
+	if err := file.Unmarshal(o.File, &provisioning); err != nil {
+		return err
+	}
+	entities, err := migrateDatasources(provisioning, o.project, o.errWriter)
+	if err != nil {
+		return err
+	}
+	return output.Handle(o.writer, o.Output, entities)
+}
+
 func (o *option) onlineExecution(grafanaDashboard json.RawMessage) (*modelV1.Dashboard, error) {
 	return o.apiClient.Migrate(&modelAPI.Migrate{
 		Input:            o.input,
@@ -189,10 +223,16 @@ func NewCMD() *cobra.Command {
 	o := &option{}
 	cmd := &cobra.Command{
 		Use:   "migrate -f [GRAFANA_DASHBOARD_JSON_FILE]",
-		Short: "migrate a Grafana dashboard to the Perses format",
+		Short: "migrate a Grafana dashboard or Grafana datasources to the Perses format",
 		Example: `
 # Migrate a Grafana dashboard with input
 percli migrate -f ./dashboard.json --input=DS_PROMETHEUS=PrometheusDemo --online
+
+# Migrate the Prometheus, Loki and Tempo datasources of a Grafana provisioning file to global datasources
+percli migrate -f ./datasources.yaml --kind datasource
+
+# Migrate the datasources of a Grafana provisioning file to the datasources of a project
+percli migrate -f ./datasources.yaml --kind datasource --project my-project
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return persesCMD.Run(o, cmd, args)
@@ -202,9 +242,10 @@ percli migrate -f ./dashboard.json --input=DS_PROMETHEUS=PrometheusDemo --online
 	opt.AddOutputFlags(cmd, &o.OutputOption)
 	opt.MarkFileFlagAsMandatory(cmd)
 	cmd.Flags().StringVar((*string)(&o.migrationFormat), "format", string(nativeFormat), "The format of the migration. Can be 'native' or 'custom-resource' or shorter 'cr'.")
+	cmd.Flags().StringVar((*string)(&o.migrationKind), "kind", string(dashboardKind), "The kind of resource to migrate. Can be 'dashboard' or 'datasource'. With 'datasource', the file is a Grafana datasource provisioning file, and the datasources are migrated with the secrets holding their credentials.")
 	cmd.Flags().StringVar(&o.pluginPath, "plugin.path", "", "Path to the Perses plugins.")
 	cmd.Flags().BoolVar(&o.online, "online", false, "When enable, it can request the API to use it to perform the migration")
-	cmd.Flags().StringVar(&o.project, "project", "", "The project to use for the migration. If not set, then the field 'project' in the dashboard will not be set. When the format 'cr' is used, the project will be set to the namespace of the custom resource.")
+	cmd.Flags().StringVar(&o.project, "project", "", "The project to use for the migration. If not set, then the field 'project' in the dashboard will not be set, and the datasources will be migrated to global datasources. When the format 'cr' is used, the project will be set to the namespace of the custom resource.")
 	// When "online" flag is used, the CLI will call the endpoint /migrate that will then use the schema from the server.
 	// So no need to use / load the schemas with the CLI.
 	cmd.MarkFlagsMutuallyExclusive("plugin.path", "online")
@@ -0,0 +1,124 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package move
+
+import (
+	"fmt"
+	"io"
+
+	persesCMD "github.com/perses/perses/internal/cli/cmd"
+	"github.com/perses/perses/internal/cli/config"
+	"github.com/perses/perses/internal/cli/opt"
+	"github.com/perses/perses/internal/cli/output"
+	"github.com/perses/perses/pkg/client/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/spf13/cobra"
+)
+
+type option struct {
+	persesCMD.Option
+	opt.ProjectOption
+	writer    io.Writer
+	errWriter io.Writer
+	dashboard string
+	request   modelV1.DashboardMoveRequest
+	apiClient api.ClientInterface
+}
+
+func (o *option) Complete(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("please specify the name of the dashboard and the project to move it to")
+	}
+	o.dashboard = args[0]
+	o.request.Project = args[1]
+	if projectErr := o.ProjectOption.Complete(); projectErr != nil {
+		return projectErr
+	}
+	apiClient, err := config.Global.GetAPIClient()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
+	return nil
+}
+
+func (o *option) Validate() error {
+	return o.request.Validate()
+}
+
+func (o *option) Execute() error {
+	result, err := o.apiClient.V1().MoveDashboard(o.Project, o.dashboard, &o.request)
+	if err != nil {
+		return err
+	}
+	for _, ref := range result.Rewritten {
+		if outputErr := output.HandleString(o.writer, fmt.Sprintf("rewritten: %s %q at %s: %s", ref.Kind, ref.Name, ref.Path, ref.Reason)); outputErr != nil {
+			return outputErr
+		}
+	}
+	for _, ref := range result.Broken {
+		if outputErr := output.HandleString(o.writer, fmt.Sprintf("broken: %s %q at %s: %s", ref.Kind, ref.Name, ref.Path, ref.Reason)); outputErr != nil {
+			return outputErr
+		}
+	}
+	action := "moved"
+	if o.request.Copy {
+		action = "copied"
+	}
+	msg := fmt.Sprintf("dashboard %q has been %s to the project %q as %q", o.dashboard, action, o.request.Project, result.Dashboard.Metadata.Name)
+	if o.request.DryRun {
+		msg = fmt.Sprintf("dashboard %q can be %s to the project %q as %q (dry run)", o.dashboard, action, o.request.Project, result.Dashboard.Metadata.Name)
+	}
+	return output.HandleString(o.writer, msg)
+}
+
+func (o *option) SetWriter(writer io.Writer) {
+	o.writer = writer
+}
+
+func (o *option) SetErrWriter(errWriter io.Writer) {
+	o.errWriter = errWriter
+}
+
+func NewCMD() *cobra.Command {
+	o := &option{}
+	cmd := &cobra.Command{
+		Use:   "mv DASHBOARD_NAME TARGET_PROJECT",
+		Short: "Move or copy a dashboard to another project",
+		Long: `
+The library panels, the variables and the datasources of the project used by the dashboard that don't exist in the
+target project are copied in the dashboard when possible. The references that cannot be rewritten are reported, and the
+dashboard is not moved unless the flag --force is used.
+`,
+		Example: `
+# Move the dashboard node_exporter of the current project to the project production
+percli mv node_exporter production
+
+# Check which references will be rewritten or will break, without moving the dashboard
+percli mv node_exporter production --dry-run
+
+# Copy the dashboard to the project staging with another name
+percli mv node_exporter staging --copy --name node_exporter_staging
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return persesCMD.Run(o, cmd, args)
+		},
+	}
+	opt.AddProjectFlags(cmd, &o.ProjectOption)
+	cmd.Flags().StringVar(&o.request.Name, "name", o.request.Name, "The name of the dashboard in the target project. By default, the dashboard keeps its name.")
+	cmd.Flags().BoolVar(&o.request.Copy, "copy", o.request.Copy, "Keep the dashboard in its current project.")
+	cmd.Flags().BoolVar(&o.request.DryRun, "dry-run", o.request.DryRun, "Only report the references that will be rewritten or will break.")
+	cmd.Flags().BoolVar(&o.request.Force, "force", o.request.Force, "Move the dashboard even if some references will break.")
+	return cmd
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package move
+
+import (
+	"testing"
+
+	cmdTest "github.com/perses/perses/internal/cli/test"
+	fakeapi "github.com/perses/perses/pkg/client/fake/api"
+)
+
+func TestMoveCMD(t *testing.T) {
+	testSuite := []cmdTest.Suite{
+		{
+			Title:           "missing target project",
+			Args:            []string{"node_exporter"},
+			IsErrorExpected: true,
+			ExpectedMessage: "please specify the name of the dashboard and the project to move it to",
+		},
+		{
+			Title:           "not connected to any API",
+			Args:            []string{"node_exporter", "production", "--project", "perses"},
+			IsErrorExpected: true,
+			ExpectedMessage: "you are not connected to any API",
+		},
+		{
+			Title:           "invalid name",
+			Args:            []string{"node_exporter", "production", "--project", "perses", "--name", "node exporter"},
+			APIClient:       fakeapi.New(),
+			IsErrorExpected: true,
+			ExpectedMessage: `invalid name: "node exporter" is not a correct name. It should match the regexp: ^[a-zA-Z0-9_.-]+$`,
+		},
+		{
+			Title:           "move a dashboard",
+			Args:            []string{"node_exporter", "production", "--project", "perses"},
+			APIClient:       fakeapi.New(),
+			IsErrorExpected: false,
+			ExpectedMessage: `rewritten: Datasource "prometheus" at spec.panels.cpu.spec.queries[0]: the datasource doesn't exist in the project, it is copied in the dashboard
+dashboard "node_exporter" has been moved to the project "production" as "node_exporter"
+`,
+		},
+		{
+			Title:           "copy a dashboard with another name in dry run",
+			Args:            []string{"node_exporter", "staging", "--project", "perses", "--copy", "--name", "node_exporter_staging", "--dry-run"},
+			APIClient:       fakeapi.New(),
+			IsErrorExpected: false,
+			ExpectedMessage: `rewritten: Datasource "prometheus" at spec.panels.cpu.spec.queries[0]: the datasource doesn't exist in the project, it is copied in the dashboard
+dashboard "node_exporter" can be copied to the project "staging" as "node_exporter_staging" (dry run)
+`,
+		},
+	}
+	cmdTest.ExecuteSuiteTest(t, NewCMD, testSuite)
+}
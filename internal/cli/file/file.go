@@ -98,6 +98,16 @@ func UnmarshalEntitiesFromFile(file string) ([]modelAPI.Entity, error) {
 	return u.unmarshal()
 }
 
+// UnmarshalEntitiesFromData extracts the Perses resources from the given data, e.g. the output of a DaC program.
+// The source is only used in the error messages.
+func UnmarshalEntitiesFromData(data []byte, source string) ([]modelAPI.Entity, error) {
+	u := &unmarshaller{file: source}
+	if err := u.parse(data, isJSONData(data)); err != nil {
+		return nil, err
+	}
+	return u.unmarshalEntities()
+}
+
 func visit(dir string) ([]string, error) {
 	var files []string
 	err := filepath.Walk(dir, func(path string, info fs.FileInfo, err error) error {
@@ -175,6 +185,10 @@ func (u *unmarshaller) read() error {
 	if err != nil {
 		return err
 	}
+	return u.parse(data, isJSON)
+}
+
+func (u *unmarshaller) parse(data []byte, isJSON bool) error {
 	u.isJSON = isJSON
 
 	var objects []map[string]interface{}
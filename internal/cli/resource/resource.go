@@ -101,6 +101,14 @@ var resources = []resource{
 			"gvs",
 		},
 	},
+	{
+		kind:      modelV1.KindLibraryPanel,
+		shortTerm: "lpnl",
+		aliases: []string{
+			"libraryPanels",
+			"lpnls",
+		},
+	},
 	{
 		kind: modelV1.KindProject,
 		aliases: []string{
@@ -128,6 +136,14 @@ var resources = []resource{
 			"scrt",
 		},
 	},
+	{
+		kind:      modelV1.KindSnapshot,
+		shortTerm: "snap",
+		aliases: []string{
+			"snapshots",
+			"snaps",
+		},
+	},
 	{
 		kind:      modelV1.KindUser,
 		shortTerm: "usr",
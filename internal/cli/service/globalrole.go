@@ -54,7 +54,7 @@ func buildPermissionMatrix(permissions []apiRole.Permission, spaces []string, fi
 				currentScopeLine = append(currentScopeLine, spaces...)
 				currentScopeLine = append(currentScopeLine, "")
 			}
-			currentScopeLine = append(currentScopeLine, string(scope))
+			currentScopeLine = append(currentScopeLine, string(scope), strings.Join(permission.Resources, ","))
 			matrix = append(matrix, currentScopeLine)
 		}
 	}
@@ -106,5 +106,6 @@ func (g *globalRole) GetColumHeader() []string {
 		"AGE",
 		"ACTIONS",
 		"SCOPE",
+		"RESOURCES",
 	}
 }
@@ -0,0 +1,70 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"github.com/perses/perses/internal/cli/output"
+	v1 "github.com/perses/perses/pkg/client/api/v1"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type libraryPanel struct {
+	Service
+	apiClient v1.LibraryPanelInterface
+}
+
+func (l *libraryPanel) CreateResource(entity modelAPI.Entity) (modelAPI.Entity, error) {
+	return l.apiClient.Create(entity.(*modelV1.LibraryPanel))
+}
+
+func (l *libraryPanel) UpdateResource(entity modelAPI.Entity) (modelAPI.Entity, error) {
+	return l.apiClient.Update(entity.(*modelV1.LibraryPanel))
+}
+
+func (l *libraryPanel) ListResource(prefix string) ([]modelAPI.Entity, error) {
+	return convertToEntityIfNoError(l.apiClient.List(prefix))
+}
+
+func (l *libraryPanel) GetResource(name string) (modelAPI.Entity, error) {
+	return l.apiClient.Get(name)
+}
+
+func (l *libraryPanel) DeleteResource(name string) error {
+	return l.apiClient.Delete(name)
+}
+
+func (l *libraryPanel) BuildMatrix(hits []modelAPI.Entity) [][]string {
+	var data [][]string
+	for _, hit := range hits {
+		entity := hit.(*modelV1.LibraryPanel)
+		line := []string{
+			entity.Metadata.Name,
+			entity.Metadata.Project,
+			entity.Spec.Plugin.Kind,
+			output.FormatAge(entity.Metadata.UpdatedAt),
+		}
+		data = append(data, line)
+	}
+	return data
+}
+
+func (l *libraryPanel) GetColumHeader() []string {
+	return []string{
+		"NAME",
+		"PROJECT",
+		"PANEL_TYPE",
+		"AGE",
+	}
+}
@@ -66,5 +66,6 @@ func (r *role) GetColumHeader() []string {
 		"AGE",
 		"ACTION",
 		"SCOPE",
+		"RESOURCES",
 	}
 }
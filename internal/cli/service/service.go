@@ -94,6 +94,10 @@ func New(kind modelV1.Kind, projectName string, apiClient api.ClientInterface) (
 		return &globalVariable{
 			apiClient: apiClient.V1().GlobalVariable(),
 		}, nil
+	case modelV1.KindLibraryPanel:
+		return &libraryPanel{
+			apiClient: apiClient.V1().LibraryPanel(projectName),
+		}, nil
 	case modelV1.KindProject:
 		return &project{
 			apiClient: apiClient.V1().Project(),
@@ -110,6 +114,10 @@ func New(kind modelV1.Kind, projectName string, apiClient api.ClientInterface) (
 		return &secret{
 			apiClient: apiClient.V1().Secret(projectName),
 		}, nil
+	case modelV1.KindSnapshot:
+		return &snapshot{
+			apiClient: apiClient.V1().Snapshot(projectName),
+		}, nil
 	case modelV1.KindUser:
 		return &user{
 			apiClient: apiClient.V1().User(),
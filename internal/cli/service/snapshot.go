@@ -0,0 +1,73 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"fmt"
+
+	"github.com/perses/perses/internal/cli/output"
+	v1 "github.com/perses/perses/pkg/client/api/v1"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type snapshot struct {
+	Service
+	apiClient v1.SnapshotInterface
+}
+
+// CreateResource is not supported, as a snapshot can only be taken from a dashboard displayed by the UI.
+func (s *snapshot) CreateResource(_ modelAPI.Entity) (modelAPI.Entity, error) {
+	return nil, fmt.Errorf("resource %q cannot be created, a snapshot can only be taken from a dashboard", modelV1.KindSnapshot)
+}
+
+func (s *snapshot) UpdateResource(_ modelAPI.Entity) (modelAPI.Entity, error) {
+	return nil, fmt.Errorf("resource %q cannot be updated, a snapshot is immutable", modelV1.KindSnapshot)
+}
+
+func (s *snapshot) ListResource(prefix string) ([]modelAPI.Entity, error) {
+	return convertToEntityIfNoError(s.apiClient.List(prefix))
+}
+
+func (s *snapshot) GetResource(name string) (modelAPI.Entity, error) {
+	return s.apiClient.Get(name)
+}
+
+func (s *snapshot) DeleteResource(name string) error {
+	return s.apiClient.Delete(name)
+}
+
+func (s *snapshot) BuildMatrix(hits []modelAPI.Entity) [][]string {
+	var data [][]string
+	for _, hit := range hits {
+		entity := hit.(*modelV1.Snapshot)
+		line := []string{
+			entity.Metadata.Name,
+			entity.Metadata.Project,
+			entity.Spec.Dashboard,
+			output.FormatAge(entity.Metadata.CreatedAt),
+		}
+		data = append(data, line)
+	}
+	return data
+}
+
+func (s *snapshot) GetColumHeader() []string {
+	return []string{
+		"NAME",
+		"PROJECT",
+		"DASHBOARD",
+		"AGE",
+	}
+}
@@ -17,11 +17,19 @@ import (
 	"net/url"
 
 	"github.com/perses/perses/pkg/client/perseshttp"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
 )
 
 type ClientInterface interface {
 	RESTClient() *perseshttp.RESTClient
+	// Apply creates or updates the resources in one request. The supported kinds are Dashboard, Datasource,
+	// LibraryPanel, Secret and Variable. When a resource cannot be applied, none of the resources of its project are applied.
+	Apply(entities []modelAPI.Entity) ([]modelAPI.ApplyResult, error)
 	Dashboard(project string) DashboardInterface
+	// MoveDashboard moves or copies the dashboard to the project of the request. The references to the resources of the
+	// project missing in the target project are rewritten when possible, the other ones are reported as broken.
+	MoveDashboard(project string, name string, request *v1.DashboardMoveRequest) (*v1.DashboardMoveResult, error)
 	Datasource(project string) DatasourceInterface
 	EphemeralDashboard(project string) EphemeralDashboardInterface
 	Folder(project string) FolderInterface
@@ -31,11 +39,13 @@ type ClientInterface interface {
 	GlobalSecret() GlobalSecretInterface
 	GlobalVariable() GlobalVariableInterface
 	Health() HealthInterface
+	LibraryPanel(project string) LibraryPanelInterface
 	Plugin() PluginInterface
 	Project() ProjectInterface
 	Role(project string) RoleInterface
 	RoleBinding(project string) RoleBindingInterface
 	Secret(project string) SecretInterface
+	Snapshot(project string) SnapshotInterface
 	User() UserInterface
 	Variable(project string) VariableInterface
 }
@@ -55,10 +65,33 @@ func (c *client) RESTClient() *perseshttp.RESTClient {
 	return c.restClient
 }
 
+func (c *client) Apply(entities []modelAPI.Entity) ([]modelAPI.ApplyResult, error) {
+	var result []modelAPI.ApplyResult
+	err := c.restClient.Post().
+		Resource("apply").
+		Body(entities).
+		Do().
+		Object(&result)
+	return result, err
+}
+
 func (c *client) Dashboard(project string) DashboardInterface {
 	return newDashboard(c.restClient, project)
 }
 
+func (c *client) MoveDashboard(project string, name string, request *v1.DashboardMoveRequest) (*v1.DashboardMoveResult, error) {
+	result := &v1.DashboardMoveResult{}
+	err := c.restClient.Post().
+		Resource(dashboardResource).
+		Name(name).
+		SubResource("move").
+		Project(project).
+		Body(request).
+		Do().
+		Object(result)
+	return result, err
+}
+
 func (c *client) Datasource(project string) DatasourceInterface {
 	return newDatasource(c.restClient, project)
 }
@@ -71,6 +104,10 @@ func (c *client) Folder(project string) FolderInterface {
 	return newFolder(c.restClient, project)
 }
 
+func (c *client) LibraryPanel(project string) LibraryPanelInterface {
+	return newLibraryPanel(c.restClient, project)
+}
+
 func (c *client) GlobalDatasource() GlobalDatasourceInterface {
 	return newGlobalDatasource(c.restClient)
 }
@@ -115,6 +152,10 @@ func (c *client) Secret(project string) SecretInterface {
 	return newSecret(c.restClient, project)
 }
 
+func (c *client) Snapshot(project string) SnapshotInterface {
+	return newSnapshot(c.restClient, project)
+}
+
 func (c *client) User() UserInterface {
 	return newUser(c.restClient)
 }
@@ -0,0 +1,96 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"github.com/perses/perses/pkg/client/perseshttp"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const (
+	snapshotResource          = "snapshots"
+	dashboardSnapshotResource = "snapshot"
+)
+
+type SnapshotInterface interface {
+	// Create takes a snapshot of the dashboard with the query results of the request.
+	Create(dashboard string, request *v1.SnapshotRequest) (*v1.Snapshot, error)
+	Delete(name string) error
+	// Get is returning an unique Snapshot.
+	// As such name is the exact value of Snapshot.metadata.name. It cannot be empty.
+	// If you want to perform a research by prefix, please use the method List
+	Get(name string) (*v1.Snapshot, error)
+	// prefix is a prefix of the Snapshot.metadata.name to search for.
+	// It can be empty in case you want to get the full list of Snapshot available
+	List(prefix string) ([]*v1.Snapshot, error)
+}
+
+type snapshot struct {
+	SnapshotInterface
+	client  *perseshttp.RESTClient
+	project string
+}
+
+func newSnapshot(client *perseshttp.RESTClient, project string) SnapshotInterface {
+	return &snapshot{
+		client:  client,
+		project: project,
+	}
+}
+
+func (c *snapshot) Create(dashboard string, request *v1.SnapshotRequest) (*v1.Snapshot, error) {
+	result := &v1.Snapshot{}
+	err := c.client.Post().
+		Resource(dashboardResource).
+		Name(dashboard).
+		SubResource(dashboardSnapshotResource).
+		Project(c.project).
+		Body(request).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *snapshot) Delete(name string) error {
+	return c.client.Delete().
+		Resource(snapshotResource).
+		Name(name).
+		Project(c.project).
+		Do().
+		Error()
+}
+
+func (c *snapshot) Get(name string) (*v1.Snapshot, error) {
+	result := &v1.Snapshot{}
+	err := c.client.Get().
+		Resource(snapshotResource).
+		Name(name).
+		Project(c.project).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *snapshot) List(prefix string) ([]*v1.Snapshot, error) {
+	var result []*v1.Snapshot
+	err := c.client.Get().
+		Resource(snapshotResource).
+		Query(&query{
+			name: prefix,
+		}).
+		Project(c.project).
+		Do().
+		Object(&result)
+	return result, err
+}
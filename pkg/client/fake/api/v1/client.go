@@ -16,6 +16,7 @@ package fakev1
 import (
 	v1 "github.com/perses/perses/pkg/client/api/v1"
 	"github.com/perses/perses/pkg/client/perseshttp"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
 )
 
 type client struct {
@@ -35,6 +36,26 @@ func (c *client) Dashboard(_ string) v1.DashboardInterface {
 	return &dashboard{}
 }
 
+func (c *client) MoveDashboard(_ string, name string, request *modelV1.DashboardMoveRequest) (*modelV1.DashboardMoveResult, error) {
+	if len(request.Name) > 0 {
+		name = request.Name
+	}
+	return &modelV1.DashboardMoveResult{
+		Dashboard: &modelV1.Dashboard{
+			Kind:     modelV1.KindDashboard,
+			Metadata: *modelV1.NewProjectMetadata(request.Project, name),
+		},
+		Rewritten: []modelV1.DashboardReference{
+			{
+				Kind:   modelV1.KindDatasource,
+				Name:   "prometheus",
+				Path:   "spec.panels.cpu.spec.queries[0]",
+				Reason: "the datasource doesn't exist in the project, it is copied in the dashboard",
+			},
+		},
+	}, nil
+}
+
 func (c *client) EphemeralDashboard(_ string) v1.EphemeralDashboardInterface {
 	return &ephemeralDashboard{}
 }
@@ -53,6 +74,12 @@ func (c *client) Health() v1.HealthInterface {
 	return &health{}
 }
 
+func (c *client) LibraryPanel(project string) v1.LibraryPanelInterface {
+	return &libraryPanel{
+		project: project,
+	}
+}
+
 func (c *client) Plugin() v1.PluginInterface {
 	return &plugin{}
 }
@@ -60,3 +87,9 @@ func (c *client) Plugin() v1.PluginInterface {
 func (c *client) Project() v1.ProjectInterface {
 	return &project{}
 }
+
+func (c *client) Snapshot(project string) v1.SnapshotInterface {
+	return &snapshot{
+		project: project,
+	}
+}
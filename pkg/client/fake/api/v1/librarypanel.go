@@ -0,0 +1,91 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakev1
+
+import (
+	"strings"
+
+	v1 "github.com/perses/perses/pkg/client/api/v1"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+func LibraryPanelList(project string, prefix string) []*modelV1.LibraryPanel {
+	initialList := []*modelV1.LibraryPanel{
+		{
+			Kind: modelV1.KindLibraryPanel,
+			Metadata: modelV1.ProjectMetadata{
+				Metadata: modelV1.Metadata{
+					Name: "cpu-usage",
+				},
+				ProjectMetadataWrapper: modelV1.ProjectMetadataWrapper{
+					Project: "perses",
+				},
+			},
+		},
+		{
+			Kind: modelV1.KindLibraryPanel,
+			Metadata: modelV1.ProjectMetadata{
+				Metadata: modelV1.Metadata{
+					Name: "memory-usage",
+				},
+				ProjectMetadataWrapper: modelV1.ProjectMetadataWrapper{
+					Project: "AnotherProject",
+				},
+			},
+		},
+	}
+	var result []*modelV1.LibraryPanel
+	for _, p := range initialList {
+		if (len(prefix) == 0 || strings.HasPrefix(p.Metadata.Name, prefix)) && (len(project) == 0 || p.Metadata.Project == project) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+type libraryPanel struct {
+	v1.LibraryPanelInterface
+	project string
+}
+
+func (c *libraryPanel) Create(entity *modelV1.LibraryPanel) (*modelV1.LibraryPanel, error) {
+	return entity, nil
+}
+
+func (c *libraryPanel) Update(entity *modelV1.LibraryPanel) (*modelV1.LibraryPanel, error) {
+	return entity, nil
+}
+
+func (c *libraryPanel) Delete(_ string) error {
+	return nil
+}
+
+func (c *libraryPanel) Get(name string) (*modelV1.LibraryPanel, error) {
+	return &modelV1.LibraryPanel{
+
+		Kind: modelV1.KindLibraryPanel,
+		Metadata: modelV1.ProjectMetadata{
+			Metadata: modelV1.Metadata{
+				Name: name,
+			},
+			ProjectMetadataWrapper: modelV1.ProjectMetadataWrapper{
+				Project: c.project,
+			},
+		},
+	}, nil
+}
+
+func (c *libraryPanel) List(prefix string) ([]*modelV1.LibraryPanel, error) {
+	return LibraryPanelList(c.project, prefix), nil
+}
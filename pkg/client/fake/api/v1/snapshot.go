@@ -0,0 +1,93 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakev1
+
+import (
+	"strings"
+
+	v1 "github.com/perses/perses/pkg/client/api/v1"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+func SnapshotList(project string, prefix string) []*modelV1.Snapshot {
+	initialList := []*modelV1.Snapshot{
+		{
+			Kind: modelV1.KindSnapshot,
+			Metadata: modelV1.ProjectMetadata{
+				Metadata: modelV1.Metadata{
+					Name: "node-exporter-incident",
+				},
+				ProjectMetadataWrapper: modelV1.ProjectMetadataWrapper{
+					Project: "perses",
+				},
+			},
+			Spec: modelV1.SnapshotSpec{
+				Dashboard: "node-exporter",
+			},
+		},
+		{
+			Kind: modelV1.KindSnapshot,
+			Metadata: modelV1.ProjectMetadata{
+				Metadata: modelV1.Metadata{
+					Name: "cpu-incident",
+				},
+				ProjectMetadataWrapper: modelV1.ProjectMetadataWrapper{
+					Project: "AnotherProject",
+				},
+			},
+			Spec: modelV1.SnapshotSpec{
+				Dashboard: "cpu",
+			},
+		},
+	}
+	var result []*modelV1.Snapshot
+	for _, p := range initialList {
+		if (len(prefix) == 0 || strings.HasPrefix(p.Metadata.Name, prefix)) && (len(project) == 0 || p.Metadata.Project == project) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+type snapshot struct {
+	v1.SnapshotInterface
+	project string
+}
+
+func (c *snapshot) Create(dashboard string, request *modelV1.SnapshotRequest) (*modelV1.Snapshot, error) {
+	return &modelV1.Snapshot{
+		Kind:     modelV1.KindSnapshot,
+		Metadata: *modelV1.NewProjectMetadata(c.project, request.Name),
+		Spec: modelV1.SnapshotSpec{
+			Dashboard: dashboard,
+			TimeRange: request.TimeRange,
+			Data:      request.Data,
+		},
+	}, nil
+}
+
+func (c *snapshot) Delete(_ string) error {
+	return nil
+}
+
+func (c *snapshot) Get(name string) (*modelV1.Snapshot, error) {
+	return &modelV1.Snapshot{
+		Kind:     modelV1.KindSnapshot,
+		Metadata: *modelV1.NewProjectMetadata(c.project, name),
+	}, nil
+}
+
+func (c *snapshot) List(prefix string) ([]*modelV1.Snapshot, error) {
+	return SnapshotList(c.project, prefix), nil
+}
@@ -51,9 +51,10 @@ type Request struct {
 	apiPrefix  string // it's the api prefix such as /api
 	apiVersion string
 	// Resource
-	project  string
-	resource string
-	name     string
+	project     string
+	resource    string
+	name        string
+	subResource string
 
 	queryParam url.Values
 	body       io.Reader
@@ -102,6 +103,12 @@ func (r *Request) Name(name string) *Request {
 	return r
 }
 
+// SubResource set the path following the name of the resource, e.g. "snapshot" for /projects/perses/dashboards/node/snapshot
+func (r *Request) SubResource(subResource string) *Request {
+	r.subResource = subResource
+	return r
+}
+
 // Query set all queryParameter contains in the query passed as a parameter
 func (r *Request) Query(query QueryInterface) *Request {
 	if query == nil {
@@ -128,6 +135,12 @@ func (r *Request) Body(obj interface{}) *Request {
 	return r
 }
 
+// Context sets the context of the request, so it can be cancelled or given a deadline.
+func (r *Request) Context(ctx context.Context) *Request {
+	r.ctx = ctx
+	return r
+}
+
 // Do build the query and execute it.
 // The error and/or the response from the server are set in the object Response
 func (r *Request) Do() *Response {
@@ -217,7 +230,7 @@ func (r *Request) url() string {
 }
 
 // buildPath builds the REST path according to a predefined ordering
-// /<api name>/<api version>[/<address>]/<resource type>[/<resource name>[/versions/<resource version>]][/<verb>]
+// /<api name>/<api version>[/<address>]/<resource type>[/<resource name>[/<sub resource>]]
 func (r *Request) buildPath() string {
 	var path strings.Builder
 
@@ -245,6 +258,10 @@ func (r *Request) buildPath() string {
 	// Resource name
 	if len(r.name) > 0 {
 		path.WriteString(fmt.Sprintf("/%s", r.name))
+		// Sub resource
+		if len(r.subResource) > 0 {
+			path.WriteString(fmt.Sprintf("/%s", r.subResource))
+		}
 	}
 
 	return path.String()
@@ -75,6 +75,18 @@ func TestRequest_BuildPath(t *testing.T) {
 			},
 			expectedResult: "/api/v1/projects/perses/prometheusrules",
 		},
+		{
+			title: "Path using a sub resource",
+			request: &Request{
+				apiPrefix:   defaultAPIPrefix,
+				apiVersion:  defaultAPIVersion,
+				project:     "perses",
+				resource:    "dashboards",
+				name:        "node",
+				subResource: "snapshot",
+			},
+			expectedResult: "/api/v1/projects/perses/dashboards/node/snapshot",
+		},
 	}
 	for _, test := range testSuites {
 		t.Run(test.title, func(t *testing.T) {
@@ -0,0 +1,29 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+type ApplyAction string
+
+const (
+	ApplyActionCreated ApplyAction = "created"
+	ApplyActionUpdated ApplyAction = "updated"
+)
+
+// ApplyResult is the result of the endpoint /api/v1/apply for one of the resources applied.
+type ApplyResult struct {
+	Kind    string      `json:"kind" yaml:"kind"`
+	Project string      `json:"project" yaml:"project"`
+	Name    string      `json:"name" yaml:"name"`
+	Action  ApplyAction `json:"action" yaml:"action"`
+}
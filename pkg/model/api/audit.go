@@ -0,0 +1,41 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import "time"
+
+type AuditAction string
+
+const (
+	AuditActionCreated AuditAction = "created"
+	AuditActionUpdated AuditAction = "updated"
+	AuditActionDeleted AuditAction = "deleted"
+)
+
+// AuditEvent is an entry of the audit log, recorded when a resource is created, updated or deleted through the API.
+type AuditEvent struct {
+	// ID identifies the event. The IDs are sorted in the order the events are recorded.
+	ID        string    `json:"id" yaml:"id"`
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+	// User is the username of the user who made the change. It is empty when the authorization is disabled.
+	User    string      `json:"user,omitempty" yaml:"user,omitempty"`
+	Action  AuditAction `json:"action" yaml:"action"`
+	Kind    string      `json:"kind" yaml:"kind"`
+	Project string      `json:"project,omitempty" yaml:"project,omitempty"`
+	Name    string      `json:"name" yaml:"name"`
+	// OldSpecHash is the SHA-256 of the JSON spec before the change. It is empty for a creation.
+	OldSpecHash string `json:"oldSpecHash,omitempty" yaml:"oldSpecHash,omitempty"`
+	// NewSpecHash is the SHA-256 of the JSON spec after the change. It is empty for a deletion.
+	NewSpecHash string `json:"newSpecHash,omitempty" yaml:"newSpecHash,omitempty"`
+}
@@ -0,0 +1,38 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"time"
+
+	"github.com/perses/perses/pkg/model/api/v1/common"
+)
+
+const defaultAuditCleanupInterval = common.Duration(time.Hour)
+
+type Audit struct {
+	// Enable records every creation, update and deletion of a resource in the audit log.
+	Enable bool `json:"enable" yaml:"enable"`
+	// Retention is the duration the events of the audit log are kept. When it is not set, the events are never deleted.
+	Retention common.Duration `json:"retention,omitempty" yaml:"retention,omitempty"`
+	// CleanupInterval is the interval at which the events older than the retention are deleted.
+	CleanupInterval common.Duration `json:"cleanup_interval,omitempty" yaml:"cleanup_interval,omitempty"`
+}
+
+func (a *Audit) Verify() error {
+	if a.Enable && a.Retention > 0 && a.CleanupInterval <= 0 {
+		a.CleanupInterval = defaultAuditCleanupInterval
+	}
+	return nil
+}
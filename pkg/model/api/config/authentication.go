@@ -16,6 +16,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/perses/perses/pkg/model/api/v1/common"
@@ -82,12 +83,45 @@ func (p *Provider) Verify() error {
 	return nil
 }
 
+// RoleMapping grants a role to the users logged in with an OIDC provider when one of the values of a claim of the
+// user matches a regular expression, e.g. to bind the members of an LDAP group to the editor role of a project.
+type RoleMapping struct {
+	// Claim is the name of the claim of the ID token or of the user info, e.g. "groups". A nested claim is designated
+	// with dots, e.g. "realm_access.roles".
+	Claim string `json:"claim" yaml:"claim"`
+	// Match is the regular expression a value of the claim must fully match.
+	Match string `json:"match" yaml:"match"`
+	// Role is the name of the role granted.
+	Role string `json:"role" yaml:"role"`
+	// Project is the project of the role. When empty, the role is a GlobalRole.
+	Project string `json:"project,omitempty" yaml:"project,omitempty"`
+}
+
+func (r *RoleMapping) Verify() error {
+	if len(r.Claim) == 0 {
+		return errors.New("role mapping's `claim` is mandatory")
+	}
+	if len(r.Match) == 0 {
+		return errors.New("role mapping's `match` is mandatory")
+	}
+	if _, err := regexp.Compile(r.Match); err != nil {
+		return fmt.Errorf("role mapping's `match` is not a valid regular expression: %w", err)
+	}
+	if len(r.Role) == 0 {
+		return errors.New("role mapping's `role` is mandatory")
+	}
+	return nil
+}
+
 type OIDCProvider struct {
 	Provider     `json:",inline" yaml:",inline"`
 	Issuer       common.URL        `json:"issuer" yaml:"issuer"`
 	DiscoveryURL common.URL        `json:"discovery_url,omitempty" yaml:"discovery_url,omitempty"`
 	URLParams    map[string]string `json:"url_params,omitempty" yaml:"url_params,omitempty"`
 	DisablePKCE  bool              `json:"disable_pkce" yaml:"disable_pkce"`
+	// RoleMappings grant roles to the users according to their claims. The role bindings are updated each time a
+	// user logs in, so the roles of a user removed from a group are revoked at the next login.
+	RoleMappings []RoleMapping `json:"role_mappings,omitempty" yaml:"role_mappings,omitempty"`
 }
 
 func (p *OIDCProvider) Verify() error {
@@ -100,3 +100,16 @@ func TestAppendIfMissing(t *testing.T) {
 	assert.Len(t, slice, 3)
 	assert.False(t, ok3)
 }
+
+func TestRoleMapping_Verify(t *testing.T) {
+	valid := RoleMapping{Claim: "groups", Match: "team-.*", Role: "editor", Project: "perses"}
+	assert.NoError(t, valid.Verify())
+	global := RoleMapping{Claim: "groups", Match: "admins", Role: "admin"}
+	assert.NoError(t, global.Verify())
+	noClaim := RoleMapping{Match: "admins", Role: "admin"}
+	assert.ErrorContains(t, noClaim.Verify(), "`claim` is mandatory")
+	wrongRegexp := RoleMapping{Claim: "groups", Match: "team-(", Role: "editor"}
+	assert.ErrorContains(t, wrongRegexp.Verify(), "`match` is not a valid regular expression")
+	noRole := RoleMapping{Claim: "groups", Match: "admins"}
+	assert.ErrorContains(t, noRole.Verify(), "`role` is mandatory")
+}
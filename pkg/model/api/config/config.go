@@ -71,6 +71,14 @@ type Config struct {
 	EphemeralDashboardsCleanupInterval common.Duration `json:"ephemeral_dashboards_cleanup_interval,omitempty" yaml:"ephemeral_dashboards_cleanup_interval,omitempty"`
 	// EphemeralDashboard contains the config about the ephemeral dashboard feature
 	EphemeralDashboard EphemeralDashboard `json:"ephemeral_dashboard,omitempty" yaml:"ephemeral_dashboard,omitempty"`
+	// Rendering contains the config to render the dashboards as a PNG image or as a PDF document.
+	Rendering Rendering `json:"rendering,omitempty" yaml:"rendering,omitempty"`
+	// Webhooks is the list of the webhooks notified when a dashboard or a datasource is created, updated or deleted.
+	Webhooks []Webhook `json:"webhooks,omitempty" yaml:"webhooks,omitempty"`
+	// Audit contains the config of the audit log recording the changes of the resources.
+	Audit Audit `json:"audit,omitempty" yaml:"audit,omitempty"`
+	// Search contains the config of the full-text search across the dashboards.
+	Search Search `json:"search,omitempty" yaml:"search,omitempty"`
 	// Frontend contains any config that will be used by the frontend itself.
 	Frontend Frontend `json:"frontend,omitempty" yaml:"frontend,omitempty"`
 	// Plugin contains the config for runtime plugins.
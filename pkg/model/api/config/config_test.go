@@ -69,7 +69,10 @@ func TestJSONMarshalConfig(t *testing.T) {
     "project": {
       "disable": false
     },
-    "disable_local": false
+    "disable_local": false,
+    "cache": {
+      "enable": false
+    }
   },
   "variable": {
     "global": {
@@ -84,6 +87,13 @@ func TestJSONMarshalConfig(t *testing.T) {
     "enable": false,
     "cleanup_interval": "0s"
   },
+  "rendering": {
+    "enable": false
+  },
+  "audit": {
+    "enable": false
+  },
+  "search": {},
   "frontend": {
     "disable": false,
     "explorer": {
@@ -133,7 +143,9 @@ func TestJSONMarshalConfig(t *testing.T) {
       "case_sensitive": false
     }
   },
-  "dashboard": {},
+  "dashboard": {
+    "max_versions": 10
+  },
   "provisioning": {
     "interval": "1h"
   },
@@ -144,7 +156,10 @@ func TestJSONMarshalConfig(t *testing.T) {
     "project": {
       "disable": false
     },
-    "disable_local": false
+    "disable_local": false,
+    "cache": {
+      "enable": false
+    }
   },
   "variable": {
     "global": {
@@ -159,6 +174,15 @@ func TestJSONMarshalConfig(t *testing.T) {
     "enable": false,
     "cleanup_interval": "0s"
   },
+  "rendering": {
+    "enable": false
+  },
+  "audit": {
+    "enable": false
+  },
+  "search": {
+    "refresh_interval": "1m"
+  },
   "frontend": {
     "disable": false,
     "explorer": {
@@ -522,6 +546,12 @@ plugin:
 					Enable:          false,
 					CleanupInterval: common.Duration(2 * time.Hour),
 				},
+				Dashboard: DashboardConfig{
+					MaxVersions: defaultMaxVersions,
+				},
+				Search: Search{
+					RefreshInterval: defaultSearchRefreshInterval,
+				},
 			},
 		},
 	}
@@ -114,11 +114,18 @@ func (c *CustomLintRule) evaluateAndLoadCELExpression() error {
 	return nil
 }
 
+const defaultMaxVersions = 10
+
 type DashboardConfig struct {
 	CustomLintRules []*CustomLintRule `json:"custom_lint_rules,omitempty" yaml:"custom_lint_rules,omitempty"`
+	// MaxVersions is the number of previous versions kept for each dashboard. The oldest versions are removed first.
+	MaxVersions int `json:"max_versions,omitempty" yaml:"max_versions,omitempty"`
 }
 
 func (c *DashboardConfig) Verify() error {
+	if c.MaxVersions <= 0 {
+		c.MaxVersions = defaultMaxVersions
+	}
 	ruleName := make(map[string]struct{})
 	for _, rule := range c.CustomLintRules {
 		if _, ok := ruleName[rule.Name]; ok {
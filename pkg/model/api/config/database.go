@@ -15,10 +15,13 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/perses/perses/pkg/model/api/v1/common"
+	datasourceSQL "github.com/perses/perses/pkg/model/api/v1/datasource/sql"
 	"github.com/perses/perses/pkg/model/api/v1/secret"
 	"github.com/prometheus/common/config"
 	"github.com/sirupsen/logrus"
@@ -128,13 +131,162 @@ func (s *SQL) Verify() error {
 	return nil
 }
 
+const defaultPostgresSchema = "public"
+
+type Postgres struct {
+	// TLS configuration. It requires ssl_mode to be set to something else than disable.
+	TLSConfig *config.TLSConfig `json:"tls_config,omitempty" yaml:"tls_config,omitempty"`
+	// Username
+	User secret.Hidden `json:"user,omitempty" yaml:"user,omitempty"`
+	// Password (requires User)
+	Password secret.Hidden `json:"password,omitempty" yaml:"password,omitempty"`
+	// PasswordFile is a path to a file that contains a password
+	PasswordFile string `json:"password_file,omitempty" yaml:"password_file,omitempty"`
+	// Address of the server, e.g. localhost:5432
+	Addr secret.Hidden `json:"addr" yaml:"addr"`
+	// Database name
+	DBName string `json:"db_name" yaml:"db_name"`
+	// Schema in which the tables are created. It is created if it doesn't exist.
+	// +kubebuilder:validation:Optional
+	Schema string `json:"schema,omitempty" yaml:"schema,omitempty"`
+	// SSLMode to use when connecting to the server
+	// +kubebuilder:validation:Optional
+	SSLMode datasourceSQL.SSLMode `json:"ssl_mode,omitempty" yaml:"ssl_mode,omitempty"`
+	// Dial timeout
+	ConnectTimeout common.Duration `json:"connect_timeout,omitempty" yaml:"connect_timeout,omitempty"`
+	// Maximum number of open connections of the pool. 0 means unlimited.
+	MaxOpenConns int `json:"max_open_conns,omitempty" yaml:"max_open_conns,omitempty"`
+	// Maximum number of idle connections kept in the pool
+	MaxIdleConns int `json:"max_idle_conns,omitempty" yaml:"max_idle_conns,omitempty"`
+	// Maximum amount of time a connection may be reused
+	ConnMaxLifetime common.Duration `json:"conn_max_lifetime,omitempty" yaml:"conn_max_lifetime,omitempty"`
+	// Maximum amount of time a connection may be idle
+	ConnMaxIdleTime common.Duration `json:"conn_max_idle_time,omitempty" yaml:"conn_max_idle_time,omitempty"`
+	// +kubebuilder:validation:Optional
+	CaseSensitive bool `json:"case_sensitive" yaml:"case_sensitive"`
+}
+
+func (p *Postgres) Verify() error {
+	if len(p.Addr) == 0 {
+		return fmt.Errorf("addr must be specified")
+	}
+	if len(p.DBName) == 0 {
+		return fmt.Errorf("db_name must be specified")
+	}
+	if len(p.Schema) == 0 {
+		p.Schema = defaultPostgresSchema
+	}
+	switch p.SSLMode {
+	case "", datasourceSQL.SSLModeDisable, datasourceSQL.SSLModeAllow, datasourceSQL.SSLModePreferable,
+		datasourceSQL.SSLModeRequire, datasourceSQL.SSLModeVerifyCA, datasourceSQL.SSLModeVerifyFull:
+	default:
+		return fmt.Errorf("ssl_mode %q is not supported", p.SSLMode)
+	}
+	if p.TLSConfig != nil && (len(p.SSLMode) == 0 || p.SSLMode == datasourceSQL.SSLModeDisable) {
+		return fmt.Errorf("tls_config cannot be used when ssl_mode is disable")
+	}
+	if p.MaxOpenConns < 0 || p.MaxIdleConns < 0 {
+		return fmt.Errorf("max_open_conns and max_idle_conns cannot be negative")
+	}
+	if (len(p.Password) > 0 || len(p.PasswordFile) > 0) && len(p.User) == 0 {
+		return fmt.Errorf("password or password_file cannot be filled if no user is provided")
+	}
+	if len(p.Password) > 0 && len(p.PasswordFile) > 0 {
+		return fmt.Errorf("password and password_file are mutually exclusive. Use one or the other not both at the same time")
+	}
+	if len(p.PasswordFile) > 0 {
+		// Read the file and load the password contained
+		data, err := os.ReadFile(p.PasswordFile)
+		if err != nil {
+			return err
+		}
+		p.Password = secret.Hidden(data)
+	}
+	return nil
+}
+
+const defaultObjectStorageRegion = "us-east-1"
+
+// ObjectStorage is the configuration of a bucket exposing the S3 API, like AWS S3, MinIO or Google Cloud Storage.
+type ObjectStorage struct {
+	// Bucket in which the resources are stored
+	Bucket string `json:"bucket" yaml:"bucket"`
+	// Endpoint of the S3 API. By default, the endpoint of AWS S3 for the region is used.
+	Endpoint string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+	// Region of the bucket
+	// +kubebuilder:validation:Optional
+	Region string `json:"region,omitempty" yaml:"region,omitempty"`
+	// AccessKeyID used to sign the requests. When empty, the environment variable AWS_ACCESS_KEY_ID is used.
+	AccessKeyID secret.Hidden `json:"access_key_id,omitempty" yaml:"access_key_id,omitempty"`
+	// SecretAccessKey used to sign the requests. When empty, the environment variable AWS_SECRET_ACCESS_KEY is used.
+	SecretAccessKey secret.Hidden `json:"secret_access_key,omitempty" yaml:"secret_access_key,omitempty"`
+	// SecretAccessKeyFile is a path to a file that contains the secret access key
+	SecretAccessKeyFile string `json:"secret_access_key_file,omitempty" yaml:"secret_access_key_file,omitempty"`
+	// SessionToken of temporary credentials. When empty, the environment variable AWS_SESSION_TOKEN is used.
+	SessionToken secret.Hidden `json:"session_token,omitempty" yaml:"session_token,omitempty"`
+	// ForcePathStyle uses the URLs <endpoint>/<bucket>/<key> instead of <bucket>.<endpoint>/<key>
+	ForcePathStyle bool `json:"force_path_style,omitempty" yaml:"force_path_style,omitempty"`
+	// Prefix of the keys of the objects, to share a bucket with other applications
+	Prefix string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	// TLS configuration
+	TLSConfig *config.TLSConfig `json:"tls_config,omitempty" yaml:"tls_config,omitempty"`
+	// CacheTTL is the duration during which a resource read is served from the local cache. 0 disables the cache.
+	CacheTTL common.Duration `json:"cache_ttl,omitempty" yaml:"cache_ttl,omitempty"`
+	// +kubebuilder:validation:Optional
+	CaseSensitive bool `json:"case_sensitive" yaml:"case_sensitive"`
+}
+
+func (o *ObjectStorage) Verify() error {
+	if len(o.Bucket) == 0 {
+		return fmt.Errorf("bucket must be specified")
+	}
+	if len(o.Region) == 0 {
+		o.Region = defaultObjectStorageRegion
+	}
+	if len(o.Endpoint) == 0 {
+		o.Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", o.Region)
+	}
+	if u, err := url.Parse(o.Endpoint); err != nil || len(u.Scheme) == 0 || len(u.Host) == 0 {
+		return fmt.Errorf("endpoint %q is not a valid URL", o.Endpoint)
+	}
+	if o.CacheTTL < 0 {
+		return fmt.Errorf("cache_ttl cannot be negative")
+	}
+	if len(o.SecretAccessKey) > 0 && len(o.SecretAccessKeyFile) > 0 {
+		return fmt.Errorf("secret_access_key and secret_access_key_file are mutually exclusive. Use one or the other not both at the same time")
+	}
+	if len(o.SecretAccessKeyFile) > 0 {
+		// Read the file and load the secret access key contained
+		data, err := os.ReadFile(o.SecretAccessKeyFile)
+		if err != nil {
+			return err
+		}
+		o.SecretAccessKey = secret.Hidden(strings.TrimSpace(string(data)))
+	}
+	if len(o.AccessKeyID) == 0 {
+		o.AccessKeyID = secret.Hidden(os.Getenv("AWS_ACCESS_KEY_ID"))
+		if len(o.SecretAccessKey) == 0 {
+			o.SecretAccessKey = secret.Hidden(os.Getenv("AWS_SECRET_ACCESS_KEY"))
+		}
+		if len(o.SessionToken) == 0 {
+			o.SessionToken = secret.Hidden(os.Getenv("AWS_SESSION_TOKEN"))
+		}
+	}
+	if len(o.AccessKeyID) > 0 && len(o.SecretAccessKey) == 0 {
+		return fmt.Errorf("secret_access_key must be specified when access_key_id is provided")
+	}
+	return nil
+}
+
 type Database struct {
-	File *File `json:"file,omitempty" yaml:"file,omitempty"`
-	SQL  *SQL  `json:"sql,omitempty" yaml:"sql,omitempty"`
+	File          *File          `json:"file,omitempty" yaml:"file,omitempty"`
+	SQL           *SQL           `json:"sql,omitempty" yaml:"sql,omitempty"`
+	Postgres      *Postgres      `json:"postgres,omitempty" yaml:"postgres,omitempty"`
+	ObjectStorage *ObjectStorage `json:"object_storage,omitempty" yaml:"object_storage,omitempty"`
 }
 
 func (d *Database) Verify() error {
-	if d.File == nil && d.SQL == nil {
+	if d.File == nil && d.SQL == nil && d.Postgres == nil && d.ObjectStorage == nil {
 		logrus.Debug("no database has been specified, therefore a file system database is used")
 		d.File = &File{
 			Folder: defaultFileDBFolder,
@@ -143,5 +295,11 @@ func (d *Database) Verify() error {
 	if d.File != nil && d.SQL != nil {
 		return fmt.Errorf("you cannot tel to Perses to use SQL and the filesystem at the same time")
 	}
+	if d.Postgres != nil && (d.File != nil || d.SQL != nil) {
+		return fmt.Errorf("you cannot tel to Perses to use PostgreSQL and another database at the same time")
+	}
+	if d.ObjectStorage != nil && (d.File != nil || d.SQL != nil || d.Postgres != nil) {
+		return fmt.Errorf("you cannot tel to Perses to use an object storage and another database at the same time")
+	}
 	return nil
 }
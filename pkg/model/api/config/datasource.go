@@ -13,7 +13,15 @@
 
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/secret"
+	"github.com/prometheus/common/config"
+)
 
 type GlobalDatasourceConfig struct {
 	// Disable is used to disable the global datasource feature.
@@ -39,10 +47,105 @@ type ProjectDatasourceConfig struct {
 	Disable bool `json:"disable" yaml:"disable"`
 }
 
+type QueryCacheBackend string
+
+const (
+	MemoryQueryCache QueryCacheBackend = "memory"
+	RedisQueryCache  QueryCacheBackend = "redis"
+)
+
+const (
+	defaultQueryCacheTTL             = 30 * time.Second
+	defaultQueryCacheMaxEntries      = 10000
+	defaultQueryCacheMaxResponseSize = 10 * 1024 * 1024
+)
+
+type Redis struct {
+	// Address of the server, e.g. localhost:6379
+	Addr string `json:"addr" yaml:"addr"`
+	// Username used with the ACL of Redis 6
+	Username string `json:"username,omitempty" yaml:"username,omitempty"`
+	// Password of the user
+	Password secret.Hidden `json:"password,omitempty" yaml:"password,omitempty"`
+	// PasswordFile is a path to a file that contains a password
+	PasswordFile string `json:"password_file,omitempty" yaml:"password_file,omitempty"`
+	// DB is the number of the database to use
+	DB int `json:"db,omitempty" yaml:"db,omitempty"`
+	// TLS configuration
+	TLSConfig *config.TLSConfig `json:"tls_config,omitempty" yaml:"tls_config,omitempty"`
+	// Timeout of the connection and of every command
+	Timeout common.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+func (r *Redis) Verify() error {
+	if len(r.Addr) == 0 {
+		return fmt.Errorf("addr must be specified")
+	}
+	if len(r.Password) > 0 && len(r.PasswordFile) > 0 {
+		return fmt.Errorf("password and password_file are mutually exclusive. Use one or the other not both at the same time")
+	}
+	if len(r.PasswordFile) > 0 {
+		// Read the file and load the password contained
+		data, err := os.ReadFile(r.PasswordFile)
+		if err != nil {
+			return err
+		}
+		r.Password = secret.Hidden(data)
+	}
+	return nil
+}
+
+// QueryCacheConfig is the configuration of the cache of the responses of the queries sent through the proxy of the
+// HTTP datasources.
+type QueryCacheConfig struct {
+	// Enable the cache of the responses of the queries.
+	Enable bool `json:"enable" yaml:"enable"`
+	// Backend in which the responses are stored. The memory is local to each server, while redis is shared.
+	Backend QueryCacheBackend `json:"backend,omitempty" yaml:"backend,omitempty"`
+	// DefaultTTL is the duration during which a response is cached, when the datasource doesn't define its own.
+	DefaultTTL common.Duration `json:"default_ttl,omitempty" yaml:"default_ttl,omitempty"`
+	// MaxEntries is the maximum number of responses kept by the memory backend.
+	MaxEntries int `json:"max_entries,omitempty" yaml:"max_entries,omitempty"`
+	// MaxResponseSize is the maximum size in bytes of a response to be cached.
+	MaxResponseSize int `json:"max_response_size,omitempty" yaml:"max_response_size,omitempty"`
+	// Redis is the configuration of the redis backend.
+	Redis *Redis `json:"redis,omitempty" yaml:"redis,omitempty"`
+}
+
+func (c *QueryCacheConfig) Verify() error {
+	if !c.Enable {
+		return nil
+	}
+	if len(c.Backend) == 0 {
+		c.Backend = MemoryQueryCache
+	}
+	if c.Backend != MemoryQueryCache && c.Backend != RedisQueryCache {
+		return fmt.Errorf("unknown query cache backend %q, it should be %q or %q", c.Backend, MemoryQueryCache, RedisQueryCache)
+	}
+	if c.Backend == RedisQueryCache && c.Redis == nil {
+		return fmt.Errorf("redis must be configured to use the redis backend of the query cache")
+	}
+	if c.DefaultTTL == 0 {
+		c.DefaultTTL = common.Duration(defaultQueryCacheTTL)
+	}
+	if c.DefaultTTL < 0 {
+		return fmt.Errorf("default_ttl cannot be negative")
+	}
+	if c.MaxEntries <= 0 {
+		c.MaxEntries = defaultQueryCacheMaxEntries
+	}
+	if c.MaxResponseSize <= 0 {
+		c.MaxResponseSize = defaultQueryCacheMaxResponseSize
+	}
+	return nil
+}
+
 type DatasourceConfig struct {
 	Global  GlobalDatasourceConfig  `json:"global" yaml:"global"`
 	Project ProjectDatasourceConfig `json:"project" yaml:"project"`
 	// DisableLocal when used is preventing the possibility to add a datasource directly in the dashboard spec.
 	// It will also disable the associated proxy.
 	DisableLocal bool `json:"disable_local" yaml:"disable_local"`
+	// Cache is the cache of the responses of the queries sent through the proxy.
+	Cache QueryCacheConfig `json:"cache,omitempty" yaml:"cache,omitempty"`
 }
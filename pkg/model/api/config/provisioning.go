@@ -14,11 +14,49 @@
 package config
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
 	"github.com/perses/perses/pkg/model/api/v1/common"
 )
 
+var defaultGitFolder = filepath.Join(os.TempDir(), "perses-git-provisioning")
+
+// GitRepository is a Git repository containing resources to provision.
+type GitRepository struct {
+	// URL of the repository, e.g. https://github.com/my-org/dashboards.git or git@github.com:my-org/dashboards.git.
+	// The repository is cloned with the git command, so the credentials are the ones of the git command (e.g. the SSH
+	// keys or the credential helper), or can be part of the URL.
+	URL string `json:"url" yaml:"url"`
+	// Branch to pull. When empty, the default branch of the repository is used.
+	Branch string `json:"branch,omitempty" yaml:"branch,omitempty"`
+	// Path is the folder of the repository containing the resources. When empty, the whole repository is read.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// Prune deletes the dashboards and the datasources provisioned from the repository when their file is removed.
+	Prune bool `json:"prune,omitempty" yaml:"prune,omitempty"`
+}
+
+func (g *GitRepository) Verify() error {
+	if len(g.URL) == 0 {
+		return fmt.Errorf("the url of the git repository to provision cannot be empty")
+	}
+	if strings.HasPrefix(g.Branch, "-") {
+		return fmt.Errorf("the branch %q of the git repository %q cannot start with a dash", g.Branch, g.URL)
+	}
+	if len(g.Path) > 0 && !filepath.IsLocal(g.Path) {
+		return fmt.Errorf("the path %q of the git repository %q must be a relative path inside the repository", g.Path, g.URL)
+	}
+	return nil
+}
+
 type ProvisioningConfig struct {
 	Folders []string `json:"folders,omitempty" yaml:"folders,omitempty"`
+	// Git is the list of the Git repositories Perses clones and pulls periodically to provision the resources.
+	Git []GitRepository `json:"git,omitempty" yaml:"git,omitempty"`
+	// GitFolder is the folder where the Git repositories are cloned.
+	GitFolder string `json:"git_folder,omitempty" yaml:"git_folder,omitempty"`
 	// Interval is the refresh frequency
 	Interval common.Duration `json:"interval,omitempty" yaml:"interval,omitempty"`
 }
@@ -27,5 +65,8 @@ func (p *ProvisioningConfig) Verify() error {
 	if p.Interval <= 0 {
 		p.Interval = common.Duration(defaultInterval)
 	}
+	if len(p.Git) > 0 && len(p.GitFolder) == 0 {
+		p.GitFolder = defaultGitFolder
+	}
 	return nil
 }
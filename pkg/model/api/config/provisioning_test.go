@@ -0,0 +1,35 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvisioningConfig_Verify(t *testing.T) {
+	c := ProvisioningConfig{Git: []GitRepository{{URL: "https://github.com/my-org/dashboards.git"}}}
+	assert.NoError(t, c.Verify())
+	assert.Equal(t, defaultGitFolder, c.GitFolder)
+
+	noURL := GitRepository{Path: "dashboards"}
+	assert.ErrorContains(t, noURL.Verify(), "the url of the git repository to provision cannot be empty")
+
+	dashBranch := GitRepository{URL: "https://github.com/my-org/dashboards.git", Branch: "--upload-pack=touch"}
+	assert.ErrorContains(t, dashBranch.Verify(), `the branch "--upload-pack=touch" of the git repository "https://github.com/my-org/dashboards.git" cannot start with a dash`)
+
+	outside := GitRepository{URL: "https://github.com/my-org/dashboards.git", Path: "../dashboards"}
+	assert.ErrorContains(t, outside.Verify(), `the path "../dashboards" of the git repository "https://github.com/my-org/dashboards.git" must be a relative path inside the repository`)
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/perses/perses/pkg/client/config"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+)
+
+const (
+	defaultRenderingTimeout = common.Duration(30 * time.Second)
+	defaultRenderingWidth   = 1600
+	defaultRenderingHeight  = 900
+	defaultRenderingMaxSize = 8192
+)
+
+type Rendering struct {
+	// When true, the dashboards can be rendered as a PNG image or as a PDF document through the renderer service.
+	Enable bool `json:"enable" yaml:"enable"`
+	// Renderer is the configuration to contact the renderer service, a headless browser taking screenshots of web pages.
+	Renderer *config.RestConfigClient `json:"renderer,omitempty" yaml:"renderer,omitempty"`
+	// PersesURL is the URL the renderer service uses to reach the Perses UI.
+	PersesURL *common.URL `json:"perses_url,omitempty" yaml:"perses_url,omitempty"`
+	// Timeout is the maximum duration of a rendering.
+	Timeout common.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// Width is the default width in pixels of the rendered dashboard.
+	Width int `json:"width,omitempty" yaml:"width,omitempty"`
+	// Height is the default height in pixels of the rendered dashboard.
+	Height int `json:"height,omitempty" yaml:"height,omitempty"`
+	// MaxWidth is the maximum width in pixels a user can request, as the cost of a rendering grows with its size.
+	MaxWidth int `json:"max_width,omitempty" yaml:"max_width,omitempty"`
+	// MaxHeight is the maximum height in pixels a user can request.
+	MaxHeight int `json:"max_height,omitempty" yaml:"max_height,omitempty"`
+}
+
+func (r *Rendering) Verify() error {
+	if !r.Enable {
+		return nil
+	}
+	if r.Renderer == nil || r.Renderer.URL == nil {
+		return fmt.Errorf("the URL of the renderer service must be set when the rendering is enabled")
+	}
+	if err := r.Renderer.Validate(); err != nil {
+		return err
+	}
+	if r.PersesURL == nil {
+		return fmt.Errorf("the URL of Perses must be set when the rendering is enabled")
+	}
+	if r.Timeout <= 0 {
+		r.Timeout = defaultRenderingTimeout
+	}
+	if r.Width <= 0 {
+		r.Width = defaultRenderingWidth
+	}
+	if r.Height <= 0 {
+		r.Height = defaultRenderingHeight
+	}
+	if r.MaxWidth <= 0 {
+		r.MaxWidth = defaultRenderingMaxSize
+	}
+	if r.MaxHeight <= 0 {
+		r.MaxHeight = defaultRenderingMaxSize
+	}
+	if r.Width > r.MaxWidth {
+		return fmt.Errorf("the default width %d of the rendering is greater than the maximum width %d", r.Width, r.MaxWidth)
+	}
+	if r.Height > r.MaxHeight {
+		return fmt.Errorf("the default height %d of the rendering is greater than the maximum height %d", r.Height, r.MaxHeight)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/perses/perses/pkg/client/config"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRendering_Verify(t *testing.T) {
+	renderer, err := url.Parse("http://renderer:8080")
+	require.NoError(t, err)
+	perses, err := url.Parse("http://perses:8080")
+	require.NoError(t, err)
+	newRendering := func() Rendering {
+		return Rendering{
+			Enable:    true,
+			Renderer:  &config.RestConfigClient{URL: &common.URL{URL: renderer}},
+			PersesURL: &common.URL{URL: perses},
+		}
+	}
+
+	r := newRendering()
+	require.NoError(t, r.Verify())
+	assert.Equal(t, defaultRenderingTimeout, r.Timeout)
+	assert.Equal(t, defaultRenderingWidth, r.Width)
+	assert.Equal(t, defaultRenderingHeight, r.Height)
+	assert.Equal(t, defaultRenderingMaxSize, r.MaxWidth)
+	assert.Equal(t, defaultRenderingMaxSize, r.MaxHeight)
+
+	tooWide := newRendering()
+	tooWide.Width = 2000
+	tooWide.MaxWidth = 1920
+	assert.ErrorContains(t, tooWide.Verify(), "the default width 2000 of the rendering is greater than the maximum width 1920")
+
+	tooHigh := newRendering()
+	tooHigh.MaxHeight = 600
+	assert.ErrorContains(t, tooHigh.Verify(), "the default height 900 of the rendering is greater than the maximum height 600")
+}
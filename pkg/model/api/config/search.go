@@ -0,0 +1,35 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"time"
+
+	"github.com/perses/perses/pkg/model/api/v1/common"
+)
+
+const defaultSearchRefreshInterval = common.Duration(time.Minute)
+
+type Search struct {
+	// RefreshInterval is the interval at which the search index of the dashboards is rebuilt.
+	// A dashboard created or modified is found by the search after at most this interval.
+	RefreshInterval common.Duration `json:"refresh_interval,omitempty" yaml:"refresh_interval,omitempty"`
+}
+
+func (s *Search) Verify() error {
+	if s.RefreshInterval <= 0 {
+		s.RefreshInterval = defaultSearchRefreshInterval
+	}
+	return nil
+}
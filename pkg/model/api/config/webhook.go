@@ -0,0 +1,49 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/perses/perses/pkg/client/config"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+)
+
+const defaultWebhookTimeout = common.Duration(10 * time.Second)
+
+type Webhook struct {
+	// Name identifies the webhook in the logs.
+	Name string `json:"name" yaml:"name"`
+	// Endpoint is the configuration to contact the webhook. The events are sent with a POST request to its URL.
+	Endpoint *config.RestConfigClient `json:"endpoint" yaml:"endpoint"`
+	// Timeout is the maximum duration of the request sending an event.
+	Timeout common.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+func (w *Webhook) Verify() error {
+	if len(w.Name) == 0 {
+		return fmt.Errorf("the name of a webhook must be set")
+	}
+	if w.Endpoint == nil || w.Endpoint.URL == nil {
+		return fmt.Errorf("the URL of the webhook %q must be set", w.Name)
+	}
+	if err := w.Endpoint.Validate(); err != nil {
+		return fmt.Errorf("invalid endpoint for the webhook %q: %w", w.Name, err)
+	}
+	if w.Timeout <= 0 {
+		w.Timeout = defaultWebhookTimeout
+	}
+	return nil
+}
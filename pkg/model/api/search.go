@@ -0,0 +1,43 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+type SearchField string
+
+const (
+	SearchFieldName             SearchField = "name"
+	SearchFieldDescription      SearchField = "description"
+	SearchFieldPanelTitle       SearchField = "panel_title"
+	SearchFieldPanelDescription SearchField = "panel_description"
+	SearchFieldQuery            SearchField = "query"
+)
+
+// SearchMatch is a field of a dashboard matching the searched terms.
+type SearchMatch struct {
+	Field SearchField `json:"field" yaml:"field"`
+	// Panel is the key of the panel when the field belongs to a panel.
+	Panel string `json:"panel,omitempty" yaml:"panel,omitempty"`
+	Value string `json:"value" yaml:"value"`
+}
+
+// SearchResult is a dashboard found by the endpoint /api/v1/search.
+type SearchResult struct {
+	Project     string `json:"project" yaml:"project"`
+	Name        string `json:"name" yaml:"name"`
+	DisplayName string `json:"displayName,omitempty" yaml:"displayName,omitempty"`
+	// Score ranks the results: the higher, the more relevant. A match in the name counts more than a match in a
+	// description, which counts more than a match in a panel or in a query.
+	Score   int           `json:"score" yaml:"score"`
+	Matches []SearchMatch `json:"matches" yaml:"matches"`
+}
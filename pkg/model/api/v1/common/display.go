@@ -16,4 +16,7 @@ package common
 type Display struct {
 	Name        string `json:"name,omitempty" yaml:"name,omitempty"`
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// LocalizedNames contains alternate names per locale, the key being a BCP 47 language tag (e.g. "fr-FR").
+	// Name remains the name displayed when no localized name matches the locale of the user.
+	LocalizedNames map[string]string `json:"localizedNames,omitempty" yaml:"localizedNames,omitempty"`
 }
@@ -0,0 +1,74 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ExternalRef is a reference to a resource stored outside the document, written "<resource>/<name>" where the
+// resource is the plural kind used in the API paths, e.g. "librarypanels/cpu-usage" or "globalvariables/cluster".
+// Unlike JSONRef that points inside the document, it is resolved by the server when the document is saved or
+// retrieved.
+type ExternalRef struct {
+	Resource string `json:"-" yaml:"-"`
+	Name     string `json:"-" yaml:"-"`
+}
+
+func NewExternalRef(resource string, name string) ExternalRef {
+	return ExternalRef{Resource: resource, Name: name}
+}
+
+func (r ExternalRef) String() string {
+	return r.Resource + "/" + r.Name
+}
+
+func (r ExternalRef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+func (r ExternalRef) MarshalYAML() (interface{}, error) {
+	return r.String(), nil
+}
+
+func (r *ExternalRef) UnmarshalJSON(data []byte) error {
+	var ref string
+	if err := json.Unmarshal(data, &ref); err != nil {
+		return err
+	}
+	return r.parse(ref)
+}
+
+func (r *ExternalRef) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var ref string
+	if err := unmarshal(&ref); err != nil {
+		return err
+	}
+	return r.parse(ref)
+}
+
+func (r *ExternalRef) parse(ref string) error {
+	resource, name, found := strings.Cut(ref, "/")
+	if !found || len(resource) == 0 || strings.HasPrefix(resource, "#") {
+		return fmt.Errorf("ref %q is not accepted, it should be <resource>/<name>", ref)
+	}
+	if err := ValidateID(name); err != nil {
+		return fmt.Errorf("ref %q is not accepted: %w", ref, err)
+	}
+	r.Resource = resource
+	r.Name = name
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestUnmarshalExternalRef(t *testing.T) {
+	testSuite := []struct {
+		title     string
+		ref       string
+		result    ExternalRef
+		expectErr bool
+	}{
+		{
+			title:  "library panel",
+			ref:    "librarypanels/cpu-usage",
+			result: ExternalRef{Resource: "librarypanels", Name: "cpu-usage"},
+		},
+		{
+			title:  "global variable",
+			ref:    "globalvariables/cluster",
+			result: ExternalRef{Resource: "globalvariables", Name: "cluster"},
+		},
+		{
+			title:     "reference inside the document",
+			ref:       "#/spec/panels",
+			expectErr: true,
+		},
+		{
+			title:     "missing name",
+			ref:       "variables/",
+			expectErr: true,
+		},
+		{
+			title:     "name with a slash",
+			ref:       "variables/env/prod",
+			expectErr: true,
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			data, _ := json.Marshal(test.ref)
+			result := ExternalRef{}
+			err := json.Unmarshal(data, &result)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.result, result)
+
+			yamlResult := ExternalRef{}
+			assert.NoError(t, yaml.Unmarshal([]byte(test.ref), &yamlResult))
+			assert.Equal(t, test.result, yamlResult)
+		})
+	}
+}
+
+func TestMarshalExternalRef(t *testing.T) {
+	ref := NewExternalRef("librarypanels", "cpu-usage")
+	data, err := json.Marshal(ref)
+	assert.NoError(t, err)
+	assert.Equal(t, `"librarypanels/cpu-usage"`, string(data))
+
+	data, err = yaml.Marshal(ref)
+	assert.NoError(t, err)
+	assert.Equal(t, "librarypanels/cpu-usage\n", string(data))
+}
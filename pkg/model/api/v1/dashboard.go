@@ -17,6 +17,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"time"
 
 	modelAPI "github.com/perses/perses/pkg/model/api"
 	"github.com/perses/perses/pkg/model/api/v1/common"
@@ -74,11 +75,75 @@ type PanelSpec struct {
 	Plugin  common.Plugin `json:"plugin" yaml:"plugin"`
 	Queries []Query       `json:"queries,omitempty" yaml:"queries,omitempty"`
 	Links   []Link        `json:"links,omitempty" yaml:"links,omitempty"`
+	// Transforms are applied, in order, to the series returned by the queries, e.g. to compute the ratio of two
+	// queries. See dashboard.ApplyTransforms.
+	Transforms []dashboard.Transform `json:"transforms,omitempty" yaml:"transforms,omitempty"`
+	// AlertRule is the name of the alert rule visualized by the panel, so tools can cross-link panels and alerts.
+	AlertRule string `json:"alertRule,omitempty" yaml:"alertRule,omitempty"`
+	// Extensions is a free map to store the metadata specific to an organization (e.g. the owner of the panel).
+	// It is ignored by Perses.
+	Extensions map[string]interface{} `json:"extensions,omitempty" yaml:"extensions,omitempty"`
 }
 
 type Panel struct {
-	Kind string    `json:"kind" yaml:"kind"`
-	Spec PanelSpec `json:"spec" yaml:"spec"`
+	Kind string `json:"kind" yaml:"kind"`
+	// LibraryPanel is the name of the LibraryPanel, in the project of the dashboard, the panel refers to.
+	// When set, the spec is replaced by the one of the LibraryPanel each time the dashboard is saved or retrieved.
+	LibraryPanel string `json:"libraryPanel,omitempty" yaml:"libraryPanel,omitempty"`
+	// Ref is the same reference to a LibraryPanel written as an external reference: "librarypanels/<name>".
+	Ref  *common.ExternalRef `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Spec PanelSpec           `json:"spec" yaml:"spec"`
+}
+
+func (p *Panel) UnmarshalJSON(data []byte) error {
+	var tmp Panel
+	type plain Panel
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*p = tmp
+	return nil
+}
+
+func (p *Panel) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp Panel
+	type plain Panel
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*p = tmp
+	return nil
+}
+
+func (p *Panel) validate() error {
+	if err := dashboard.ValidateTransforms(p.Spec.Transforms, len(p.Spec.Queries)); err != nil {
+		return err
+	}
+	if p.Ref == nil {
+		return nil
+	}
+	if p.Ref.Resource != PluralKindMap[KindLibraryPanel] {
+		return fmt.Errorf("a panel can only refer to a library panel, got the ref %q", p.Ref)
+	}
+	if len(p.LibraryPanel) > 0 && p.LibraryPanel != p.Ref.Name {
+		return fmt.Errorf("the panel refers to the library panels %q and %q at the same time", p.LibraryPanel, p.Ref.Name)
+	}
+	return nil
+}
+
+// LibraryPanelName returns the name of the LibraryPanel the panel refers to, either with LibraryPanel or with Ref. It
+// is empty when the panel doesn't refer to a LibraryPanel.
+func (p *Panel) LibraryPanelName() string {
+	if p.Ref != nil {
+		return p.Ref.Name
+	}
+	return p.LibraryPanel
 }
 
 type Query struct {
@@ -87,7 +152,16 @@ type Query struct {
 }
 
 type QuerySpec struct {
-	Plugin common.Plugin `json:"plugin" yaml:"plugin"`
+	// Datasource is the name of the datasource targeted by the query. When set, it takes precedence over the
+	// datasource of the plugin spec, so the queries of a single panel can target different datasources.
+	Datasource string        `json:"datasource,omitempty" yaml:"datasource,omitempty"`
+	Plugin     common.Plugin `json:"plugin" yaml:"plugin"`
+}
+
+// AbsoluteTimeRange is a fixed time range, e.g. the window of an incident.
+type AbsoluteTimeRange struct {
+	Start time.Time `json:"start" yaml:"start"`
+	End   time.Time `json:"end" yaml:"end"`
 }
 
 type DashboardSpec struct {
@@ -99,8 +173,22 @@ type DashboardSpec struct {
 	Layouts     []dashboard.Layout         `json:"layouts" yaml:"layouts"`
 	// Duration is the default time range to use when getting data to fill the dashboard
 	Duration common.Duration `json:"duration" yaml:"duration"`
+	// TimeRange is the fixed time range to use when landing on the dashboard. When set, it takes precedence over
+	// Duration.
+	TimeRange *AbsoluteTimeRange `json:"timeRange,omitempty" yaml:"timeRange,omitempty"`
 	// RefreshInterval is the default refresh interval to use when landing on the dashboard
 	RefreshInterval common.Duration `json:"refreshInterval,omitempty" yaml:"refreshInterval,omitempty"`
+	// RefreshIntervals is the list of refresh intervals selectable on the dashboard.
+	// When empty, the default list of the UI is used. A zero duration stands for "Off".
+	RefreshIntervals []common.Duration `json:"refreshIntervals,omitempty" yaml:"refreshIntervals,omitempty"`
+	// Timezone is the IANA timezone name (e.g. "UTC", "Europe/Paris") used to display the dashboard.
+	// When empty, the timezone of the browser is used.
+	Timezone string `json:"timezone,omitempty" yaml:"timezone,omitempty"`
+	// Links are the links displayed in the toolbar of the dashboard, e.g. to a runbook or to related dashboards.
+	Links []Link `json:"links,omitempty" yaml:"links,omitempty"`
+	// Extensions is a free map to store the metadata specific to an organization (e.g. the owner of the dashboard).
+	// It is ignored by Perses.
+	Extensions map[string]interface{} `json:"extensions,omitempty" yaml:"extensions,omitempty"`
 }
 
 func (d *DashboardSpec) UnmarshalJSON(data []byte) error {
@@ -138,12 +226,18 @@ func (d *DashboardSpec) validate() error {
 		} else {
 			return fmt.Errorf("variable %q (index %d) already exists", name, i)
 		}
+		if variable.Ref != nil && variable.Ref.Resource != PluralKindMap[KindVariable] && variable.Ref.Resource != PluralKindMap[KindGlobalVariable] {
+			return fmt.Errorf("variable %q can only refer to a project or a global variable, got the ref %q", name, variable.Ref)
+		}
 	}
 	for panelKey := range d.Panels {
 		if err := common.ValidateID(panelKey); err != nil {
 			return err
 		}
 	}
+	if d.TimeRange != nil && !d.TimeRange.End.After(d.TimeRange.Start) {
+		return fmt.Errorf("timeRange.end must be after timeRange.start")
+	}
 	return nil
 }
 
@@ -0,0 +1,203 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+type TransformKind string
+
+const (
+	KindMergeSeriesTransform TransformKind = "MergeSeries"
+	KindJoinByLabelTransform TransformKind = "JoinByLabel"
+	KindCalculateTransform   TransformKind = "Calculate"
+)
+
+// maxQueryRefs is the number of queries that can be referenced by the expression of a Calculate transform: from "a" to
+// "z".
+const maxQueryRefs = 26
+
+// QueryRef returns the name used to reference the query at the given index in the expression of a Calculate transform:
+// "a" for the first query, "b" for the second one, etc.
+func QueryRef(index int) string {
+	return string(rune('a' + index))
+}
+
+// MergeSeriesTransformSpec merges the series of a same query having the same labels, e.g. when the query is sharded.
+type MergeSeriesTransformSpec struct {
+	Disabled bool `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+}
+
+// JoinByLabelTransformSpec sums the series of each query by the values of the given labels, so the series of the
+// different queries can be matched by a Calculate transform.
+type JoinByLabelTransformSpec struct {
+	Labels   []string `json:"labels" yaml:"labels"`
+	Disabled bool     `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+}
+
+// Validate checks that the transform has at least one label.
+func (s *JoinByLabelTransformSpec) Validate() error {
+	if len(s.Labels) == 0 {
+		return fmt.Errorf("the JoinByLabel transform requires at least one label")
+	}
+	for _, label := range s.Labels {
+		if len(label) == 0 {
+			return fmt.Errorf("the labels of the JoinByLabel transform cannot be empty")
+		}
+	}
+	return nil
+}
+
+// CalculateTransformSpec computes a new series from the series having the same labels, e.g. "a / b" divides the series
+// of the first query by the one of the second query.
+type CalculateTransformSpec struct {
+	// Name is the name of the series computed. It can be used in the expression of the next Calculate transforms.
+	Name string `json:"name" yaml:"name"`
+	// Expression is an arithmetic expression (+, -, *, /, parentheses and numbers) of the queries, referenced by their
+	// position ("a" for the first query, "b" for the second one, etc.), and of the series computed by the previous
+	// Calculate transforms.
+	Expression string `json:"expression" yaml:"expression"`
+	Disabled   bool   `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+}
+
+// Validate checks the name and the syntax of the expression of the transform.
+func (s *CalculateTransformSpec) Validate() error {
+	if err := validateTransformName(s.Name); err != nil {
+		return err
+	}
+	if _, err := parseExpression(s.Expression); err != nil {
+		return fmt.Errorf("invalid expression of the Calculate transform %q: %w", s.Name, err)
+	}
+	return nil
+}
+
+func validateTransformName(name string) error {
+	if len(name) == 0 {
+		return fmt.Errorf("the name of the Calculate transform cannot be empty")
+	}
+	for i, r := range name {
+		if r != '_' && !isLetter(r) && (i == 0 || !isDigit(r)) {
+			return fmt.Errorf("invalid name %q of the Calculate transform: it must contain only letters, digits or underscores, and cannot start with a digit", name)
+		}
+	}
+	return nil
+}
+
+type tmpTransform struct {
+	Kind TransformKind          `json:"kind" yaml:"kind"`
+	Spec map[string]interface{} `json:"spec" yaml:"spec"`
+}
+
+type Transform struct {
+	Kind TransformKind `json:"kind" yaml:"kind"`
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Spec interface{} `json:"spec" yaml:"spec"`
+}
+
+func (t *Transform) UnmarshalJSON(data []byte) error {
+	jsonUnmarshalFunc := func(transform interface{}) error {
+		return json.Unmarshal(data, transform)
+	}
+	return t.unmarshal(jsonUnmarshalFunc, json.Marshal, json.Unmarshal)
+}
+
+func (t *Transform) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return t.unmarshal(unmarshal, yaml.Marshal, yaml.Unmarshal)
+}
+
+func (t *Transform) unmarshal(unmarshal func(interface{}) error, staticMarshal func(interface{}) ([]byte, error), staticUnmarshal func([]byte, interface{}) error) error {
+	var tmp tmpTransform
+	if err := unmarshal(&tmp); err != nil {
+		return err
+	}
+	if len(tmp.Kind) == 0 {
+		return fmt.Errorf("transform.kind cannot be empty")
+	}
+	rawSpec, err := staticMarshal(tmp.Spec)
+	if err != nil {
+		return err
+	}
+	switch tmp.Kind {
+	case KindMergeSeriesTransform:
+		spec := &MergeSeriesTransformSpec{}
+		if unmarshalErr := staticUnmarshal(rawSpec, spec); unmarshalErr != nil {
+			return unmarshalErr
+		}
+		t.Spec = spec
+	case KindJoinByLabelTransform:
+		spec := &JoinByLabelTransformSpec{}
+		if unmarshalErr := staticUnmarshal(rawSpec, spec); unmarshalErr != nil {
+			return unmarshalErr
+		}
+		if validateErr := spec.Validate(); validateErr != nil {
+			return validateErr
+		}
+		t.Spec = spec
+	case KindCalculateTransform:
+		spec := &CalculateTransformSpec{}
+		if unmarshalErr := staticUnmarshal(rawSpec, spec); unmarshalErr != nil {
+			return unmarshalErr
+		}
+		if validateErr := spec.Validate(); validateErr != nil {
+			return validateErr
+		}
+		t.Spec = spec
+	default:
+		return fmt.Errorf("unknown transform.kind %q used", tmp.Kind)
+	}
+	t.Kind = tmp.Kind
+	return nil
+}
+
+// ValidateTransforms checks that the Calculate transforms only reference the queries of the panel, given their number,
+// or the series computed by the previous Calculate transforms.
+func ValidateTransforms(transforms []Transform, queries int) error {
+	if queries > maxQueryRefs {
+		for _, transform := range transforms {
+			if transform.Kind == KindCalculateTransform {
+				return fmt.Errorf("the Calculate transform cannot be used with more than %d queries", maxQueryRefs)
+			}
+		}
+	}
+	known := make([]string, 0, queries)
+	for i := 0; i < queries; i++ {
+		known = append(known, QueryRef(i))
+	}
+	for _, transform := range transforms {
+		spec, ok := transform.Spec.(*CalculateTransformSpec)
+		if !ok {
+			continue
+		}
+		if err := spec.Validate(); err != nil {
+			return err
+		}
+		if slices.Contains(known, spec.Name) {
+			return fmt.Errorf("the name %q of the Calculate transform is already used by a query or by another Calculate transform", spec.Name)
+		}
+		expr, _ := parseExpression(spec.Expression)
+		for _, name := range expr.references() {
+			if !slices.Contains(known, name) {
+				return fmt.Errorf("the Calculate transform %q references %q, which is neither a query nor a previous Calculate transform", spec.Name, name)
+			}
+		}
+		known = append(known, spec.Name)
+	}
+	return nil
+}
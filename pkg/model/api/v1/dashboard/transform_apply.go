@@ -0,0 +1,193 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+)
+
+type Point struct {
+	Timestamp int64   `json:"timestamp" yaml:"timestamp"`
+	Value     float64 `json:"value" yaml:"value"`
+}
+
+// Series is a time series transformed by the transforms of a panel.
+type Series struct {
+	// Query is the reference of the query the series comes from (see QueryRef), or the name of the Calculate transform
+	// that computed it.
+	Query  string            `json:"query" yaml:"query"`
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	// Points are sorted by timestamp.
+	Points []Point `json:"points" yaml:"points"`
+}
+
+// ApplyTransforms applies the transforms of a panel, in order, to the series returned by its queries. The disabled
+// transforms are skipped. It allows to evaluate the transforms server-side, e.g. to export the data of a panel.
+func ApplyTransforms(series []Series, transforms []Transform) ([]Series, error) {
+	result := series
+	for _, transform := range transforms {
+		var err error
+		switch spec := transform.Spec.(type) {
+		case *MergeSeriesTransformSpec:
+			if !spec.Disabled {
+				result = mergeSeries(result)
+			}
+		case *JoinByLabelTransformSpec:
+			if !spec.Disabled {
+				result = joinByLabel(result, spec.Labels)
+			}
+		case *CalculateTransformSpec:
+			if !spec.Disabled {
+				result, err = calculate(result, spec)
+			}
+		default:
+			err = fmt.Errorf("unknown transform.kind %q used", transform.Kind)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// labelsKey returns a key identifying the given labels, whatever their order.
+func labelsKey(labels map[string]string) string {
+	keys := slices.Sorted(maps.Keys(labels))
+	var builder strings.Builder
+	for _, key := range keys {
+		builder.WriteString(fmt.Sprintf("%q=%q,", key, labels[key]))
+	}
+	return builder.String()
+}
+
+// groupSeries groups the series by query and by labels, keeping the order in which the groups are first seen.
+func groupSeries(series []Series, key func(s Series) string) ([]string, map[string][]Series) {
+	var keys []string
+	groups := make(map[string][]Series)
+	for _, s := range series {
+		k := key(s)
+		if _, exist := groups[k]; !exist {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], s)
+	}
+	return keys, groups
+}
+
+// combinePoints combines the points of the given series having the same timestamp.
+func combinePoints(series []Series, combine func(current, value float64) float64) []Point {
+	values := make(map[int64]float64)
+	for _, s := range series {
+		for _, p := range s.Points {
+			if current, exist := values[p.Timestamp]; exist {
+				values[p.Timestamp] = combine(current, p.Value)
+			} else {
+				values[p.Timestamp] = p.Value
+			}
+		}
+	}
+	points := make([]Point, 0, len(values))
+	for timestamp, value := range values {
+		points = append(points, Point{Timestamp: timestamp, Value: value})
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Timestamp < points[j].Timestamp })
+	return points
+}
+
+func mergeSeries(series []Series) []Series {
+	keys, groups := groupSeries(series, func(s Series) string { return s.Query + "|" + labelsKey(s.Labels) })
+	result := make([]Series, 0, len(keys))
+	for _, k := range keys {
+		group := groups[k]
+		// The first series wins when several series have a point at the same timestamp.
+		result = append(result, Series{
+			Query:  group[0].Query,
+			Labels: group[0].Labels,
+			Points: combinePoints(group, func(current, _ float64) float64 { return current }),
+		})
+	}
+	return result
+}
+
+func joinByLabel(series []Series, labels []string) []Series {
+	joinedLabels := func(s Series) map[string]string {
+		result := make(map[string]string, len(labels))
+		for _, label := range labels {
+			result[label] = s.Labels[label]
+		}
+		return result
+	}
+	keys, groups := groupSeries(series, func(s Series) string { return s.Query + "|" + labelsKey(joinedLabels(s)) })
+	result := make([]Series, 0, len(keys))
+	for _, k := range keys {
+		group := groups[k]
+		result = append(result, Series{
+			Query:  group[0].Query,
+			Labels: joinedLabels(group[0]),
+			Points: combinePoints(group, func(current, value float64) float64 { return current + value }),
+		})
+	}
+	return result
+}
+
+func calculate(series []Series, spec *CalculateTransformSpec) ([]Series, error) {
+	expr, err := parseExpression(spec.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression of the Calculate transform %q: %w", spec.Name, err)
+	}
+	references := expr.references()
+	keys, groups := groupSeries(series, func(s Series) string { return labelsKey(s.Labels) })
+	result := slices.Clone(series)
+	for _, k := range keys {
+		// Index the values of the series referenced by the expression, per timestamp.
+		values := make(map[int64]map[string]float64)
+		var timestamps []int64
+		found := 0
+		for _, s := range groups[k] {
+			if !slices.Contains(references, s.Query) {
+				continue
+			}
+			found++
+			for _, p := range s.Points {
+				if _, exist := values[p.Timestamp]; !exist {
+					values[p.Timestamp] = make(map[string]float64, len(references))
+					timestamps = append(timestamps, p.Timestamp)
+				}
+				if _, exist := values[p.Timestamp][s.Query]; exist {
+					return nil, fmt.Errorf("the Calculate transform %q found several series of %q with the labels %s, use a JoinByLabel transform first", spec.Name, s.Query, k)
+				}
+				values[p.Timestamp][s.Query] = p.Value
+			}
+		}
+		if found == 0 {
+			continue
+		}
+		slices.Sort(timestamps)
+		var points []Point
+		for _, timestamp := range timestamps {
+			// The timestamps missing a referenced value are skipped.
+			if value, ok := expr.eval(values[timestamp]); ok {
+				points = append(points, Point{Timestamp: timestamp, Value: value})
+			}
+		}
+		if len(points) > 0 {
+			result = append(result, Series{Query: spec.Name, Labels: groups[k][0].Labels, Points: points})
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,226 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// expression is the parsed expression of a Calculate transform.
+type expression interface {
+	// eval returns the value of the expression. It returns false when a referenced value is missing.
+	eval(values map[string]float64) (float64, bool)
+	// references returns the names referenced in the expression.
+	references() []string
+}
+
+type numberExpression float64
+
+func (n numberExpression) eval(_ map[string]float64) (float64, bool) {
+	return float64(n), true
+}
+
+func (n numberExpression) references() []string {
+	return nil
+}
+
+type referenceExpression string
+
+func (r referenceExpression) eval(values map[string]float64) (float64, bool) {
+	value, ok := values[string(r)]
+	return value, ok
+}
+
+func (r referenceExpression) references() []string {
+	return []string{string(r)}
+}
+
+type negativeExpression struct {
+	operand expression
+}
+
+func (n negativeExpression) eval(values map[string]float64) (float64, bool) {
+	value, ok := n.operand.eval(values)
+	return -value, ok
+}
+
+func (n negativeExpression) references() []string {
+	return n.operand.references()
+}
+
+type binaryExpression struct {
+	operator    byte
+	left, right expression
+}
+
+func (b binaryExpression) eval(values map[string]float64) (float64, bool) {
+	left, ok := b.left.eval(values)
+	if !ok {
+		return 0, false
+	}
+	right, ok := b.right.eval(values)
+	if !ok {
+		return 0, false
+	}
+	switch b.operator {
+	case '+':
+		return left + right, true
+	case '-':
+		return left - right, true
+	case '*':
+		return left * right, true
+	default:
+		return left / right, true
+	}
+}
+
+func (b binaryExpression) references() []string {
+	result := b.left.references()
+	for _, name := range b.right.references() {
+		if !slices.Contains(result, name) {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// expressionParser is a recursive descent parser of the arithmetic expressions:
+//
+//	expression = term { ("+" | "-") term }
+//	term       = factor { ("*" | "/") factor }
+//	factor     = number | name | "(" expression ")" | "-" factor
+type expressionParser struct {
+	input    string
+	position int
+}
+
+func parseExpression(input string) (expression, error) {
+	p := &expressionParser{input: input}
+	p.skipSpaces()
+	if p.position == len(p.input) {
+		return nil, fmt.Errorf("expression cannot be empty")
+	}
+	expr, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if p.position < len(p.input) {
+		return nil, fmt.Errorf("unexpected character %q at position %d", p.input[p.position], p.position)
+	}
+	return expr, nil
+}
+
+func (p *expressionParser) parseExpression() (expression, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.position < len(p.input) && (p.input[p.position] == '+' || p.input[p.position] == '-') {
+		operator := p.next()
+		right, termErr := p.parseTerm()
+		if termErr != nil {
+			return nil, termErr
+		}
+		left = binaryExpression{operator: operator, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *expressionParser) parseTerm() (expression, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.position < len(p.input) && (p.input[p.position] == '*' || p.input[p.position] == '/') {
+		operator := p.next()
+		right, factorErr := p.parseFactor()
+		if factorErr != nil {
+			return nil, factorErr
+		}
+		left = binaryExpression{operator: operator, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *expressionParser) parseFactor() (expression, error) {
+	if p.position == len(p.input) {
+		return nil, fmt.Errorf("unexpected end of the expression")
+	}
+	c := p.input[p.position]
+	switch {
+	case c == '(':
+		p.next()
+		expr, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if p.position == len(p.input) || p.input[p.position] != ')' {
+			return nil, fmt.Errorf("missing closing parenthesis at position %d", p.position)
+		}
+		p.next()
+		return expr, nil
+	case c == '-':
+		p.next()
+		operand, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return negativeExpression{operand: operand}, nil
+	case isDigit(rune(c)) || c == '.':
+		start := p.position
+		for p.position < len(p.input) && (isDigit(rune(p.input[p.position])) || p.input[p.position] == '.') {
+			p.position++
+		}
+		value, err := strconv.ParseFloat(p.input[start:p.position], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", p.input[start:p.position])
+		}
+		p.skipSpaces()
+		return numberExpression(value), nil
+	case isLetter(rune(c)) || c == '_':
+		start := p.position
+		for p.position < len(p.input) && (isLetter(rune(p.input[p.position])) || isDigit(rune(p.input[p.position])) || p.input[p.position] == '_') {
+			p.position++
+		}
+		name := p.input[start:p.position]
+		p.skipSpaces()
+		return referenceExpression(name), nil
+	default:
+		return nil, fmt.Errorf("unexpected character %q at position %d", c, p.position)
+	}
+}
+
+// next consumes the current character and the spaces after it, and returns the character.
+func (p *expressionParser) next() byte {
+	c := p.input[p.position]
+	p.position++
+	p.skipSpaces()
+	return c
+}
+
+func (p *expressionParser) skipSpaces() {
+	for p.position < len(p.input) && (p.input[p.position] == ' ' || p.input[p.position] == '\t') {
+		p.position++
+	}
+}
+
+func isLetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
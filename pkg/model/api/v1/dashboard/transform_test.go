@@ -0,0 +1,150 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestUnmarshalTransform(t *testing.T) {
+	testSuite := []struct {
+		title  string
+		jason  string
+		result Transform
+	}{
+		{
+			title: "merge series",
+			jason: `{"kind": "MergeSeries", "spec": {}}`,
+			result: Transform{
+				Kind: KindMergeSeriesTransform,
+				Spec: &MergeSeriesTransformSpec{},
+			},
+		},
+		{
+			title: "join by label",
+			jason: `{"kind": "JoinByLabel", "spec": {"labels": ["pod"]}}`,
+			result: Transform{
+				Kind: KindJoinByLabelTransform,
+				Spec: &JoinByLabelTransformSpec{Labels: []string{"pod"}},
+			},
+		},
+		{
+			title: "calculate",
+			jason: `{"kind": "Calculate", "spec": {"name": "ratio", "expression": "a / b * 100", "disabled": true}}`,
+			result: Transform{
+				Kind: KindCalculateTransform,
+				Spec: &CalculateTransformSpec{Name: "ratio", Expression: "a / b * 100", Disabled: true},
+			},
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			result := Transform{}
+			require.NoError(t, json.Unmarshal([]byte(test.jason), &result))
+			assert.Equal(t, test.result, result)
+			// JSON is valid YAML
+			result = Transform{}
+			require.NoError(t, yaml.Unmarshal([]byte(test.jason), &result))
+			assert.Equal(t, test.result, result)
+		})
+	}
+}
+
+func TestUnmarshalTransformError(t *testing.T) {
+	testSuite := []struct {
+		title string
+		jason string
+		err   string
+	}{
+		{
+			title: "unknown kind",
+			jason: `{"kind": "Pivot", "spec": {}}`,
+			err:   `unknown transform.kind "Pivot" used`,
+		},
+		{
+			title: "join without label",
+			jason: `{"kind": "JoinByLabel", "spec": {"labels": []}}`,
+			err:   "the JoinByLabel transform requires at least one label",
+		},
+		{
+			title: "calculate without name",
+			jason: `{"kind": "Calculate", "spec": {"expression": "a / b"}}`,
+			err:   "the name of the Calculate transform cannot be empty",
+		},
+		{
+			title: "invalid expression",
+			jason: `{"kind": "Calculate", "spec": {"name": "ratio", "expression": "a / (b + 1"}}`,
+			err:   `invalid expression of the Calculate transform "ratio": missing closing parenthesis at position 10`,
+		},
+		{
+			title: "unexpected character",
+			jason: `{"kind": "Calculate", "spec": {"name": "ratio", "expression": "a % b"}}`,
+			err:   `invalid expression of the Calculate transform "ratio": unexpected character '%' at position 2`,
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			result := Transform{}
+			assert.EqualError(t, json.Unmarshal([]byte(test.jason), &result), test.err)
+		})
+	}
+}
+
+func TestValidateTransforms(t *testing.T) {
+	calculate := func(name string, expression string) Transform {
+		return Transform{Kind: KindCalculateTransform, Spec: &CalculateTransformSpec{Name: name, Expression: expression}}
+	}
+	assert.NoError(t, ValidateTransforms([]Transform{calculate("ratio", "a / b"), calculate("percent", "ratio * 100")}, 2))
+	assert.EqualError(t, ValidateTransforms([]Transform{calculate("ratio", "a / c")}, 2),
+		`the Calculate transform "ratio" references "c", which is neither a query nor a previous Calculate transform`)
+	assert.EqualError(t, ValidateTransforms([]Transform{calculate("b", "a * 2")}, 2),
+		`the name "b" of the Calculate transform is already used by a query or by another Calculate transform`)
+}
+
+func TestApplyTransforms(t *testing.T) {
+	series := []Series{
+		{Query: "a", Labels: map[string]string{"pod": "api-1", "container": "api"}, Points: []Point{{Timestamp: 1, Value: 2}, {Timestamp: 2, Value: 4}}},
+		{Query: "a", Labels: map[string]string{"pod": "api-1", "container": "proxy"}, Points: []Point{{Timestamp: 1, Value: 1}, {Timestamp: 2, Value: 2}}},
+		{Query: "a", Labels: map[string]string{"pod": "api-2", "container": "api"}, Points: []Point{{Timestamp: 2, Value: 3}}},
+		{Query: "a", Labels: map[string]string{"pod": "api-2", "container": "api"}, Points: []Point{{Timestamp: 1, Value: 5}, {Timestamp: 2, Value: 9}}},
+		{Query: "b", Labels: map[string]string{"pod": "api-1"}, Points: []Point{{Timestamp: 1, Value: 6}, {Timestamp: 2, Value: 12}}},
+		{Query: "b", Labels: map[string]string{"pod": "api-2"}, Points: []Point{{Timestamp: 2, Value: 6}}},
+	}
+	transforms := []Transform{
+		{Kind: KindMergeSeriesTransform, Spec: &MergeSeriesTransformSpec{}},
+		{Kind: KindJoinByLabelTransform, Spec: &JoinByLabelTransformSpec{Labels: []string{"pod"}}},
+		{Kind: KindCalculateTransform, Spec: &CalculateTransformSpec{Name: "ratio", Expression: "a / b"}},
+		{Kind: KindCalculateTransform, Spec: &CalculateTransformSpec{Name: "percent", Expression: "ratio * 100", Disabled: true}},
+	}
+	result, err := ApplyTransforms(series, transforms)
+	require.NoError(t, err)
+	assert.Equal(t, []Series{
+		{Query: "a", Labels: map[string]string{"pod": "api-1"}, Points: []Point{{Timestamp: 1, Value: 3}, {Timestamp: 2, Value: 6}}},
+		{Query: "a", Labels: map[string]string{"pod": "api-2"}, Points: []Point{{Timestamp: 1, Value: 5}, {Timestamp: 2, Value: 3}}},
+		{Query: "b", Labels: map[string]string{"pod": "api-1"}, Points: []Point{{Timestamp: 1, Value: 6}, {Timestamp: 2, Value: 12}}},
+		{Query: "b", Labels: map[string]string{"pod": "api-2"}, Points: []Point{{Timestamp: 2, Value: 6}}},
+		{Query: "ratio", Labels: map[string]string{"pod": "api-1"}, Points: []Point{{Timestamp: 1, Value: 0.5}, {Timestamp: 2, Value: 0.5}}},
+		{Query: "ratio", Labels: map[string]string{"pod": "api-2"}, Points: []Point{{Timestamp: 2, Value: 0.5}}},
+	}, result)
+
+	_, err = ApplyTransforms(series, []Transform{
+		{Kind: KindCalculateTransform, Spec: &CalculateTransformSpec{Name: "ratio", Expression: "a / b"}},
+	})
+	assert.ErrorContains(t, err, `the Calculate transform "ratio" found several series of "a"`)
+}
@@ -124,14 +124,19 @@ func (v *ListVariableSpec) validate() error {
 type Variable struct {
 	// Kind is the type of the variable. Depending on the value of Kind, it will change the content of Spec.
 	Kind variable.Kind `json:"kind" yaml:"kind"`
+	// Ref is the project variable ("variables/<name>") or the global variable ("globalvariables/<name>") the variable
+	// refers to. When set, the kind and the spec are replaced by the ones of the referenced variable each time the
+	// dashboard is saved or retrieved. Only the name of the variable is kept.
+	Ref *common.ExternalRef `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:Schemaless
 	Spec variableSpec `json:"spec" yaml:"spec"`
 }
 
 type tmpVariable struct {
-	Kind variable.Kind `json:"kind" yaml:"kind"`
-	Spec interface{}   `json:"spec" yaml:"spec"`
+	Kind variable.Kind       `json:"kind" yaml:"kind"`
+	Ref  *common.ExternalRef `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Spec interface{}         `json:"spec" yaml:"spec"`
 }
 
 func (v *Variable) UnmarshalJSON(data []byte) error {
@@ -167,6 +172,7 @@ func (v *Variable) unmarshal(unmarshal func(interface{}) error, staticMarshal fu
 		return unMarshalErr
 	}
 	v.Kind = tmp.Kind
+	v.Ref = tmp.Ref
 	v.Spec = spec
 	return nil
 }
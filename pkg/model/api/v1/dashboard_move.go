@@ -0,0 +1,77 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/perses/perses/pkg/model/api/v1/common"
+)
+
+// DashboardMoveRequest is the body of the request moving or copying a dashboard to another project.
+type DashboardMoveRequest struct {
+	// Project is the project the dashboard is moved or copied to.
+	Project string `json:"project" yaml:"project"`
+	// Name is the name of the dashboard in the target project. When empty, the dashboard keeps its name.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Copy keeps the dashboard in its current project.
+	Copy bool `json:"copy,omitempty" yaml:"copy,omitempty"`
+	// DryRun only reports the references that are rewritten and the ones that will break, nothing is saved.
+	DryRun bool `json:"dryRun,omitempty" yaml:"dryRun,omitempty"`
+	// Force moves the dashboard even if some references will break in the target project.
+	Force bool `json:"force,omitempty" yaml:"force,omitempty"`
+}
+
+// Flatten lowers the project and the name of the target when the names are not case-sensitive, like the parameters of
+// the request.
+func (r *DashboardMoveRequest) Flatten(sensitive bool) {
+	if !sensitive {
+		r.Project = strings.ToLower(r.Project)
+		r.Name = strings.ToLower(r.Name)
+	}
+}
+
+func (r *DashboardMoveRequest) Validate() error {
+	if err := common.ValidateID(r.Project); err != nil {
+		return fmt.Errorf("invalid project: %w", err)
+	}
+	if len(r.Name) > 0 {
+		if err := common.ValidateID(r.Name); err != nil {
+			return fmt.Errorf("invalid name: %w", err)
+		}
+	}
+	return nil
+}
+
+// DashboardReference is a reference of a dashboard to a resource of its project.
+type DashboardReference struct {
+	Kind Kind   `json:"kind" yaml:"kind"`
+	Name string `json:"name" yaml:"name"`
+	// Path is the location of the reference in the dashboard, e.g. "spec.panels.cpu.spec.queries[0]".
+	Path string `json:"path" yaml:"path"`
+	// Reason explains why the reference has been rewritten or will break.
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+// DashboardMoveResult is the result of the move or the copy of a dashboard to another project.
+type DashboardMoveResult struct {
+	// Dashboard is the dashboard in the target project. With a dry run, it is the dashboard as it would be saved.
+	Dashboard *Dashboard `json:"dashboard" yaml:"dashboard"`
+	// Rewritten are the references to resources missing in the target project that have been replaced by a copy of the
+	// resource in the dashboard.
+	Rewritten []DashboardReference `json:"rewritten,omitempty" yaml:"rewritten,omitempty"`
+	// Broken are the references to resources missing in the target project that cannot be rewritten.
+	Broken []DashboardReference `json:"broken,omitempty" yaml:"broken,omitempty"`
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/perses/perses/pkg/model/api/v1/dashboard"
 	"github.com/perses/perses/pkg/model/api/v1/variable"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type TimeSeriesSpec struct {
@@ -523,6 +524,80 @@ func TestUnmarshalDashboardError(t *testing.T) {
 `,
 			err: fmt.Errorf("spec cannot be empty"),
 		},
+		{
+			title: "time range end before start",
+			jason: `
+{
+  "kind": "Dashboard",
+  "metadata": {
+    "name": "test",
+    "project": "perses"
+  },
+  "spec": {
+    "duration": "1h",
+    "timeRange": {
+      "start": "2025-03-01T12:00:00Z",
+      "end": "2025-03-01T10:00:00Z"
+    },
+    "panels": {},
+    "layouts": []
+  }
+}
+`,
+			err: fmt.Errorf("timeRange.end must be after timeRange.start"),
+		},
+		{
+			title: "panel referring to a variable",
+			jason: `
+{
+  "kind": "Dashboard",
+  "metadata": {
+    "name": "test",
+    "project": "perses"
+  },
+  "spec": {
+    "duration": "1h",
+    "panels": {
+      "cpu": {
+        "kind": "Panel",
+        "$ref": "variables/cpu",
+        "spec": {
+          "display": {"name": "cpu"},
+          "plugin": {"kind": "Markdown", "spec": {}}
+        }
+      }
+    },
+    "layouts": []
+  }
+}
+`,
+			err: fmt.Errorf("a panel can only refer to a library panel, got the ref \"variables/cpu\""),
+		},
+		{
+			title: "variable referring to a library panel",
+			jason: `
+{
+  "kind": "Dashboard",
+  "metadata": {
+    "name": "test",
+    "project": "perses"
+  },
+  "spec": {
+    "duration": "1h",
+    "variables": [
+      {
+        "kind": "TextVariable",
+        "$ref": "librarypanels/env",
+        "spec": {"name": "env", "value": ""}
+      }
+    ],
+    "panels": {},
+    "layouts": []
+  }
+}
+`,
+			err: fmt.Errorf("variable \"env\" can only refer to a project or a global variable, got the ref \"librarypanels/env\""),
+		},
 	}
 	for _, test := range testSuite {
 		t.Run(test.title, func(t *testing.T) {
@@ -531,3 +606,46 @@ func TestUnmarshalDashboardError(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalDashboardExternalRefs(t *testing.T) {
+	jason := `
+{
+  "kind": "Dashboard",
+  "metadata": {
+    "name": "test",
+    "project": "perses"
+  },
+  "spec": {
+    "duration": "1h",
+    "variables": [
+      {
+        "kind": "TextVariable",
+        "$ref": "globalvariables/cluster",
+        "spec": {"name": "cluster", "value": ""}
+      }
+    ],
+    "panels": {
+      "cpu": {
+        "kind": "Panel",
+        "$ref": "librarypanels/cpu-usage",
+        "spec": {
+          "display": {"name": "cpu"},
+          "plugin": {"kind": "Markdown", "spec": {}}
+        }
+      }
+    },
+    "layouts": []
+  }
+}
+`
+	result := Dashboard{}
+	require.NoError(t, json.Unmarshal([]byte(jason), &result))
+	assert.Equal(t, "cpu-usage", result.Spec.Panels["cpu"].LibraryPanelName())
+	assert.Equal(t, &common.ExternalRef{Resource: "globalvariables", Name: "cluster"}, result.Spec.Variables[0].Ref)
+
+	// the references are kept when the dashboard is saved
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"$ref":"librarypanels/cpu-usage"`)
+	assert.Contains(t, string(data), `"$ref":"globalvariables/cluster"`)
+}
@@ -81,6 +81,10 @@ type Config struct {
 	// Secret is the name of the secret that should be used for the proxy or discovery configuration
 	// It will contain any sensitive information such as password, token, certificate.
 	Secret string `json:"secret,omitempty" yaml:"secret,omitempty"`
+	// CacheTTL is the duration during which the responses of the queries are cached by the proxy, when the cache is
+	// enabled in the configuration of the server. When not set, the default TTL of the server is used. 0 disables
+	// the cache for this datasource.
+	CacheTTL *common.Duration `json:"cacheTTL,omitempty" yaml:"cacheTTL,omitempty"`
 }
 
 func (h *Config) UnmarshalJSON(data []byte) error {
@@ -113,6 +117,9 @@ func (h *Config) validate() error {
 	if h.URL == nil {
 		return fmt.Errorf("url cannot be empty")
 	}
+	if h.CacheTTL != nil && *h.CacheTTL < 0 {
+		return fmt.Errorf("cacheTTL cannot be negative")
+	}
 	return nil
 }
 
@@ -26,6 +26,7 @@ type Driver string
 const (
 	DriverMySQL      Driver = "mysql"
 	DriverPostgreSQL Driver = "postgres"
+	DriverClickHouse Driver = "clickhouse"
 )
 
 // SSLMode postgres ssl modes
@@ -48,6 +49,16 @@ type MySQLConfig struct {
 	WriteTimeout     time.Duration     `json:"writeTimeout,omitempty" yaml:"writeTimeout,omitempty"`
 }
 
+// ClickHouseConfig is the ClickHouse specific driver config. ClickHouse is reached through its HTTP interface.
+type ClickHouseConfig struct {
+	// Secure makes the proxy use HTTPS to contact ClickHouse
+	Secure bool `json:"secure,omitempty" yaml:"secure,omitempty"`
+	// Timeout is the maximum duration of a query
+	Timeout time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// Settings are the ClickHouse settings applied to the queries, e.g. max_execution_time
+	Settings map[string]string `json:"settings,omitempty" yaml:"settings,omitempty"`
+}
+
 type PostgresConfig struct {
 	// MaxConns is the maximum size of the pool
 	MaxConns int32 `json:"maxConns,omitempty" yaml:"maxConns,omitempty"`
@@ -116,6 +127,8 @@ type Config struct {
 	MySQL *MySQLConfig `json:"mysql,omitempty" yaml:"mysql,omitempty"`
 	// Postgres specific driver config
 	Postgres *PostgresConfig `json:"postgres,omitempty" yaml:"postgres,omitempty"`
+	// ClickHouse specific driver config
+	ClickHouse *ClickHouseConfig `json:"clickhouse,omitempty" yaml:"clickhouse,omitempty"`
 }
 
 func (s *Config) UnmarshalJSON(data []byte) error {
@@ -149,7 +162,7 @@ func (s *Config) validate() error {
 		return errors.New("driver is required")
 	}
 
-	if s.Driver != DriverMySQL && s.Driver != DriverPostgreSQL {
+	if s.Driver != DriverMySQL && s.Driver != DriverPostgreSQL && s.Driver != DriverClickHouse {
 		return fmt.Errorf("driver %s is not supported", s.Driver)
 	}
 
@@ -49,6 +49,44 @@ func TestUnmarshalJSONConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			title: "clickhouse config",
+			jason: `
+{
+  "driver": "clickhouse",
+  "host": "localhost:8123",
+  "database": "otel",
+  "clickhouse": {
+    "secure": true,
+    "settings": {
+      "max_execution_time": "30"
+    }
+  }
+}
+`,
+			result: Config{
+				Driver:   DriverClickHouse,
+				Host:     "localhost:8123",
+				Database: "otel",
+				ClickHouse: &ClickHouseConfig{
+					Secure: true,
+					Settings: map[string]string{
+						"max_execution_time": "30",
+					},
+				},
+			},
+		},
+		{
+			title: "unsupported driver",
+			jason: `
+{
+  "driver": "oracle",
+  "host": "localhost:1521",
+  "database": "test"
+}
+`,
+			expectErr: true,
+		},
 		{
 			title: "invalid SSL mode",
 			jason: `
@@ -33,12 +33,19 @@ const (
 	KindGlobalRoleBinding  Kind = "GlobalRoleBinding"
 	KindGlobalVariable     Kind = "GlobalVariable"
 	KindGlobalSecret       Kind = "GlobalSecret"
+	KindLibraryPanel       Kind = "LibraryPanel"
 	KindProject            Kind = "Project"
 	KindRole               Kind = "Role"
 	KindRoleBinding        Kind = "RoleBinding"
 	KindSecret             Kind = "Secret"
+	KindSnapshot           Kind = "Snapshot"
 	KindUser               Kind = "User"
 	KindVariable           Kind = "Variable"
+	// KindDashboardHistory is only used to store the previous versions of the dashboards. It is not a resource of
+	// the API.
+	KindDashboardHistory Kind = "DashboardHistory"
+	// KindAuditEvent is only used to store the events of the audit log. It is not a resource of the API.
+	KindAuditEvent Kind = "AuditEvent"
 )
 
 var PluralKindMap = map[Kind]string{
@@ -51,12 +58,16 @@ var PluralKindMap = map[Kind]string{
 	KindGlobalRoleBinding:  "globalrolebindings",
 	KindGlobalSecret:       "globalsecrets",
 	KindGlobalVariable:     "globalvariables",
+	KindLibraryPanel:       "librarypanels",
 	KindProject:            "projects",
 	KindRole:               "roles",
 	KindRoleBinding:        "rolebindings",
 	KindSecret:             "secrets",
+	KindSnapshot:           "snapshots",
 	KindUser:               "users",
 	KindVariable:           "variables",
+	KindDashboardHistory:   "dashboardhistories",
+	KindAuditEvent:         "auditevents",
 }
 
 func (k *Kind) UnmarshalJSON(data []byte) error {
@@ -118,6 +129,8 @@ func GetStruct(kind Kind) (modelAPI.Entity, error) {
 		return &GlobalSecret{}, nil
 	case KindGlobalVariable:
 		return &GlobalVariable{}, nil
+	case KindLibraryPanel:
+		return &LibraryPanel{}, nil
 	case KindProject:
 		return &Project{}, nil
 	case KindRole:
@@ -126,6 +139,8 @@ func GetStruct(kind Kind) (modelAPI.Entity, error) {
 		return &RoleBinding{}, nil
 	case KindSecret:
 		return &Secret{}, nil
+	case KindSnapshot:
+		return &Snapshot{}, nil
 	case KindUser:
 		return &User{}, nil
 	case KindVariable:
@@ -174,6 +189,9 @@ func GetKind(kind string) (*Kind, error) {
 	case strings.ToLower(string(KindGlobalVariable)):
 		result := KindGlobalVariable
 		return &result, nil
+	case strings.ToLower(string(KindLibraryPanel)):
+		result := KindLibraryPanel
+		return &result, nil
 	case strings.ToLower(string(KindProject)):
 		result := KindProject
 		return &result, nil
@@ -186,6 +204,9 @@ func GetKind(kind string) (*Kind, error) {
 	case strings.ToLower(string(KindSecret)):
 		result := KindSecret
 		return &result, nil
+	case strings.ToLower(string(KindSnapshot)):
+		result := KindSnapshot
+		return &result, nil
 	case strings.ToLower(string(KindUser)):
 		result := KindUser
 		return &result, nil
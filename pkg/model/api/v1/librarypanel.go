@@ -0,0 +1,77 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+)
+
+// LibraryPanel is a panel defined once in a project and referenced by the dashboards of the project (see
+// Panel.LibraryPanel), so updating it updates all these dashboards.
+type LibraryPanel struct {
+	Kind     Kind            `json:"kind" yaml:"kind"`
+	Metadata ProjectMetadata `json:"metadata" yaml:"metadata"`
+	Spec     PanelSpec       `json:"spec" yaml:"spec"`
+}
+
+func (l *LibraryPanel) GetMetadata() modelAPI.Metadata {
+	return &l.Metadata
+}
+
+func (l *LibraryPanel) GetKind() string {
+	return string(l.Kind)
+}
+
+func (l *LibraryPanel) GetSpec() interface{} {
+	return l.Spec
+}
+
+func (l *LibraryPanel) UnmarshalJSON(data []byte) error {
+	var tmp LibraryPanel
+	type plain LibraryPanel
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*l = tmp
+	return nil
+}
+
+func (l *LibraryPanel) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp LibraryPanel
+	type plain LibraryPanel
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*l = tmp
+	return nil
+}
+
+func (l *LibraryPanel) validate() error {
+	if l.Kind != KindLibraryPanel {
+		return fmt.Errorf("invalid kind: %q for a LibraryPanel type", l.Kind)
+	}
+	if len(l.Spec.Plugin.Kind) == 0 {
+		return fmt.Errorf("spec.plugin.kind cannot be empty")
+	}
+	return nil
+}
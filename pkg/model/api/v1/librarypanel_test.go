@@ -0,0 +1,102 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalLibraryPanel(t *testing.T) {
+	jason := `
+{
+  "kind": "LibraryPanel",
+  "metadata": {
+    "name": "cpu-usage",
+    "project": "perses"
+  },
+  "spec": {
+    "display": {
+      "name": "CPU usage"
+    },
+    "plugin": {
+      "kind": "TimeSeriesChart",
+      "spec": {}
+    }
+  }
+}
+`
+	result := LibraryPanel{}
+	assert.NoError(t, json.Unmarshal([]byte(jason), &result))
+	assert.Equal(t, "CPU usage", result.Spec.Display.Name)
+	assert.Equal(t, "TimeSeriesChart", result.Spec.Plugin.Kind)
+}
+
+func TestUnmarshalLibraryPanelError(t *testing.T) {
+	testSuite := []struct {
+		title string
+		jason string
+		err   error
+	}{
+		{
+			title: "invalid kind",
+			jason: `
+{
+  "kind": "Dashboard",
+  "metadata": {
+    "name": "cpu-usage",
+    "project": "perses"
+  },
+  "spec": {
+    "display": {
+      "name": "CPU usage"
+    },
+    "plugin": {
+      "kind": "TimeSeriesChart",
+      "spec": {}
+    }
+  }
+}
+`,
+			err: fmt.Errorf("invalid kind: \"Dashboard\" for a LibraryPanel type"),
+		},
+		{
+			title: "plugin cannot be empty",
+			jason: `
+{
+  "kind": "LibraryPanel",
+  "metadata": {
+    "name": "cpu-usage",
+    "project": "perses"
+  },
+  "spec": {
+    "display": {
+      "name": "CPU usage"
+    }
+  }
+}
+`,
+			err: fmt.Errorf("spec.plugin.kind cannot be empty"),
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			result := LibraryPanel{}
+			assert.Equal(t, test.err, json.Unmarshal([]byte(test.jason), &result))
+		})
+	}
+}
@@ -40,6 +40,9 @@ type Metadata struct {
 	// +kubebuilder:validation:Optional
 	UpdatedAt time.Time `json:"updatedAt" yaml:"updatedAt"`
 	Version   uint64    `json:"version" yaml:"version"`
+	// Annotations are free key/value pairs that tools can attach to a resource. Perses only uses the ones prefixed by
+	// perses.dev/, e.g. to know from which Git repository a resource has been provisioned.
+	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
 }
 
 func (m *Metadata) CreateNow() {
@@ -16,6 +16,8 @@ package role
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 )
 
 type Permission struct {
@@ -24,6 +26,10 @@ type Permission struct {
 	// The list of kind targeted by the permission. For example: `Datasource`, `Dashboard`, ...
 	// With Role, you can't target global kinds
 	Scopes []Scope `json:"scopes" yaml:"scopes"`
+	// Resources restricts the permission to the resources with these names, e.g. to give access to some dashboards of a
+	// project only. When empty, the permission applies to all the resources of the scopes.
+	// It can only be used with the scope `Dashboard`.
+	Resources []string `json:"resources,omitempty" yaml:"resources,omitempty"`
 }
 
 func (p *Permission) UnmarshalJSON(data []byte) error {
@@ -59,5 +65,37 @@ func (p *Permission) validate() error {
 	if len(p.Scopes) == 0 {
 		return fmt.Errorf("permission scopes cannot be empty")
 	}
+	if len(p.Resources) > 0 {
+		for _, scope := range p.Scopes {
+			if scope != DashboardScope {
+				return fmt.Errorf("permission resources can only be used with the scope %q, not %q", DashboardScope, scope)
+			}
+		}
+		for _, resource := range p.Resources {
+			if len(resource) == 0 {
+				return fmt.Errorf("permission resources cannot contain an empty name")
+			}
+		}
+	}
 	return nil
 }
+
+// IsRestricted returns true if the permission only applies to some resources of its scopes.
+func (p *Permission) IsRestricted() bool {
+	return len(p.Resources) > 0
+}
+
+// Flatten lowercases the names of the resources when the names are not case-sensitive, like the names of the
+// resources themselves (see Metadata.Flatten).
+func (p *Permission) Flatten(sensitive bool) {
+	if !sensitive {
+		for i, resource := range p.Resources {
+			p.Resources[i] = strings.ToLower(resource)
+		}
+	}
+}
+
+// AppliesTo returns true if the permission applies to the resource with the given name.
+func (p *Permission) AppliesTo(name string) bool {
+	return !p.IsRestricted() || slices.Contains(p.Resources, name)
+}
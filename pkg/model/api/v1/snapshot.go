@@ -0,0 +1,107 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+)
+
+// SnapshotRequest is the body of the request taking a snapshot of a dashboard. The query results are the ones
+// displayed by the client, since the queries are run by the client.
+type SnapshotRequest struct {
+	// Name of the snapshot. When empty, a name is generated from the name of the dashboard.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// TimeRange is the absolute time range of the query results.
+	TimeRange AbsoluteTimeRange `json:"timeRange" yaml:"timeRange"`
+	// Data is, for each panel key, the results of the queries of the panel in the order of the queries.
+	Data map[string][]interface{} `json:"data" yaml:"data"`
+}
+
+func (s *SnapshotRequest) Validate() error {
+	if !s.TimeRange.End.After(s.TimeRange.Start) {
+		return fmt.Errorf("timeRange.end must be after timeRange.start")
+	}
+	return nil
+}
+
+type SnapshotSpec struct {
+	// Dashboard is the name of the dashboard the snapshot has been taken from.
+	Dashboard string `json:"dashboard" yaml:"dashboard"`
+	// TimeRange is the absolute time range of the query results.
+	TimeRange AbsoluteTimeRange `json:"timeRange" yaml:"timeRange"`
+	// DashboardSpec is the definition of the dashboard when the snapshot has been taken.
+	DashboardSpec DashboardSpec `json:"dashboardSpec" yaml:"dashboardSpec"`
+	// Data is, for each panel key, the results of the queries of the panel in the order of the queries. The results are
+	// stored as returned by the query plugins, so the snapshot can be viewed without access to the datasources.
+	Data map[string][]interface{} `json:"data" yaml:"data"`
+}
+
+// Snapshot is an immutable copy of a dashboard with the results of its queries for a time range, e.g. to share in a
+// postmortem what a dashboard displayed during an incident.
+type Snapshot struct {
+	Kind     Kind            `json:"kind" yaml:"kind"`
+	Metadata ProjectMetadata `json:"metadata" yaml:"metadata"`
+	Spec     SnapshotSpec    `json:"spec" yaml:"spec"`
+}
+
+func (s *Snapshot) GetMetadata() modelAPI.Metadata {
+	return &s.Metadata
+}
+
+func (s *Snapshot) GetKind() string {
+	return string(s.Kind)
+}
+
+func (s *Snapshot) GetSpec() interface{} {
+	return s.Spec
+}
+
+func (s *Snapshot) UnmarshalJSON(data []byte) error {
+	var tmp Snapshot
+	type plain Snapshot
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*s = tmp
+	return nil
+}
+
+func (s *Snapshot) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp Snapshot
+	type plain Snapshot
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*s = tmp
+	return nil
+}
+
+func (s *Snapshot) validate() error {
+	if s.Kind != KindSnapshot {
+		return fmt.Errorf("invalid kind: %q for a Snapshot type", s.Kind)
+	}
+	if len(s.Spec.Dashboard) == 0 {
+		return fmt.Errorf("spec.dashboard cannot be empty")
+	}
+	return verifyAndSetJSONReferences(s.Spec.DashboardSpec.Layouts, s.Spec.DashboardSpec.Panels)
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalSnapshot(t *testing.T) {
+	jason := `
+{
+  "kind": "Snapshot",
+  "metadata": {
+    "name": "node-incident",
+    "project": "perses"
+  },
+  "spec": {
+    "dashboard": "node",
+    "timeRange": {
+      "start": "2025-01-01T10:00:00Z",
+      "end": "2025-01-01T11:00:00Z"
+    },
+    "dashboardSpec": {
+      "duration": "1h",
+      "panels": {
+        "cpu": {
+          "kind": "Panel",
+          "spec": {
+            "display": {
+              "name": "CPU"
+            },
+            "plugin": {
+              "kind": "TimeSeriesChart",
+              "spec": {}
+            }
+          }
+        }
+      },
+      "layouts": [
+        {
+          "kind": "Grid",
+          "spec": {
+            "items": [
+              {
+                "x": 0,
+                "y": 0,
+                "width": 12,
+                "height": 6,
+                "content": {
+                  "$ref": "#/spec/panels/cpu"
+                }
+              }
+            ]
+          }
+        }
+      ]
+    },
+    "data": {
+      "cpu": [
+        {
+          "series": []
+        }
+      ]
+    }
+  }
+}
+`
+	result := Snapshot{}
+	assert.NoError(t, json.Unmarshal([]byte(jason), &result))
+	assert.Equal(t, "node", result.Spec.Dashboard)
+	assert.Equal(t, time.Date(2025, 1, 1, 11, 0, 0, 0, time.UTC), result.Spec.TimeRange.End)
+	assert.Equal(t, []interface{}{map[string]interface{}{"series": []interface{}{}}}, result.Spec.Data["cpu"])
+}
+
+func TestUnmarshalSnapshotError(t *testing.T) {
+	testSuite := []struct {
+		title string
+		jason string
+		err   error
+	}{
+		{
+			title: "invalid kind",
+			jason: `
+{
+  "kind": "Dashboard",
+  "metadata": {
+    "name": "node-incident",
+    "project": "perses"
+  },
+  "spec": {
+    "dashboard": "node"
+  }
+}
+`,
+			err: fmt.Errorf("invalid kind: \"Dashboard\" for a Snapshot type"),
+		},
+		{
+			title: "dashboard cannot be empty",
+			jason: `
+{
+  "kind": "Snapshot",
+  "metadata": {
+    "name": "node-incident",
+    "project": "perses"
+  },
+  "spec": {}
+}
+`,
+			err: fmt.Errorf("spec.dashboard cannot be empty"),
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			result := Snapshot{}
+			assert.Equal(t, test.err, json.Unmarshal([]byte(test.jason), &result))
+		})
+	}
+}
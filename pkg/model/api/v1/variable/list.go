@@ -123,6 +123,58 @@ func (s *Sort) validate() error {
 	return nil
 }
 
+// Refresh tells when the values of a list variable are reloaded. Without refresh policy, the values are reloaded each
+// time the dashboard is refreshed. In any case, the values are reloaded when a variable they depend on changes.
+type Refresh string
+
+const (
+	// RefreshOnDashboardLoad loads the values once, when the dashboard is loaded.
+	RefreshOnDashboardLoad Refresh = "onDashboardLoad"
+	// RefreshOnTimeRangeChange reloads the values when the time range of the dashboard changes.
+	RefreshOnTimeRangeChange Refresh = "onTimeRangeChange"
+)
+
+var RefreshMap = map[Refresh]bool{
+	RefreshOnDashboardLoad:   true,
+	RefreshOnTimeRangeChange: true,
+}
+
+func (r *Refresh) UnmarshalJSON(data []byte) error {
+	var tmp Refresh
+	type plain Refresh
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*r = tmp
+	return nil
+}
+
+func (r *Refresh) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp Refresh
+	type plain Refresh
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*r = tmp
+	return nil
+}
+
+func (r *Refresh) validate() error {
+	if len(*r) == 0 {
+		return nil
+	}
+	if _, ok := RefreshMap[*r]; !ok {
+		return fmt.Errorf("unknown refresh policy %q used", *r)
+	}
+	return nil
+}
+
 type ListSpec struct {
 	Display *Display `json:"display,omitempty" yaml:"display,omitempty"`
 	// Value from the list to be selected by default.
@@ -137,8 +189,13 @@ type ListSpec struct {
 	// If empty, then nothing is filtered. That's the equivalent of setting CapturingRegexp with (.*)
 	CapturingRegexp string `json:"capturingRegexp,omitempty" yaml:"capturingRegexp,omitempty"`
 	// Sort method to apply when rendering the list of values
-	Sort   *Sort         `json:"sort,omitempty" yaml:"sort,omitempty"`
-	Plugin common.Plugin `json:"plugin" yaml:"plugin"`
+	Sort *Sort `json:"sort,omitempty" yaml:"sort,omitempty"`
+	// Refresh tells when the values are reloaded. See Refresh.
+	Refresh *Refresh      `json:"refresh,omitempty" yaml:"refresh,omitempty"`
+	Plugin  common.Plugin `json:"plugin" yaml:"plugin"`
+	// Extensions is a free map to store the metadata specific to an organization (e.g. the owner of the variable).
+	// It is ignored by Perses.
+	Extensions map[string]interface{} `json:"extensions,omitempty" yaml:"extensions,omitempty"`
 }
 
 func (v *ListSpec) Validate() error {
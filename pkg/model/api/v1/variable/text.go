@@ -21,6 +21,9 @@ type TextSpec struct {
 	Display  *Display `json:"display,omitempty" yaml:"display,omitempty"`
 	Value    string   `json:"value" yaml:"value"`
 	Constant bool     `json:"constant,omitempty" yaml:"constant,omitempty"`
+	// Extensions is a free map to store the metadata specific to an organization (e.g. the owner of the variable).
+	// It is ignored by Perses.
+	Extensions map[string]interface{} `json:"extensions,omitempty" yaml:"extensions,omitempty"`
 }
 
 func (v *TextSpec) Validate() error {
@@ -0,0 +1,38 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import "time"
+
+type WebhookAction string
+
+const (
+	WebhookActionCreated WebhookAction = "created"
+	WebhookActionUpdated WebhookAction = "updated"
+	WebhookActionDeleted WebhookAction = "deleted"
+)
+
+// WebhookEvent is the body sent to the webhooks when a resource is created, updated or deleted.
+type WebhookEvent struct {
+	Kind    string        `json:"kind" yaml:"kind"`
+	Project string        `json:"project,omitempty" yaml:"project,omitempty"`
+	Name    string        `json:"name" yaml:"name"`
+	Action  WebhookAction `json:"action" yaml:"action"`
+	// Actor is the username of the user who made the change. It is empty when the authorization is disabled.
+	Actor     string    `json:"actor,omitempty" yaml:"actor,omitempty"`
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+	// Diff summarizes the changes of the spec for an update, one line per field added (+), removed (-) or
+	// modified (~), e.g. "~ panels.cpu".
+	Diff []string `json:"diff,omitempty" yaml:"diff,omitempty"`
+}
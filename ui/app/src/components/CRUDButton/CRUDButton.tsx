@@ -22,17 +22,20 @@ export interface CRUDButtonProps extends Omit<ButtonProps, 'action'> {
   action?: Action;
   scope?: Scope;
   project?: string;
+  resourceName?: string;
 }
 
 /*
  * CRUDButton is an alias of MUI Button, that will add a Tooltip with a reason if the button need to be disabled.
  * If action, scope and project are provided, it will check if the user has the permission to execute the action.
+ * The name of the resource can be provided as well, to consider the permissions restricted to some resources.
  */
 export function CRUDButton({
   children,
   action,
   scope,
   project,
+  resourceName,
   variant,
   color,
   disabled,
@@ -41,7 +44,7 @@ export function CRUDButton({
 }: CRUDButtonProps): ReactElement {
   const isReadonly = useIsReadonly();
   const isMobileSize = useIsMobileSize();
-  const hasPermission = useHasPermission(action ?? '*', project ?? GlobalProject, scope ?? '*');
+  const hasPermission = useHasPermission(action ?? '*', project ?? GlobalProject, scope ?? '*', resourceName);
 
   if (isReadonly) {
     return (
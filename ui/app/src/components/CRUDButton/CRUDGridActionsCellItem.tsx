@@ -24,12 +24,14 @@ interface CRUDGridActionsCellItemProps {
   action?: Action;
   scope?: Scope;
   project?: string;
+  resourceName?: string;
   onClick: () => void;
 }
 
 /*
  * CRUDGridActionsCellItem is an alias of MUI GridActionsCellItem, that will add a Tooltip with a reason if the button need to be disabled.
  * If action, scope and project are provided, it will check if the user has the permission to execute the action.
+ * The name of the resource can be provided as well, to consider the permissions restricted to some resources.
  */
 export function CRUDGridActionsCellItem({
   icon,
@@ -37,10 +39,11 @@ export function CRUDGridActionsCellItem({
   action,
   scope,
   project,
+  resourceName,
   onClick,
 }: CRUDGridActionsCellItemProps): ReactElement {
   const isReadonly = useIsReadonly();
-  const hasPermission = useHasPermission(action ?? '*', project ?? GlobalProject, scope ?? '*');
+  const hasPermission = useHasPermission(action ?? '*', project ?? GlobalProject, scope ?? '*', resourceName);
 
   if (isReadonly) {
     return (
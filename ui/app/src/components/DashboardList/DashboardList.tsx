@@ -185,6 +185,7 @@ export function DashboardList(props: DashboardListProperties): ReactElement {
             action="update"
             scope="Dashboard"
             project={params.row.project}
+            resourceName={params.row.name}
             onClick={handleRenameButtonClick(params.row.project, params.row.name)}
           />,
           <CRUDGridActionsCellItem
@@ -203,6 +204,7 @@ export function DashboardList(props: DashboardListProperties): ReactElement {
             action="delete"
             scope="Dashboard"
             project={params.row.project}
+            resourceName={params.row.name}
             onClick={handleDeleteButtonClick(params.row.project, params.row.name)}
           />,
         ],
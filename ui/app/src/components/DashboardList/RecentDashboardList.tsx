@@ -163,6 +163,7 @@ export function RecentDashboardList(props: RecentDashboardListProperties): React
             action="update"
             scope="Dashboard"
             project={params.row.project}
+            resourceName={params.row.name}
             onClick={onRenameButtonClick(params.row.project, params.row.name)}
           />,
           <CRUDGridActionsCellItem
@@ -172,6 +173,7 @@ export function RecentDashboardList(props: RecentDashboardListProperties): React
             action="delete"
             scope="Dashboard"
             project={params.row.project}
+            resourceName={params.row.name}
             onClick={onDeleteButtonClick(params.row.project, params.row.name)}
           />,
         ],
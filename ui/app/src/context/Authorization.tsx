@@ -81,8 +81,10 @@ export function useDashboardCreateAllowedProjects(): ProjectResource[] {
  * useHasPermission is a helper for knowing if a user has the permission to perform an action
  * It's only a check client-side, easily bypassable.
  * It will always return true if the authorization is disabled
+ * When the name of the resource is provided, the permissions restricted to some resources (e.g. to some dashboards)
+ * including this one are considered as well.
  */
-export function useHasPermission(action: Action, project: string, scope: Scope): boolean {
+export function useHasPermission(action: Action, project: string, scope: Scope, name?: string): boolean {
   const { enabled, username, userPermissions } = useAuthorizationContext();
 
   // Authorization not enabled
@@ -97,18 +99,53 @@ export function useHasPermission(action: Action, project: string, scope: Scope):
 
   // Checking global perm first
   if (project !== GlobalProject) {
-    if (permissionListHasPermission(userPermissions[GlobalProject] ?? [], action, scope)) {
+    if (permissionListHasPermission(userPermissions[GlobalProject] ?? [], action, scope, name)) {
       return true;
     }
   }
 
   // Checking project perm
-  return permissionListHasPermission(userPermissions[project] ?? [], action, scope);
+  return permissionListHasPermission(userPermissions[project] ?? [], action, scope, name);
 }
 
-function permissionListHasPermission(permissions: Permission[], requestAction: Action, requestScope: Scope): boolean {
+/*
+ * useHasResourcePermission is a helper for knowing if a user has the permission to perform an action on at least
+ * some resources of the project, e.g. to read some dashboards of the project only.
+ * It's only a check client-side, easily bypassable.
+ * It will always return true if the authorization is disabled
+ */
+export function useHasResourcePermission(action: Action, project: string, scope: Scope): boolean {
+  const { enabled, username, userPermissions } = useAuthorizationContext();
+
+  // Authorization not enabled
+  if (!enabled) {
+    return true;
+  }
+
+  // User not logged in
+  if (!username) {
+    return false;
+  }
+
+  const permissions = [...(userPermissions[GlobalProject] ?? []), ...(userPermissions[project] ?? [])];
+  return permissions.some(
+    (permission) =>
+      permission.actions.some((a) => a === action || a === '*') &&
+      permission.scopes.some((s) => s === scope || s === '*')
+  );
+}
+
+// The permissions restricted to some resources only apply when the name of one of these resources is provided.
+function permissionListHasPermission(
+  permissions: Permission[],
+  requestAction: Action,
+  requestScope: Scope,
+  requestName?: string
+): boolean {
   return permissions.some(
     (permission) =>
+      ((permission.resources ?? []).length === 0 ||
+        (requestName !== undefined && (permission.resources ?? []).includes(requestName))) &&
       permission.actions.some((action) => action === requestAction || action === '*') &&
       permission.scopes.some((scope) => scope === requestScope || scope === '*')
   );
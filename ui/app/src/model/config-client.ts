@@ -74,9 +74,43 @@ export interface DatabaseSQL {
   case_sensitive: boolean;
 }
 
+export interface DatabasePostgres {
+  tls_config?: TLSConfig;
+  user?: string;
+  password?: string;
+  password_file?: string;
+  addr: string;
+  db_name: string;
+  schema?: string;
+  ssl_mode?: 'disable' | 'allow' | 'prefer' | 'require' | 'verify-ca' | 'verify-full';
+  connect_timeout?: string;
+  max_open_conns?: number;
+  max_idle_conns?: number;
+  conn_max_lifetime?: string;
+  conn_max_idle_time?: string;
+  case_sensitive: boolean;
+}
+
+export interface DatabaseObjectStorage {
+  bucket: string;
+  endpoint?: string;
+  region?: string;
+  access_key_id?: string;
+  secret_access_key?: string;
+  secret_access_key_file?: string;
+  session_token?: string;
+  force_path_style?: boolean;
+  prefix?: string;
+  tls_config?: TLSConfig;
+  cache_ttl?: string;
+  case_sensitive: boolean;
+}
+
 export interface Database {
   file?: DatabaseFile;
   sql?: DatabaseSQL;
+  postgres?: DatabasePostgres;
+  object_storage?: DatabaseObjectStorage;
 }
 
 export interface ProvisioningConfig {
@@ -175,10 +209,30 @@ export interface ProjectDatasourceConfig {
   disable: boolean;
 }
 
+export interface QueryCacheRedis {
+  addr: string;
+  username?: string;
+  password?: string;
+  password_file?: string;
+  db?: number;
+  tls_config?: TLSConfig;
+  timeout?: DurationString;
+}
+
+export interface QueryCacheConfig {
+  enable: boolean;
+  backend?: 'memory' | 'redis';
+  default_ttl?: DurationString;
+  max_entries?: number;
+  max_response_size?: number;
+  redis?: QueryCacheRedis;
+}
+
 export interface DatasourceConfig {
   global: GlobalDatasourceConfig;
   project: ProjectDatasourceConfig;
   disable_local: boolean;
+  cache?: QueryCacheConfig;
 }
 
 export interface ConfigModel {
@@ -53,7 +53,7 @@ import { useIsMobileSize } from '../../utils/browser-size';
 import { SecretDrawer } from '../../components/secrets/SecretDrawer';
 import { useCreateSecretMutation } from '../../model/secret-client';
 import { useEphemeralDashboardList } from '../../model/ephemeral-dashboard-client';
-import { useHasPermission } from '../../context/Authorization';
+import { useHasPermission, useHasResourcePermission } from '../../context/Authorization';
 import { ProjectDashboards } from './tabs/ProjectDashboards';
 import { ProjectEphemeralDashboards } from './tabs/ProjectEphemeralDashboards';
 import { ProjectVariables } from './tabs/ProjectVariables';
@@ -428,7 +428,8 @@ export function ProjectTabs(props: DashboardVariableTabsProps): ReactElement {
 
   const [value, setValue] = useState((initialTab ?? dashboardsTabIndex).toLowerCase());
 
-  const hasDashboardReadPermission = useHasPermission('read', projectName, 'Dashboard');
+  // The user may only read some dashboards of the project.
+  const hasDashboardReadPermission = useHasResourcePermission('read', projectName, 'Dashboard');
   const hasDatasourceReadPermission = useHasPermission('read', projectName, 'Datasource');
   const hasEphemeralDashboardReadPermission = useHasPermission('read', projectName, 'EphemeralDashboard');
   const hasRoleReadPermission = useHasPermission('read', projectName, 'Role');
@@ -13,7 +13,7 @@
 
 import { DatasourceSpec } from './datasource';
 import { LayoutDefinition } from './layout';
-import { PanelDefinition } from './panels';
+import { Link, PanelDefinition } from './panels';
 import { ProjectMetadata } from './resource';
 import { DurationString } from './time';
 import { VariableDefinition } from './variables';
@@ -29,12 +29,25 @@ export interface DashboardSpec {
   display?: Display;
   datasources?: Record<string, DatasourceSpec>;
   duration: DurationString;
+  timeRange?: DashboardTimeRange;
   refreshInterval?: DurationString;
+  refreshIntervals?: DurationString[];
+  timezone?: string;
+  links?: Link[];
+  extensions?: Record<string, unknown>;
   variables: VariableDefinition[];
   layouts: LayoutDefinition[];
   panels: Record<string, PanelDefinition>;
 }
 
+/**
+ * Fixed time range of a dashboard, with the dates in the RFC 3339 format.
+ */
+export interface DashboardTimeRange {
+  start: string;
+  end: string;
+}
+
 export interface DashboardSelector {
   project: string;
   dashboard: string;
@@ -14,4 +14,5 @@
 export interface Display {
   name?: string;
   description?: string;
+  localizedNames?: Record<string, string>;
 }
@@ -29,6 +29,9 @@ export interface HTTPProxySpec {
   // secret is the name of the secret that should be used for the proxy or discovery configuration
   // It will contain any sensitive information such as password, token, certificate.
   secret?: string;
+  // cacheTTL is the duration during which the responses of the queries are cached by the proxy, when the cache is
+  // enabled in the configuration of the server. 0s disables the cache for this datasource.
+  cacheTTL?: string;
 }
 
 export interface HTTPAllowedEndpoint {
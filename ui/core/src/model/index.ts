@@ -31,6 +31,7 @@ export * from './resource';
 export * from './roles';
 export * from './rolebindings';
 export * from './secrets';
+export * from './snapshot';
 export * from './thresholds';
 export * from './time';
 export * from './time-series-data';
@@ -13,6 +13,7 @@
 
 import { Definition, UnknownSpec } from './definitions';
 import { QueryDefinition } from './query';
+import { PanelTransform } from './transforms';
 
 export interface Link {
   name?: string;
@@ -29,6 +30,14 @@ export interface PanelDisplay {
 
 export interface PanelDefinition<PluginSpec = UnknownSpec> extends Definition<PanelSpec<PluginSpec>> {
   kind: 'Panel';
+  /**
+   * Name of the LibraryPanel the panel refers to. The server sets the spec of the panel from the LibraryPanel.
+   */
+  libraryPanel?: string;
+  /**
+   * Same reference to a LibraryPanel written as an external reference: "librarypanels/<name>".
+   */
+  $ref?: string;
 }
 
 export interface PanelSpec<PluginSpec = UnknownSpec> {
@@ -36,6 +45,9 @@ export interface PanelSpec<PluginSpec = UnknownSpec> {
   plugin: Definition<PluginSpec>;
   queries?: QueryDefinition[];
   links?: Link[];
+  transforms?: PanelTransform[];
+  alertRule?: string;
+  extensions?: Record<string, unknown>;
 }
 
 /**
@@ -17,6 +17,10 @@ import { TraceData } from './trace-data';
 import { ProfileData } from './profile-data';
 
 interface QuerySpec<PluginSpec> {
+  /**
+   * Name of the datasource targeted by the query. When set, it takes precedence over the datasource of the plugin spec.
+   */
+  datasource?: string;
   plugin: Definition<PluginSpec>;
 }
 /**
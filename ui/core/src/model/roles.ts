@@ -16,7 +16,7 @@ import { Kind } from './kind';
 
 export type Action = 'create' | 'read' | 'update' | 'delete' | '*';
 export const ACTIONS = ['*', 'create', 'read', 'update', 'delete'];
-export type Scope = Kind | '*';
+export type Scope = Kind | 'Audit' | '*';
 export const PROJECT_SCOPES = [
   '*',
   'Dashboard',
@@ -31,6 +31,7 @@ export const PROJECT_SCOPES = [
 ];
 
 export const GLOBAL_SCOPES = [
+  'Audit',
   'GlobalDatasource',
   'GlobalRole',
   'GlobalRoleBinding',
@@ -42,6 +43,7 @@ export const GLOBAL_SCOPES = [
 export interface Permission {
   actions: Action[];
   scopes: Scope[];
+  resources?: string[];
 }
 
 export interface RoleSpec {
@@ -0,0 +1,46 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import { DashboardSpec } from './dashboard';
+import { ProjectMetadata } from './resource';
+
+export interface SnapshotTimeRange {
+  start: string;
+  end: string;
+}
+
+/**
+ * An immutable copy of a dashboard with the results of its queries, viewable without access to the datasources.
+ */
+export interface SnapshotResource {
+  kind: 'Snapshot';
+  metadata: ProjectMetadata;
+  spec: SnapshotSpec;
+}
+
+export interface SnapshotSpec {
+  dashboard: string;
+  timeRange: SnapshotTimeRange;
+  dashboardSpec: DashboardSpec;
+  // For each panel key, the results of the queries of the panel, in the order of the queries.
+  data: Record<string, unknown[]>;
+}
+
+/**
+ * The body of the request taking a snapshot of a dashboard.
+ */
+export interface SnapshotRequest {
+  name?: string;
+  timeRange: SnapshotTimeRange;
+  data: Record<string, unknown[]>;
+}
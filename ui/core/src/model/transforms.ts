@@ -48,6 +48,27 @@ export type Transform =
   | MergeIndexedColumnsTransform
   | MergeSeriesTransform;
 
+export interface JoinByLabelPanelTransform {
+  kind: 'JoinByLabel';
+  spec: TransformCommonSpec & {
+    labels: string[];
+  };
+}
+
+export interface CalculatePanelTransform {
+  kind: 'Calculate';
+  spec: TransformCommonSpec & {
+    name: string;
+    // Arithmetic expression of the queries ('a' for the first query, 'b' for the second one, ...), e.g. 'a / b'
+    expression: string;
+  };
+}
+
+/**
+ * Transform applied, in order, to the series returned by the queries of a panel.
+ */
+export type PanelTransform = MergeSeriesTransform | JoinByLabelPanelTransform | CalculatePanelTransform;
+
 // Can be moved somewhere else
 export const TRANSFORM_TEXT = {
   JoinByColumnValue: 'Join by column value',
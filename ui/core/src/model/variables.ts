@@ -26,10 +26,16 @@ export interface VariableDisplay extends Display {
 export interface VariableSpec {
   name: VariableName;
   display?: VariableDisplay;
+  extensions?: Record<string, unknown>;
 }
 
 export interface TextVariableDefinition extends Definition<TextVariableSpec> {
   kind: 'TextVariable';
+  /**
+   * Project ("variables/<name>") or global ("globalvariables/<name>") variable the variable refers to. The server sets
+   * the kind and the spec of the variable from the referenced variable.
+   */
+  $ref?: string;
 }
 
 export interface TextVariableSpec extends VariableSpec {
@@ -39,6 +45,11 @@ export interface TextVariableSpec extends VariableSpec {
 
 export interface ListVariableDefinition extends Definition<ListVariableSpec> {
   kind: 'ListVariable';
+  /**
+   * Project ("variables/<name>") or global ("globalvariables/<name>") variable the variable refers to. The server sets
+   * the kind and the spec of the variable from the referenced variable.
+   */
+  $ref?: string;
 }
 
 export interface ListVariableSpec<PluginSpec = UnknownSpec> extends VariableSpec {
@@ -48,6 +59,7 @@ export interface ListVariableSpec<PluginSpec = UnknownSpec> extends VariableSpec
   customAllValue?: string;
   capturingRegexp?: string;
   sort?: string;
+  refresh?: 'onDashboardLoad' | 'onTimeRangeChange';
   plugin: Definition<PluginSpec>;
 }
 
@@ -22,6 +22,7 @@ export const permissionSchema: z.ZodSchema<Permission> = z.object({
     .array(
       z.enum([
         '*',
+        'Audit',
         'Dashboard',
         'Datasource',
         'EphemeralDashboard',
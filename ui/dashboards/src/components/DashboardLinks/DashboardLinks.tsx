@@ -0,0 +1,54 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import { Button, Stack } from '@mui/material';
+import { InfoTooltip } from '@perses-dev/components';
+import { Link } from '@perses-dev/core';
+import LaunchIcon from 'mdi-material-ui/Launch';
+import { ReactElement } from 'react';
+import { useDashboardLinks } from '../../context';
+import { useLink } from '../Panel/PanelLinks';
+
+// Links of the dashboard (e.g. to a runbook or to related dashboards), displayed in the toolbar
+export function DashboardLinks(): ReactElement | null {
+  const links = useDashboardLinks();
+  if (links === undefined || links.length === 0) {
+    return null;
+  }
+
+  return (
+    <Stack direction="row" gap={1} data-testid="dashboard-links">
+      {links.map((link, index) => (
+        <DashboardLinkButton key={`${index}-${link.url}`} link={link} />
+      ))}
+    </Stack>
+  );
+}
+
+function DashboardLinkButton({ link }: { link: Link }): ReactElement {
+  const { url, name, tooltip, targetBlank } = useLink(link);
+
+  return (
+    <InfoTooltip description={tooltip ?? url} enterDelay={100}>
+      <Button
+        variant="outlined"
+        size="small"
+        href={url}
+        target={targetBlank ? '_blank' : '_self'}
+        endIcon={<LaunchIcon fontSize="inherit" />}
+      >
+        {name ?? url}
+      </Button>
+    </InfoTooltip>
+  );
+}
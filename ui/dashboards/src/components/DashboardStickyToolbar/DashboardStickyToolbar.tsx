@@ -26,6 +26,7 @@ import {
 import PinOutline from 'mdi-material-ui/PinOutline';
 import PinOffOutline from 'mdi-material-ui/PinOffOutline';
 import { TimeRangeControls } from '@perses-dev/plugin-system';
+import { useDashboardRefreshIntervals } from '../../context';
 import { VariableList } from '../Variables';
 
 interface DashboardStickyToolbarProps {
@@ -40,6 +41,7 @@ export function DashboardStickyToolbar(props: DashboardStickyToolbarProps): Reac
   const isSticky = scrollTrigger && props.initialVariableIsSticky && isPin;
 
   const isBiggerThanMd = useMediaQuery(useTheme().breakpoints.up('md'));
+  const refreshIntervals = useDashboardRefreshIntervals();
 
   return (
     // marginBottom={-1} counteracts the marginBottom={1} on every variable input.
@@ -97,7 +99,7 @@ export function DashboardStickyToolbar(props: DashboardStickyToolbarProps): Reac
               direction="row"
               justifyContent="end"
             >
-              <TimeRangeControls />
+              <TimeRangeControls refreshIntervals={refreshIntervals} />
             </Stack>
           )}
         </Box>
@@ -15,7 +15,7 @@ import { Typography, Stack, Button, Box, useTheme, useMediaQuery, Alert } from '
 import { ErrorBoundary, ErrorAlert } from '@perses-dev/components';
 import { TimeRangeControls } from '@perses-dev/plugin-system';
 import { ReactElement } from 'react';
-import { OnSaveDashboard, useEditMode } from '../../context';
+import { OnSaveDashboard, useDashboardRefreshIntervals, useEditMode } from '../../context';
 import { AddPanelButton } from '../AddPanelButton';
 import { AddGroupButton } from '../AddGroupButton';
 import { DownloadButton } from '../DownloadButton';
@@ -25,6 +25,7 @@ import { EditButton } from '../EditButton';
 import { EditJsonButton } from '../EditJsonButton';
 import { SaveDashboardButton } from '../SaveDashboardButton';
 import { DashboardStickyToolbar } from '../DashboardStickyToolbar';
+import { DashboardLinks } from '../DashboardLinks';
 
 export interface DashboardToolbarProps {
   dashboardName: string;
@@ -52,6 +53,7 @@ export const DashboardToolbar = (props: DashboardToolbarProps): ReactElement =>
   } = props;
 
   const { isEditMode } = useEditMode();
+  const refreshIntervals = useDashboardRefreshIntervals();
 
   const isBiggerThanSm = useMediaQuery(useTheme().breakpoints.up('sm'));
   const isBiggerThanMd = useMediaQuery(useTheme().breakpoints.up('md'));
@@ -125,7 +127,8 @@ export const DashboardToolbar = (props: DashboardToolbarProps): ReactElement =>
           </Box>
           <Stack direction="row" ml="auto" flexWrap="wrap" justifyContent="end">
             <Stack direction="row" spacing={1} mt={1} ml={1}>
-              <TimeRangeControls />
+              <DashboardLinks />
+              <TimeRangeControls refreshIntervals={refreshIntervals} />
               <DownloadButton />
               <EditJsonButton isReadonly={!isEditMode} />
             </Stack>
@@ -98,7 +98,7 @@ function LinkMenuItem({ link }: { link: Link }): ReactElement {
   );
 }
 
-function useLink(link: Link): Link {
+export function useLink(link: Link): Link {
   const url = useReplaceVariablesInString(link.url) ?? link.url;
   const name = useReplaceVariablesInString(link.name);
   const tooltip = useReplaceVariablesInString(link.tooltip);
@@ -26,6 +26,7 @@ import {
   DEFAULT_REFRESH_INTERVAL,
   DatasourceSpec,
   EphemeralDashboardResource,
+  Link,
 } from '@perses-dev/core';
 import { usePlugin, usePluginRegistry } from '@perses-dev/plugin-system';
 import { createPanelGroupEditorSlice, PanelGroupEditorSlice } from './panel-group-editor-slice';
@@ -60,8 +61,11 @@ export interface DashboardStoreState
   metadata: ProjectMetadata;
   duration: DurationString;
   refreshInterval: DurationString;
+  refreshIntervals?: DurationString[];
+  timezone?: string;
   display?: Display;
   datasources?: Record<string, DatasourceSpec>;
+  links?: Link[];
   ttl?: DurationString;
 }
 
@@ -123,7 +127,15 @@ function initStore(props: DashboardProviderProps): StoreApi<DashboardStoreState>
   const {
     kind,
     metadata,
-    spec: { display, duration, refreshInterval = DEFAULT_REFRESH_INTERVAL, datasources },
+    spec: {
+      display,
+      duration,
+      refreshInterval = DEFAULT_REFRESH_INTERVAL,
+      refreshIntervals,
+      timezone,
+      datasources,
+      links,
+    },
   } = dashboardResource;
 
   const ttl = 'ttl' in dashboardResource.spec ? dashboardResource.spec.ttl : undefined;
@@ -160,14 +172,27 @@ function initStore(props: DashboardProviderProps): StoreApi<DashboardStoreState>
           display,
           duration,
           refreshInterval,
+          refreshIntervals,
+          timezone,
           datasources,
+          links,
           ttl,
           isEditMode: !!isEditMode,
           setEditMode: (isEditMode: boolean): void => set({ isEditMode }),
           setDashboard: ({
             kind,
             metadata,
-            spec: { display, panels = {}, layouts = [], duration, refreshInterval, datasources = {} },
+            spec: {
+              display,
+              panels = {},
+              layouts = [],
+              duration,
+              refreshInterval,
+              refreshIntervals,
+              timezone,
+              datasources = {},
+              links,
+            },
           }): void => {
             set((state) => {
               state.kind = kind;
@@ -179,7 +204,10 @@ function initStore(props: DashboardProviderProps): StoreApi<DashboardStoreState>
               state.panelGroupOrder = panelGroupOrder;
               state.duration = duration;
               state.refreshInterval = refreshInterval ?? DEFAULT_REFRESH_INTERVAL;
+              state.refreshIntervals = refreshIntervals;
+              state.timezone = timezone;
               state.datasources = datasources;
+              state.links = links;
               // TODO: add ttl here to e.g allow edition from JSON view, but probably requires quite some refactoring
             });
           },
@@ -16,6 +16,7 @@ import {
   DashboardResource,
   DurationString,
   EphemeralDashboardResource,
+  Link,
   PanelDefinition,
   PanelGroupId,
 } from '@perses-dev/core';
@@ -319,6 +320,25 @@ export function useDashboardDuration(): DurationString {
   return useDashboardStore(selectDashboardDuration);
 }
 
+const selectDashboardRefreshIntervals: (state: DashboardStoreState) => DurationString[] | undefined = (
+  state: DashboardStoreState
+) => state.refreshIntervals;
+export function useDashboardRefreshIntervals(): DurationString[] | undefined {
+  return useDashboardStore(selectDashboardRefreshIntervals);
+}
+
+const selectDashboardTimeZone: (state: DashboardStoreState) => string | undefined = (state: DashboardStoreState) =>
+  state.timezone;
+export function useDashboardTimeZone(): string | undefined {
+  return useDashboardStore(selectDashboardTimeZone);
+}
+
+const selectDashboardLinks: (state: DashboardStoreState) => Link[] | undefined = (state: DashboardStoreState) =>
+  state.links;
+export function useDashboardLinks(): Link[] | undefined {
+  return useDashboardStore(selectDashboardLinks);
+}
+
 const selectViewPanel: (state: DashboardStoreState) => {
   setViewPanel: DashboardStoreState['setViewPanel'];
   getViewPanel: DashboardStoreState['getViewPanel'];
@@ -35,7 +35,10 @@ export function useDashboard(): {
     display,
     duration,
     refreshInterval,
+    refreshIntervals,
+    timezone,
     datasources,
+    links,
     ttl,
   } = useDashboardStore(
     ({
@@ -48,7 +51,10 @@ export function useDashboard(): {
       display,
       duration,
       refreshInterval,
+      refreshIntervals,
+      timezone,
       datasources,
+      links,
       ttl,
     }) => ({
       panels,
@@ -60,7 +66,10 @@ export function useDashboard(): {
       display,
       duration,
       refreshInterval,
+      refreshIntervals,
+      timezone,
       datasources,
+      links,
       ttl,
     })
   );
@@ -80,7 +89,10 @@ export function useDashboard(): {
             variables,
             duration,
             refreshInterval,
+            refreshIntervals,
+            timezone,
             datasources,
+            links,
           },
         } as DashboardResource)
       : ({
@@ -93,7 +105,10 @@ export function useDashboard(): {
             variables,
             duration,
             refreshInterval,
+            refreshIntervals,
+            timezone,
             datasources,
+            links,
             ttl,
           },
         } as EphemeralDashboardResource);
@@ -11,9 +11,16 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-import { ReactElement, useState } from 'react';
+import { ReactElement, useContext, useState } from 'react';
 import { Box } from '@mui/material';
-import { ChartsProvider, ErrorAlert, ErrorBoundary, useChartsTheme } from '@perses-dev/components';
+import {
+  ChartsProvider,
+  ErrorAlert,
+  ErrorBoundary,
+  TimeZoneContext,
+  TimeZoneProvider,
+  useChartsTheme,
+} from '@perses-dev/components';
 import { DashboardResource, EphemeralDashboardResource } from '@perses-dev/core';
 import { useDatasourceStore } from '@perses-dev/plugin-system';
 import {
@@ -28,7 +35,13 @@ import {
   EditJsonDialog,
   SaveChangesConfirmationDialog,
 } from '../../components';
-import { OnSaveDashboard, useDashboard, useDiscardChangesConfirmationDialog, useEditMode } from '../../context';
+import {
+  OnSaveDashboard,
+  useDashboard,
+  useDashboardTimeZone,
+  useDiscardChangesConfirmationDialog,
+  useEditMode,
+} from '../../context';
 
 export interface DashboardAppProps {
   emptyDashboardProps?: Partial<EmptyDashboardProps>;
@@ -58,6 +71,9 @@ export const DashboardApp = (props: DashboardAppProps): ReactElement => {
   } = props;
 
   const chartsTheme = useChartsTheme();
+  // The timezone of the dashboard, when set, takes precedence over the one provided by the application
+  const appTimeZone = useContext(TimeZoneContext);
+  const dashboardTimeZone = useDashboardTimeZone();
 
   const { isEditMode, setEditMode } = useEditMode();
   const { dashboard, setDashboard } = useDashboard();
@@ -104,45 +120,47 @@ export const DashboardApp = (props: DashboardAppProps): ReactElement => {
   };
 
   return (
-    <Box
-      sx={{
-        flexGrow: 1,
-        overflowX: 'hidden',
-        overflowY: 'auto',
-        display: 'flex',
-        flexDirection: 'column',
-      }}
-    >
-      <DashboardToolbar
-        dashboardName={dashboardResource.metadata.name}
-        dashboardTitleComponent={dashboardTitleComponent}
-        initialVariableIsSticky={initialVariableIsSticky}
-        onSave={onSave}
-        isReadonly={isReadonly}
-        isVariableEnabled={isVariableEnabled}
-        isDatasourceEnabled={isDatasourceEnabled}
-        onEditButtonClick={onEditButtonClick}
-        onCancelButtonClick={onCancelButtonClick}
-      />
-      <Box sx={{ paddingTop: 2, paddingX: 2, height: '100%' }}>
-        <ErrorBoundary FallbackComponent={ErrorAlert}>
-          <Dashboard
-            emptyDashboardProps={{
-              onEditButtonClick,
-              ...emptyDashboardProps,
-            }}
-          />
-        </ErrorBoundary>
-        <ChartsProvider chartsTheme={chartsTheme} enablePinning={false} enableSyncGrouping={false}>
-          <PanelDrawer />
-        </ChartsProvider>
-        <PanelGroupDialog />
-        <DeletePanelGroupDialog />
-        <DeletePanelDialog />
-        <DashboardDiscardChangesConfirmationDialog />
-        <EditJsonDialog isReadonly={!isEditMode} disableMetadataEdition={!isCreating} />
-        <SaveChangesConfirmationDialog />
+    <TimeZoneProvider timeZone={dashboardTimeZone ?? appTimeZone}>
+      <Box
+        sx={{
+          flexGrow: 1,
+          overflowX: 'hidden',
+          overflowY: 'auto',
+          display: 'flex',
+          flexDirection: 'column',
+        }}
+      >
+        <DashboardToolbar
+          dashboardName={dashboardResource.metadata.name}
+          dashboardTitleComponent={dashboardTitleComponent}
+          initialVariableIsSticky={initialVariableIsSticky}
+          onSave={onSave}
+          isReadonly={isReadonly}
+          isVariableEnabled={isVariableEnabled}
+          isDatasourceEnabled={isDatasourceEnabled}
+          onEditButtonClick={onEditButtonClick}
+          onCancelButtonClick={onCancelButtonClick}
+        />
+        <Box sx={{ paddingTop: 2, paddingX: 2, height: '100%' }}>
+          <ErrorBoundary FallbackComponent={ErrorAlert}>
+            <Dashboard
+              emptyDashboardProps={{
+                onEditButtonClick,
+                ...emptyDashboardProps,
+              }}
+            />
+          </ErrorBoundary>
+          <ChartsProvider chartsTheme={chartsTheme} enablePinning={false} enableSyncGrouping={false}>
+            <PanelDrawer />
+          </ChartsProvider>
+          <PanelGroupDialog />
+          <DeletePanelGroupDialog />
+          <DeletePanelDialog />
+          <DashboardDiscardChangesConfirmationDialog />
+          <EditJsonDialog isReadonly={!isEditMode} disableMetadataEdition={!isCreating} />
+          <SaveChangesConfirmationDialog />
+        </Box>
       </Box>
-    </Box>
+    </TimeZoneProvider>
   );
 };
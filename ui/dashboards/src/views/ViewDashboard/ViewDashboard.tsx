@@ -61,7 +61,12 @@ export function ViewDashboard(props: ViewDashboardProps): ReactElement {
   const { spec } = dashboardResource;
   const dashboardDuration = spec.duration ?? DEFAULT_DASHBOARD_DURATION;
   const dashboardRefreshInterval = spec.refreshInterval ?? DEFAULT_REFRESH_INTERVAL;
-  const initialTimeRange = useInitialTimeRange(dashboardDuration);
+  const dashboardTimeRange = useMemo(
+    () =>
+      spec.timeRange ? { start: new Date(spec.timeRange.start), end: new Date(spec.timeRange.end) } : undefined,
+    [spec.timeRange]
+  );
+  const initialTimeRange = useInitialTimeRange(dashboardDuration, dashboardTimeRange);
   const initialRefreshInterval = useInitialRefreshInterval(dashboardRefreshInterval);
   const { data } = usePluginBuiltinVariableDefinitions();
 
@@ -141,5 +141,16 @@ describe('TimeRangeControls', () => {
     expect(history.location.pathname).toEqual('/home');
   });
 
+  it('should only propose the given refresh intervals', () => {
+    renderWithContext(
+      <TimeRangeProvider refreshInterval={testDefaultRefreshInterval} timeRange={testDefaultTimeRange}>
+        <TimeRangeControls refreshIntervals={['0s', '1m', '5m']} />
+      </TimeRangeProvider>
+    );
+    const refreshButton = screen.getByLabelText(/refresh interval/i, { selector: '[role="combobox"]' });
+    userEvent.click(refreshButton);
+    expect(screen.getAllByRole('option').map((option) => option.textContent)).toEqual(['Off', '1m', '5m']);
+  });
+
   // TODO: add additional tests for absolute time selection, other inputs, form validation, etc.
 });
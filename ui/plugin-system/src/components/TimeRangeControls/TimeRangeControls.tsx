@@ -26,7 +26,7 @@ import {
   buildRelativeTimeOption,
 } from '@perses-dev/components';
 import { AbsoluteTimeRange, DurationString, parseDurationString, RelativeTimeRange } from '@perses-dev/core';
-import { ReactElement, useCallback } from 'react';
+import { ReactElement, useCallback, useMemo } from 'react';
 import { TOOLTIP_TEXT } from '../../constants';
 import {
   useTimeRange,
@@ -46,6 +46,17 @@ export const DEFAULT_REFRESH_INTERVAL_OPTIONS: TimeOption[] = [
 
 const DEFAULT_HEIGHT = '34px';
 
+/**
+ * Builds the options of the refresh interval picker from a list of durations, e.g. the refresh intervals of a dashboard.
+ * A zero duration stands for "Off".
+ */
+export function buildRefreshIntervalOptions(intervals: DurationString[]): TimeOption[] {
+  return intervals.map((interval) => {
+    const isOff = Object.values(parseDurationString(interval)).every((value) => !value);
+    return { value: { pastDuration: interval }, display: isOff ? 'Off' : interval };
+  });
+}
+
 interface TimeRangeControlsProps {
   // The controls look best at heights >= 28 pixels
   heightPx?: number;
@@ -55,6 +66,8 @@ interface TimeRangeControlsProps {
   showCustomTimeRange?: boolean;
   showZoomButtons?: boolean;
   timePresets?: TimeOption[];
+  // The refresh intervals selectable in the refresh interval picker. When empty, the default ones are proposed.
+  refreshIntervals?: DurationString[];
 }
 
 export function TimeRangeControls({
@@ -65,6 +78,7 @@ export function TimeRangeControls({
   showCustomTimeRange,
   showZoomButtons = true,
   timePresets,
+  refreshIntervals,
 }: TimeRangeControlsProps): ReactElement {
   const { timeRange, setTimeRange, refresh, refreshInterval, setRefreshInterval } = useTimeRange();
 
@@ -83,6 +97,14 @@ export function TimeRangeControls({
     timePresetsValue.push(buildRelativeTimeOption(timeRange['pastDuration']));
   }
 
+  const refreshIntervalOptions = useMemo(
+    () =>
+      refreshIntervals && refreshIntervals.length > 0
+        ? buildRefreshIntervalOptions(refreshIntervals)
+        : DEFAULT_REFRESH_INTERVAL_OPTIONS,
+    [refreshIntervals]
+  );
+
   // set the new refresh interval both in the dashboard context & as query param
   const handleRefreshIntervalChange = useCallback(
     (duration: DurationString) => {
@@ -194,7 +216,7 @@ export function TimeRangeControls({
       {showRefreshInterval && (
         <InfoTooltip description={TOOLTIP_TEXT.refreshInterval}>
           <RefreshIntervalPicker
-            timeOptions={DEFAULT_REFRESH_INTERVAL_OPTIONS}
+            timeOptions={refreshIntervalOptions}
             value={refreshInterval}
             onChange={handleRefreshIntervalChange}
             height={height}
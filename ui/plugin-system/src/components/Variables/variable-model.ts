@@ -68,8 +68,16 @@ export function useListVariablePluginValues(definition: ListVariableDefinition):
 
   const variablesValueKey = getVariableValuesKey(variables);
 
+  // Without refresh policy, the values are reloaded each time the dashboard is refreshed
+  let refreshQueryKey: unknown[] = [timeRange, refreshKey];
+  if (definition.spec.refresh === 'onDashboardLoad') {
+    refreshQueryKey = [];
+  } else if (definition.spec.refresh === 'onTimeRangeChange') {
+    refreshQueryKey = [timeRange];
+  }
+
   return useQuery({
-    queryKey: [definition, variablesValueKey, timeRange, refreshKey],
+    queryKey: [definition, variablesValueKey, ...refreshQueryKey],
     queryFn: async () => {
       const resp = await variablePlugin?.getVariableOptions(spec, { datasourceStore, variables, timeRange });
       if (resp === undefined) {
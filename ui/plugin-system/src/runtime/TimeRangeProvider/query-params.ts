@@ -93,14 +93,17 @@ export const refreshIntervalQueryConfig = {
 };
 
 /**
- * Gets the initial time range taking into account URL params and dashboard JSON duration
+ * Gets the initial time range taking into account URL params and dashboard JSON duration or fixed time range
  * Sets start query param if it is empty on page load
  */
-export function useInitialTimeRange(dashboardDuration: DurationString): TimeRangeValue {
+export function useInitialTimeRange(
+  dashboardDuration: DurationString,
+  dashboardTimeRange?: AbsoluteTimeRange
+): TimeRangeValue {
   const [query] = useQueryParams(timeRangeQueryConfig, { updateType: 'replaceIn' });
   const { start, end } = query;
   return useMemo(() => {
-    let initialTimeRange: TimeRangeValue = { pastDuration: dashboardDuration };
+    let initialTimeRange: TimeRangeValue = dashboardTimeRange ?? { pastDuration: dashboardDuration };
     if (!start) {
       return initialTimeRange;
     }
@@ -111,7 +114,7 @@ export function useInitialTimeRange(dashboardDuration: DurationString): TimeRang
       initialTimeRange = { start: start, end: end } as AbsoluteTimeRange;
     }
     return initialTimeRange;
-  }, [start, end, dashboardDuration]);
+  }, [start, end, dashboardDuration, dashboardTimeRange]);
 }
 
 /**
@@ -126,12 +129,14 @@ export function useTimeRangeParams(initialTimeRange: TimeRangeValue): Pick<TimeR
   const { start } = query;
 
   useEffect(() => {
-    // when dashboard loaded with no params, default to dashboard duration
+    // when dashboard loaded with no params, default to dashboard duration or fixed time range
     if (!paramsLoaded && !start) {
       if (isRelativeTimeRange(initialTimeRange)) {
         setQuery({ start: initialTimeRange.pastDuration, end: undefined });
-        setParamsLoaded(true);
+      } else {
+        setQuery(initialTimeRange);
       }
+      setParamsLoaded(true);
     }
   }, [initialTimeRange, paramsLoaded, start, setQuery]);
 
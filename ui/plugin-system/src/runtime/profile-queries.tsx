@@ -16,6 +16,7 @@ import { useQueries, UseQueryResult } from '@tanstack/react-query';
 import { useDatasourceStore } from './datasources';
 import { usePluginRegistry } from './plugin-registry';
 import { useTimeRange } from './TimeRangeProvider';
+import { getQueryPluginSpec } from './utils';
 export type ProfileQueryDefinition<PluginSpec = UnknownSpec> = QueryDefinition<'ProfileQuery', PluginSpec>;
 export const PROFILE_QUERY_KEY = 'ProfileQuery';
 
@@ -43,7 +44,7 @@ export function useProfileQueries(definitions: ProfileQueryDefinition[]): Array<
         queryKey: queryKey,
         queryFn: async (): Promise<ProfileData> => {
           const plugin = await getPlugin(PROFILE_QUERY_KEY, profileQueryKind);
-          const data = await plugin.getProfileData(definition.spec.plugin.spec, context);
+          const data = await plugin.getProfileData(getQueryPluginSpec(definition), context);
           return data;
         },
 
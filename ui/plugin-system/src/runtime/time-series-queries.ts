@@ -26,7 +26,7 @@ import { TimeSeriesDataQuery, TimeSeriesQueryContext, TimeSeriesQueryMode, TimeS
 import { useTimeRange } from './TimeRangeProvider';
 import { useDatasourceStore } from './datasources';
 import { usePlugin, usePluginRegistry, usePlugins } from './plugin-registry';
-import { filterVariableStateMap, getVariableValuesKey } from './utils';
+import { filterVariableStateMap, getQueryPluginSpec, getVariableValuesKey } from './utils';
 import { useAllVariableValues } from './variables';
 
 export interface UseTimeSeriesQueryOptions {
@@ -50,7 +50,7 @@ function getQueryOptions({
 } {
   const { timeRange, datasourceStore, suggestedStepMs, mode, variableState, refreshKey } = context;
 
-  const dependencies = plugin?.dependsOn ? plugin.dependsOn(definition.spec.plugin.spec, context) : {};
+  const dependencies = plugin?.dependsOn ? plugin.dependsOn(getQueryPluginSpec(definition), context) : {};
   const variableDependencies = dependencies?.variables;
 
   // Determine queryKey
@@ -102,7 +102,7 @@ export const useTimeSeriesQuery = (
       }
       // Keep options out of query key so we don't re-run queries because suggested step changes
       const ctx: TimeSeriesQueryContext = { ...context, suggestedStepMs: options?.suggestedStepMs };
-      return plugin.getTimeSeriesData(definition.spec.plugin.spec, ctx);
+      return plugin.getTimeSeriesData(getQueryPluginSpec(definition), ctx);
     },
   });
 };
@@ -142,7 +142,7 @@ export function useTimeSeriesQueries(
             suggestedStepMs: options?.suggestedStepMs,
           };
           const plugin = await getPlugin(TIME_SERIES_QUERY_KEY, definition.spec.plugin.kind);
-          const data = await plugin.getTimeSeriesData(definition.spec.plugin.spec, ctx);
+          const data = await plugin.getTimeSeriesData(getQueryPluginSpec(definition), ctx);
           return data;
         },
       };
@@ -19,7 +19,7 @@ import { useDatasourceStore } from './datasources';
 import { usePluginRegistry, usePlugins } from './plugin-registry';
 import { useTimeRange } from './TimeRangeProvider';
 import { useAllVariableValues } from './variables';
-import { filterVariableStateMap, getVariableValuesKey } from './utils';
+import { filterVariableStateMap, getQueryPluginSpec, getVariableValuesKey } from './utils';
 export type TraceQueryDefinition<PluginSpec = UnknownSpec> = QueryDefinition<'TraceQuery', PluginSpec>;
 export const TRACE_QUERY_KEY = 'TraceQuery';
 
@@ -57,7 +57,7 @@ export function useTraceQueries(definitions: TraceQueryDefinition[]): Array<UseQ
         queryKey: queryKey,
         queryFn: async (): Promise<TraceData> => {
           const plugin = await getPlugin(TRACE_QUERY_KEY, traceQueryKind);
-          const data = await plugin.getTraceData(definition.spec.plugin.spec, context);
+          const data = await plugin.getTraceData(getQueryPluginSpec(definition), context);
           return data;
         },
       };
@@ -79,7 +79,7 @@ function getQueryOptions({
 } {
   const { datasourceStore, variableState, absoluteTimeRange } = context;
 
-  const dependencies = plugin?.dependsOn ? plugin.dependsOn(definition.spec.plugin.spec, context) : {};
+  const dependencies = plugin?.dependsOn ? plugin.dependsOn(getQueryPluginSpec(definition), context) : {};
   const variableDependencies = dependencies?.variables;
 
   const filteredVariabledState = filterVariableStateMap(variableState, variableDependencies);
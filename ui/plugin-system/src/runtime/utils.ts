@@ -11,6 +11,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+import { QueryDefinition, UnknownSpec } from '@perses-dev/core';
 import { VariableStateMap } from './variables';
 
 export function filterVariableStateMap(v: VariableStateMap, names?: string[]): VariableStateMap {
@@ -28,3 +29,17 @@ export function getVariableValuesKey(v: VariableStateMap): string {
     .map((v) => JSON.stringify(v.value))
     .join(',');
 }
+
+/**
+ * Returns the spec of the query plugin. When the query targets a datasource, it takes precedence over the datasource of
+ * the plugin spec. Its kind follows the naming convention of the plugins, e.g. the PrometheusTimeSeriesQuery plugin of a
+ * TimeSeriesQuery targets a PrometheusDatasource.
+ */
+export function getQueryPluginSpec(definition: QueryDefinition): UnknownSpec {
+  const { datasource, plugin } = definition.spec;
+  if (datasource === undefined) {
+    return plugin.spec;
+  }
+  const prefix = plugin.kind.endsWith(definition.kind) ? plugin.kind.slice(0, -definition.kind.length) : plugin.kind;
+  return { ...plugin.spec, datasource: { kind: `${prefix}Datasource`, name: datasource } };
+}